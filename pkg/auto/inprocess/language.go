@@ -0,0 +1,131 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// languageRuntimeServer is a language host that runs a pulumi.RunFunc in the current process. The engine connects to
+// it as a "client" runtime, in the same way that the CLI connects to the language host of an inline program.
+type languageRuntimeServer struct {
+	pulumirpc.UnimplementedLanguageRuntimeServer
+
+	fn      pulumi.RunFunc
+	address string
+
+	cancel chan bool
+	done   <-chan error
+}
+
+// startLanguageRuntimeServer starts serving a language host for the given program on a local port.
+func startLanguageRuntimeServer(fn pulumi.RunFunc) (*languageRuntimeServer, error) {
+	s := &languageRuntimeServer{
+		fn:     fn,
+		cancel: make(chan bool),
+	}
+
+	handle, err := rpcutil.ServeWithOptions(rpcutil.ServeOptions{
+		Cancel: s.cancel,
+		Init: func(srv *grpc.Server) error {
+			pulumirpc.RegisterLanguageRuntimeServer(srv, s)
+			return nil
+		},
+		Options: rpcutil.OpenTracingServerInterceptorOptions(nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.address, s.done = fmt.Sprintf("127.0.0.1:%d", handle.Port), handle.Done
+	return s, nil
+}
+
+// Close stops the language host. The engine has always finished running the program by the time an operation
+// returns, so there is no need to wait for it.
+func (s *languageRuntimeServer) Close() error {
+	s.cancel <- true
+	close(s.cancel)
+	return <-s.done
+}
+
+func (s *languageRuntimeServer) GetRequiredPlugins(ctx context.Context,
+	req *pulumirpc.GetRequiredPluginsRequest,
+) (*pulumirpc.GetRequiredPluginsResponse, error) {
+	return &pulumirpc.GetRequiredPluginsResponse{}, nil
+}
+
+func (s *languageRuntimeServer) Run(ctx context.Context, req *pulumirpc.RunRequest) (*pulumirpc.RunResponse, error) {
+	var engineAddress string
+	if len(req.Args) > 0 {
+		engineAddress = req.Args[0]
+	}
+	runInfo := pulumi.RunInfo{
+		EngineAddr:       engineAddress,
+		MonitorAddr:      req.GetMonitorAddress(),
+		Config:           req.GetConfig(),
+		ConfigSecretKeys: req.GetConfigSecretKeys(),
+		Project:          req.GetProject(),
+		Stack:            req.GetStack(),
+		Parallel:         int(req.GetParallel()),
+		DryRun:           req.GetDryRun(),
+		Organization:     req.GetOrganization(),
+	}
+
+	pulumiCtx, err := pulumi.NewContext(ctx, runInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer pulumiCtx.Close()
+
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if pErr, ok := r.(error); ok {
+					err = fmt.Errorf("go inline source runtime error, an unhandled error occurred: %w", pErr)
+				} else {
+					err = errors.New("go inline source runtime error, an unhandled error occurred: unknown error")
+				}
+			}
+		}()
+
+		return pulumi.RunWithContext(pulumiCtx, s.fn)
+	}()
+	if err != nil {
+		return &pulumirpc.RunResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.RunResponse{}, nil
+}
+
+func (s *languageRuntimeServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{
+		Version: "1.0.0",
+	}, nil
+}
+
+func (s *languageRuntimeServer) InstallDependencies(
+	req *pulumirpc.InstallDependenciesRequest,
+	server pulumirpc.LanguageRuntime_InstallDependenciesServer,
+) error {
+	return nil
+}
@@ -0,0 +1,628 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/util/cancel"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optdestroy"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/opthistory"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optpreview"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optrefresh"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optup"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/constant"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// operationOptions are the options that are common to all stack lifecycle operations.
+type operationOptions struct {
+	parallel             int
+	message              string
+	diff                 bool
+	replace              []string
+	target               []string
+	targetDependents     bool
+	debug                bool
+	progressStreams      []io.Writer
+	errorProgressStreams []io.Writer
+	eventStreams         []chan<- events.EngineEvent
+	userAgent            string
+	color                string
+	summaryFile          string
+	plan                 string
+	policyPacks          []string
+	policyPackConfigs    []string
+}
+
+// operation is a stack lifecycle operation that is ready to run.
+type operation struct {
+	stack  backend.Stack
+	op     backend.UpdateOperation
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+
+	language  *languageRuntimeServer
+	eventsIn  chan engine.Event
+	eventsOut <-chan bool
+}
+
+// newOperation prepares a stack lifecycle operation, starting a language host for the workspace's program if it has
+// one. The operation must be closed once it has run. If the operation can't be prepared, its event streams are closed.
+func (w *Workspace) newOperation(
+	ctx context.Context, stackName string, kind apitype.UpdateKind, opts operationOptions,
+) (_ *operation, err error) {
+	defer func() {
+		if err != nil {
+			for _, s := range opts.eventStreams {
+				close(s)
+			}
+		}
+	}()
+
+	if opts.plan != "" || len(opts.policyPacks) > 0 || len(opts.policyPackConfigs) > 0 {
+		return nil, errors.New("plans and policy packs are not supported in process")
+	}
+
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	project, err := w.ProjectSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps.Base) > 0 {
+		return nil, errors.New("stack configuration with base files is not supported in process")
+	}
+	sm, err := w.stackSecretsManager(ctx, stackName, ps)
+	if err != nil {
+		return nil, err
+	}
+	var decrypter config.Decrypter = config.NewPanicCrypter()
+	if ps.Config.HasSecureValue() {
+		if decrypter, err = sm.Decrypter(); err != nil {
+			return nil, fmt.Errorf("getting configuration decrypter: %w", err)
+		}
+	}
+
+	// Apply the same project settings as the CLI: config defaults and constraints, concurrency limits and, for
+	// operations that change resources, the retry policy.
+	err = workspace.ValidateStackConfigAndApplyProjectConfig(stackName, project, ps.Config, decrypter)
+	if err != nil {
+		return nil, fmt.Errorf("validating stack config: %w", err)
+	}
+	limits, err := backend.GetProjectConcurrencyLimits(project)
+	if err != nil {
+		return nil, err
+	}
+	if len(limits) == 0 {
+		limits = nil
+	}
+	var retryPolicy *resource.RetryPolicy
+	if kind != apitype.RefreshUpdate {
+		if retryPolicy, err = backend.GetProjectRetryPolicy(project); err != nil {
+			return nil, err
+		}
+	}
+
+	o := &operation{
+		stack:  s,
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+	}
+
+	execKind := constant.ExecKindAutoLocal
+	if w.program != nil {
+		// The engine needs a language host to gather plugins for every kind of operation, so one is started even for
+		// refreshes and destroys, which don't run the program.
+		if o.language, err = startLanguageRuntimeServer(w.program); err != nil {
+			return nil, fmt.Errorf("failed to launch language host: %w", err)
+		}
+		project.Runtime = workspace.NewProjectRuntimeInfo("client", map[string]interface{}{
+			"address": o.language.address,
+		})
+		execKind = constant.ExecKindAutoInline
+	}
+
+	environment := map[string]string{backend.ExecutionKind: execKind}
+	if opts.userAgent != "" {
+		environment[backend.ExecutionAgent] = opts.userAgent
+	}
+
+	displayType := display.DisplayProgress
+	if opts.diff {
+		displayType = display.DisplayDiff
+	}
+	color := colors.Never
+	switch opts.color {
+	case "always":
+		color = colors.Always
+	case "raw":
+		color = colors.Raw
+	}
+
+	parallel := opts.parallel
+	if parallel <= 0 {
+		parallel = math.MaxInt32
+	}
+
+	var eventStream chan<- engine.Event
+	if len(opts.eventStreams) > 0 {
+		in, done := make(chan engine.Event), make(chan bool)
+		go forwardEvents(in, done, opts.eventStreams)
+		eventStream, o.eventsIn, o.eventsOut = in, in, done
+	}
+
+	o.op = backend.UpdateOperation{
+		Proj: project,
+		Root: w.workDir,
+		M: &backend.UpdateMetadata{
+			Message:     opts.message,
+			Environment: environment,
+		},
+		Opts: backend.UpdateOptions{
+			Engine: engine.UpdateOptions{
+				Parallel:          parallel,
+				ConcurrencyLimits: limits,
+				RetryPolicy:       retryPolicy,
+				Debug:             opts.debug,
+				ReplaceTargets:    deploy.NewUrnTargets(opts.replace),
+				Targets:           deploy.NewUrnTargets(opts.target),
+				TargetDependents:  opts.targetDependents,
+			},
+			// Display the operation as the CLI does by default, which is how the local workspace runs it.
+			Display: display.Options{
+				Color:                color,
+				ShowReplacementSteps: false,
+				ShowSameResources:    false,
+				SuppressOutputs:      false,
+				// The CLI doesn't print permalinks for stacks in self-managed backends by default.
+				SuppressPermalink: true,
+				IsInteractive:     false,
+				Type:              displayType,
+				SummaryFilePath:   w.summaryFileName(opts.summaryFile),
+				Debug:             opts.debug,
+				Stdout:            io.MultiWriter(append([]io.Writer{o.stdout}, opts.progressStreams...)...),
+				Stderr:            io.MultiWriter(append([]io.Writer{o.stderr}, opts.errorProgressStreams...)...),
+				EventStream:       eventStream,
+			},
+			AutoApprove: true,
+			SkipPreview: true,
+		},
+		StackConfiguration: backend.StackConfiguration{
+			Config:    ps.Config,
+			Decrypter: decrypter,
+		},
+		SecretsManager:  sm,
+		SecretsProvider: w.stateSecretsProvider(),
+		Scopes:          cancellationScopeSource{ctx: ctx},
+	}
+	return o, nil
+}
+
+// Close stops the language host of the operation and waits for its events to be delivered.
+func (o *operation) Close() error {
+	if o.eventsIn != nil {
+		close(o.eventsIn)
+		<-o.eventsOut
+	}
+	if o.language != nil {
+		return o.language.Close()
+	}
+	return nil
+}
+
+// fail returns the error for an operation that failed, in the same format as a failed CLI command.
+func (o *operation) fail(verb string, res result.Result) error {
+	err := res.Error()
+	if err == nil {
+		err = errors.New("one or more errors occurred")
+	}
+	return fmt.Errorf("failed to %s: %w\nstdout: %s\nstderr: %s", verb, err, o.stdout.String(), o.stderr.String())
+}
+
+// forwardEvents converts engine events and sends them to the given event streams. Events are queued for each stream,
+// so the engine never waits for a consumer that is slow to read them or has stopped reading them, just as it doesn't
+// when the CLI writes events to a log file. Once the events channel is closed, forwardEvents waits for every queued
+// event to be delivered and closes the streams, so an operation doesn't return until its event streams are drained.
+func forwardEvents(in <-chan engine.Event, done chan<- bool, streams []chan<- events.EngineEvent) {
+	defer close(done)
+
+	queues, queuesDone := make([]chan<- events.EngineEvent, len(streams)), make([]chan bool, len(streams))
+	for i, s := range streams {
+		queuesDone[i] = make(chan bool)
+		queues[i] = queueEvents(s, queuesDone[i])
+	}
+	defer func() {
+		for i, q := range queues {
+			close(q)
+			<-queuesDone[i]
+		}
+	}()
+
+	sequence := 0
+	for e := range in {
+		apiEvent, err := display.ConvertEngineEvent(e, false /* showSecrets */)
+		if err != nil {
+			// Events that the service wouldn't accept aren't forwarded, just as they aren't logged by the CLI.
+			continue
+		}
+		sequence++
+		apiEvent.Sequence = sequence
+		apiEvent.Timestamp = int(time.Now().Unix())
+
+		for _, q := range queues {
+			q <- events.EngineEvent{EngineEvent: apiEvent}
+		}
+	}
+}
+
+// queueEvents returns a channel whose events are delivered to the given stream in order. Sends to the channel never
+// wait for the stream's consumer. Once the channel is closed and its queued events are delivered, the stream and done
+// are closed.
+func queueEvents(stream chan<- events.EngineEvent, done chan<- bool) chan<- events.EngineEvent {
+	in := make(chan events.EngineEvent)
+	go func() {
+		defer close(done)
+		defer close(stream)
+
+		src := in
+		var queue []events.EngineEvent
+		for src != nil || len(queue) > 0 {
+			// A nil channel is never ready, so nothing is sent until an event has been queued.
+			var out chan<- events.EngineEvent
+			var next events.EngineEvent
+			if len(queue) > 0 {
+				out, next = stream, queue[0]
+			}
+
+			select {
+			case e, ok := <-src:
+				if !ok {
+					src = nil
+					continue
+				}
+				queue = append(queue, e)
+			case out <- next:
+				queue = queue[1:]
+			}
+		}
+	}()
+	return in
+}
+
+// cancellationScopeSource creates cancellation scopes that cancel the operation when a context is done.
+type cancellationScopeSource struct {
+	ctx context.Context
+}
+
+type cancellationScope struct {
+	context *cancel.Context
+	done    chan bool
+}
+
+func (s cancellationScopeSource) NewScope(events chan<- engine.Event, isPreview bool) backend.CancellationScope {
+	cancelContext, cancelSource := cancel.NewContext(context.Background())
+	scope := &cancellationScope{context: cancelContext, done: make(chan bool)}
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			cancelSource.Cancel()
+		case <-scope.done:
+		}
+	}()
+	return scope
+}
+
+func (s *cancellationScope) Context() *cancel.Context {
+	return s.context
+}
+
+func (s *cancellationScope) Close() {
+	close(s.done)
+}
+
+// Preview performs a dry-run update to a stack, returning pending changes.
+func (w *Workspace) Preview(
+	ctx context.Context, stackName string, opts *optpreview.Options,
+) (auto.PreviewResult, error) {
+	var res auto.PreviewResult
+	o, err := w.newOperation(ctx, stackName, apitype.PreviewUpdate, operationOptions{
+		parallel:             opts.Parallel,
+		message:              opts.Message,
+		diff:                 opts.Diff,
+		replace:              opts.Replace,
+		target:               opts.Target,
+		targetDependents:     opts.TargetDependents,
+		debug:                opts.DebugLogOpts.Debug,
+		progressStreams:      opts.ProgressStreams,
+		errorProgressStreams: opts.ErrorProgressStreams,
+		eventStreams:         opts.EventStreams,
+		userAgent:            opts.UserAgent,
+		color:                opts.Color,
+		summaryFile:          opts.SummaryFile,
+		plan:                 opts.Plan,
+		policyPacks:          opts.PolicyPacks,
+		policyPackConfigs:    opts.PolicyPackConfigs,
+	})
+	if err != nil {
+		return res, fmt.Errorf("failed to run preview: %w", err)
+	}
+
+	_, changes, r := w.backend.Preview(ctx, o.stack, o.op)
+	if err := o.Close(); err != nil && r == nil {
+		r = result.FromError(err)
+	}
+	if r != nil {
+		return res, o.fail("run preview", r)
+	}
+	if opts.ExpectNoChanges && engine.HasChanges(changes) {
+		return res, o.fail("run preview",
+			result.FromError(errors.New("error: no changes were expected but changes were proposed")))
+	}
+
+	res.StdOut, res.StdErr = o.stdout.String(), o.stderr.String()
+	res.ChangeSummary = map[apitype.OpType]int{}
+	for op, count := range changes {
+		res.ChangeSummary[apitype.OpType(op)] = count
+	}
+	return res, nil
+}
+
+// Up creates or updates the resources in a stack by executing the program in the Workspace.
+func (w *Workspace) Up(ctx context.Context, stackName string, opts *optup.Options) (auto.UpResult, error) {
+	var res auto.UpResult
+	o, err := w.newOperation(ctx, stackName, apitype.UpdateUpdate, operationOptions{
+		parallel:             opts.Parallel,
+		message:              opts.Message,
+		diff:                 opts.Diff,
+		replace:              opts.Replace,
+		target:               opts.Target,
+		targetDependents:     opts.TargetDependents,
+		debug:                opts.DebugLogOpts.Debug,
+		progressStreams:      opts.ProgressStreams,
+		errorProgressStreams: opts.ErrorProgressStreams,
+		eventStreams:         opts.EventStreams,
+		userAgent:            opts.UserAgent,
+		color:                opts.Color,
+		summaryFile:          opts.SummaryFile,
+		plan:                 opts.Plan,
+		policyPacks:          opts.PolicyPacks,
+		policyPackConfigs:    opts.PolicyPackConfigs,
+	})
+	if err != nil {
+		return res, fmt.Errorf("failed to run update: %w", err)
+	}
+
+	changes, r := w.backend.Update(ctx, o.stack, o.op)
+	if err := o.Close(); err != nil && r == nil {
+		r = result.FromError(err)
+	}
+	if r != nil {
+		return res, o.fail("run update", r)
+	}
+	if opts.ExpectNoChanges && engine.HasChanges(changes) {
+		return res, o.fail("run update",
+			result.FromError(errors.New("error: no changes were expected but changes occurred")))
+	}
+
+	outputs, err := w.StackOutputs(ctx, stackName)
+	if err != nil {
+		return res, err
+	}
+	summary, err := w.latestUpdate(ctx, stackName, opts.ShowSecrets)
+	if err != nil {
+		return res, err
+	}
+
+	res.StdOut, res.StdErr = o.stdout.String(), o.stderr.String()
+	res.Outputs, res.Summary = outputs, summary
+	return res, nil
+}
+
+// Refresh refreshes the resources in a stack, reading their current state from the cloud.
+func (w *Workspace) Refresh(
+	ctx context.Context, stackName string, opts *optrefresh.Options,
+) (auto.RefreshResult, error) {
+	var res auto.RefreshResult
+	o, err := w.newOperation(ctx, stackName, apitype.RefreshUpdate, operationOptions{
+		parallel:             opts.Parallel,
+		message:              opts.Message,
+		target:               opts.Target,
+		debug:                opts.DebugLogOpts.Debug,
+		progressStreams:      opts.ProgressStreams,
+		errorProgressStreams: opts.ErrorProgressStreams,
+		eventStreams:         opts.EventStreams,
+		userAgent:            opts.UserAgent,
+		color:                opts.Color,
+		summaryFile:          opts.SummaryFile,
+	})
+	if err != nil {
+		return res, fmt.Errorf("failed to refresh stack: %w", err)
+	}
+
+	changes, r := w.backend.Refresh(ctx, o.stack, o.op)
+	if err := o.Close(); err != nil && r == nil {
+		r = result.FromError(err)
+	}
+	if r != nil {
+		return res, o.fail("refresh stack", r)
+	}
+	if opts.ExpectNoChanges && engine.HasChanges(changes) {
+		return res, o.fail("refresh stack",
+			result.FromError(errors.New("error: no changes were expected but changes occurred")))
+	}
+
+	summary, err := w.latestUpdate(ctx, stackName, opts.ShowSecrets)
+	if err != nil {
+		return res, err
+	}
+
+	res.StdOut, res.StdErr = o.stdout.String(), o.stderr.String()
+	res.Summary = summary
+	return res, nil
+}
+
+// Destroy deletes all resources in a stack, leaving all history and configuration intact.
+func (w *Workspace) Destroy(
+	ctx context.Context, stackName string, opts *optdestroy.Options,
+) (auto.DestroyResult, error) {
+	var res auto.DestroyResult
+	o, err := w.newOperation(ctx, stackName, apitype.DestroyUpdate, operationOptions{
+		parallel:             opts.Parallel,
+		message:              opts.Message,
+		target:               opts.Target,
+		targetDependents:     opts.TargetDependents,
+		debug:                opts.DebugLogOpts.Debug,
+		progressStreams:      opts.ProgressStreams,
+		errorProgressStreams: opts.ErrorProgressStreams,
+		eventStreams:         opts.EventStreams,
+		userAgent:            opts.UserAgent,
+		color:                opts.Color,
+		summaryFile:          opts.SummaryFile,
+	})
+	if err != nil {
+		return res, fmt.Errorf("failed to destroy stack: %w", err)
+	}
+
+	_, r := w.backend.Destroy(ctx, o.stack, o.op)
+	if err := o.Close(); err != nil && r == nil {
+		r = result.FromError(err)
+	}
+	if r != nil {
+		return res, o.fail("destroy stack", r)
+	}
+
+	summary, err := w.latestUpdate(ctx, stackName, opts.ShowSecrets)
+	if err != nil {
+		return res, err
+	}
+
+	res.StdOut, res.StdErr = o.stdout.String(), o.stderr.String()
+	res.Summary = summary
+	return res, nil
+}
+
+// latestUpdate returns a summary of the most recent update to a stack.
+func (w *Workspace) latestUpdate(ctx context.Context, stackName string, showSecrets *bool) (auto.UpdateSummary, error) {
+	history, err := w.History(ctx, stackName, 1 /* pageSize */, 1 /* page */, &opthistory.Options{
+		ShowSecrets: showSecrets,
+	})
+	if err != nil {
+		return auto.UpdateSummary{}, fmt.Errorf("failed to get update history: %w", err)
+	}
+	if len(history) == 0 {
+		return auto.UpdateSummary{}, errors.New("failed to get update history: no updates found")
+	}
+	return history[0], nil
+}
+
+// History returns a list summarizing all previous and current results from Stack lifecycle operations
+// (up/preview/refresh/destroy).
+func (w *Workspace) History(
+	ctx context.Context, stackName string, pageSize, page int, opts *opthistory.Options,
+) ([]auto.UpdateSummary, error) {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stack history: %w", err)
+	}
+	updates, err := w.backend.GetHistory(ctx, s.Ref(), pageSize, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stack history: %w", err)
+	}
+
+	var decrypter config.Decrypter
+	if opts.ShowSecrets == nil || *opts.ShowSecrets {
+		ps, err := w.stackSettings(ctx, stackName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stack history: %w", err)
+		}
+		sm, err := w.stackSecretsManager(ctx, stackName, ps)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stack history: %w", err)
+		}
+		if decrypter, err = sm.Decrypter(); err != nil {
+			return nil, fmt.Errorf("failed to get stack history: %w", err)
+		}
+	}
+
+	history := make([]auto.UpdateSummary, len(updates))
+	for i, update := range updates {
+		summary := auto.UpdateSummary{
+			Version:     update.Version,
+			Kind:        string(update.Kind),
+			StartTime:   time.Unix(update.StartTime, 0).UTC().Format(timeFormat),
+			Message:     update.Message,
+			Environment: update.Environment,
+			Config:      auto.ConfigMap{},
+			Result:      string(update.Result),
+		}
+		for k, v := range update.Config {
+			value := auto.ConfigValue{Secret: v.Secure()}
+			if !v.Secure() || decrypter != nil {
+				plaintext, err := v.Value(decrypter)
+				if err != nil {
+					plaintext = "ERROR_UNABLE_TO_DECRYPT"
+				}
+				value.Value = plaintext
+			}
+			summary.Config[k.String()] = value
+		}
+		if update.Result != backend.InProgressResult {
+			endTime := time.Unix(update.EndTime, 0).UTC().Format(timeFormat)
+			resourceChanges := make(map[string]int)
+			for k, v := range update.ResourceChanges {
+				resourceChanges[string(k)] = v
+			}
+			summary.EndTime, summary.ResourceChanges = &endTime, &resourceChanges
+		}
+		history[i] = summary
+	}
+	return history, nil
+}
+
+// summaryFileName resolves the path of a summary file relative to the working directory of the workspace, as the CLI
+// would.
+func (w *Workspace) summaryFileName(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(w.workDir, path)
+}
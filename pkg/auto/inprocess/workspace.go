@@ -0,0 +1,1067 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inprocess implements an Automation API workspace that runs the Pulumi engine in the current process, rather
+// than running the Pulumi CLI.
+//
+// A Workspace keeps its state in a self-managed backend (file://, s3://, azblob:// or gs://) and runs inline programs
+// with an in-memory language host, so it needs neither the Pulumi CLI nor the pulumi-language-go plugin. It
+// implements auto.EngineWorkspace, so its stacks are used in the same way as those of an auto.LocalWorkspace:
+//
+//	ws, err := inprocess.NewWorkspace(ctx,
+//		inprocess.Project(project), inprocess.Program(program), inprocess.BackendURL("file://"+stateDir))
+//	...
+//	s, err := auto.UpsertStack(ctx, "dev", ws)
+//	...
+//	res, err := s.Up(ctx)
+//
+// Like a LocalWorkspace, a Workspace keeps project and stack settings in Pulumi.yaml and Pulumi.<stack>.yaml files in
+// its working directory. Secrets use the passphrase secrets provider unless another one is configured. The
+// passphrase is read from the PULUMI_CONFIG_PASSPHRASE or PULUMI_CONFIG_PASSPHRASE_FILE variables of the workspace's
+// environment, or else of the process's environment. Other environment variables of the workspace are not applied
+// to the process, so provider plugins only see the process's environment.
+//
+// Stack operations that have no in-process implementation, such as Stack.Cancel, Stack.ImportResources and the
+// Stack state operations, still run the Pulumi CLI.
+package inprocess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/external"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/v3/version"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optremove"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+var settingsExtensions = []string{".yaml", ".yml", ".json"}
+
+// Workspace is an auto.EngineWorkspace that runs the Pulumi engine in the current process.
+type Workspace struct {
+	workDir         string
+	program         pulumi.RunFunc
+	envvars         map[string]string
+	secretsProvider string
+	backend         backend.Backend
+	currentStack    string
+}
+
+var _ auto.EngineWorkspace = (*Workspace)(nil)
+
+type options struct {
+	workDir         string
+	program         pulumi.RunFunc
+	project         *workspace.Project
+	stacks          map[string]workspace.ProjectStack
+	backendURL      string
+	secretsProvider string
+	envvars         map[string]string
+}
+
+// Option is used to customize and configure a Workspace at initialization time.
+type Option interface {
+	applyOption(*options)
+}
+
+type optionFunc func(*options)
+
+func (o optionFunc) applyOption(opts *options) {
+	o(opts)
+}
+
+// WorkDir is the directory that holds the project and stack settings. Defaults to a new temporary directory.
+func WorkDir(workDir string) Option {
+	return optionFunc(func(o *options) {
+		o.workDir = workDir
+	})
+}
+
+// Program is the Pulumi program to run. If none is supplied, the program identified by the project settings is run
+// with its language plugin.
+func Program(program pulumi.RunFunc) Option {
+	return optionFunc(func(o *options) {
+		o.program = program
+	})
+}
+
+// Project sets project settings for the workspace.
+func Project(settings workspace.Project) Option {
+	return optionFunc(func(o *options) {
+		o.project = &settings
+	})
+}
+
+// Stacks is a list of stack settings objects to seed the workspace.
+func Stacks(settings map[string]workspace.ProjectStack) Option {
+	return optionFunc(func(o *options) {
+		o.stacks = settings
+	})
+}
+
+// BackendURL is the URL of the self-managed backend that stores the state of the workspace's stacks. Defaults to the
+// backend URL of the project settings, then to PULUMI_BACKEND_URL, and then to file://~.
+func BackendURL(url string) Option {
+	return optionFunc(func(o *options) {
+		o.backendURL = url
+	})
+}
+
+// SecretsProvider is the secrets provider to use for stacks created by the workspace.
+func SecretsProvider(secretsProvider string) Option {
+	return optionFunc(func(o *options) {
+		o.secretsProvider = secretsProvider
+	})
+}
+
+// EnvVars is a map of environment values scoped to the workspace.
+func EnvVars(envvars map[string]string) Option {
+	return optionFunc(func(o *options) {
+		o.envvars = envvars
+	})
+}
+
+// NewWorkspace creates and configures a Workspace.
+func NewWorkspace(ctx context.Context, opts ...Option) (*Workspace, error) {
+	var wsOpts options
+	for _, o := range opts {
+		o.applyOption(&wsOpts)
+	}
+
+	workDir := wsOpts.workDir
+	if workDir == "" {
+		dir, err := os.MkdirTemp("", "pulumi_inprocess")
+		if err != nil {
+			return nil, fmt.Errorf("unable to create tmp directory for workspace: %w", err)
+		}
+		workDir = dir
+	}
+
+	w := &Workspace{
+		workDir:         workDir,
+		program:         wsOpts.program,
+		envvars:         map[string]string{},
+		secretsProvider: wsOpts.secretsProvider,
+	}
+	for k, v := range wsOpts.envvars {
+		w.envvars[k] = v
+	}
+
+	if wsOpts.project != nil {
+		if err := w.SaveProjectSettings(ctx, wsOpts.project); err != nil {
+			return nil, fmt.Errorf("failed to save project settings: %w", err)
+		}
+	}
+	project, err := w.ProjectSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, settings := range wsOpts.stacks {
+		settings := settings
+		if err := w.SaveStackSettings(ctx, name, &settings); err != nil {
+			return nil, fmt.Errorf("failed to save stack settings for %s: %w", name, err)
+		}
+	}
+
+	url := wsOpts.backendURL
+	if url == "" && project.Backend != nil {
+		url = project.Backend.URL
+	}
+	if url == "" {
+		url = w.getenv("PULUMI_BACKEND_URL")
+	}
+	if url == "" {
+		url = filestate.FilePathPrefix + "~"
+	}
+	b, err := filestate.New(ctx, cmdutil.Diag(), url, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backend %s: %w", url, err)
+	}
+	w.backend = b
+
+	return w, nil
+}
+
+// NewStackInlineSource creates a Stack backed by a new Workspace with the specified program. It fails if the stack
+// already exists. If no Project option is specified and the working directory has no project settings, default
+// project settings are created with the specified project name.
+func NewStackInlineSource(
+	ctx context.Context, stackName, projectName string, program pulumi.RunFunc, opts ...Option,
+) (auto.Stack, error) {
+	w, err := newInlineWorkspace(ctx, projectName, program, opts)
+	if err != nil {
+		return auto.Stack{}, fmt.Errorf("failed to create stack: %w", err)
+	}
+	return auto.NewStack(ctx, stackName, w)
+}
+
+// SelectStackInlineSource selects an existing Stack backed by a new Workspace with the specified program. If no
+// Project option is specified and the working directory has no project settings, default project settings are created
+// with the specified project name.
+func SelectStackInlineSource(
+	ctx context.Context, stackName, projectName string, program pulumi.RunFunc, opts ...Option,
+) (auto.Stack, error) {
+	w, err := newInlineWorkspace(ctx, projectName, program, opts)
+	if err != nil {
+		return auto.Stack{}, fmt.Errorf("failed to select stack: %w", err)
+	}
+	return auto.SelectStack(ctx, stackName, w)
+}
+
+// UpsertStackInlineSource selects an existing Stack backed by a new Workspace with the specified program, or creates
+// it if it doesn't exist. If no Project option is specified and the working directory has no project settings,
+// default project settings are created with the specified project name.
+func UpsertStackInlineSource(
+	ctx context.Context, stackName, projectName string, program pulumi.RunFunc, opts ...Option,
+) (auto.Stack, error) {
+	w, err := newInlineWorkspace(ctx, projectName, program, opts)
+	if err != nil {
+		return auto.Stack{}, fmt.Errorf("failed to create stack: %w", err)
+	}
+	return auto.UpsertStack(ctx, stackName, w)
+}
+
+// newInlineWorkspace creates a Workspace for an inline program, with default project settings if there are none.
+func newInlineWorkspace(
+	ctx context.Context, projectName string, program pulumi.RunFunc, opts []Option,
+) (*Workspace, error) {
+	var wsOpts options
+	for _, o := range opts {
+		o.applyOption(&wsOpts)
+	}
+
+	opts = append(opts, Program(program))
+	if wsOpts.project == nil && (wsOpts.workDir == "" || findSettingsFile(wsOpts.workDir, "Pulumi") == "") {
+		opts = append(opts, Project(workspace.Project{
+			Name:    tokens.PackageName(projectName),
+			Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+		}))
+	}
+	return NewWorkspace(ctx, opts...)
+}
+
+// findSettingsFile returns the path of the settings file with the given base name in a directory, or "" if there is
+// none.
+func findSettingsFile(dir, base string) string {
+	for _, ext := range settingsExtensions {
+		path := filepath.Join(dir, base+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// getStackSettingsName returns the name of a stack without the organization and project that may qualify it.
+func getStackSettingsName(stackName string) string {
+	parts := strings.Split(stackName, "/")
+	return parts[len(parts)-1]
+}
+
+// getenv returns the value of an environment variable of the workspace, or else of the process.
+func (w *Workspace) getenv(key string) string {
+	if v, ok := w.envvars[key]; ok {
+		return v
+	}
+	return os.Getenv(key)
+}
+
+// ProjectSettings returns the settings object for the current project if any.
+// Workspace reads settings from the Pulumi.yaml in the workspace.
+func (w *Workspace) ProjectSettings(ctx context.Context) (*workspace.Project, error) {
+	path := findSettingsFile(w.workDir, "Pulumi")
+	if path == "" {
+		return nil, fmt.Errorf("unable to find project settings in workspace %s", w.workDir)
+	}
+	return workspace.LoadProject(path)
+}
+
+// SaveProjectSettings overwrites the settings object in the current project.
+// Workspace writes this value to a Pulumi.yaml file in Workspace.WorkDir().
+func (w *Workspace) SaveProjectSettings(ctx context.Context, settings *workspace.Project) error {
+	if err := settings.Save(filepath.Join(w.workDir, "Pulumi.yaml")); err != nil {
+		return err
+	}
+	if w.backend != nil {
+		w.backend.SetCurrentProject(settings)
+	}
+	return nil
+}
+
+// StackSettings returns the settings object for the stack matching the specified stack name if any.
+// Workspace reads this from a Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) StackSettings(ctx context.Context, stackName string) (*workspace.ProjectStack, error) {
+	project, err := w.ProjectSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := findSettingsFile(w.workDir, "Pulumi."+getStackSettingsName(stackName))
+	if path == "" {
+		return nil, fmt.Errorf("unable to find stack settings in workspace for %s", stackName)
+	}
+	ps, err := workspace.LoadProjectStack(project, path)
+	if err != nil {
+		return nil, fmt.Errorf("found stack settings, but failed to load: %w", err)
+	}
+	return ps, nil
+}
+
+// SaveStackSettings overwrites the settings object for the stack matching the specified stack name.
+// Workspace writes this value to a Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) SaveStackSettings(
+	ctx context.Context, stackName string, settings *workspace.ProjectStack,
+) error {
+	path := filepath.Join(w.workDir, fmt.Sprintf("Pulumi.%s.yaml", getStackSettingsName(stackName)))
+	if err := settings.Save(path); err != nil {
+		return fmt.Errorf("failed to save stack setttings for %s: %w", stackName, err)
+	}
+	return nil
+}
+
+// stackSettings returns the settings of a stack, or empty settings if the stack has none yet.
+func (w *Workspace) stackSettings(ctx context.Context, stackName string) (*workspace.ProjectStack, error) {
+	if findSettingsFile(w.workDir, "Pulumi."+getStackSettingsName(stackName)) == "" {
+		return &workspace.ProjectStack{Config: config.Map{}}, nil
+	}
+	ps, err := w.StackSettings(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	if ps.Config == nil {
+		ps.Config = config.Map{}
+	}
+	return ps, nil
+}
+
+// SerializeArgsForOp is hook to provide additional args to every CLI commands before they are executed.
+// Workspace does not utilize this extensibility point.
+func (w *Workspace) SerializeArgsForOp(ctx context.Context, stackName string) ([]string, error) {
+	return nil, nil
+}
+
+// PostCommandCallback is a hook executed after every command.
+// Workspace does not utilize this extensibility point.
+func (w *Workspace) PostCommandCallback(ctx context.Context, stackName string) error {
+	return nil
+}
+
+// passphrase returns the passphrase for the passphrase secrets provider.
+func (w *Workspace) passphrase() (string, error) {
+	if phrase, ok := w.envvars["PULUMI_CONFIG_PASSPHRASE"]; ok {
+		return phrase, nil
+	}
+	if path, ok := w.envvars["PULUMI_CONFIG_PASSPHRASE_FILE"]; ok {
+		return passphrase.ReadPassphraseFile(path)
+	}
+	phrase, ok, err := passphrase.LookupPassphraseEnv("PULUMI_CONFIG_PASSPHRASE", "PULUMI_CONFIG_PASSPHRASE_FILE")
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", errors.New("passphrase must be set with PULUMI_CONFIG_PASSPHRASE or " +
+			"PULUMI_CONFIG_PASSPHRASE_FILE environment variables")
+	}
+	return phrase, nil
+}
+
+// secretsManager returns the secrets manager for a stack's settings, configuring the secrets provider in the
+// settings if it isn't configured yet.
+func (w *Workspace) secretsManager(ps *workspace.ProjectStack) (secrets.Manager, error) {
+	switch {
+	case external.IsExternalSecretsProvider(ps.SecretsProvider):
+		return external.NewExternalSecretsManager(ps, ps.SecretsProvider)
+	case ps.SecretsProvider != "" && ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default":
+		return cloud.NewCloudSecretsManager(ps, ps.SecretsProvider, false /* rotateSecretsProvider */)
+	}
+
+	phrase, err := w.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if ps.EncryptionSalt != "" {
+		return passphrase.GetPassphraseSecretsManager(phrase, ps.EncryptionSalt)
+	}
+	state, sm, err := passphrase.NewPassphraseSecretsManager(phrase)
+	if err != nil {
+		return nil, err
+	}
+	ps.EncryptionSalt = state
+	return sm, nil
+}
+
+// stackSecretsManager returns the secrets manager of a stack, saving the stack's settings if configuring the secrets
+// provider changed them.
+func (w *Workspace) stackSecretsManager(
+	ctx context.Context, stackName string, ps *workspace.ProjectStack,
+) (secrets.Manager, error) {
+	salt, key, provider, recipients := ps.EncryptionSalt, ps.EncryptedKey, ps.SecretsProvider, ps.SecretsRecipients
+	sm, err := w.secretsManager(ps)
+	if err != nil {
+		return nil, err
+	}
+	if ps.EncryptionSalt != salt || ps.EncryptedKey != key || ps.SecretsProvider != provider ||
+		!reflect.DeepEqual(ps.SecretsRecipients, recipients) {
+		if err := w.SaveStackSettings(ctx, stackName, ps); err != nil {
+			return nil, err
+		}
+	}
+	return stack.NewCachingSecretsManager(sm), nil
+}
+
+// stateSecretsProvider returns the secrets provider used to read the state of the workspace's stacks.
+func (w *Workspace) stateSecretsProvider() secrets.Provider {
+	return secretsProvider{w: w}
+}
+
+// secretsProvider creates passphrase secrets managers with the workspace's passphrase, rather than one from the
+// process's environment. Other types of secrets managers are created by the default provider.
+type secretsProvider struct {
+	w *Workspace
+}
+
+func (p secretsProvider) OfType(ty string, state json.RawMessage) (secrets.Manager, error) {
+	if ty != passphrase.Type {
+		return stack.DefaultSecretsProvider.OfType(ty, state)
+	}
+	phrase, err := p.w.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	sm, err := passphrase.NewPassphraseSecretsManagerFromState(phrase, state)
+	if err != nil {
+		return nil, fmt.Errorf("constructing secrets manager of type %q: %w", ty, err)
+	}
+	return sm, nil
+}
+
+// parseConfigKey parses a config key, treating a key without a namespace as belonging to the project.
+func (w *Workspace) parseConfigKey(ctx context.Context, key string) (config.Key, error) {
+	if !strings.Contains(key, tokens.TokenDelimiter) {
+		project, err := w.ProjectSettings(ctx)
+		if err != nil {
+			return config.Key{}, err
+		}
+		key = fmt.Sprintf("%s:%s", project.Name, key)
+	}
+	return config.ParseKey(key)
+}
+
+// configDecrypter returns a decrypter for the config of a stack, which only needs the stack's secrets manager if the
+// config has secrets.
+func (w *Workspace) configDecrypter(
+	ctx context.Context, stackName string, ps *workspace.ProjectStack,
+) (config.Decrypter, error) {
+	if !ps.Config.HasSecureValue() {
+		return config.NewPanicCrypter(), nil
+	}
+	sm, err := w.stackSecretsManager(ctx, stackName, ps)
+	if err != nil {
+		return nil, err
+	}
+	return sm.Decrypter()
+}
+
+// GetConfig returns the value associated with the specified stack name and key,
+// scoped to the current workspace. Workspace reads this config from the matching Pulumi.stack.yaml file.
+func (w *Workspace) GetConfig(ctx context.Context, stackName string, key string) (auto.ConfigValue, error) {
+	return w.GetConfigWithOptions(ctx, stackName, key, nil)
+}
+
+// GetConfigWithOptions returns the value associated with the specified stack name and key using the optional
+// ConfigOptions, scoped to the current workspace. Workspace reads this config from the matching Pulumi.stack.yaml
+// file.
+func (w *Workspace) GetConfigWithOptions(
+	ctx context.Context, stackName string, key string, opts *auto.ConfigOptions,
+) (auto.ConfigValue, error) {
+	var val auto.ConfigValue
+	k, err := w.parseConfigKey(ctx, key)
+	if err != nil {
+		return val, fmt.Errorf("unable to read config: %w", err)
+	}
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return val, fmt.Errorf("unable to read config: %w", err)
+	}
+
+	v, ok, err := ps.Config.Get(k, opts != nil && opts.Path)
+	if err != nil {
+		return val, fmt.Errorf("unable to read config: %w", err)
+	}
+	if !ok {
+		return val, fmt.Errorf("unable to read config: configuration key '%s' not found for stack '%s'",
+			key, stackName)
+	}
+
+	var decrypter config.Decrypter = config.NewPanicCrypter()
+	if v.Secure() {
+		if decrypter, err = w.configDecrypter(ctx, stackName, ps); err != nil {
+			return val, fmt.Errorf("unable to read config: %w", err)
+		}
+	}
+	if val.Value, err = v.Value(decrypter); err != nil {
+		return val, fmt.Errorf("unable to read config: %w", err)
+	}
+	val.Secret = v.Secure()
+	return val, nil
+}
+
+// GetAllConfig returns the config map for the specified stack name, scoped to the current workspace.
+// Workspace reads this config from the matching Pulumi.stack.yaml file.
+func (w *Workspace) GetAllConfig(ctx context.Context, stackName string) (auto.ConfigMap, error) {
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+	decrypter, err := w.configDecrypter(ctx, stackName, ps)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+
+	val := auto.ConfigMap{}
+	for k, v := range ps.Config {
+		plaintext, err := v.Value(decrypter)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read config: %w", err)
+		}
+		val[k.String()] = auto.ConfigValue{Value: plaintext, Secret: v.Secure()}
+	}
+	return val, nil
+}
+
+// SetConfig sets the specified key-value pair on the provided stack name.
+// Workspace writes this value to the matching Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) SetConfig(ctx context.Context, stackName string, key string, val auto.ConfigValue) error {
+	return w.SetConfigWithOptions(ctx, stackName, key, val, nil)
+}
+
+// SetConfigWithOptions sets the specified key-value pair on the provided stack name using the optional ConfigOptions.
+// Workspace writes this value to the matching Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) SetConfigWithOptions(
+	ctx context.Context, stackName string, key string, val auto.ConfigValue, opts *auto.ConfigOptions,
+) error {
+	return w.SetAllConfigWithOptions(ctx, stackName, auto.ConfigMap{key: val}, opts)
+}
+
+// SetAllConfig sets all values in the provided config map for the specified stack name.
+// Workspace writes the config to the matching Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) SetAllConfig(ctx context.Context, stackName string, config auto.ConfigMap) error {
+	return w.SetAllConfigWithOptions(ctx, stackName, config, nil)
+}
+
+// SetAllConfigWithOptions sets all values in the provided config map for the specified stack name using the optional
+// ConfigOptions. Workspace writes the config to the matching Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) SetAllConfigWithOptions(
+	ctx context.Context, stackName string, cfg auto.ConfigMap, opts *auto.ConfigOptions,
+) error {
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("unable to set config: %w", err)
+	}
+
+	var encrypter config.Encrypter
+	for key, val := range cfg {
+		k, err := w.parseConfigKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("unable to set config: %w", err)
+		}
+
+		v := config.NewValue(val.Value)
+		if val.Secret {
+			if encrypter == nil {
+				sm, err := w.stackSecretsManager(ctx, stackName, ps)
+				if err != nil {
+					return fmt.Errorf("unable to set config: %w", err)
+				}
+				if encrypter, err = sm.Encrypter(); err != nil {
+					return fmt.Errorf("unable to set config: %w", err)
+				}
+			}
+			ciphertext, err := encrypter.EncryptValue(ctx, val.Value)
+			if err != nil {
+				return fmt.Errorf("unable to set config: %w", err)
+			}
+			v = config.NewSecureValue(ciphertext)
+		}
+
+		if err = ps.Config.Set(k, v, opts != nil && opts.Path); err != nil {
+			return fmt.Errorf("unable to set config: %w", err)
+		}
+	}
+	return w.SaveStackSettings(ctx, stackName, ps)
+}
+
+// RemoveConfig removes the specified key-value pair on the provided stack name.
+// It will remove any matching values in the Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) RemoveConfig(ctx context.Context, stackName string, key string) error {
+	return w.RemoveConfigWithOptions(ctx, stackName, key, nil)
+}
+
+// RemoveConfigWithOptions removes the specified key-value pair on the provided stack name using the optional
+// ConfigOptions. It will remove any matching values in the Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) RemoveConfigWithOptions(
+	ctx context.Context, stackName string, key string, opts *auto.ConfigOptions,
+) error {
+	return w.RemoveAllConfigWithOptions(ctx, stackName, []string{key}, opts)
+}
+
+// RemoveAllConfig removes all values in the provided key list for the specified stack name.
+// It will remove any matching values in the Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) RemoveAllConfig(ctx context.Context, stackName string, keys []string) error {
+	return w.RemoveAllConfigWithOptions(ctx, stackName, keys, nil)
+}
+
+// RemoveAllConfigWithOptions removes all values in the provided key list for the specified stack name using the
+// optional ConfigOptions. It will remove any matching values in the Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) RemoveAllConfigWithOptions(
+	ctx context.Context, stackName string, keys []string, opts *auto.ConfigOptions,
+) error {
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("unable to remove config: %w", err)
+	}
+	for _, key := range keys {
+		k, err := w.parseConfigKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("unable to remove config: %w", err)
+		}
+		if err = ps.Config.Remove(k, opts != nil && opts.Path); err != nil {
+			return fmt.Errorf("unable to remove config: %w", err)
+		}
+	}
+	return w.SaveStackSettings(ctx, stackName, ps)
+}
+
+// RefreshConfig gets and sets the config map used with the last update for Stack matching stack name.
+// It will overwrite all configuration in the Pulumi.<stack>.yaml file in Workspace.WorkDir().
+func (w *Workspace) RefreshConfig(ctx context.Context, stackName string) (auto.ConfigMap, error) {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh config: %w", err)
+	}
+	cfg, err := w.backend.GetLatestConfiguration(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh config: %w", err)
+	}
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh config: %w", err)
+	}
+	ps.Config = cfg
+	if err = w.SaveStackSettings(ctx, stackName, ps); err != nil {
+		return nil, fmt.Errorf("could not refresh config: %w", err)
+	}
+	return w.GetAllConfig(ctx, stackName)
+}
+
+// GetTag returns the value associated with the specified stack name and key.
+func (w *Workspace) GetTag(ctx context.Context, stackName string, key string) (string, error) {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get stack tag: %w", err)
+	}
+	value, ok := s.Tags()[key]
+	if !ok {
+		return "", fmt.Errorf("failed to get stack tag: stack tag '%s' has no value", key)
+	}
+	return value, nil
+}
+
+// SetTag sets the specified key-value pair on the provided stack name.
+func (w *Workspace) SetTag(ctx context.Context, stackName string, key string, value string) error {
+	return w.updateTags(ctx, stackName, func(tags map[apitype.StackTagName]string) {
+		tags[key] = value
+	})
+}
+
+// RemoveTag removes the specified key-value pair on the provided stack name.
+func (w *Workspace) RemoveTag(ctx context.Context, stackName string, key string) error {
+	return w.updateTags(ctx, stackName, func(tags map[apitype.StackTagName]string) {
+		delete(tags, key)
+	})
+}
+
+// updateTags replaces the tags of a stack with a modified copy.
+func (w *Workspace) updateTags(
+	ctx context.Context, stackName string, update func(map[apitype.StackTagName]string),
+) error {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("failed to update stack tags: %w", err)
+	}
+	tags := map[apitype.StackTagName]string{}
+	for k, v := range s.Tags() {
+		tags[k] = v
+	}
+	update(tags)
+	if err = w.backend.UpdateStackTags(ctx, s, tags); err != nil {
+		return fmt.Errorf("failed to update stack tags: %w", err)
+	}
+	return nil
+}
+
+// ListTags returns the tag map for the specified stack name.
+func (w *Workspace) ListTags(ctx context.Context, stackName string) (map[string]string, error) {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stack tags: %w", err)
+	}
+	tags := map[string]string{}
+	for k, v := range s.Tags() {
+		tags[k] = v
+	}
+	return tags, nil
+}
+
+// GetEnvVars returns the environment values scoped to the current workspace.
+func (w *Workspace) GetEnvVars() map[string]string {
+	return w.envvars
+}
+
+// SetEnvVars sets the specified map of environment values scoped to the current workspace.
+func (w *Workspace) SetEnvVars(envvars map[string]string) error {
+	for k, v := range envvars {
+		w.envvars[k] = v
+	}
+	return nil
+}
+
+// SetEnvVar sets the specified environment value scoped to the current workspace.
+func (w *Workspace) SetEnvVar(key, value string) {
+	w.envvars[key] = value
+}
+
+// UnsetEnvVar unsets the specified environment value scoped to the current workspace.
+func (w *Workspace) UnsetEnvVar(key string) {
+	delete(w.envvars, key)
+}
+
+// WorkDir returns the directory that holds the project and stack settings.
+func (w *Workspace) WorkDir() string {
+	return w.workDir
+}
+
+// PulumiHome returns the directory override for CLI metadata if set. Workspace uses the PULUMI_HOME of the process,
+// so this is always empty.
+func (w *Workspace) PulumiHome() string {
+	return ""
+}
+
+// PulumiVersion returns the version of the engine running in the current process.
+func (w *Workspace) PulumiVersion() string {
+	return version.Version
+}
+
+// WhoAmI returns the currently authenticated user.
+func (w *Workspace) WhoAmI(ctx context.Context) (string, error) {
+	user, _, _, err := w.backend.CurrentUser()
+	if err != nil {
+		return "", fmt.Errorf("could not determine authenticated user: %w", err)
+	}
+	return user, nil
+}
+
+// WhoAmIDetails returns detailed information about the currently logged-in Pulumi identity.
+func (w *Workspace) WhoAmIDetails(ctx context.Context) (auto.WhoAmIResult, error) {
+	user, orgs, _, err := w.backend.CurrentUser()
+	if err != nil {
+		return auto.WhoAmIResult{}, fmt.Errorf("could not determine authenticated user: %w", err)
+	}
+	return auto.WhoAmIResult{User: user, Organizations: orgs, URL: w.backend.URL()}, nil
+}
+
+// getStack returns the stack with the given name, or an error wrapping auto.ErrStackNotFound if there is none.
+func (w *Workspace) getStack(ctx context.Context, stackName string) (backend.Stack, error) {
+	ref, err := w.backend.ParseStackReference(stackName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := w.backend.GetStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("no stack named '%s' found: %w", stackName, auto.ErrStackNotFound)
+	}
+	return s, nil
+}
+
+// Stack returns a summary of the currently selected stack, if any.
+func (w *Workspace) Stack(ctx context.Context) (*auto.StackSummary, error) {
+	stacks, err := w.ListStacks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine selected stack: %w", err)
+	}
+	for _, s := range stacks {
+		if s.Current {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateStack creates and sets a new stack with the stack name, failing if one already exists.
+func (w *Workspace) CreateStack(ctx context.Context, stackName string) error {
+	ref, err := w.backend.ParseStackReference(stackName)
+	if err != nil {
+		return fmt.Errorf("failed to create stack: %w", err)
+	}
+	ps, err := w.stackSettings(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("failed to create stack: %w", err)
+	}
+
+	if _, err = w.backend.CreateStack(ctx, ref, w.workDir, nil /* opts */); err != nil {
+		var existsErr *backend.StackAlreadyExistsError
+		if errors.As(err, &existsErr) {
+			return fmt.Errorf("failed to create stack: %v: %w", err, auto.ErrStackAlreadyExists)
+		}
+		return fmt.Errorf("failed to create stack: %w", err)
+	}
+
+	if w.secretsProvider != "" {
+		ps.SecretsProvider = w.secretsProvider
+	}
+	if _, err = w.secretsManager(ps); err != nil {
+		return fmt.Errorf("failed to create stack: %w", err)
+	}
+	if err = w.SaveStackSettings(ctx, stackName, ps); err != nil {
+		return fmt.Errorf("failed to create stack: %w", err)
+	}
+
+	w.currentStack = ref.String()
+	return nil
+}
+
+// SelectStack selects and sets an existing stack matching the stack name, failing if none exists.
+func (w *Workspace) SelectStack(ctx context.Context, stackName string) error {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("failed to select stack: %w", err)
+	}
+	w.currentStack = s.Ref().String()
+	return nil
+}
+
+// RemoveStack deletes the stack and all associated configuration and history.
+func (w *Workspace) RemoveStack(ctx context.Context, stackName string, opts ...optremove.Option) error {
+	optRemoveOpts := &optremove.Options{}
+	for _, o := range opts {
+		o.ApplyOption(optRemoveOpts)
+	}
+
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("failed to remove stack: %w", err)
+	}
+	if _, err = w.backend.RemoveStack(ctx, s, optRemoveOpts.Force); err != nil {
+		return fmt.Errorf("failed to remove stack: %w", err)
+	}
+
+	if path := findSettingsFile(w.workDir, "Pulumi."+getStackSettingsName(stackName)); path != "" {
+		if err = os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove stack settings: %w", err)
+		}
+	}
+	if w.currentStack == s.Ref().String() {
+		w.currentStack = ""
+	}
+	return nil
+}
+
+// ListStacks returns all Stacks created under the current Project.
+// This queries the backend and may return stacks not present in the Workspace (as Pulumi.<stack>.yaml files).
+func (w *Workspace) ListStacks(ctx context.Context) ([]auto.StackSummary, error) {
+	project, err := w.ProjectSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list stacks: %w", err)
+	}
+	projectName := string(project.Name)
+	summaries, _, err := w.backend.ListStacks(ctx, backend.ListStacksFilter{Project: &projectName}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not list stacks: %w", err)
+	}
+
+	stacks := make([]auto.StackSummary, len(summaries))
+	for i, summary := range summaries {
+		stacks[i] = auto.StackSummary{
+			Name:          summary.Name().String(),
+			Current:       summary.Name().String() == w.currentStack,
+			ResourceCount: summary.ResourceCount(),
+		}
+		if lastUpdate := summary.LastUpdate(); lastUpdate != nil {
+			stacks[i].LastUpdate = lastUpdate.UTC().Format(timeFormat)
+		}
+	}
+	return stacks, nil
+}
+
+// InstallPlugin acquires the plugin matching the specified name and version.
+func (w *Workspace) InstallPlugin(ctx context.Context, name string, version string) error {
+	return w.InstallPluginFromServer(ctx, name, version, "")
+}
+
+// InstallPluginFromServer acquires the plugin matching the specified name and version from a third party server.
+func (w *Workspace) InstallPluginFromServer(ctx context.Context, name string, version string, server string) error {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return fmt.Errorf("failed to install plugin: invalid plugin semver: %w", err)
+	}
+	spec := workspace.PluginSpec{
+		Kind:              workspace.ResourcePlugin,
+		Name:              name,
+		Version:           &v,
+		PluginDownloadURL: server,
+	}
+	if workspace.HasPlugin(spec) {
+		return nil
+	}
+
+	_, err = pkgWorkspace.InstallPlugin(spec, func(sev diag.Severity, msg string) {
+		cmdutil.Diag().Logf(sev, diag.RawMessage("", msg))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install plugin: %w", err)
+	}
+	return nil
+}
+
+// RemovePlugin deletes the plugin matching the specified name and version.
+func (w *Workspace) RemovePlugin(ctx context.Context, name string, version string) error {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return fmt.Errorf("failed to remove plugin: invalid plugin semver: %w", err)
+	}
+	plugins, err := workspace.GetPlugins()
+	if err != nil {
+		return fmt.Errorf("failed to remove plugin: %w", err)
+	}
+	for _, plugin := range plugins {
+		if plugin.Kind == workspace.ResourcePlugin && plugin.Name == name &&
+			plugin.Version != nil && plugin.Version.EQ(v) {
+			if err := plugin.Delete(); err != nil {
+				return fmt.Errorf("failed to remove plugin: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ListPlugins lists all installed plugins.
+func (w *Workspace) ListPlugins(ctx context.Context) ([]workspace.PluginInfo, error) {
+	plugins, err := workspace.GetPluginsWithMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("could not list plugins: %w", err)
+	}
+	return plugins, nil
+}
+
+// Program returns the program `pulumi.RunFunc` to be used for Preview/Update if any.
+// If none is specified, the stack will refer to ProjectSettings for this information.
+func (w *Workspace) Program() pulumi.RunFunc {
+	return w.program
+}
+
+// SetProgram sets the program associated with the Workspace to the specified `pulumi.RunFunc`.
+func (w *Workspace) SetProgram(fn pulumi.RunFunc) {
+	w.program = fn
+}
+
+// ExportStack exports the deployment state of the stack matching the given name.
+// This can be combined with ImportStack to edit a stack's state (such as recovery from failed deployments).
+func (w *Workspace) ExportStack(ctx context.Context, stackName string) (apitype.UntypedDeployment, error) {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return apitype.UntypedDeployment{}, fmt.Errorf("could not export stack: %w", err)
+	}
+	deployment, err := s.ExportDeployment(ctx)
+	if err != nil {
+		return apitype.UntypedDeployment{}, fmt.Errorf("could not export stack: %w", err)
+	}
+	return *deployment, nil
+}
+
+// ImportStack imports the specified deployment state into a pre-existing stack.
+// This can be combined with ExportStack to edit a stack's state (such as recovery from failed deployments).
+func (w *Workspace) ImportStack(ctx context.Context, stackName string, state apitype.UntypedDeployment) error {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("could not import stack: %w", err)
+	}
+	if err = s.ImportDeployment(ctx, &state); err != nil {
+		return fmt.Errorf("could not import stack: %w", err)
+	}
+	return nil
+}
+
+// StackOutputs gets the current set of Stack outputs from the last Stack.Up().
+func (w *Workspace) StackOutputs(ctx context.Context, stackName string) (auto.OutputMap, error) {
+	s, err := w.getStack(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get outputs: %w", err)
+	}
+	// The outputs may be encrypted by an external command, which is only run if it is the stack's secrets provider.
+	if ps, err := w.stackSettings(ctx, stackName); err == nil && external.IsExternalSecretsProvider(ps.SecretsProvider) {
+		external.Trust(ps.SecretsProvider)
+	}
+	snap, err := s.Snapshot(ctx, w.stateSecretsProvider())
+	if err != nil {
+		return nil, fmt.Errorf("could not get outputs: %w", err)
+	}
+	root, err := stack.GetRootStackResource(snap)
+	if err != nil {
+		return nil, fmt.Errorf("could not get outputs: %w", err)
+	}
+
+	outputs := auto.OutputMap{}
+	if root == nil {
+		return outputs, nil
+	}
+	plaintexts, err := stack.SerializeProperties(display.MassageSecrets(root.Outputs, true /* showSecrets */),
+		config.NewPanicCrypter(), true /* showSecrets */)
+	if err != nil {
+		return nil, fmt.Errorf("could not get outputs: %w", err)
+	}
+	for k, v := range plaintexts {
+		outputs[k] = auto.OutputValue{
+			Value:  v,
+			Secret: root.Outputs[resource.PropertyKey(k)].ContainsSecrets(),
+		}
+	}
+	return outputs, nil
+}
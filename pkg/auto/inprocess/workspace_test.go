@@ -0,0 +1,230 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optup"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+func newTestStack(t *testing.T, program pulumi.RunFunc) auto.Stack {
+	ctx := context.Background()
+	s, err := NewStackInlineSource(ctx, "dev", "inprocess-test", program,
+		WorkDir(t.TempDir()),
+		BackendURL("file://"+t.TempDir()),
+		EnvVars(map[string]string{"PULUMI_CONFIG_PASSPHRASE": "correct horse battery staple"}))
+	require.NoError(t, err)
+	return s
+}
+
+//nolint:paralleltest // the engine reads the process environment
+func TestInlineStackLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStack(t, func(ctx *pulumi.Context) error {
+		c := config.New(ctx, "")
+		ctx.Export("greeting", pulumi.String("hello "+c.Require("name")))
+		ctx.Export("password", c.RequireSecret("password"))
+		return nil
+	})
+
+	require.NoError(t, s.SetConfig(ctx, "name", auto.ConfigValue{Value: "world"}))
+	require.NoError(t, s.SetConfig(ctx, "password", auto.ConfigValue{Value: "hunter2", Secret: true}))
+
+	password, err := s.GetConfig(ctx, "password")
+	require.NoError(t, err)
+	assert.Equal(t, auto.ConfigValue{Value: "hunter2", Secret: true}, password)
+
+	previewRes, err := s.Preview(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, previewRes.ChangeSummary["create"])
+	require.Len(t, previewRes.Steps, 1)
+	assert.Equal(t, apitype.OpCreate, previewRes.Steps[0].Op)
+	assert.Equal(t, "pulumi:pulumi:Stack", previewRes.Steps[0].Type)
+
+	upEvents := make(chan events.EngineEvent)
+	var summaryEvents []events.EngineEvent
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for e := range upEvents {
+			if e.SummaryEvent != nil {
+				summaryEvents = append(summaryEvents, e)
+			}
+		}
+	}()
+
+	upRes, err := s.Up(ctx, optup.EventStreams(upEvents))
+	require.NoError(t, err)
+	<-collected
+	assert.Len(t, summaryEvents, 1)
+	assert.Equal(t, auto.OutputValue{Value: "hello world"}, upRes.Outputs["greeting"])
+	assert.Equal(t, auto.OutputValue{Value: "hunter2", Secret: true}, upRes.Outputs["password"])
+	assert.Equal(t, "update", upRes.Summary.Kind)
+	assert.Equal(t, "succeeded", upRes.Summary.Result)
+	require.Len(t, upRes.Steps, 1)
+	assert.Equal(t, apitype.OpCreate, upRes.Steps[0].Op)
+
+	history, err := s.History(ctx, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	_, err = s.Refresh(ctx)
+	require.NoError(t, err)
+
+	destroyRes, err := s.Destroy(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "destroy", destroyRes.Summary.Kind)
+
+	require.NoError(t, s.Workspace().RemoveStack(ctx, s.Name()))
+	_, err = auto.SelectStack(ctx, s.Name(), s.Workspace())
+	assert.True(t, auto.IsSelectStack404Error(err))
+}
+
+//nolint:paralleltest // the engine reads the process environment
+func TestInlineStackAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStack(t, func(ctx *pulumi.Context) error { return nil })
+
+	_, err := auto.NewStack(ctx, s.Name(), s.Workspace())
+	assert.True(t, auto.IsCreateStack409Error(err))
+}
+
+//nolint:paralleltest // the engine reads the process environment
+func TestInlineStackProgramError(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStack(t, func(ctx *pulumi.Context) error {
+		panic(errors.New("boom"))
+	})
+
+	_, err := s.Up(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "go inline source runtime error")
+}
+
+//nolint:paralleltest // the engine reads the process environment
+func TestInlineStackProjectConfig(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStack(t, func(ctx *pulumi.Context) error {
+		ctx.Export("greeting", pulumi.String("hello "+config.New(ctx, "").Require("name")))
+		return nil
+	})
+
+	project, err := s.Workspace().ProjectSettings(ctx)
+	require.NoError(t, err)
+	stringType, minLength := "string", 2
+	project.Config = map[string]workspace.ProjectConfigType{
+		"name": {
+			Type:                     &stringType,
+			Default:                  "world",
+			ProjectConfigConstraints: workspace.ProjectConfigConstraints{MinLength: &minLength},
+		},
+	}
+	require.NoError(t, s.Workspace().SaveProjectSettings(ctx, project))
+
+	// The project's default is used when the stack doesn't set a value.
+	upRes, err := s.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, auto.OutputValue{Value: "hello world"}, upRes.Outputs["greeting"])
+
+	// The project's constraints are checked before the operation runs.
+	require.NoError(t, s.SetConfig(ctx, "name", auto.ConfigValue{Value: "x"}))
+	_, err = s.Preview(ctx)
+	assert.ErrorContains(t, err, "validating stack config")
+}
+
+func TestQueueEvents(t *testing.T) {
+	t.Parallel()
+
+	stream, done := make(chan events.EngineEvent), make(chan bool)
+	queue := queueEvents(stream, done)
+
+	// Nothing reads the stream yet, but sending doesn't block.
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for i := 0; i < 100; i++ {
+			queue <- events.EngineEvent{EngineEvent: apitype.EngineEvent{Sequence: i}}
+		}
+		close(queue)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(10 * time.Second):
+		t.Fatal("sending events blocked on the stream's consumer")
+	}
+
+	// The events are delivered in order, and then the stream is closed.
+	var sequence []int
+	for e := range stream {
+		sequence = append(sequence, e.Sequence)
+	}
+	<-done
+	require.Len(t, sequence, 100)
+	for i, s := range sequence {
+		assert.Equal(t, i, s)
+	}
+}
+
+// TestPreviewOutputMatchesLocalWorkspace checks that a preview in process displays the same output as a preview run
+// by the CLI through a local workspace.
+//
+//nolint:paralleltest // the engine reads the process environment
+func TestPreviewOutputMatchesLocalWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("pulumi"); err != nil {
+		t.Skip("the local workspace needs the pulumi CLI")
+	}
+
+	ctx := context.Background()
+	program := func(ctx *pulumi.Context) error {
+		ctx.Export("greeting", pulumi.String("hello"))
+		return nil
+	}
+	envVars := map[string]string{"PULUMI_CONFIG_PASSPHRASE": "correct horse battery staple"}
+
+	inProcess, err := NewStackInlineSource(ctx, "dev", "inprocess-test", program,
+		WorkDir(t.TempDir()),
+		BackendURL("file://"+t.TempDir()),
+		EnvVars(envVars))
+	require.NoError(t, err)
+	local, err := auto.NewStackInlineSource(ctx, "dev", "inprocess-test", program,
+		auto.WorkDir(t.TempDir()),
+		auto.Project(workspace.Project{
+			Name:    "inprocess-test",
+			Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+			Backend: &workspace.ProjectBackend{URL: "file://" + t.TempDir()},
+		}),
+		auto.EnvVars(envVars))
+	require.NoError(t, err)
+
+	inProcessRes, err := inProcess.Preview(ctx)
+	require.NoError(t, err)
+	localRes, err := local.Preview(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, localRes.StdOut, inProcessRes.StdOut)
+}
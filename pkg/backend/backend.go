@@ -207,6 +207,10 @@ type Backend interface {
 	ExportDeployment(ctx context.Context, stack Stack) (*apitype.UntypedDeployment, error)
 	// ImportDeployment imports the given deployment into the indicated stack.
 	ImportDeployment(ctx context.Context, stack Stack, deployment *apitype.UntypedDeployment) error
+	// RestoreDeployment makes the deployment from the given version of the stack's history its current deployment.
+	// The meaning of version is the same as for SpecificDeploymentExporter. The restore is itself recorded as a new
+	// update in the stack's history.
+	RestoreDeployment(ctx context.Context, stack Stack, version string) error
 	// Returns the identity of the current user and any organizations they are in for the backend.
 	CurrentUser() (string, []string, *workspace.TokenInformation, error)
 
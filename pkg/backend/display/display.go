@@ -66,6 +66,12 @@ func ShowEvents(
 	if opts.EventLogPath != "" {
 		events, done = startEventLogger(events, done, opts)
 	}
+	if opts.SummaryFilePath != "" {
+		events, done = startSummaryWriter(events, done, opts)
+	}
+	if opts.EventStream != nil {
+		events, done = startEventForwarder(events, done, opts.EventStream)
+	}
 
 	streamPreview := cmdutil.IsTruthy(os.Getenv("PULUMI_ENABLE_STREAMING_JSON_PREVIEW"))
 
@@ -165,6 +171,28 @@ func startEventLogger(events <-chan engine.Event, done chan<- bool, opts Options
 	return outEvents, outDone
 }
 
+// startEventForwarder sends every event to the given stream before passing it on to the display.
+func startEventForwarder(events <-chan engine.Event, done chan<- bool, stream chan<- engine.Event,
+) (<-chan engine.Event, chan<- bool) {
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		for e := range events {
+			stream <- e
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+	}()
+
+	return outEvents, outDone
+}
+
 type nopSpinner struct{}
 
 func (s *nopSpinner) Tick() {
@@ -18,6 +18,7 @@ import (
 	"io"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display/internal/terminal"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 )
 
@@ -50,11 +51,13 @@ type Options struct {
 	Type                 Type                // type of display (rich diff, progress, or query).
 	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
+	SummaryFilePath      string              // the path to the file to write a summary report to, if any.
 	Debug                bool                // true to enable debug output.
 	Stdin                io.Reader           // the reader to use for stdin. Defaults to os.Stdin if unset.
 	Stdout               io.Writer           // the writer to use for stdout. Defaults to os.Stdout if unset.
 	Stderr               io.Writer           // the writer to use for stderr. Defaults to os.Stderr if unset.
 	SuppressTimings      bool                // true to suppress displaying timings of resource actions
+	EventStream          chan<- engine.Event // a channel to which every event is also sent, if any.
 
 	// testing-only options
 	term                terminal.Terminal
@@ -0,0 +1,230 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// The possible results of an operation in a summary report.
+const (
+	summaryResultSucceeded = "succeeded"
+	summaryResultFailed    = "failed"
+	summaryResultCancelled = "cancelled"
+)
+
+// summaryStep identifies a step of a resource. A resource may have several steps, e.g. when it is replaced.
+type summaryStep struct {
+	urn resource.URN
+	op  display.StepOp
+}
+
+// summaryReporter accumulates a summary report from the engine events of an operation.
+type summaryReporter struct {
+	opts   Options
+	now    func() time.Time
+	report display.SummaryReport
+
+	// steps maps each step that has started to its index in report.Resources.
+	steps map[summaryStep]int
+	// starts records the time at which each step started.
+	starts map[summaryStep]time.Time
+
+	// finished is true once the engine has reported the outcome of the operation in a summary event. An operation
+	// that ends without one failed before it could run.
+	finished bool
+	// failed is true if the operation or any of its steps failed.
+	failed bool
+	// cancelled is true if the operation was cancelled.
+	cancelled bool
+}
+
+func newSummaryReporter(opts Options, now func() time.Time) *summaryReporter {
+	return &summaryReporter{
+		opts:   opts,
+		now:    now,
+		steps:  make(map[summaryStep]int),
+		starts: make(map[summaryStep]time.Time),
+	}
+}
+
+// record adds the given event to the report.
+func (r *summaryReporter) record(e engine.Event) {
+	switch e.Type {
+	case engine.DiagEvent:
+		// Skip any ephemeral or debug messages, and elide all colorization.
+		p := e.Payload().(engine.DiagEventPayload)
+		if p.Ephemeral || p.Severity == diag.Debug {
+			return
+		}
+		r.report.Diagnostics = append(r.report.Diagnostics, display.PreviewDiagnostic{
+			URN:      p.URN,
+			Message:  colors.Never.Colorize(p.Prefix + p.Message),
+			Severity: p.Severity,
+		})
+		if p.Severity == diag.Error {
+			r.failed = true
+		}
+	case engine.PolicyViolationEvent:
+		p := e.Payload().(engine.PolicyViolationEventPayload)
+		r.report.PolicyViolations = append(r.report.PolicyViolations, display.SummaryPolicyViolation{
+			URN:               p.ResourceURN,
+			PolicyPackName:    p.PolicyPackName,
+			PolicyPackVersion: p.PolicyPackVersion,
+			PolicyName:        p.PolicyName,
+			EnforcementLevel:  p.EnforcementLevel,
+			Message:           colors.Never.Colorize(p.Message),
+		})
+		if p.EnforcementLevel == apitype.Mandatory {
+			r.failed = true
+		}
+	case engine.ResourcePreEvent:
+		m := e.Payload().(engine.ResourcePreEventPayload).Metadata
+		if m.Op == deploy.OpSame {
+			return
+		}
+
+		var diffs []string
+		if m.DetailedDiff != nil {
+			for k := range m.DetailedDiff {
+				diffs = append(diffs, k)
+			}
+			sort.Strings(diffs)
+		} else {
+			for _, k := range m.Diffs {
+				diffs = append(diffs, string(k))
+			}
+		}
+
+		key := summaryStep{urn: m.URN, op: m.Op}
+		r.steps[key] = len(r.report.Resources)
+		r.starts[key] = r.now()
+		r.report.Resources = append(r.report.Resources, display.SummaryResource{
+			URN:            m.URN,
+			Op:             m.Op,
+			Diffs:          diffs,
+			ReplaceReasons: m.Keys,
+		})
+	case engine.ResourceOutputsEvent:
+		m := e.Payload().(engine.ResourceOutputsEventPayload).Metadata
+		if isRootStack(m) && m.New != nil && !r.opts.SuppressOutputs {
+			outputs, err := stack.SerializeProperties(
+				MassageSecrets(m.New.Outputs, false), config.NewPanicCrypter(), false /* showSecrets */)
+			if err == nil {
+				r.report.Outputs = outputs
+			} else {
+				logging.V(7).Infof("not adding stack outputs as there was an error serializing: %s", err)
+			}
+		}
+		r.finishStep(m, false)
+	case engine.ResourceOperationFailed:
+		r.finishStep(e.Payload().(engine.ResourceOperationFailedPayload).Metadata, true)
+		r.failed = true
+	case engine.SummaryEvent:
+		p := e.Payload().(engine.SummaryEventPayload)
+		r.report.Preview = p.IsPreview
+		r.report.Duration = p.Duration
+		r.report.ResourceChanges = p.ResourceChanges
+		r.report.MaybeCorrupt = p.MaybeCorrupt
+		r.finished = true
+		r.failed = r.failed || p.Failed
+		r.cancelled = p.Cancelled
+	}
+}
+
+// finishStep records the duration of the given step, and whether it failed.
+func (r *summaryReporter) finishStep(m engine.StepEventMetadata, failed bool) {
+	key := summaryStep{urn: m.URN, op: m.Op}
+	i, ok := r.steps[key]
+	if !ok {
+		return
+	}
+	r.report.Resources[i].Duration = r.now().Sub(r.starts[key])
+	r.report.Resources[i].Failed = failed
+	delete(r.steps, key)
+	delete(r.starts, key)
+}
+
+// summary returns the report of the operation so far.
+func (r *summaryReporter) summary() display.SummaryReport {
+	report := r.report
+	switch {
+	case r.cancelled:
+		report.Result = summaryResultCancelled
+	case r.failed || !r.finished:
+		report.Result = summaryResultFailed
+	default:
+		report.Result = summaryResultSucceeded
+	}
+	return report
+}
+
+// startSummaryWriter forwards events to the returned channel and writes a summary report of the operation to
+// opts.SummaryFilePath once the event stream ends.
+func startSummaryWriter(events <-chan engine.Event, done chan<- bool, opts Options) (<-chan engine.Event, chan<- bool) {
+	// Before moving further, attempt to open the summary file, so that we can warn about it early.
+	summaryFile, err := os.OpenFile(opts.SummaryFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		cmdutil.Diag().Warningf(diag.Message("", "could not create summary file: %v"), err)
+		return events, done
+	}
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+		defer func() {
+			contract.IgnoreError(summaryFile.Close())
+		}()
+
+		reporter := newSummaryReporter(opts, time.Now)
+		for e := range events {
+			reporter.record(e)
+
+			outEvents <- e
+
+			// The engine ends the event stream of every operation with a cancel event.
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		encoder := json.NewEncoder(summaryFile)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "    ")
+		if err := encoder.Encode(reporter.summary()); err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "could not write summary file: %v"), err)
+		}
+
+		<-outDone
+	}()
+
+	return outEvents, outDone
+}
@@ -0,0 +1,242 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+)
+
+func TestSummaryReport(t *testing.T) {
+	t.Parallel()
+
+	stackURN := resource.URN("urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev")
+	bucketURN := resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::bucket")
+	queueURN := resource.URN("urn:pulumi:dev::proj::aws:sqs/queue:Queue::queue")
+
+	clock := time.Unix(0, 0)
+	reporter := newSummaryReporter(Options{}, func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	})
+
+	events := []engine.Event{
+		engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{Op: deploy.OpSame, URN: stackURN},
+		}),
+		engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:  deploy.OpUpdate,
+				URN: bucketURN,
+				DetailedDiff: map[string]plugin.PropertyDiff{
+					"tags":    {Kind: plugin.DiffUpdate},
+					"acl":     {Kind: plugin.DiffAdd},
+					"website": {Kind: plugin.DiffDelete},
+				},
+			},
+		}),
+		engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:    deploy.OpCreateReplacement,
+				URN:   queueURN,
+				Diffs: []resource.PropertyKey{"name"},
+				Keys:  []resource.PropertyKey{"name"},
+			},
+		}),
+		engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata: engine.StepEventMetadata{Op: deploy.OpUpdate, URN: bucketURN},
+		}),
+		engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN: queueURN, Message: "<{%fg 1%}>name already taken<{%reset%}>", Severity: diag.Error,
+		}),
+		engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{Message: "working...", Ephemeral: true}),
+		engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{
+			Metadata: engine.StepEventMetadata{Op: deploy.OpCreateReplacement, URN: queueURN},
+		}),
+		engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:      bucketURN,
+			Message:          "buckets must not be public",
+			PolicyName:       "no-public-buckets",
+			PolicyPackName:   "security",
+			EnforcementLevel: apitype.Advisory,
+		}),
+		engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:  deploy.OpSame,
+				URN: stackURN,
+				New: &engine.StepEventStateMetadata{
+					Outputs: resource.PropertyMap{
+						"url":      resource.NewStringProperty("https://example.com"),
+						"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+					},
+				},
+			},
+		}),
+		engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			Duration:        10 * time.Second,
+			ResourceChanges: display.ResourceChanges{deploy.OpUpdate: 1, deploy.OpSame: 1},
+			Failed:          true,
+		}),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}
+	for _, e := range events {
+		reporter.record(e)
+	}
+
+	assert.Equal(t, display.SummaryReport{
+		Result:          "failed",
+		Duration:        10 * time.Second,
+		ResourceChanges: display.ResourceChanges{deploy.OpUpdate: 1, deploy.OpSame: 1},
+		Resources: []display.SummaryResource{
+			{URN: bucketURN, Op: deploy.OpUpdate, Diffs: []string{"acl", "tags", "website"}, Duration: 2 * time.Second},
+			{
+				URN:            queueURN,
+				Op:             deploy.OpCreateReplacement,
+				Diffs:          []string{"name"},
+				ReplaceReasons: []resource.PropertyKey{"name"},
+				Duration:       2 * time.Second,
+				Failed:         true,
+			},
+		},
+		PolicyViolations: []display.SummaryPolicyViolation{{
+			URN:              bucketURN,
+			PolicyPackName:   "security",
+			PolicyName:       "no-public-buckets",
+			EnforcementLevel: apitype.Advisory,
+			Message:          "buckets must not be public",
+		}},
+		Diagnostics: []display.PreviewDiagnostic{
+			{URN: queueURN, Message: "name already taken", Severity: diag.Error},
+		},
+		Outputs: map[string]interface{}{
+			"url":      "https://example.com",
+			"password": "[secret]",
+		},
+	}, reporter.summary())
+}
+
+func TestSummaryReportResult(t *testing.T) {
+	t.Parallel()
+
+	errorDiag := engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+		Message: "something went wrong", Severity: diag.Error,
+	})
+	// The engine ends the event stream of every operation with a cancel event, whether or not it was cancelled.
+	endOfStream := engine.NewEvent(engine.CancelEvent, nil)
+
+	cases := []struct {
+		name     string
+		events   []engine.Event
+		expected string
+	}{
+		{
+			name: "succeeded",
+			events: []engine.Event{
+				engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{Message: "careful", Severity: diag.Warning}),
+				engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{}),
+				endOfStream,
+			},
+			expected: "succeeded",
+		},
+		{
+			name: "failed",
+			events: []engine.Event{
+				engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{Failed: true}),
+				endOfStream,
+			},
+			expected: "failed",
+		},
+		{
+			name: "error diagnostic",
+			events: []engine.Event{
+				errorDiag,
+				engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{}),
+				endOfStream,
+			},
+			expected: "failed",
+		},
+		{
+			name:     "failed before running",
+			events:   []engine.Event{errorDiag, endOfStream},
+			expected: "failed",
+		},
+		{
+			name: "cancelled",
+			events: []engine.Event{
+				engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+					Message: "update canceled", Severity: diag.Error,
+				}),
+				engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{Failed: true, Cancelled: true}),
+				endOfStream,
+			},
+			expected: "cancelled",
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			reporter := newSummaryReporter(Options{}, time.Now)
+			for _, e := range c.events {
+				reporter.record(e)
+			}
+			assert.Equal(t, c.expected, reporter.summary().Result)
+		})
+	}
+}
+
+func TestStartSummaryWriter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "summary.json")
+	events, done := make(chan engine.Event), make(chan bool)
+	outEvents, outDone := startSummaryWriter(events, done, Options{SummaryFilePath: path})
+
+	go func() {
+		events <- engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			IsPreview:       true,
+			ResourceChanges: display.ResourceChanges{deploy.OpCreate: 2},
+		})
+		events <- engine.NewEvent(engine.CancelEvent, nil)
+		close(events)
+	}()
+	assert.Equal(t, engine.SummaryEvent, (<-outEvents).Type)
+	assert.Equal(t, engine.CancelEvent, (<-outEvents).Type)
+	close(outDone)
+	<-done
+
+	bytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report display.SummaryReport
+	require.NoError(t, json.Unmarshal(bytes, &report))
+	assert.True(t, report.Preview)
+	assert.Equal(t, "succeeded", report.Result)
+	assert.Equal(t, display.ResourceChanges{deploy.OpCreate: 2}, report.ResourceChanges)
+}
@@ -110,6 +110,9 @@ type UpgradeOptions struct {
 	ProjectsForDetachedStacks func(stacks []tokens.Name) (projects []tokens.Name, err error)
 }
 
+// Assert we implement the backend.SpecificDeploymentExporter interface.
+var _ backend.SpecificDeploymentExporter = &localBackend{}
+
 // Backend extends the base backend interface with specific information about local backends.
 type Backend interface {
 	backend.Backend
@@ -117,6 +120,10 @@ type Backend interface {
 
 	// Upgrade to the latest state store version.
 	Upgrade(ctx context.Context, opts *UpgradeOptions) error
+
+	// PruneHistory deletes the update history and checkpoint backups of a stack that fall outside its retention
+	// policy, and returns how many entries were deleted.
+	PruneHistory(ctx context.Context, stackRef backend.StackReference, opts *PruneOptions) (int, error)
 }
 
 type localBackend struct {
@@ -133,8 +140,18 @@ type localBackend struct {
 
 	lockID string
 
+	// lockLease is how long the locks taken by this backend are leased for. Zero means defaultLockLease.
+	lockLease time.Duration
+
+	// leaseMutex protects leases, which maps the path of each lock this backend holds to the renewal of its lease.
+	leaseMutex sync.Mutex
+	leases     map[string]*leaseRenewal
+
 	gzip bool
 
+	// retention is the retention policy from the metadata file, if any.
+	retention *RetentionPolicy
+
 	Getenv func(string) string // == os.Getenv
 
 	// The current project, if any.
@@ -203,6 +220,7 @@ func (r *localBackendReference) Validate() error       { return r.store.Validate
 func (r *localBackendReference) StackBasePath() string { return r.store.StackBasePath(r) }
 func (r *localBackendReference) HistoryDir() string    { return r.store.HistoryDir(r) }
 func (r *localBackendReference) BackupDir() string     { return r.store.BackupDir(r) }
+func (r *localBackendReference) TagsPath() string      { return r.store.TagsPath(r) }
 
 func IsFileStateBackendURL(urlstr string) bool {
 	u, err := url.Parse(urlstr)
@@ -314,6 +332,11 @@ func newLocalBackend(
 	if err != nil {
 		return nil, err
 	}
+	if r := meta.Retention; r != nil {
+		// readPulumiMeta has already validated the policy.
+		backend.retention, err = newRetentionPolicy(r.Keep, r.MaxAge)
+		contract.AssertNoErrorf(err, "invalid retention policy in metadata file")
+	}
 
 	// projectMode tracks whether the current state supports project-scoped stacks.
 	// Historically, the filestate backend did not support this.
@@ -458,6 +481,9 @@ func (b *localBackend) Upgrade(ctx context.Context, opts *UpgradeOptions) error
 	// (e.g., we can write to .pulumi/*/*" but not ".pulumi/*.")
 	// we don't leave the bucket in a completely inaccessible state.
 	meta := pulumiMeta{Version: 1}
+	if old, err := readPulumiMeta(ctx, b.bucket); err == nil && old != nil {
+		meta.Retention = old.Retention
+	}
 	if err := meta.WriteTo(ctx, b.bucket); err != nil {
 		var s strings.Builder
 		fmt.Fprintf(&s, "Could not write new state metadata file: %v\n", err)
@@ -633,7 +659,7 @@ func (b *localBackend) ListPolicyPacks(ctx context.Context, orgName string, _ ba
 }
 
 func (b *localBackend) SupportsTags() bool {
-	return false
+	return true
 }
 
 func (b *localBackend) SupportsOrganizations() bool {
@@ -723,7 +749,11 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, &backend.StackAlreadyExistsError{StackName: string(stackName)}
 	}
 
-	if err = validation.ValidateStackProperties(stackName.Name().String(), nil); err != nil {
+	tags, err := backend.GetEnvironmentTagsForCurrentStack(root, b.currentProject.Load(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting stack tags: %w", err)
+	}
+	if err = validation.ValidateStackProperties(stackName.Name().String(), tags); err != nil {
 		return nil, fmt.Errorf("validating stack properties: %w", err)
 	}
 
@@ -732,7 +762,11 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, err
 	}
 
-	stack := newStack(localStackRef, b)
+	if err = b.saveStackTags(ctx, localStackRef, tags); err != nil {
+		return nil, err
+	}
+
+	stack := newStack(localStackRef, b, tags)
 	b.d.Infof(diag.Message("", "Created stack '%s'"), stack.Ref())
 
 	return stack, nil
@@ -752,7 +786,12 @@ func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackRefer
 		return nil, err
 	}
 
-	return newStack(localStackRef, b), nil
+	tags, err := b.getStackTags(ctx, localStackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStack(localStackRef, b, tags), nil
 }
 
 func (b *localBackend) ListStacks(
@@ -764,7 +803,7 @@ func (b *localBackend) ListStacks(
 		return nil, nil, err
 	}
 
-	// Note that the provided stack filter is only partially honored, since fields like organizations and tags
+	// Note that the provided stack filter is only partially honored, since fields like organizations
 	// aren't persisted in the local backend.
 	results := slice.Prealloc[backend.StackSummary](len(stacks))
 	for _, stackRef := range stacks {
@@ -774,6 +813,17 @@ func (b *localBackend) ListStacks(
 			continue
 		}
 
+		if filter.TagName != nil {
+			tags, err := b.getStackTags(ctx, stackRef)
+			if err != nil {
+				return nil, nil, err
+			}
+			value, has := tags[*filter.TagName]
+			if !has || filter.TagValue != nil && value != *filter.TagValue {
+				continue
+			}
+		}
+
 		chk, err := b.getCheckpoint(ctx, stackRef)
 		if err != nil {
 			return nil, nil, err
@@ -885,7 +935,9 @@ func (b *localBackend) renameStack(ctx context.Context, oldRef *localBackendRefe
 	if err = b.renameHistory(ctx, oldRef, newRef); err != nil {
 		return err
 	}
-	return err
+
+	// And move the stack's tags along with it.
+	return b.renameStackTags(ctx, oldRef, newRef)
 }
 
 func (b *localBackend) GetLatestConfiguration(ctx context.Context,
@@ -996,12 +1048,21 @@ func (b *localBackend) apply(
 		return nil, nil, result.Errorf("provided project name %q doesn't match Pulumi.yaml", localStackRef.project)
 	}
 
+	retention, err := b.retentionPolicy(op.Proj)
+	if err != nil {
+		return nil, nil, result.FromError(err)
+	}
+
 	stackName := stackRef.FullyQualifiedName()
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
 	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
 		// Print a banner so it's clear this is a local deployment.
-		fmt.Printf(op.Opts.Display.Color.Colorize(
+		stdout := op.Opts.Display.Stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		fmt.Fprintf(stdout, op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
 	}
 
@@ -1011,6 +1072,20 @@ func (b *localBackend) apply(
 		return nil, nil, result.FromError(err)
 	}
 
+	// Pick up any metadata changes in the stack's tags, as the service does when an update starts.
+	if !opts.DryRun {
+		tags, err := backend.GetMergedStackTags(ctx, stack, op.Root, op.Proj, op.StackConfiguration.Config)
+		if err != nil {
+			return nil, nil, result.FromError(fmt.Errorf("getting stack tags: %w", err))
+		}
+		if err = b.saveStackTags(ctx, localStackRef, tags); err != nil {
+			return nil, nil, result.FromError(err)
+		}
+		if s, ok := stack.(*localStack); ok {
+			s.tags.Store(&tags)
+		}
+	}
+
 	// Spawn a display loop to show events on the CLI.
 	displayEvents := make(chan engine.Event)
 	displayDone := make(chan bool)
@@ -1038,10 +1113,14 @@ func (b *localBackend) apply(
 	}()
 
 	// Create the management machinery.
+	// Another process may take the lock on the stack once it's lost, so the operation is canceled then.
+	cancelCtx, stopWatchingLease := b.cancelOnLeaseLost(scope.Context(), localStackRef)
+	defer stopWatchingLease()
+
 	persister := b.newSnapshotPersister(ctx, localStackRef)
 	manager := backend.NewSnapshotManager(persister, op.SecretsManager, update.GetTarget().Snapshot)
 	engineCtx := &engine.Context{
-		Cancel:          scope.Context(),
+		Cancel:          cancelCtx,
 		Events:          engineEvents,
 		SnapshotManager: manager,
 		BackendClient:   backend.NewBackendClient(b, op.SecretsProvider),
@@ -1107,6 +1186,9 @@ func (b *localBackend) apply(
 	if !opts.DryRun {
 		saveErr = b.addToHistory(ctx, localStackRef, info)
 		backupErr = b.backupStack(ctx, localStackRef)
+		if saveErr == nil && backupErr == nil {
+			b.enforceRetention(ctx, localStackRef, retention)
+		}
 	}
 
 	if updateRes != nil {
@@ -1247,6 +1329,32 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 	}, nil
 }
 
+// ExportDeploymentForVersion exports the deployment saved with the given version of the stack's history. Versions
+// count up from 1 for the first update of the stack, and keep their numbers when older updates are pruned.
+func (b *localBackend) ExportDeploymentForVersion(ctx context.Context,
+	stk backend.Stack, version string,
+) (*apitype.UntypedDeployment, error) {
+	localStackRef, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+
+	chk, err := b.getHistoryCheckpoint(ctx, localStackRef, version)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encoding.JSON.Marshal(chk.Latest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
 func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment,
 ) error {
@@ -1271,6 +1379,56 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	return err
 }
 
+func (b *localBackend) RestoreDeployment(ctx context.Context, stk backend.Stack, version string) error {
+	localStackRef, err := b.getReference(stk.Ref())
+	if err != nil {
+		return err
+	}
+
+	err = b.Lock(ctx, localStackRef)
+	if err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, localStackRef)
+
+	start := time.Now().Unix()
+	chk, err := b.getHistoryCheckpoint(ctx, localStackRef, version)
+	if err != nil {
+		return err
+	}
+
+	snap, err := stack.DeserializeCheckpoint(ctx, stack.DefaultSecretsProvider, chk)
+	if err != nil {
+		return fmt.Errorf("reading version %s: %w", version, err)
+	}
+	if snap != nil {
+		if err := snap.VerifyIntegrity(); err != nil {
+			return fmt.Errorf("version %s has an invalid state: %w", version, err)
+		}
+	}
+
+	// The stack may have been renamed since this version was saved.
+	chk.Stack = localStackRef.FullyQualifiedName()
+	data, err := encoding.JSON.Marshal(chk)
+	if err != nil {
+		return fmt.Errorf("marshalling checkpoint: %w", err)
+	}
+	if _, _, err = b.saveCheckpoint(ctx, localStackRef, &apitype.VersionedCheckpoint{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Checkpoint: json.RawMessage(data),
+	}); err != nil {
+		return err
+	}
+
+	return b.addToHistory(ctx, localStackRef, backend.UpdateInfo{
+		Kind:      apitype.StackImportUpdate,
+		StartTime: start,
+		Message:   "Restored version " + version,
+		Result:    backend.SucceededResult,
+		EndTime:   time.Now().Unix(),
+	})
+}
+
 func (b *localBackend) CurrentUser() (string, []string, *workspace.TokenInformation, error) {
 	user, err := user.Current()
 	if err != nil {
@@ -1287,13 +1445,35 @@ func (b *localBackend) getLocalStacks(ctx context.Context) ([]*localBackendRefer
 func (b *localBackend) UpdateStackTags(ctx context.Context,
 	stack backend.Stack, tags map[apitype.StackTagName]string,
 ) error {
-	// The local backend does not currently persist tags.
-	return errors.New("stack tags not supported in --local mode")
+	localStackRef, err := b.getReference(stack.Ref())
+	if err != nil {
+		return err
+	}
+
+	if err := validation.ValidateStackTags(tags); err != nil {
+		return err
+	}
+
+	err = b.Lock(ctx, localStackRef)
+	if err != nil {
+		return err
+	}
+	defer b.Unlock(ctx, localStackRef)
+
+	if err := b.saveStackTags(ctx, localStackRef, tags); err != nil {
+		return err
+	}
+
+	// Keep the stack's view of its tags up to date.
+	if s, ok := stack.(*localStack); ok {
+		s.tags.Store(&tags)
+	}
+	return nil
 }
 
 func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
 	// Try to delete ALL the lock files
-	allFiles, err := listBucket(ctx, b.bucket, stackLockDir(stackRef.FullyQualifiedName()))
+	locks, err := b.readLocks(ctx, stackRef)
 	if err != nil {
 		// Don't error if it just wasn't found
 		if gcerrors.Code(err) == gcerrors.NotFound {
@@ -1302,16 +1482,24 @@ func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend
 		return err
 	}
 
-	for _, file := range allFiles {
-		if file.IsDir {
-			continue
+	now := time.Now()
+	for _, lock := range locks {
+		// Let the user know whose update they are cancelling, if it might still be running.
+		switch {
+		case lock.err != nil:
+			b.d.Infof(diag.Message("", "Removing unreadable lock %v"), b.url+"/"+lock.key)
+		case lock.content.expired(now):
+			b.d.Infof(diag.Message("", "Removing stale lock %v"), lock.content)
+		default:
+			b.d.Warningf(diag.Message("", "Removing lock held by a process that may still be running: %v"),
+				lock.content)
 		}
 
-		err := b.bucket.Delete(ctx, file.Key)
+		err := b.bucket.Delete(ctx, lock.key)
 		if err != nil {
 			// Race condition, don't error if the file was delete between us calling list and now
 			if gcerrors.Code(err) == gcerrors.NotFound {
-				return nil
+				continue
 			}
 			return err
 		}
@@ -156,12 +156,12 @@ func TestCancel_legacy(t *testing.T) {
 	// Lock the stack with this new backend, then check that checkForLocks on the first backend now errors
 	err = otherBackend.Lock(ctx, aStackRef)
 	assert.NoError(t, err)
-	err = lb.checkForLock(ctx, aStackRef)
+	err = lb.checkForLock(ctx, aStackRef, nil)
 	assert.Error(t, err)
 	// Now call CancelCurrentUpdate and check that checkForLocks no longer errors
 	err = lb.CancelCurrentUpdate(ctx, aStackRef)
 	assert.NoError(t, err)
-	err = lb.checkForLock(ctx, aStackRef)
+	err = lb.checkForLock(ctx, aStackRef, nil)
 	assert.NoError(t, err)
 }
 
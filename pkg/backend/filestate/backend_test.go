@@ -292,12 +292,12 @@ func TestCancel(t *testing.T) {
 	// Lock the stack with this new backend, then check that checkForLocks on the first backend now errors
 	err = otherBackend.Lock(ctx, aStackRef)
 	assert.NoError(t, err)
-	err = lb.checkForLock(ctx, aStackRef)
+	err = lb.checkForLock(ctx, aStackRef, nil)
 	assert.Error(t, err)
 	// Now call CancelCurrentUpdate and check that checkForLocks no longer errors
 	err = lb.CancelCurrentUpdate(ctx, aStackRef)
 	assert.NoError(t, err)
-	err = lb.checkForLock(ctx, aStackRef)
+	err = lb.checkForLock(ctx, aStackRef, nil)
 	assert.NoError(t, err)
 }
 
@@ -1347,6 +1347,107 @@ func TestCreateStack_retainCheckpoints(t *testing.T) {
 		"file with a timestamp extension not found in %v", got)
 }
 
+func TestStackTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(tmpDir), nil)
+	require.NoError(t, err)
+	assert.True(t, b.SupportsTags())
+
+	aRef, err := b.ParseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, aRef, "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, aStack.Tags())
+
+	bRef, err := b.ParseStackReference("organization/project/b")
+	require.NoError(t, err)
+	_, err = b.CreateStack(ctx, bRef, "", nil)
+	require.NoError(t, err)
+
+	tags := map[apitype.StackTagName]string{"team": "infra", "env": "dev"}
+	require.NoError(t, backend.UpdateStackTags(ctx, aStack, tags))
+	assert.Equal(t, tags, aStack.Tags())
+
+	// Tags are persisted and visible to freshly loaded stacks.
+	aStack, err = b.GetStack(ctx, aRef)
+	require.NoError(t, err)
+	assert.Equal(t, tags, aStack.Tags())
+
+	// Invalid tags are rejected.
+	err = backend.UpdateStackTags(ctx, aStack, map[apitype.StackTagName]string{"bad tag!": "x"})
+	assert.Error(t, err)
+
+	// Stacks can be filtered by tag name and value.
+	tagName, tagValue, otherValue := "team", "infra", "other"
+	stacks, _, err := b.ListStacks(ctx, backend.ListStacksFilter{TagName: &tagName}, nil /* inContToken */)
+	require.NoError(t, err)
+	require.Len(t, stacks, 1)
+	assert.Equal(t, "organization/project/a", stacks[0].Name().String())
+
+	stacks, _, err = b.ListStacks(ctx, backend.ListStacksFilter{TagName: &tagName, TagValue: &tagValue}, nil)
+	require.NoError(t, err)
+	assert.Len(t, stacks, 1)
+
+	stacks, _, err = b.ListStacks(ctx, backend.ListStacksFilter{TagName: &tagName, TagValue: &otherValue}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, stacks)
+
+	// Tags follow the stack when it's renamed.
+	cRef, err := b.RenameStack(ctx, aStack, "organization/project/c")
+	require.NoError(t, err)
+	cStack, err := b.GetStack(ctx, cRef)
+	require.NoError(t, err)
+	assert.Equal(t, tags, cStack.Tags())
+	assert.NoFileExists(t, filepath.Join(tmpDir, ".pulumi", "tags", "project", "a.json"))
+
+	// And are removed with it.
+	_, err = b.RemoveStack(ctx, cStack, false)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(tmpDir, ".pulumi", "tags", "project", "c.json"))
+}
+
+func TestLegacyUpgrade_tags(t *testing.T) {
+	t.Parallel()
+
+	// Make a dummy stack file in the legacy location, with tags.
+	tmpDir := t.TempDir()
+	err := os.MkdirAll(path.Join(tmpDir, ".pulumi", "stacks"), os.ModePerm)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(tmpDir, ".pulumi", "stacks", "a.json"), []byte(`{
+		"latest": {
+			"resources": [
+				{
+					"type": "package:module:resource",
+					"urn": "urn:pulumi:stack::project::package:module:resource::name"
+				}
+			]
+		}
+	}`), os.ModePerm)
+	require.NoError(t, err)
+	err = os.MkdirAll(path.Join(tmpDir, ".pulumi", "tags"), os.ModePerm)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(tmpDir, ".pulumi", "tags", "a.json"), []byte(`{"team": "infra"}`), os.ModePerm)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(tmpDir), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	require.NoError(t, lb.Upgrade(ctx, nil /* opts */))
+
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.GetStack(ctx, aStackRef)
+	require.NoError(t, err)
+	assert.Equal(t, map[apitype.StackTagName]string{"team": "infra"}, aStack.Tags())
+	assert.NoFileExists(t, path.Join(tmpDir, ".pulumi", "tags", "a.json"))
+}
+
 // mapGetenv builds an os.Getenv-like function
 // that returns values from the given map.
 func mapGetenv(m map[string]string) func(string) string {
@@ -1354,3 +1455,98 @@ func mapGetenv(m map[string]string) func(string) string {
 		return m[key]
 	}
 }
+
+func TestExportDeploymentForVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, aStackRef, "", nil)
+	require.NoError(t, err)
+
+	// Fake up two updates, each saving a different deployment.
+	for _, name := range []tokens.QName{"first", "second"} {
+		snap := deploy.NewSnapshot(deploy.Manifest{}, b64.NewBase64SecretsManager(), []*resource.State{
+			{URN: resource.NewURN("a", "proj", "d:e:f", "a:b:c", name), Type: "a:b:c"},
+		}, nil)
+		sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager, false /* showSecrets */)
+		require.NoError(t, err)
+		data, err := json.Marshal(sdep)
+		require.NoError(t, err)
+		require.NoError(t, b.ImportDeployment(ctx, aStack, &apitype.UntypedDeployment{
+			Version:    3,
+			Deployment: json.RawMessage(data),
+		}))
+		require.NoError(t, lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	}
+
+	history, err := b.GetHistory(ctx, aStackRef, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 2, history[0].Version)
+	assert.Equal(t, 1, history[1].Version)
+
+	deployment, err := lb.ExportDeploymentForVersion(ctx, aStack, "1")
+	require.NoError(t, err)
+	assert.Contains(t, string(deployment.Deployment), "a:b:c::first")
+	assert.NotContains(t, string(deployment.Deployment), "a:b:c::second")
+
+	_, err = lb.ExportDeploymentForVersion(ctx, aStack, "3")
+	assert.ErrorContains(t, err, "has no version 3")
+	_, err = lb.ExportDeploymentForVersion(ctx, aStack, "latest")
+	assert.ErrorContains(t, err, "invalid version")
+}
+
+func TestRestoreDeployment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, aStackRef, "", nil)
+	require.NoError(t, err)
+
+	for _, name := range []tokens.QName{"first", "second"} {
+		snap := deploy.NewSnapshot(deploy.Manifest{}, b64.NewBase64SecretsManager(), []*resource.State{
+			{URN: resource.NewURN("a", "proj", "d:e:f", "a:b:c", name), Type: "a:b:c"},
+		}, nil)
+		sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager, false /* showSecrets */)
+		require.NoError(t, err)
+		data, err := json.Marshal(sdep)
+		require.NoError(t, err)
+		require.NoError(t, b.ImportDeployment(ctx, aStack, &apitype.UntypedDeployment{
+			Version:    3,
+			Deployment: json.RawMessage(data),
+		}))
+		require.NoError(t, lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	}
+
+	require.NoError(t, b.RestoreDeployment(ctx, aStack, "1"))
+
+	deployment, err := b.ExportDeployment(ctx, aStack)
+	require.NoError(t, err)
+	assert.Contains(t, string(deployment.Deployment), "a:b:c::first")
+	assert.NotContains(t, string(deployment.Deployment), "a:b:c::second")
+
+	// The restore is recorded as an update of its own.
+	history, err := b.GetHistory(ctx, aStackRef, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, 3, history[0].Version)
+	assert.Equal(t, apitype.StackImportUpdate, history[0].Kind)
+	assert.Equal(t, "Restored version 1", history[0].Message)
+
+	err = b.RestoreDeployment(ctx, aStack, "4")
+	assert.ErrorContains(t, err, "has no version 4")
+}
@@ -22,6 +22,7 @@ type Bucket interface {
 	ReadAll(ctx context.Context, key string) (_ []byte, err error)
 	WriteAll(ctx context.Context, key string, p []byte, opts *blob.WriterOptions) (err error)
 	Exists(ctx context.Context, key string) (bool, error)
+	Attributes(ctx context.Context, key string) (*blob.Attributes, error)
 }
 
 // wrappedBucket encapsulates a true gocloud blob.Bucket, but ensures that all paths we send to it
@@ -109,3 +110,7 @@ func removeAllByPrefix(ctx context.Context, bucket Bucket, dir string) error {
 
 	return nil
 }
+
+func (b *wrappedBucket) Attributes(ctx context.Context, key string) (*blob.Attributes, error) {
+	return b.bucket.Attributes(ctx, filepath.ToSlash(key))
+}
@@ -1,4 +1,4 @@
-// Copyright 2016-2018, Pulumi Corporation.
+// Copyright 2016-2023, Pulumi Corporation.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -19,28 +19,49 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
 	"os/user"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/azureblob"
+	"gocloud.dev/blob/gcsblob"
+	"gocloud.dev/gcerrors"
+
 	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/util/cancel"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
+// defaultLockLease is how long a lock is held for without being renewed. The process holding a lock renews its
+// lease well before it runs out, so a lock whose lease has expired was left behind by a process that is no longer
+// running (for example a CI job that was killed) and can be ignored.
+const defaultLockLease = 5 * time.Minute
+
 type lockContent struct {
 	Pid       int       `json:"pid"`
 	Username  string    `json:"username"`
 	Hostname  string    `json:"hostname"`
 	Timestamp time.Time `json:"timestamp"`
+	// Expires is the time at which the lease on this lock runs out unless it is renewed. Locks written by older
+	// versions of the CLI don't have a lease, and are always considered live.
+	Expires *time.Time `json:"expires,omitempty"`
 }
 
-func newLockContent() (*lockContent, error) {
+func newLockContent(lease time.Duration) (*lockContent, error) {
 	u, err := user.Current()
 	if err != nil {
 		return nil, err
@@ -49,18 +70,84 @@ func newLockContent() (*lockContent, error) {
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now()
+	expires := now.Add(lease)
 	return &lockContent{
 		Pid:       os.Getpid(),
 		Username:  u.Username,
 		Hostname:  hostname,
-		Timestamp: time.Now(),
+		Timestamp: now,
+		Expires:   &expires,
 	}, nil
 }
 
-// checkForLock looks for any existing locks for this stack, and returns a helpful diagnostic if there is one.
-func (b *localBackend) checkForLock(ctx context.Context, stackRef backend.StackReference) error {
-	stackName := stackRef.FullyQualifiedName()
-	allFiles, err := listBucket(ctx, b.bucket, stackLockDir(stackName))
+// expired returns true if the lease on this lock ran out before the given time.
+func (l *lockContent) expired(now time.Time) bool {
+	return l.Expires != nil && now.After(*l.Expires)
+}
+
+// sameHolder returns true if both locks were taken by the same process at the same time, regardless of their leases.
+func (l *lockContent) sameHolder(other *lockContent) bool {
+	return l.Pid == other.Pid && l.Username == other.Username && l.Hostname == other.Hostname &&
+		l.Timestamp.Equal(other.Timestamp)
+}
+
+// String describes who holds the lock, for use in diagnostics.
+func (l *lockContent) String() string {
+	s := fmt.Sprintf("created by %v@%v (pid %v) at %v",
+		l.Username, l.Hostname, l.Pid, l.Timestamp.Format(time.RFC3339))
+	if l.Expires != nil {
+		s += fmt.Sprintf(", lease expires at %v", l.Expires.Format(time.RFC3339))
+	}
+	return s
+}
+
+// stackLock is a lock file found in the bucket, along with its parsed content.
+type stackLock struct {
+	key     string
+	content *lockContent
+	// err is set if the content of the lock file couldn't be parsed, in which case content is nil.
+	err error
+}
+
+// readLocks returns all the locks currently held on the given stack, sorted by key. Locks that are deleted while they
+// are being read are skipped.
+func (b *localBackend) readLocks(ctx context.Context, stackRef backend.StackReference) ([]stackLock, error) {
+	allFiles, err := listBucket(ctx, b.bucket, stackLockDir(stackRef.FullyQualifiedName()))
+	if err != nil {
+		return nil, err
+	}
+
+	var locks []stackLock
+	for _, file := range allFiles {
+		if file.IsDir {
+			continue
+		}
+		content, err := b.bucket.ReadAll(ctx, file.Key)
+		if err != nil {
+			// Race condition, the lock was released between us calling list and now.
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				continue
+			}
+			return nil, err
+		}
+		l := &lockContent{}
+		if err := json.Unmarshal(content, l); err != nil {
+			locks = append(locks, stackLock{key: file.Key, err: err})
+			continue
+		}
+		locks = append(locks, stackLock{key: file.Key, content: l})
+	}
+	return locks, nil
+}
+
+// checkForLock looks for any existing locks for this stack other than the given one, which this backend holds, and
+// returns a helpful diagnostic if there is one. held is nil if this backend doesn't hold a lock on the stack. Locks
+// whose lease has expired are stale, and are deleted rather than reported.
+func (b *localBackend) checkForLock(
+	ctx context.Context, stackRef backend.StackReference, held *lockContent,
+) error {
+	locks, err := b.readLocks(ctx, stackRef)
 	if err != nil {
 		return err
 	}
@@ -69,38 +156,31 @@ func (b *localBackend) checkForLock(ctx context.Context, stackRef backend.StackR
 	// We need to convert it to a slash path (/) to compare it to
 	// the keys in the bucket which are always slash paths.
 	wantLock := filepath.ToSlash(b.lockPath(stackRef))
-	var lockKeys []string
-	for _, file := range allFiles {
-		if file.IsDir {
+	now := time.Now()
+	var liveLocks []stackLock
+	for _, lock := range locks {
+		if lock.key == wantLock && held != nil && lock.content != nil && lock.content.sameHolder(held) {
 			continue
 		}
-		if file.Key != wantLock {
-			lockKeys = append(lockKeys, file.Key)
+		if lock.err != nil {
+			return fmt.Errorf("could not read lock %v: %w", b.url+"/"+lock.key, lock.err)
+		}
+		if lock.content.expired(now) {
+			logging.V(5).Infof("removing stale lock %v %v", lock.key, lock.content)
+			if err := b.bucket.Delete(ctx, lock.key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+				return fmt.Errorf("could not remove stale lock %v: %w", b.url+"/"+lock.key, err)
+			}
+			continue
 		}
+		liveLocks = append(liveLocks, lock)
 	}
 
-	if len(lockKeys) > 0 {
+	if len(liveLocks) > 0 {
 		errorString := fmt.Sprintf("the stack is currently locked by %v lock(s). Either wait for the other "+
-			"process(es) to end or delete the lock file with `pulumi cancel`.", len(lockKeys))
-
-		for _, lock := range lockKeys {
-			content, err := b.bucket.ReadAll(ctx, lock)
-			if err != nil {
-				return err
-			}
-			l := &lockContent{}
-			err = json.Unmarshal(content, &l)
-			if err != nil {
-				return err
-			}
+			"process(es) to end or delete the lock file with `pulumi cancel`.", len(liveLocks))
 
-			errorString += fmt.Sprintf("\n  %v: created by %v@%v (pid %v) at %v",
-				b.url+"/"+lock,
-				l.Username,
-				l.Hostname,
-				l.Pid,
-				l.Timestamp.Format(time.RFC3339),
-			)
+		for _, lock := range liveLocks {
+			errorString += fmt.Sprintf("\n  %v: %v", b.url+"/"+lock.key, lock.content)
 		}
 
 		return errors.New(errorString)
@@ -108,38 +188,393 @@ func (b *localBackend) checkForLock(ctx context.Context, stackRef backend.StackR
 	return nil
 }
 
+// Lock takes a lock on the given stack. The lock is held on a lease which is renewed in the background until Unlock
+// is called.
+//
+// On buckets that can create an object only if it doesn't exist yet (local directories, Google Cloud Storage and Azure
+// Blob Storage, as well as in-memory buckets), every backend takes the lock by creating the same key, so at most one
+// of them can hold it. Other buckets, such as S3, can't, so there the lock is best-effort: each backend writes a key of
+// its own and checks for other locks after writing it. Two processes that take the lock at the same time then see
+// each other's locks and both back off, unless the bucket lists a lock only some time after it is written.
+//
+// Removing a stale lock isn't atomic on any bucket: if two processes remove the same stale lock, one of them can
+// remove the lock that the other has just taken. The other process then loses the lock when it next renews its lease.
 func (b *localBackend) Lock(ctx context.Context, stackRef backend.StackReference) error {
-	//
-	err := b.checkForLock(ctx, stackRef)
+	err := b.checkForLock(ctx, stackRef, nil)
 	if err != nil {
 		return err
 	}
-	lockContent, err := newLockContent()
+	lease := b.lockLeaseDuration()
+	lockContent, err := newLockContent(lease)
 	if err != nil {
 		return err
 	}
-	content, err := json.Marshal(lockContent)
+	created, err := b.writeLock(ctx, stackRef, lockContent)
 	if err != nil {
 		return err
 	}
-	err = b.bucket.WriteAll(ctx, b.lockPath(stackRef), content, nil)
+	if !created {
+		// Another process took the lock since we checked for it.
+		if err := b.checkForLock(ctx, stackRef, nil); err != nil {
+			return err
+		}
+		return errors.New("the stack was locked by another process while taking the lock on it; try again")
+	}
+	// Older versions of the CLI, like buckets that can't create the lock only if it doesn't exist, write a lock of
+	// their own, so check for those again after writing ours.
+	err = b.checkForLock(ctx, stackRef, lockContent)
 	if err != nil {
+		b.removeLock(ctx, stackRef, lockContent)
 		return err
 	}
-	err = b.checkForLock(ctx, stackRef)
+	b.renewLease(stackRef, lockContent, lease)
+	return nil
+}
+
+// writeLock writes the given lock for the given stack. On buckets with shared locks, it returns false without writing
+// the lock if the lock already exists.
+func (b *localBackend) writeLock(
+	ctx context.Context, stackRef backend.StackReference, lock *lockContent,
+) (bool, error) {
+	content, err := json.Marshal(lock)
 	if err != nil {
-		b.Unlock(ctx, stackRef)
-		return err
+		return false, err
+	}
+	key := b.lockPath(stackRef)
+	if !b.sharedLocks() {
+		return true, b.bucket.WriteAll(ctx, key, content, nil)
+	}
+	switch b.lockScheme() {
+	case "file":
+		return createLockFile(b.url, key, content)
+	case "mem":
+		// In-memory buckets only exist in this process, so no other process can write the lock between checking for
+		// it and writing it.
+		memLockMutex.Lock()
+		defer memLockMutex.Unlock()
+		exists, err := b.bucket.Exists(ctx, key)
+		if err != nil || exists {
+			return false, err
+		}
+		return true, b.bucket.WriteAll(ctx, key, content, nil)
+	case gcsblob.Scheme, azureblob.Scheme:
+		if err := b.bucket.WriteAll(ctx, key, content, ifNotExists()); err != nil {
+			// Buckets don't all report a failed precondition in the same way, so look for the lock instead.
+			if exists, existsErr := b.bucket.Exists(ctx, key); existsErr == nil && exists {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	default:
+		contract.Failf("unexpected lock scheme %q", b.lockScheme())
+		return false, nil
+	}
+}
+
+// memLockMutex serializes taking locks in in-memory buckets.
+var memLockMutex sync.Mutex
+
+// createLockFile creates the lock file with the given key in the directory of a file:// bucket, if it doesn't exist
+// yet. The file is created directly rather than through the bucket, which writes files by renaming them over any
+// existing file.
+func createLockFile(bucketURL, key string, content []byte) (bool, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return false, err
+	}
+	dir := u.Path
+	// As in fileblob, drop the leading "/" of relative paths and of paths on Windows.
+	if u.Host == "." || os.PathSeparator != '/' {
+		dir = strings.TrimPrefix(dir, "/")
+	}
+	// Lock keys are made of stack names, which never need escaping like other keys can in fileblob.
+	path := filepath.Join(filepath.FromSlash(dir), filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return false, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if _, err := f.Write(content); err != nil {
+		contract.IgnoreClose(f)
+		contract.IgnoreError(os.Remove(path))
+		return false, err
+	}
+	if err := f.Close(); err != nil {
+		contract.IgnoreError(os.Remove(path))
+		return false, err
+	}
+	return true, nil
+}
+
+// ifNotExists returns options that make a write to Google Cloud Storage or Azure Blob Storage fail if the object
+// already exists.
+func ifNotExists() *blob.WriterOptions {
+	return &blob.WriterOptions{
+		BeforeWrite: func(as func(interface{}) bool) error {
+			var obj **storage.ObjectHandle
+			if as(&obj) {
+				*obj = (*obj).If(storage.Conditions{DoesNotExist: true})
+			}
+			var upload **azblob.UploadStreamOptions
+			if as(&upload) {
+				anyETag := string(azblob.ETagAny)
+				(*upload).BlobAccessConditions = &azblob.BlobAccessConditions{
+					ModifiedAccessConditions: &azblob.ModifiedAccessConditions{IfNoneMatch: &anyETag},
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// leaseRenewal renews the lease on a lock that this backend holds.
+type leaseRenewal struct {
+	// holder is the lock as it was taken. The renewals only change its lease.
+	holder lockContent
+	stop   func()
+	// lost is closed if the lock is lost while its lease is being renewed. err says why it was lost, and is set before
+	// lost is closed.
+	lost chan struct{}
+	err  error
+}
+
+// Err returns why the lock was lost, or nil if it is still held.
+func (r *leaseRenewal) Err() error {
+	select {
+	case <-r.lost:
+		return r.err
+	default:
+		return nil
+	}
+}
+
+// renewLease starts renewing the lease on a lock this backend holds, until stopLeaseRenewal is called. If the lock is
+// removed by another process, or its lease runs out because it can't be renewed, the lock is lost: checkpoints are no
+// longer saved for the stack, and operations watching the lock with cancelOnLeaseLost are canceled.
+func (b *localBackend) renewLease(stackRef backend.StackReference, lock *lockContent, lease time.Duration) {
+	ctx, stopRenewing := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	renewal := &leaseRenewal{
+		holder: *lock,
+		stop: func() {
+			stopRenewing()
+			<-done
+		},
+		lost: make(chan struct{}),
+	}
+	lose := func(err error) {
+		b.d.Warningf(diag.Message("", "%v"), err)
+		renewal.err = err
+		close(renewal.lost)
+	}
+
+	b.leaseMutex.Lock()
+	if b.leases == nil {
+		b.leases = make(map[string]*leaseRenewal)
+	}
+	b.leases[b.lockPath(stackRef)] = renewal
+	b.leaseMutex.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			held, err := b.renewLock(ctx, stackRef, lock, lease)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logging.V(5).Infof("error renewing lock %v: %v", b.lockPath(stackRef), err)
+				if !lock.expired(time.Now()) {
+					continue
+				}
+				// Other processes may take the lock now, so it must be treated as lost.
+				lose(fmt.Errorf("the lease on the lock on stack %v expired because it could not be renewed: %w",
+					stackRef.FullyQualifiedName(), err))
+				return
+			}
+			if !held {
+				// Someone ran `pulumi cancel`. Don't write the lock back.
+				lose(fmt.Errorf("the lock on stack %v was removed by another process",
+					stackRef.FullyQualifiedName()))
+				return
+			}
+		}
+	}()
+}
+
+// lease returns the renewal of the lease on the given stack's lock, or nil if this backend doesn't hold it.
+func (b *localBackend) lease(stackRef backend.StackReference) *leaseRenewal {
+	b.leaseMutex.Lock()
+	defer b.leaseMutex.Unlock()
+	return b.leases[b.lockPath(stackRef)]
+}
+
+// checkLease returns an error if this backend held the lock on the given stack, but has lost it since.
+func (b *localBackend) checkLease(stackRef backend.StackReference) error {
+	if renewal := b.lease(stackRef); renewal != nil {
+		return renewal.Err()
 	}
 	return nil
 }
 
-func (b *localBackend) Unlock(ctx context.Context, stackRef backend.StackReference) {
-	err := b.bucket.Delete(ctx, b.lockPath(stackRef))
+// cancelOnLeaseLost returns a cancellation context that is canceled and terminated along with the given one, and is
+// also canceled if this backend loses the lock on the given stack. The returned function stops watching the lock.
+func (b *localBackend) cancelOnLeaseLost(
+	parent *cancel.Context, stackRef backend.StackReference,
+) (*cancel.Context, func()) {
+	renewal := b.lease(stackRef)
+	if renewal == nil {
+		return parent, func() {}
+	}
+
+	ctx, source := cancel.NewContext(context.Background())
+	done := make(chan struct{})
+	go func() {
+		canceled, lost := parent.Canceled(), renewal.lost
+		for {
+			select {
+			case <-done:
+				return
+			case <-parent.Terminated():
+				source.Terminate()
+				return
+			case <-canceled:
+				source.Cancel()
+				canceled = nil
+			case <-lost:
+				source.Cancel()
+				lost = nil
+			}
+		}
+	}()
+	return ctx, func() { close(done) }
+}
+
+// renewLock extends the lease on a lock this backend holds. It returns false without writing anything if the lock
+// has been removed or replaced since it was taken.
+//
+// The lock is only written back if it is unchanged since it was checked. On buckets that support conditional writes
+// (currently Google Cloud Storage) this is enforced by the bucket, so a lock that is removed between the check and the
+// write stays removed. Other buckets leave a window of a single round trip in which a removed lock can be written
+// back; the next renewal then finds a lock that it still holds, so the removal is lost.
+func (b *localBackend) renewLock(
+	ctx context.Context, stackRef backend.StackReference, lock *lockContent, lease time.Duration,
+) (bool, error) {
+	key := b.lockPath(stackRef)
+	attrs, err := b.bucket.Attributes(ctx, key)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	content, err := b.bucket.ReadAll(ctx, key)
 	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	var current lockContent
+	if err := json.Unmarshal(content, &current); err != nil || !current.sameHolder(lock) {
+		return false, nil
+	}
+
+	renewed := *lock
+	expires := time.Now().Add(lease)
+	renewed.Expires = &expires
+	content, err = json.Marshal(&renewed)
+	if err != nil {
+		return false, err
+	}
+	if err := b.bucket.WriteAll(ctx, key, content, ifUnmodifiedSince(attrs)); err != nil {
+		if gcerrors.Code(err) == gcerrors.FailedPrecondition {
+			// The lock was removed or replaced after we checked it.
+			return false, nil
+		}
+		return false, err
+	}
+	*lock = renewed
+	return true, nil
+}
+
+// ifUnmodifiedSince returns options that make a write fail with gcerrors.FailedPrecondition if the object has changed
+// since its attributes were read, on buckets that support conditional writes. Other buckets write unconditionally.
+func ifUnmodifiedSince(attrs *blob.Attributes) *blob.WriterOptions {
+	var gcsAttrs storage.ObjectAttrs
+	if !attrs.As(&gcsAttrs) {
+		return nil
+	}
+	return &blob.WriterOptions{
+		BeforeWrite: func(as func(interface{}) bool) error {
+			var obj **storage.ObjectHandle
+			if as(&obj) {
+				*obj = (*obj).If(storage.Conditions{GenerationMatch: gcsAttrs.Generation})
+			}
+			return nil
+		},
+	}
+}
+
+// stopLeaseRenewal stops renewing the lease on the given stack's lock, waiting for any renewal in progress to finish.
+// It returns the renewal that was stopped, or nil if this backend doesn't hold the lock.
+func (b *localBackend) stopLeaseRenewal(stackRef backend.StackReference) *leaseRenewal {
+	b.leaseMutex.Lock()
+	renewal, has := b.leases[b.lockPath(stackRef)]
+	delete(b.leases, b.lockPath(stackRef))
+	b.leaseMutex.Unlock()
+
+	if has {
+		renewal.stop()
+	}
+	return renewal
+}
+
+func (b *localBackend) lockLeaseDuration() time.Duration {
+	if b.lockLease > 0 {
+		return b.lockLease
+	}
+	return defaultLockLease
+}
+
+func (b *localBackend) Unlock(ctx context.Context, stackRef backend.StackReference) {
+	renewal := b.stopLeaseRenewal(stackRef)
+	if renewal == nil {
+		return
+	}
+	b.removeLock(ctx, stackRef, &renewal.holder)
+}
+
+// removeLock deletes the given lock on the given stack, unless another process has taken the lock since.
+func (b *localBackend) removeLock(ctx context.Context, stackRef backend.StackReference, lock *lockContent) {
+	key := b.lockPath(stackRef)
+	content, err := b.bucket.ReadAll(ctx, key)
+	if err == nil {
+		var current lockContent
+		if err := json.Unmarshal(content, &current); err != nil || !current.sameHolder(lock) {
+			return
+		}
+		err = b.bucket.Delete(ctx, key)
+	}
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
 		b.d.Errorf(
 			diag.Message("", "there was a problem deleting the lock at %v, manual clean up may be required: %v"),
-			path.Join(b.url, b.lockPath(stackRef)),
+			path.Join(b.url, key),
 			err)
 	}
 }
@@ -153,7 +588,31 @@ func stackLockDir(stack tokens.QName) string {
 	return path.Join(lockDir(), fsutil.QnamePath(stack))
 }
 
+// lockPath returns the key of the lock that this backend takes on the given stack. On buckets that can create the
+// lock only if it doesn't exist yet, this is the same key for every backend. Otherwise each backend has a key of its
+// own, as it has in older versions of the CLI.
 func (b *localBackend) lockPath(stackRef backend.StackReference) string {
 	contract.Requiref(stackRef != nil, "stack", "must not be nil")
-	return path.Join(stackLockDir(stackRef.FullyQualifiedName()), b.lockID+".json")
+	name := b.lockID
+	if b.sharedLocks() {
+		name = "lock"
+	}
+	return path.Join(stackLockDir(stackRef.FullyQualifiedName()), name+".json")
+}
+
+// sharedLocks returns true if this backend's bucket can create a lock only if it doesn't exist yet, so that every
+// backend can take the lock on a stack by creating the same key.
+func (b *localBackend) sharedLocks() bool {
+	switch b.lockScheme() {
+	case "file", "mem", gcsblob.Scheme, azureblob.Scheme:
+		return true
+	default:
+		return false
+	}
+}
+
+// lockScheme returns the URL scheme of the bucket that this backend stores its locks in.
+func (b *localBackend) lockScheme() string {
+	scheme, _, _ := strings.Cut(b.url, "://")
+	return scheme
 }
@@ -0,0 +1,478 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"path"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/memblob" // driver for mem://
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/util/cancel"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/testing/diagtest"
+)
+
+// lockTestSchemes are the URL schemes of the buckets that locks are tested in. s3 is an in-memory bucket that is
+// locked like an S3 bucket, which can't create the lock only if it doesn't exist yet.
+var lockTestSchemes = []string{"file", "mem", "s3"}
+
+// newLockTestBackends returns two backends with different lock IDs that share the same bucket, using the blob
+// driver for the given URL scheme.
+func newLockTestBackends(t *testing.T, scheme string, d diag.Sink) (*localBackend, *localBackend) {
+	t.Helper()
+
+	ctx := context.Background()
+	var b *localBackend
+	var err error
+	switch scheme {
+	case "file":
+		b, err = newLocalBackend(ctx, d, "file://"+filepath.ToSlash(t.TempDir()), nil, nil)
+		require.NoError(t, err)
+	case "mem":
+		b, err = newLocalBackend(ctx, d, "mem://", nil, nil)
+		require.NoError(t, err)
+	case "s3":
+		b, err = newLocalBackend(ctx, d, "mem://", nil, nil)
+		require.NoError(t, err)
+		b.url = "s3://lock-test"
+	default:
+		t.Fatalf("unknown scheme %q", scheme)
+	}
+	return b, shareLockTestBackend(t, b)
+}
+
+// shareLockTestBackend returns another backend with a different lock ID that shares the given backend's bucket.
+func shareLockTestBackend(t *testing.T, b *localBackend) *localBackend {
+	t.Helper()
+
+	if b.lockScheme() == "file" {
+		other, err := newLocalBackend(context.Background(), b.d, b.url, nil, nil)
+		require.NoError(t, err)
+		return other
+	}
+	// Every mem:// URL opens a new, empty bucket, so the other backend has to share this one's bucket.
+	lockID, err := uuid.NewV4()
+	require.NoError(t, err)
+	return &localBackend{
+		d:      b.d,
+		url:    b.url,
+		bucket: b.bucket,
+		lockID: lockID.String(),
+		Getenv: b.Getenv,
+		store:  b.store,
+	}
+}
+
+// writeTestLock writes a lock for the given stack as if it were held by an older version of the CLI with the given
+// lock ID.
+func writeTestLock(
+	t *testing.T, b *localBackend, stackRef backend.StackReference, lockID string, lock *lockContent,
+) string {
+	t.Helper()
+
+	key := path.Join(stackLockDir(stackRef.FullyQualifiedName()), lockID+".json")
+	writeTestLockAt(t, b, key, lock)
+	return key
+}
+
+// writeTestLockAt writes a lock with the given key.
+func writeTestLockAt(t *testing.T, b *localBackend, key string, lock *lockContent) {
+	t.Helper()
+
+	content, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, b.bucket.WriteAll(context.Background(), key, content, nil))
+}
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range lockTestSchemes {
+		scheme := scheme
+		t.Run(scheme, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("conflict", func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				b, other := newLockTestBackends(t, scheme, diagtest.LogSink(t))
+				stackRef, err := b.ParseStackReference("organization/project/dev")
+				require.NoError(t, err)
+
+				require.NoError(t, b.Lock(ctx, stackRef))
+
+				err = other.Lock(ctx, stackRef)
+				assert.ErrorContains(t, err, "the stack is currently locked by 1 lock(s)")
+				assert.ErrorContains(t, err, "lease expires at")
+
+				// The failed attempt must not have left its own lock behind, or removed the lock that is held.
+				locks, err := b.readLocks(ctx, stackRef)
+				require.NoError(t, err)
+				require.Len(t, locks, 1)
+				assert.True(t, locks[0].content.sameHolder(&b.lease(stackRef).holder))
+
+				b.Unlock(ctx, stackRef)
+				require.NoError(t, other.Lock(ctx, stackRef))
+				other.Unlock(ctx, stackRef)
+			})
+
+			t.Run("stale lease", func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				b, _ := newLockTestBackends(t, scheme, diagtest.LogSink(t))
+				stackRef, err := b.ParseStackReference("organization/project/dev")
+				require.NoError(t, err)
+
+				// Simulate a process that crashed while holding the lock.
+				lock, err := newLockContent(time.Minute)
+				require.NoError(t, err)
+				expired := time.Now().Add(-time.Minute)
+				lock.Expires = &expired
+				key := writeTestLock(t, b, stackRef, "crashed", lock)
+
+				require.NoError(t, b.Lock(ctx, stackRef))
+				defer b.Unlock(ctx, stackRef)
+
+				exists, err := b.bucket.Exists(ctx, key)
+				require.NoError(t, err)
+				assert.False(t, exists, "stale lock should have been removed")
+			})
+
+			t.Run("lock without lease", func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				b, _ := newLockTestBackends(t, scheme, diagtest.LogSink(t))
+				stackRef, err := b.ParseStackReference("organization/project/dev")
+				require.NoError(t, err)
+
+				// Locks written by older versions of the CLI have no lease and never expire.
+				writeTestLock(t, b, stackRef, "legacy", &lockContent{
+					Pid:       1,
+					Username:  "someone",
+					Hostname:  "somewhere",
+					Timestamp: time.Now().Add(-24 * time.Hour),
+				})
+
+				err = b.Lock(ctx, stackRef)
+				assert.ErrorContains(t, err, "created by someone@somewhere (pid 1)")
+			})
+
+			t.Run("renews lease", func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				b, _ := newLockTestBackends(t, scheme, diagtest.LogSink(t))
+				b.lockLease = 300 * time.Millisecond
+				stackRef, err := b.ParseStackReference("organization/project/dev")
+				require.NoError(t, err)
+
+				readExpiry := func() (time.Time, error) {
+					content, err := b.bucket.ReadAll(ctx, b.lockPath(stackRef))
+					if err != nil {
+						return time.Time{}, err
+					}
+					var lock lockContent
+					if err := json.Unmarshal(content, &lock); err != nil {
+						return time.Time{}, err
+					}
+					if lock.Expires == nil {
+						return time.Time{}, errors.New("lock has no lease")
+					}
+					return *lock.Expires, nil
+				}
+
+				require.NoError(t, b.Lock(ctx, stackRef))
+				first, err := readExpiry()
+				require.NoError(t, err)
+
+				// The lease is renewed before it runs out, so the lock is never seen as stale.
+				assert.Eventually(t, func() bool {
+					expires, err := readExpiry()
+					return err == nil && expires.After(first)
+				}, 5*time.Second, 50*time.Millisecond)
+				time.Sleep(2 * b.lockLease)
+				expires, err := readExpiry()
+				require.NoError(t, err)
+				assert.True(t, expires.After(time.Now()))
+
+				b.Unlock(ctx, stackRef)
+				exists, err := b.bucket.Exists(ctx, b.lockPath(stackRef))
+				require.NoError(t, err)
+				assert.False(t, exists)
+
+				// Once unlocked, the lease is no longer renewed and the lock isn't written back.
+				time.Sleep(b.lockLease)
+				exists, err = b.bucket.Exists(ctx, b.lockPath(stackRef))
+				require.NoError(t, err)
+				assert.False(t, exists)
+			})
+
+			t.Run("cancel reports holders", func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				var output bytes.Buffer
+				sink := diag.DefaultSink(&output, &output, diag.FormatOptions{Color: colors.Never})
+				b, other := newLockTestBackends(t, scheme, sink)
+				stackRef, err := b.ParseStackReference("organization/project/dev")
+				require.NoError(t, err)
+
+				// Taking a lock removes stale locks, so leave the stale lock behind once the other lock is held.
+				require.NoError(t, other.Lock(ctx, stackRef))
+				lock, err := newLockContent(time.Minute)
+				require.NoError(t, err)
+				expired := time.Now().Add(-time.Minute)
+				lock.Expires = &expired
+				writeTestLock(t, b, stackRef, "crashed", lock)
+
+				require.NoError(t, b.CancelCurrentUpdate(ctx, stackRef))
+				assert.Contains(t, output.String(), "Removing stale lock created by")
+				assert.Contains(t, output.String(), "Removing lock held by a process that may still be running")
+
+				locks, err := b.readLocks(ctx, stackRef)
+				require.NoError(t, err)
+				assert.Empty(t, locks)
+
+				other.stopLeaseRenewal(stackRef)
+			})
+
+			t.Run("renewal stops when lock is removed", func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				b, other := newLockTestBackends(t, scheme, diagtest.LogSink(t))
+				stackRef, err := b.ParseStackReference("organization/project/dev")
+				require.NoError(t, err)
+
+				lock, err := newLockContent(time.Minute)
+				require.NoError(t, err)
+				key := b.lockPath(stackRef)
+				writeTestLockAt(t, b, key, lock)
+
+				held, err := b.renewLock(ctx, stackRef, lock, time.Hour)
+				require.NoError(t, err)
+				assert.True(t, held)
+				assert.True(t, lock.Expires.After(time.Now().Add(time.Minute)))
+
+				// A lock that was replaced by another holder is left alone.
+				replacement, err := newLockContent(time.Minute)
+				require.NoError(t, err)
+				replacement.Pid = lock.Pid + 1
+				writeTestLockAt(t, b, key, replacement)
+				held, err = b.renewLock(ctx, stackRef, lock, time.Hour)
+				require.NoError(t, err)
+				assert.False(t, held)
+				content, err := b.bucket.ReadAll(ctx, key)
+				require.NoError(t, err)
+				var current lockContent
+				require.NoError(t, json.Unmarshal(content, &current))
+				assert.Equal(t, replacement.Pid, current.Pid)
+
+				// A lock that was removed by `pulumi cancel` isn't written back.
+				require.NoError(t, other.CancelCurrentUpdate(ctx, stackRef))
+				held, err = b.renewLock(ctx, stackRef, lock, time.Hour)
+				require.NoError(t, err)
+				assert.False(t, held)
+				exists, err := b.bucket.Exists(ctx, key)
+				require.NoError(t, err)
+				assert.False(t, exists)
+			})
+		})
+	}
+}
+
+// unreachableBucket is a bucket whose Attributes calls fail while it is unreachable, so that leases can't be renewed.
+type unreachableBucket struct {
+	Bucket
+
+	unreachable atomic.Bool
+}
+
+func (b *unreachableBucket) Attributes(ctx context.Context, key string) (*blob.Attributes, error) {
+	if b.unreachable.Load() {
+		return nil, errors.New("bucket is unreachable")
+	}
+	return b.Bucket.Attributes(ctx, key)
+}
+
+func TestLostLockCancelsOperation(t *testing.T) {
+	t.Parallel()
+
+	// lockOperation takes the lock on a stack and starts watching it as an operation does, returning the operation's
+	// cancellation context and its snapshot persister.
+	lockOperation := func(
+		t *testing.T, b *localBackend, stackRef backend.StackReference,
+	) (*cancel.Context, *localSnapshotPersister) {
+		ctx := context.Background()
+		require.NoError(t, b.Lock(ctx, stackRef))
+		t.Cleanup(func() { b.Unlock(ctx, stackRef) })
+
+		parent, _ := cancel.NewContext(ctx)
+		cancelCtx, stopWatching := b.cancelOnLeaseLost(parent, stackRef)
+		t.Cleanup(stopWatching)
+
+		ref, err := b.getReference(stackRef)
+		require.NoError(t, err)
+		return cancelCtx, b.newSnapshotPersister(ctx, ref)
+	}
+
+	t.Run("lock removed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		b, other := newLockTestBackends(t, "mem", diagtest.LogSink(t))
+		b.lockLease = 300 * time.Millisecond
+		stackRef, err := b.ParseStackReference("organization/project/dev")
+		require.NoError(t, err)
+
+		cancelCtx, persister := lockOperation(t, b, stackRef)
+		assert.NoError(t, b.checkLease(stackRef))
+
+		// `pulumi cancel` removes the lock, so the operation is canceled and stops saving checkpoints.
+		require.NoError(t, other.CancelCurrentUpdate(ctx, stackRef))
+		assert.Eventually(t, func() bool {
+			return cancelCtx.CancelErr() != nil
+		}, 5*time.Second, 50*time.Millisecond)
+		assert.Nil(t, cancelCtx.TerminateErr())
+		err = persister.Save(&deploy.Snapshot{})
+		assert.ErrorContains(t, err, "the lock on stack organization/project/dev was removed by another process")
+
+		// Another process can take the lock, and the lost lock isn't written back over it.
+		require.NoError(t, other.Lock(ctx, stackRef))
+		defer other.Unlock(ctx, stackRef)
+		time.Sleep(b.lockLease)
+		locks, err := b.readLocks(ctx, stackRef)
+		require.NoError(t, err)
+		require.Len(t, locks, 1)
+		assert.True(t, locks[0].content.sameHolder(&other.lease(stackRef).holder))
+
+		// Nor is it removed when the operation ends.
+		b.Unlock(ctx, stackRef)
+		locks, err = b.readLocks(ctx, stackRef)
+		require.NoError(t, err)
+		assert.Len(t, locks, 1)
+	})
+
+	t.Run("lease expired", func(t *testing.T) {
+		t.Parallel()
+
+		b, _ := newLockTestBackends(t, "mem", diagtest.LogSink(t))
+		b.lockLease = 300 * time.Millisecond
+		bucket := &unreachableBucket{Bucket: b.bucket}
+		b.bucket = bucket
+		stackRef, err := b.ParseStackReference("organization/project/dev")
+		require.NoError(t, err)
+
+		cancelCtx, persister := lockOperation(t, b, stackRef)
+
+		// Failed renewals are retried until the lease runs out, and then the operation is canceled.
+		bucket.unreachable.Store(true)
+		assert.Eventually(t, func() bool {
+			return cancelCtx.CancelErr() != nil
+		}, 5*time.Second, 50*time.Millisecond)
+		err = persister.Save(&deploy.Snapshot{})
+		assert.ErrorContains(t, err, "expired because it could not be renewed: bucket is unreachable")
+	})
+}
+
+// delayedBucket is a bucket whose writes take some time, so that processes that take a lock at the same time check
+// for each other's locks while they are being written.
+type delayedBucket struct {
+	Bucket
+}
+
+func (b *delayedBucket) WriteAll(ctx context.Context, key string, p []byte, opts *blob.WriterOptions) error {
+	//nolint:gosec // The delay doesn't need to be unpredictable.
+	time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+	return b.Bucket.WriteAll(ctx, key, p, opts)
+}
+
+func TestConcurrentLock(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range lockTestSchemes {
+		scheme := scheme
+		t.Run(scheme, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			b, _ := newLockTestBackends(t, scheme, diagtest.LogSink(t))
+			stackRef, err := b.ParseStackReference("organization/project/dev")
+			require.NoError(t, err)
+			backends := []*localBackend{b}
+			for i := 0; i < 7; i++ {
+				backends = append(backends, shareLockTestBackend(t, b))
+			}
+			for _, b := range backends {
+				b.bucket = &delayedBucket{Bucket: b.bucket}
+			}
+
+			for attempt := 0; attempt < 10; attempt++ {
+				held := make([]bool, len(backends))
+				start := make(chan struct{})
+				var wg sync.WaitGroup
+				for i, b := range backends {
+					i, b := i, b
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						<-start
+						held[i] = b.Lock(ctx, stackRef) == nil
+					}()
+				}
+				close(start)
+				wg.Wait()
+
+				holders := 0
+				for i, b := range backends {
+					if held[i] {
+						holders++
+						b.Unlock(ctx, stackRef)
+					}
+				}
+				if scheme == "s3" {
+					// Processes that take the lock at the same time may all back off.
+					assert.LessOrEqual(t, holders, 1)
+				} else {
+					assert.Equal(t, 1, holders)
+				}
+
+				locks, err := b.readLocks(ctx, stackRef)
+				require.NoError(t, err)
+				assert.Empty(t, locks)
+			}
+		})
+	}
+}
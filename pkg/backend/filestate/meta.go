@@ -49,6 +49,10 @@ type pulumiMeta struct {
 	// Does not use "omitempty" to differentiate
 	// between a missing field and a zero value.
 	Version int `json:"version" yaml:"version"`
+
+	// Retention optionally limits the history kept for each stack.
+	// Projects may override it with their own policy.
+	Retention *retentionSettings `json:"retention,omitempty" yaml:"retention,omitempty"`
 }
 
 // ensurePulumiMeta loads the Pulumi state metadata file from the bucket.
@@ -135,6 +139,8 @@ func readPulumiMeta(ctx context.Context, b Bucket) (*pulumiMeta, error) {
 	var state struct {
 		// Version 0 is valid, so we need to use a pointer.
 		Version *int `yaml:"version"`
+
+		Retention *retentionSettings `yaml:"retention"`
 	}
 
 	if err := yaml.Unmarshal(metaBody, &state); err != nil {
@@ -145,8 +151,15 @@ func readPulumiMeta(ctx context.Context, b Bucket) (*pulumiMeta, error) {
 		return nil, fmt.Errorf("corrupt store: missing version in %q", pulumiMetaPath)
 	}
 
+	if r := state.Retention; r != nil {
+		if _, err := newRetentionPolicy(r.Keep, r.MaxAge); err != nil {
+			return nil, fmt.Errorf("corrupt store: %q: %w", pulumiMetaPath, err)
+		}
+	}
+
 	return &pulumiMeta{
-		Version: *state.Version,
+		Version:   *state.Version,
+		Retention: state.Retention,
 	}, nil
 }
 
@@ -95,6 +95,13 @@ func TestEnsurePulumiMeta(t *testing.T) {
 			},
 			want: pulumiMeta{Version: 42},
 		},
+		{
+			desc: "retention",
+			give: map[string]string{
+				".pulumi/meta.yaml": "version: 1\nretention:\n  keep: 10\n  maxAge: 720h\n",
+			},
+			want: pulumiMeta{Version: 1, Retention: &retentionSettings{Keep: 10, MaxAge: "720h"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -138,6 +145,11 @@ func TestEnsurePulumiMeta_corruption(t *testing.T) {
 			give:    `version: foo`,
 			wantErr: `corrupt store: unmarshal ".pulumi/meta.yaml"`,
 		},
+		{
+			desc:    "corrupt retention",
+			give:    "version: 1\nretention:\n  maxAge: forever\n",
+			wantErr: `invalid retention: maxAge must be a positive duration`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -166,6 +178,7 @@ func TestMeta_roundTrip(t *testing.T) {
 		{desc: "zero", give: pulumiMeta{Version: 0}},
 		{desc: "one", give: pulumiMeta{Version: 1}},
 		{desc: "future", give: pulumiMeta{Version: 42}},
+		{desc: "retention", give: pulumiMeta{Version: 1, Retention: &retentionSettings{Keep: 3}}},
 	}
 
 	for _, tt := range tests {
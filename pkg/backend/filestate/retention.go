@@ -0,0 +1,344 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// RetentionPolicy limits the update history, checkpoint backups and retained checkpoints
+// (see PULUMI_RETAIN_CHECKPOINTS) kept for each stack.
+//
+// An entry is kept if any of the configured limits keeps it.
+// A policy without any limits keeps everything.
+// The most recent history entry is always kept.
+type RetentionPolicy struct {
+	// Keep is the number of most recent entries to keep.
+	// Zero if entries aren't kept based on their number.
+	Keep int
+
+	// MaxAge keeps entries newer than this duration.
+	// Zero if entries aren't kept based on their age.
+	MaxAge time.Duration
+}
+
+// retentionSettings is the form a retention policy takes in .pulumi/meta.yaml.
+// It matches the form it takes in the project file.
+type retentionSettings struct {
+	Keep   int    `json:"keep,omitempty" yaml:"keep,omitempty"`
+	MaxAge string `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
+}
+
+// newRetentionPolicy builds a retention policy from its configured form.
+func newRetentionPolicy(keep int, maxAge string) (*RetentionPolicy, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("invalid retention: keep must not be negative, got %d", keep)
+	}
+
+	policy := &RetentionPolicy{Keep: keep}
+	if maxAge != "" {
+		d, err := time.ParseDuration(maxAge)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid retention: maxAge must be a positive duration such as \"720h\", got %q", maxAge)
+		}
+		policy.MaxAge = d
+	}
+	return policy, nil
+}
+
+// isEmpty reports whether the policy keeps everything.
+func (p *RetentionPolicy) isEmpty() bool {
+	return p == nil || (p.Keep == 0 && p.MaxAge == 0)
+}
+
+// prunable returns how many of the entries written at the given times, ordered oldest first,
+// fall outside the policy.
+//
+// Both limits keep the newest entries, so the entries to prune are always the oldest ones.
+func (p *RetentionPolicy) prunable(times []time.Time, now time.Time) int {
+	if p.isEmpty() {
+		return 0
+	}
+
+	n := len(times)
+	if p.Keep > 0 {
+		n = len(times) - p.Keep
+		if n < 0 {
+			n = 0
+		}
+	}
+	if p.MaxAge > 0 {
+		cutoff := now.Add(-p.MaxAge)
+		old := sort.Search(len(times), func(i int) bool {
+			return !times[i].Before(cutoff)
+		})
+		if old < n {
+			n = old
+		}
+	}
+	return n
+}
+
+// retentionPolicy returns the retention policy for stacks of the given project, or nil if there is none.
+// A policy set in the project takes precedence over one set in the backend's metadata file.
+func (b *localBackend) retentionPolicy(proj *workspace.Project) (*RetentionPolicy, error) {
+	if proj != nil && proj.Backend != nil && proj.Backend.Retention != nil {
+		r := proj.Backend.Retention
+		policy, err := newRetentionPolicy(r.Keep, r.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", proj.Name, err)
+		}
+		return policy, nil
+	}
+	return b.retention, nil
+}
+
+// PruneOptions customizes the behavior of PruneHistory.
+type PruneOptions struct {
+	// Policy is the retention policy to apply instead of the one configured for the stack.
+	Policy *RetentionPolicy
+}
+
+func (b *localBackend) PruneHistory(
+	ctx context.Context, stackRef backend.StackReference, opts *PruneOptions,
+) (int, error) {
+	if opts == nil {
+		opts = &PruneOptions{}
+	}
+
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return 0, err
+	}
+
+	policy := opts.Policy
+	if policy == nil {
+		if policy, err = b.retentionPolicy(b.currentProject.Load()); err != nil {
+			return 0, err
+		}
+		if policy.isEmpty() {
+			return 0, errors.New("no retention policy is configured in the project or in .pulumi/meta.yaml")
+		}
+	}
+
+	err = b.Lock(ctx, ref)
+	if err != nil {
+		return 0, err
+	}
+	defer b.Unlock(ctx, ref)
+
+	return b.pruneHistory(ctx, ref, policy)
+}
+
+// enforceRetention prunes the history of a stack that was just updated.
+//
+// Failures are only reported as warnings because they don't affect the update that was saved.
+func (b *localBackend) enforceRetention(ctx context.Context, ref *localBackendReference, policy *RetentionPolicy) {
+	if _, err := b.pruneHistory(ctx, ref, policy); err != nil {
+		b.d.Warningf(diag.Message("", "Could not prune the history of stack %s: %v"), ref, err)
+	}
+}
+
+// timestampedFile is a file whose name records the time it was written.
+type timestampedFile struct {
+	key  string
+	time time.Time
+}
+
+// pruneHistory deletes the history entries, checkpoint backups and retained checkpoints of the given stack
+// that fall outside the policy, and returns how many were deleted.
+// Each history entry is deleted along with the copy of the checkpoint saved with it.
+func (b *localBackend) pruneHistory(
+	ctx context.Context, ref *localBackendReference, policy *RetentionPolicy,
+) (int, error) {
+	contract.Requiref(ref != nil, "ref", "must not be nil")
+
+	if policy.isEmpty() {
+		return 0, nil
+	}
+
+	stackPath := b.stackPath(ctx, ref)
+	stackFile := filepath.Base(stackPath)
+	groups := []struct {
+		dir     string
+		parse   func(name string) (string, bool)
+		history bool
+	}{
+		{
+			// <name>-<timestamp>.history.json[.gz]
+			dir:     ref.HistoryDir(),
+			history: true,
+			parse: func(name string) (string, bool) {
+				name = strings.TrimSuffix(name, ".gz")
+				if !strings.HasSuffix(name, ".history.json") {
+					return "", false
+				}
+				name = strings.TrimSuffix(name, ".history.json")
+				idx := strings.LastIndex(name, "-")
+				return name[idx+1:], idx >= 0
+			},
+		},
+		{
+			// <name>.<timestamp>.json[.gz]
+			dir: ref.BackupDir(),
+			parse: func(name string) (string, bool) {
+				name = strings.TrimSuffix(name, ".gz")
+				if !strings.HasSuffix(name, ".json") {
+					return "", false
+				}
+				name = strings.TrimSuffix(name, ".json")
+				idx := strings.LastIndex(name, ".")
+				return name[idx+1:], idx >= 0
+			},
+		},
+		{
+			// <stack file>.<timestamp>, next to the stack file.
+			dir: filepath.Dir(stackPath),
+			parse: func(name string) (string, bool) {
+				return strings.TrimPrefix(name, stackFile+"."), strings.HasPrefix(name, stackFile+".")
+			},
+		},
+	}
+
+	now := time.Now()
+	var doomed []string
+	for _, group := range groups {
+		files, err := b.listTimestampedFiles(ctx, group.dir, group.parse)
+		if err != nil {
+			return 0, err
+		}
+
+		times := make([]time.Time, len(files))
+		for i, f := range files {
+			times[i] = f.time
+		}
+		n := policy.prunable(times, now)
+		if group.history && n > 0 {
+			if n, err = b.prepareHistoryPrune(ctx, files, n); err != nil {
+				return 0, err
+			}
+		}
+		for _, f := range files[:n] {
+			doomed = append(doomed, f.key)
+		}
+	}
+	if len(doomed) == 0 {
+		return 0, nil
+	}
+
+	pool := newWorkerPool(0, len(doomed))
+	defer pool.Close()
+
+	for _, key := range doomed {
+		key := key
+		pool.Enqueue(func() error {
+			if err := b.bucket.Delete(ctx, key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+				return fmt.Errorf("deleting %s: %w", key, err)
+			}
+
+			// History entries come with a copy of the checkpoint.
+			if strings.Contains(key, ".history.json") {
+				checkpoint := strings.Replace(key, ".history.json", ".checkpoint.json", 1)
+				if err := b.bucket.Delete(ctx, checkpoint); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+					return fmt.Errorf("deleting %s: %w", checkpoint, err)
+				}
+			}
+
+			logging.V(7).Infof("Pruned %s", key)
+			return nil
+		})
+	}
+	if err := pool.Wait(); err != nil {
+		return 0, err
+	}
+	return len(doomed), nil
+}
+
+// prepareHistoryPrune gets the given history entries, ordered oldest first, ready for the oldest n of them to be
+// pruned, and returns how many of them can be pruned.
+//
+// History entries are numbered consecutively from the oldest one, so the oldest entry that is kept records its version
+// before the entries before it are removed. The newest entry is always kept so that new entries carry on its numbering.
+func (b *localBackend) prepareHistoryPrune(ctx context.Context, files []timestampedFile, n int) (int, error) {
+	if n >= len(files) {
+		n = len(files) - 1
+	}
+	if n <= 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, len(files))
+	for i, f := range files {
+		keys[i] = f.key
+	}
+	first, err := b.firstHistoryVersion(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+	if err := b.pinHistoryVersion(ctx, files[n].key, first+n); err != nil {
+		return 0, fmt.Errorf("recording the version of %s: %w", files[n].key, err)
+	}
+	return n, nil
+}
+
+// listTimestampedFiles lists the files in dir whose timestamp, in nanoseconds since the Unix epoch, can be
+// extracted from their name with parse. The files are returned oldest first.
+func (b *localBackend) listTimestampedFiles(
+	ctx context.Context, dir string, parse func(name string) (string, bool),
+) ([]timestampedFile, error) {
+	objs, err := listBucket(ctx, b.bucket, dir)
+	if err != nil {
+		// The directory doesn't exist until something is written to it.
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []timestampedFile
+	for _, obj := range objs {
+		if obj.IsDir {
+			continue
+		}
+		timestamp, ok := parse(objectName(obj))
+		if !ok {
+			continue
+		}
+		nanos, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, timestampedFile{key: obj.Key, time: time.Unix(0, nanos)})
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].time.Before(files[j].time)
+	})
+	return files, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/testing/diagtest"
+)
+
+func TestNewRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := newRetentionPolicy(10, "24h")
+	require.NoError(t, err)
+	assert.Equal(t, &RetentionPolicy{Keep: 10, MaxAge: 24 * time.Hour}, policy)
+
+	_, err = newRetentionPolicy(-1, "")
+	assert.ErrorContains(t, err, "keep must not be negative")
+
+	_, err = newRetentionPolicy(0, "a month")
+	assert.ErrorContains(t, err, "maxAge must be a positive duration")
+}
+
+func TestRetentionPolicyPrunable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Five entries written one, two, ..., five days ago, oldest first.
+	times := []time.Time{
+		now.Add(-5 * 24 * time.Hour),
+		now.Add(-4 * 24 * time.Hour),
+		now.Add(-3 * 24 * time.Hour),
+		now.Add(-2 * 24 * time.Hour),
+		now.Add(-1 * 24 * time.Hour),
+	}
+
+	tests := []struct {
+		desc   string
+		policy *RetentionPolicy
+		want   int
+	}{
+		{desc: "nil", policy: nil, want: 0},
+		{desc: "empty", policy: &RetentionPolicy{}, want: 0},
+		{desc: "keep", policy: &RetentionPolicy{Keep: 2}, want: 3},
+		{desc: "keep more than there are", policy: &RetentionPolicy{Keep: 10}, want: 0},
+		{desc: "max age", policy: &RetentionPolicy{MaxAge: 60 * time.Hour}, want: 3},
+		{desc: "max age keeps everything", policy: &RetentionPolicy{MaxAge: 10 * 24 * time.Hour}, want: 0},
+		{desc: "keep wins", policy: &RetentionPolicy{Keep: 4, MaxAge: 60 * time.Hour}, want: 1},
+		{desc: "max age wins", policy: &RetentionPolicy{Keep: 1, MaxAge: 60 * time.Hour}, want: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.policy.prunable(times, now))
+		})
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	stackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, "", nil)
+	require.NoError(t, err)
+
+	// Fake up history entries, backups and retained checkpoints written over the last five days.
+	now := time.Now()
+	stackPath := lb.stackPath(ctx, stackRef)
+	write := func(key string) {
+		require.NoError(t, lb.bucket.WriteAll(ctx, key, []byte("{}"), nil))
+	}
+	for i := 5; i > 0; i-- {
+		ts := now.Add(-time.Duration(i) * 24 * time.Hour).UnixNano()
+		write(path.Join(stackRef.HistoryDir(), fmt.Sprintf("a-%d.history.json", ts)))
+		write(path.Join(stackRef.HistoryDir(), fmt.Sprintf("a-%d.checkpoint.json", ts)))
+		write(path.Join(stackRef.BackupDir(), fmt.Sprintf("a.%d.json", ts)))
+		write(fmt.Sprintf("%s.%d", stackPath, ts))
+	}
+
+	// Without a configured policy, there's nothing to apply.
+	_, err = lb.PruneHistory(ctx, stackRef, nil)
+	assert.ErrorContains(t, err, "no retention policy is configured")
+
+	pruned, err := lb.PruneHistory(ctx, stackRef, &PruneOptions{
+		Policy: &RetentionPolicy{Keep: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 9, pruned)
+
+	history, err := b.GetHistory(ctx, stackRef, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 5, history[0].Version)
+	assert.Equal(t, 4, history[1].Version, "versions should survive pruning")
+
+	historyFiles, err := listBucket(ctx, lb.bucket, stackRef.HistoryDir())
+	require.NoError(t, err)
+	assert.Len(t, historyFiles, 4, "checkpoints should be pruned with their history entries")
+	backups, err := listBucket(ctx, lb.bucket, stackRef.BackupDir())
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	for i := 5; i > 2; i-- {
+		ts := now.Add(-time.Duration(i) * 24 * time.Hour).UnixNano()
+		exists, err := lb.bucket.Exists(ctx, fmt.Sprintf("%s.%d", stackPath, ts))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	}
+
+	// The stack itself is left alone.
+	exists, err := lb.bucket.Exists(ctx, stackPath)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestPruneHistory_versions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	stackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, "", nil)
+	require.NoError(t, err)
+
+	versions := func() []int {
+		history, err := b.GetHistory(ctx, stackRef, 0, 0)
+		require.NoError(t, err)
+		var versions []int
+		for _, u := range history {
+			versions = append(versions, u.Version)
+		}
+		return versions
+	}
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, lb.addToHistory(ctx, stackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	}
+	assert.Equal(t, []int{3, 2, 1}, versions())
+
+	_, err = lb.PruneHistory(ctx, stackRef, &PruneOptions{Policy: &RetentionPolicy{Keep: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, versions())
+
+	// New updates carry on from the newest version, and an age limit never removes the newest update.
+	require.NoError(t, lb.addToHistory(ctx, stackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	assert.Equal(t, []int{4, 3}, versions())
+	_, err = lb.PruneHistory(ctx, stackRef, &PruneOptions{Policy: &RetentionPolicy{MaxAge: time.Nanosecond}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{4}, versions())
+	require.NoError(t, lb.addToHistory(ctx, stackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	assert.Equal(t, []int{5, 4}, versions())
+
+	_, err = lb.getHistoryCheckpoint(ctx, stackRef, "3")
+	assert.ErrorContains(t, err, "has no version 3")
+}
+
+func TestPruneHistory_metaPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	stackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, "", nil)
+	require.NoError(t, err)
+	for i := 3; i > 0; i-- {
+		ts := time.Now().Add(-time.Duration(i) * time.Hour).UnixNano()
+		key := path.Join(stackRef.BackupDir(), fmt.Sprintf("a.%d.json", ts))
+		require.NoError(t, lb.bucket.WriteAll(ctx, key, []byte("{}"), nil))
+	}
+
+	// Configure a policy in the metadata file and log in again to pick it up.
+	meta := pulumiMeta{Version: 1, Retention: &retentionSettings{MaxAge: "90m"}}
+	require.NoError(t, meta.WriteTo(ctx, lb.bucket))
+	b, err = New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+	lb, ok = b.(*localBackend)
+	require.True(t, ok)
+
+	pruned, err := lb.PruneHistory(ctx, stackRef, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+}
@@ -32,6 +32,10 @@ type localSnapshotPersister struct {
 }
 
 func (sp *localSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
+	// Once the lock on the stack is lost, another process may be writing its checkpoints.
+	if err := sp.backend.checkLease(sp.ref); err != nil {
+		return err
+	}
 	_, err := sp.backend.saveStack(sp.ctx, sp.ref, snapshot, snapshot.SecretsManager)
 	return err
 }
@@ -40,17 +40,21 @@ type localStack struct {
 	// a snapshot representing the latest deployment state, allocated on first use. It's valid for the
 	// snapshot itself to be nil.
 	snapshot atomic.Pointer[*deploy.Snapshot]
+	// the stack's tags, as of when the stack was loaded or its tags were last updated.
+	tags atomic.Pointer[map[apitype.StackTagName]string]
 	// a pointer to the backend this stack belongs to.
 	b *localBackend
 }
 
-func newStack(ref *localBackendReference, b *localBackend) backend.Stack {
+func newStack(ref *localBackendReference, b *localBackend, tags map[apitype.StackTagName]string) backend.Stack {
 	contract.Requiref(ref != nil, "ref", "ref was nil")
 
-	return &localStack{
+	s := &localStack{
 		ref: ref,
 		b:   b,
 	}
+	s.tags.Store(&tags)
+	return s
 }
 
 func (s *localStack) Ref() backend.StackReference { return s.ref }
@@ -67,8 +71,13 @@ func (s *localStack) Snapshot(ctx context.Context, secretsProvider secrets.Provi
 	s.snapshot.Store(&snap)
 	return snap, nil
 }
-func (s *localStack) Backend() backend.Backend              { return s.b }
-func (s *localStack) Tags() map[apitype.StackTagName]string { return nil }
+func (s *localStack) Backend() backend.Backend { return s.b }
+func (s *localStack) Tags() map[apitype.StackTagName]string {
+	if v := s.tags.Load(); v != nil {
+		return *v
+	}
+	return nil
+}
 
 func (s *localStack) Remove(ctx context.Context, force bool) (bool, error) {
 	return backend.RemoveStack(ctx, s, force)
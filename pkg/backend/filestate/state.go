@@ -21,6 +21,7 @@ import (
 	"io"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -330,6 +331,10 @@ func (b *localBackend) removeStack(ctx context.Context, ref *localBackendReferen
 	file := b.stackPath(ctx, ref)
 	backupTarget(ctx, b.bucket, file, false)
 
+	if err := b.removeStackTags(ctx, ref); err != nil {
+		return err
+	}
+
 	historyDir := ref.HistoryDir()
 	return removeAllByPrefix(ctx, b.bucket, historyDir)
 }
@@ -432,19 +437,11 @@ func (b *localBackend) stackPath(ctx context.Context, ref *localBackendReference
 	return plainPath
 }
 
-// getHistory returns locally stored update history. The first element of the result will be
-// the most recent update record.
-func (b *localBackend) getHistory(
-	ctx context.Context,
-	stack *localBackendReference,
-	pageSize int, page int,
-) ([]backend.UpdateInfo, error) {
-	contract.Requiref(stack != nil, "stack", "must not be nil")
+// historyEntries returns the keys of the history files for the given stack, oldest first.
+func (b *localBackend) historyEntries(ctx context.Context, ref *localBackendReference) ([]string, error) {
+	contract.Requiref(ref != nil, "ref", "must not be nil")
 
-	dir := stack.HistoryDir()
-	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
-	// Unfortunately, this is mildly invasive given the gocloud List API.
-	allFiles, err := listBucket(ctx, b.bucket, dir)
+	allFiles, err := listBucket(ctx, b.bucket, ref.HistoryDir())
 	if err != nil {
 		// History doesn't exist until a stack has been updated.
 		if gcerrors.Code(err) == gcerrors.NotFound {
@@ -453,22 +450,99 @@ func (b *localBackend) getHistory(
 		return nil, err
 	}
 
-	var historyEntries []*blob.ListObject
-
-	// filter down to just history entries, reversing list to be in most recent order.
-	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
+	// listBucket returns the array sorted by file name, and because of how we name files, older updates come before
 	// newer ones.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := file.Key
-
+	var entries []string
+	for _, file := range allFiles {
 		// ignore checkpoints
-		if !strings.HasSuffix(filepath, ".history.json") &&
-			!strings.HasSuffix(filepath, ".history.json.gz") {
+		if !strings.HasSuffix(file.Key, ".history.json") &&
+			!strings.HasSuffix(file.Key, ".history.json.gz") {
 			continue
 		}
+		entries = append(entries, file.Key)
+	}
+	return entries, nil
+}
 
-		historyEntries = append(historyEntries, file)
+// readHistoryEntry reads the update recorded in the given history file.
+func (b *localBackend) readHistoryEntry(ctx context.Context, key string) (backend.UpdateInfo, error) {
+	var update backend.UpdateInfo
+	byts, err := b.bucket.ReadAll(ctx, key)
+	if err != nil {
+		return update, fmt.Errorf("reading history file %s: %w", key, err)
+	}
+	m := encoding.JSON
+	if encoding.IsCompressed(byts) {
+		m = encoding.Gzip(m)
+	}
+	if err := m.Unmarshal(byts, &update); err != nil {
+		return update, fmt.Errorf("reading history file %s: %w", key, err)
+	}
+	return update, nil
+}
+
+// firstHistoryVersion returns the version of the oldest of the given history entries. Later entries are numbered
+// consecutively from it.
+//
+// Each entry records its version when it is written, so versions don't change when old entries are pruned. Entries
+// written by older versions of the CLI don't record a version; they are numbered by their position, counting from 1,
+// and pruning records the version of the oldest entry it keeps before removing any entries.
+func (b *localBackend) firstHistoryVersion(ctx context.Context, entries []string) (int, error) {
+	if len(entries) == 0 {
+		return 1, nil
+	}
+	oldest, err := b.readHistoryEntry(ctx, entries[0])
+	if err != nil {
+		return 0, err
+	}
+	if oldest.Version > 0 {
+		return oldest.Version, nil
+	}
+	return 1, nil
+}
+
+// pinHistoryVersion records the given version in a history entry that doesn't record one yet.
+func (b *localBackend) pinHistoryVersion(ctx context.Context, key string, version int) error {
+	byts, err := b.bucket.ReadAll(ctx, key)
+	if err != nil {
+		return fmt.Errorf("reading history file %s: %w", key, err)
+	}
+	m := encoding.JSON
+	if encoding.IsCompressed(byts) {
+		m = encoding.Gzip(m)
+	}
+	var update backend.UpdateInfo
+	if err := m.Unmarshal(byts, &update); err != nil {
+		return fmt.Errorf("reading history file %s: %w", key, err)
+	}
+	if update.Version == version {
+		return nil
+	}
+	update.Version = version
+	if byts, err = m.Marshal(&update); err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(ctx, key, byts, nil)
+}
+
+// getHistory returns locally stored update history. The first element of the result will be
+// the most recent update record.
+func (b *localBackend) getHistory(
+	ctx context.Context,
+	stack *localBackendReference,
+	pageSize int, page int,
+) ([]backend.UpdateInfo, error) {
+	contract.Requiref(stack != nil, "stack", "must not be nil")
+
+	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
+	// Unfortunately, this is mildly invasive given the gocloud List API.
+	historyEntries, err := b.historyEntries(ctx, stack)
+	if err != nil {
+		return nil, err
+	}
+	first, err := b.firstHistoryVersion(ctx, historyEntries)
+	if err != nil {
+		return nil, err
 	}
 
 	start := 0
@@ -486,23 +560,14 @@ func (b *localBackend) getHistory(
 
 	var updates []backend.UpdateInfo
 
+	// Updates are returned most recent first.
 	for i := start; i <= end; i++ {
-		file := historyEntries[i]
-		filepath := file.Key
-
-		var update backend.UpdateInfo
-		b, err := b.bucket.ReadAll(ctx, filepath)
-		if err != nil {
-			return nil, fmt.Errorf("reading history file %s: %w", filepath, err)
-		}
-		m := encoding.JSON
-		if encoding.IsCompressed(b) {
-			m = encoding.Gzip(m)
-		}
-		err = m.Unmarshal(b, &update)
+		index := len(historyEntries) - 1 - i
+		update, err := b.readHistoryEntry(ctx, historyEntries[index])
 		if err != nil {
-			return nil, fmt.Errorf("reading history file %s: %w", filepath, err)
+			return nil, err
 		}
+		update.Version = first + index
 
 		updates = append(updates, update)
 	}
@@ -510,6 +575,42 @@ func (b *localBackend) getHistory(
 	return updates, nil
 }
 
+// getHistoryCheckpoint returns the checkpoint that was saved along with the given version of the stack's history.
+func (b *localBackend) getHistoryCheckpoint(
+	ctx context.Context, ref *localBackendReference, version string,
+) (*apitype.CheckpointV3, error) {
+	contract.Requiref(ref != nil, "ref", "must not be nil")
+
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 1 {
+		return nil, fmt.Errorf("invalid version %q: versions are positive integers", version)
+	}
+
+	historyEntries, err := b.historyEntries(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	first, err := b.firstHistoryVersion(ctx, historyEntries)
+	if err != nil {
+		return nil, err
+	}
+	if v < first || v-first >= len(historyEntries) {
+		return nil, fmt.Errorf("stack %s has no version %d", ref, v)
+	}
+
+	historyFile := historyEntries[v-first]
+	checkpointFile := strings.Replace(historyFile, ".history.json", ".checkpoint.json", 1)
+	byts, err := b.bucket.ReadAll(ctx, checkpointFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint for version %d: %w", v, err)
+	}
+	m := encoding.JSON
+	if encoding.IsCompressed(byts) {
+		m = encoding.Gzip(m)
+	}
+	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(m, byts)
+}
+
 func (b *localBackend) renameHistory(ctx context.Context, oldName, newName *localBackendReference) error {
 	contract.Requiref(oldName != nil, "oldName", "must not be nil")
 	contract.Requiref(newName != nil, "newName", "must not be nil")
@@ -559,6 +660,17 @@ func (b *localBackend) addToHistory(ctx context.Context, ref *localBackendRefere
 
 	dir := ref.HistoryDir()
 
+	// The new entry's version follows on from the newest entry in the history.
+	historyEntries, err := b.historyEntries(ctx, ref)
+	if err != nil {
+		return err
+	}
+	first, err := b.firstHistoryVersion(ctx, historyEntries)
+	if err != nil {
+		return err
+	}
+	update.Version = first + len(historyEntries)
+
 	// Prefix for the update and checkpoint files.
 	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", ref.name, time.Now().UnixNano()))
 
@@ -583,3 +695,83 @@ func (b *localBackend) addToHistory(ctx context.Context, ref *localBackendRefere
 	checkpointFile := fmt.Sprintf("%s.checkpoint.%s", pathPrefix, ext)
 	return b.bucket.Copy(ctx, checkpointFile, b.stackPath(ctx, ref), nil)
 }
+
+// getStackTags returns the tags stored for the given stack. A stack without any stored tags has no tags.
+func (b *localBackend) getStackTags(
+	ctx context.Context, ref *localBackendReference,
+) (map[apitype.StackTagName]string, error) {
+	contract.Requiref(ref != nil, "ref", "must not be nil")
+
+	tagsPath := ref.TagsPath()
+	byts, err := b.bucket.ReadAll(ctx, tagsPath)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading stack tags: %w", err)
+	}
+
+	var tags map[apitype.StackTagName]string
+	if err := encoding.JSON.Unmarshal(byts, &tags); err != nil {
+		return nil, fmt.Errorf("reading stack tags %s: %w", tagsPath, err)
+	}
+	return tags, nil
+}
+
+// saveStackTags replaces the tags stored for the given stack.
+func (b *localBackend) saveStackTags(
+	ctx context.Context, ref *localBackendReference, tags map[apitype.StackTagName]string,
+) error {
+	contract.Requiref(ref != nil, "ref", "must not be nil")
+
+	tagsPath := ref.TagsPath()
+	if len(tags) == 0 {
+		return b.removeStackTags(ctx, ref)
+	}
+
+	byts, err := encoding.JSON.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("marshalling stack tags: %w", err)
+	}
+	if err := b.bucket.WriteAll(ctx, tagsPath, byts, nil); err != nil {
+		return fmt.Errorf("writing stack tags: %w", err)
+	}
+	return nil
+}
+
+// removeStackTags removes the tags stored for the given stack, if any.
+func (b *localBackend) removeStackTags(ctx context.Context, ref *localBackendReference) error {
+	contract.Requiref(ref != nil, "ref", "must not be nil")
+
+	err := b.bucket.Delete(ctx, ref.TagsPath())
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("deleting stack tags: %w", err)
+	}
+	return nil
+}
+
+// renameStackTags moves the tags stored for a stack to the location for its new name.
+func (b *localBackend) renameStackTags(ctx context.Context, oldName, newName *localBackendReference) error {
+	contract.Requiref(oldName != nil, "oldName", "must not be nil")
+	contract.Requiref(newName != nil, "newName", "must not be nil")
+
+	tags, err := b.getStackTags(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	// Keep the project tag in sync with the project the stack now belongs to.
+	if project, has := newName.Project(); has {
+		if _, hasTag := tags[apitype.ProjectNameTag]; hasTag {
+			tags[apitype.ProjectNameTag] = project.String()
+		}
+	}
+
+	if err := b.saveStackTags(ctx, newName, tags); err != nil {
+		return err
+	}
+	return b.removeStackTags(ctx, oldName)
+}
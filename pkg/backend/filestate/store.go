@@ -46,6 +46,10 @@ var (
 	// BackupsDir is a path under the state's root directory
 	// where the filestate backend stores backups of stacks.
 	BackupsDir = filepath.Join(workspace.BookkeepingDir, workspace.BackupDir)
+
+	// TagsDir is a path under the state's root directory
+	// where the filestate backend stores tags for all stacks.
+	TagsDir = filepath.Join(workspace.BookkeepingDir, workspace.TagsDir)
 )
 
 // referenceStore stores and provides access to stack information.
@@ -75,6 +79,12 @@ type referenceStore interface {
 	// This must be under BackupsDir.
 	BackupDir(*localBackendReference) string
 
+	// TagsPath returns the path to the file
+	// where tags for this stack are stored.
+	//
+	// This must be under TagsDir.
+	TagsPath(*localBackendReference) string
+
 	// ListReferences lists all stack references in the store.
 	ListReferences(context.Context) ([]*localBackendReference, error)
 
@@ -132,6 +142,11 @@ func (p *projectReferenceStore) BackupDir(stack *localBackendReference) string {
 	return filepath.Join(BackupsDir, fsutil.NamePath(stack.project), fsutil.NamePath(stack.name))
 }
 
+func (p *projectReferenceStore) TagsPath(stack *localBackendReference) string {
+	contract.Requiref(stack.project != "", "ref.project", "must not be empty")
+	return filepath.Join(TagsDir, fsutil.NamePath(stack.project), fsutil.NamePath(stack.name)+".json")
+}
+
 func (p *projectReferenceStore) ParseReference(stackRef string) (*localBackendReference, error) {
 	// We accept the following forms:
 	//
@@ -348,6 +363,11 @@ func (p *legacyReferenceStore) BackupDir(stack *localBackendReference) string {
 	return filepath.Join(BackupsDir, fsutil.NamePath(stack.name))
 }
 
+func (p *legacyReferenceStore) TagsPath(stack *localBackendReference) string {
+	contract.Requiref(stack.project == "", "ref.project", "must be empty")
+	return filepath.Join(TagsDir, fsutil.NamePath(stack.name)+".json")
+}
+
 func (p *legacyReferenceStore) ParseReference(stackRef string) (*localBackendReference, error) {
 	if !tokens.IsName(stackRef) || len(stackRef) > 100 {
 		return nil, fmt.Errorf(
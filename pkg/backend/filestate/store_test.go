@@ -40,6 +40,7 @@ func TestLegacyReferenceStore_referencePaths(t *testing.T) {
 	assert.Equal(t, ".pulumi/stacks/foo", ref.StackBasePath())
 	assert.Equal(t, ".pulumi/history/foo", ref.HistoryDir())
 	assert.Equal(t, ".pulumi/backups/foo", ref.BackupDir())
+	assert.Equal(t, ".pulumi/tags/foo.json", ref.TagsPath())
 }
 
 func TestProjectReferenceStore_referencePaths(t *testing.T) {
@@ -56,6 +57,7 @@ func TestProjectReferenceStore_referencePaths(t *testing.T) {
 	assert.Equal(t, ".pulumi/stacks/myproject/mystack", ref.StackBasePath())
 	assert.Equal(t, ".pulumi/history/myproject/mystack", ref.HistoryDir())
 	assert.Equal(t, ".pulumi/backups/myproject/mystack", ref.BackupDir())
+	assert.Equal(t, ".pulumi/tags/myproject/mystack.json", ref.TagsPath())
 }
 
 func TestProjectReferenceStore_ParseReference(t *testing.T) {
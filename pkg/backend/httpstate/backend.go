@@ -44,6 +44,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/operations"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	"github.com/pulumi/pulumi/pkg/v3/util/validation"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
@@ -1482,6 +1483,24 @@ func (b *cloudBackend) ImportDeployment(ctx context.Context, stack backend.Stack
 	return nil
 }
 
+func (b *cloudBackend) RestoreDeployment(ctx context.Context, stk backend.Stack, version string) error {
+	deployment, err := b.ExportDeploymentForVersion(ctx, stk, version)
+	if err != nil {
+		return err
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(ctx, deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return fmt.Errorf("reading version %s: %w", version, err)
+	}
+	if err := snap.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("version %s has an invalid state: %w", version, err)
+	}
+
+	// Importing the deployment records an update in the stack's history.
+	return b.ImportDeployment(ctx, stk, deployment)
+}
+
 var projectNameCleanRegexp = regexp.MustCompile("[^a-zA-Z0-9-_.]")
 
 // cleanProjectName replaces undesirable characters in project names with hyphens. At some point, these restrictions
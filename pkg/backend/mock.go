@@ -57,6 +57,7 @@ type MockBackend struct {
 	UpdateStackTagsF        func(context.Context, Stack, map[apitype.StackTagName]string) error
 	ExportDeploymentF       func(context.Context, Stack) (*apitype.UntypedDeployment, error)
 	ImportDeploymentF       func(context.Context, Stack, *apitype.UntypedDeployment) error
+	RestoreDeploymentF      func(context.Context, Stack, string) error
 	CurrentUserF            func() (string, []string, *workspace.TokenInformation, error)
 	PreviewF                func(context.Context, Stack,
 		UpdateOperation) (*deploy.Plan, sdkDisplay.ResourceChanges, result.Result)
@@ -342,6 +343,13 @@ func (be *MockBackend) ImportDeployment(ctx context.Context, stack Stack,
 	panic("not implemented")
 }
 
+func (be *MockBackend) RestoreDeployment(ctx context.Context, stack Stack, version string) error {
+	if be.RestoreDeploymentF != nil {
+		return be.RestoreDeploymentF(ctx, stack, version)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) CurrentUser() (string, []string, *workspace.TokenInformation, error) {
 	if be.CurrentUserF != nil {
 		user, org, tokenInfo, err := be.CurrentUserF()
@@ -0,0 +1,53 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// GetProjectConcurrencyLimits returns the limits on concurrent resource operations set in the project's options, keyed
+// by package or type token. The result is never nil.
+func GetProjectConcurrencyLimits(proj *workspace.Project) (map[string]int, error) {
+	limits := make(map[string]int)
+	if proj != nil && proj.Options != nil {
+		for key, limit := range proj.Options.ConcurrencyLimits {
+			if limit < 1 {
+				return nil, fmt.Errorf("concurrency limit for %q must be a positive integer, got %d", key, limit)
+			}
+			limits[key] = limit
+		}
+	}
+	return limits, nil
+}
+
+// GetProjectRetryPolicy returns the policy for retrying provider operations that fail with a transient error set in
+// the project's options, or nil if there is none.
+func GetProjectRetryPolicy(proj *workspace.Project) (*resource.RetryPolicy, error) {
+	if proj == nil || proj.Options == nil || proj.Options.Retry == nil {
+		return nil, nil
+	}
+
+	r := proj.Options.Retry
+	policy, err := resource.NewRetryPolicy(r.MaxAttempts, r.Delay, r.Backoff, r.MaxDelay,
+		r.RetryableCodes, r.RetryableErrors)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry policy: %w", err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func TestGetProjectConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := GetProjectConcurrencyLimits(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+
+	limits, err = GetProjectConcurrencyLimits(&workspace.Project{
+		Options: &workspace.ProjectOptions{ConcurrencyLimits: map[string]int{"aws": 10}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"aws": 10}, limits)
+
+	_, err = GetProjectConcurrencyLimits(&workspace.Project{
+		Options: &workspace.ProjectOptions{ConcurrencyLimits: map[string]int{"dns": 0}},
+	})
+	assert.ErrorContains(t, err, `concurrency limit for "dns" must be a positive integer`)
+}
+
+func TestGetProjectRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := GetProjectRetryPolicy(&workspace.Project{})
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = GetProjectRetryPolicy(&workspace.Project{
+		Options: &workspace.ProjectOptions{
+			Retry: &workspace.ProjectRetryPolicy{MaxAttempts: 5, Delay: "2s", RetryableCodes: []string{"Unavailable"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &resource.RetryPolicy{MaxAttempts: 5, Delay: 2, RetryableCodes: []string{"Unavailable"}}, policy)
+
+	_, err = GetProjectRetryPolicy(&workspace.Project{
+		Options: &workspace.ProjectOptions{
+			Retry: &workspace.ProjectRetryPolicy{MaxAttempts: 5, RetryableErrors: []string{"[throttled"}},
+		},
+	})
+	assert.ErrorContains(t, err, "invalid retry policy")
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
@@ -42,6 +43,7 @@ import (
 func newConfigCmd() *cobra.Command {
 	var stack string
 	var showSecrets bool
+	var showOrigin bool
 	var jsonOut bool
 
 	cmd := &cobra.Command{
@@ -67,13 +69,16 @@ func newConfigCmd() *cobra.Command {
 				return err
 			}
 
-			return listConfig(ctx, project, stack, showSecrets, jsonOut)
+			return listConfig(ctx, project, stack, showSecrets, showOrigin, jsonOut)
 		}),
 	}
 
 	cmd.Flags().BoolVar(
 		&showSecrets, "show-secrets", false,
 		"Show secret values when listing config instead of displaying blinded values")
+	cmd.Flags().BoolVar(
+		&showOrigin, "show-origin", false,
+		"Show the file that each config value comes from, for stacks that have base config files")
 	cmd.Flags().BoolVarP(
 		&jsonOut, "json", "j", false,
 		"Emit output as JSON")
@@ -91,6 +96,9 @@ func newConfigCmd() *cobra.Command {
 	cmd.AddCommand(newConfigSetAllCmd(&stack))
 	cmd.AddCommand(newConfigRefreshCmd(&stack))
 	cmd.AddCommand(newConfigCopyCmd(&stack))
+	cmd.AddCommand(newConfigDiffCmd())
+	cmd.AddCommand(newConfigExportCmd(&stack))
+	cmd.AddCommand(newConfigImportCmd(&stack))
 
 	return cmd
 }
@@ -584,6 +592,19 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
+			// Reject values that don't match the project's config schema before saving them.
+			var decrypter config.Decrypter = config.NopDecrypter
+			if secret || (path && ps.Config.HasSecureValue()) {
+				decrypter, _, err = getStackDecrypter(s, ps)
+				if err != nil {
+					return err
+				}
+			}
+			err = workspace.ValidateStackConfigValue(s.Ref().Name().String(), project, ps.Config, key, path, decrypter)
+			if err != nil {
+				return err
+			}
+
 			return saveProjectStack(s, ps)
 		}),
 	}
@@ -736,6 +757,62 @@ func loadProjectStack(project *workspace.Project, stack backend.Stack) (*workspa
 	return workspace.LoadProjectStack(project, stackConfigFile)
 }
 
+// mergeStackConfigBases returns the stack's config merged over the config of its base files, along with the files
+// that each value came from. Secrets from a base file are decrypted with the file's own secrets provider and
+// re-encrypted with the given encrypter. If the encrypter is nil, secrets from base files are left encrypted with their
+// own providers, so the result is only suitable for displaying blinded values. The stack's own config is left as is.
+func mergeStackConfigBases(
+	stack backend.Stack,
+	project *workspace.Project,
+	ps *workspace.ProjectStack,
+	encrypter config.Encrypter, // optional
+) (config.Map, map[config.Key][]string, error) {
+	if len(ps.Base) == 0 {
+		return ps.Config, nil, nil
+	}
+
+	path, err := getProjectStackPath(stack)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return nil, nil, err
+	}
+	layers, err := workspace.LoadProjectStackBases(project, path, ps)
+	if err != nil {
+		return nil, nil, err
+	}
+	layers = append(layers, workspace.ProjectStackLayer{Path: path, Stack: ps})
+
+	merged := make(config.Map)
+	origins := make(map[config.Key][]string)
+	for i, layer := range layers {
+		cfg := layer.Stack.Config
+		if i < len(layers)-1 && encrypter != nil && cfg.HasSecureValue() {
+			decrypter, err := getBaseConfigDecrypter(layer)
+			if err != nil {
+				return nil, nil, err
+			}
+			if cfg, err = cfg.Copy(decrypter, encrypter); err != nil {
+				return nil, nil, fmt.Errorf("could not decrypt base config file %s: %w", layer.Path, err)
+			}
+		}
+
+		for key, v := range cfg {
+			// Objects are merged with the values of earlier layers, so they may come from several files.
+			if existing, has := merged[key]; has && existing.Object() && v.Object() {
+				origins[key] = append(origins[key], layer.Path)
+			} else {
+				origins[key] = []string{layer.Path}
+			}
+		}
+		if merged, err = merged.Merge(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+	return merged, origins, nil
+}
+
 func saveProjectStack(stack backend.Stack, ps *workspace.ProjectStack) error {
 	if stackConfigFile == "" {
 		return workspace.SaveProjectStack(stack.Ref().Name().Q(), ps)
@@ -783,12 +860,15 @@ type configValueJSON struct {
 	Value       *string     `json:"value,omitempty"`
 	ObjectValue interface{} `json:"objectValue,omitempty"`
 	Secret      bool        `json:"secret"`
+	// When --show-origin was passed, Origin lists the files that the value comes from.
+	Origin string `json:"origin,omitempty"`
 }
 
 func listConfig(ctx context.Context,
 	project *workspace.Project,
 	stack backend.Stack,
 	showSecrets bool,
+	showOrigin bool,
 	jsonOut bool,
 ) error {
 	ps, err := loadProjectStack(project, stack)
@@ -796,20 +876,17 @@ func listConfig(ctx context.Context,
 		return err
 	}
 
-	stackName := stack.Ref().Name().String()
-	// when listing configuration values
-	// also show values coming from the project
-	err = workspace.ApplyProjectConfig(stackName, project, ps.Config)
+	// Layer the stack's config over its base config files. Until we know that we need to show secrets, we leave the
+	// secrets of the base files encrypted with their own providers, since they will only be displayed blinded.
+	cfg, origins, err := mergeStackConfigBases(stack, project, ps, nil /* encrypter */)
 	if err != nil {
 		return err
 	}
 
-	cfg := ps.Config
-
 	// By default, we will use a blinding decrypter to show "[secret]". If requested, display secrets in plaintext.
 	decrypter := config.NewBlindingDecrypter()
 	if cfg.HasSecureValue() && showSecrets {
-		stackDecrypter, needsSave, err := getStackDecrypter(stack, ps)
+		sm, needsSave, err := getStackSecretsManager(stack, ps)
 		if err != nil {
 			return err
 		}
@@ -819,7 +896,41 @@ func listConfig(ctx context.Context,
 				return fmt.Errorf("save stack config: %w", err)
 			}
 		}
-		decrypter = stackDecrypter
+		if len(ps.Base) > 0 {
+			encrypter, err := sm.Encrypter()
+			if err != nil {
+				return err
+			}
+			if cfg, origins, err = mergeStackConfigBases(stack, project, ps, encrypter); err != nil {
+				return err
+			}
+		}
+		if decrypter, err = sm.Decrypter(); err != nil {
+			return err
+		}
+	}
+
+	// Without base files, every value in the stack's config comes from its own file.
+	stackPath, err := getProjectStackPath(stack)
+	if err != nil {
+		return err
+	}
+	if stackPath, err = filepath.Abs(stackPath); err != nil {
+		return err
+	}
+	if origins == nil {
+		origins = make(map[config.Key][]string)
+		for key := range cfg {
+			origins[key] = []string{stackPath}
+		}
+	}
+
+	stackName := stack.Ref().Name().String()
+	// when listing configuration values
+	// also show values coming from the project
+	err = workspace.ApplyProjectConfig(stackName, project, cfg)
+	if err != nil {
+		return err
 	}
 
 	var keys config.KeyArray
@@ -830,6 +941,23 @@ func listConfig(ctx context.Context,
 	}
 	sort.Sort(keys)
 
+	// origin returns the files that the value of the given key comes from, relative to the stack's config file. Values
+	// that aren't in any of the stack's files come from the project's config.
+	origin := func(key config.Key) string {
+		paths, has := origins[key]
+		if !has {
+			return "Pulumi.yaml"
+		}
+		relPaths := make([]string, len(paths))
+		for i, path := range paths {
+			relPaths[i] = path
+			if rel, err := filepath.Rel(filepath.Dir(stackPath), path); err == nil {
+				relPaths[i] = rel
+			}
+		}
+		return strings.Join(relPaths, ", ")
+	}
+
 	if jsonOut {
 		configValues := make(map[string]configValueJSON)
 		for _, key := range keys {
@@ -859,6 +987,10 @@ func listConfig(ctx context.Context,
 				entry.ObjectValue = nil
 			}
 
+			if showOrigin {
+				entry.Origin = origin(key)
+			}
+
 			configValues[key.String()] = entry
 		}
 		err := printJSON(configValues)
@@ -873,11 +1005,19 @@ func listConfig(ctx context.Context,
 				return fmt.Errorf("could not decrypt configuration value: %w", err)
 			}
 
-			rows = append(rows, cmdutil.TableRow{Columns: []string{prettyKey(key), decrypted}})
+			columns := []string{prettyKey(key), decrypted}
+			if showOrigin {
+				columns = append(columns, origin(key))
+			}
+			rows = append(rows, cmdutil.TableRow{Columns: columns})
 		}
 
+		headers := []string{"KEY", "VALUE"}
+		if showOrigin {
+			headers = append(headers, "ORIGIN")
+		}
 		cmdutil.PrintTable(cmdutil.Table{
-			Headers: []string{"KEY", "VALUE"},
+			Headers: headers,
 			Rows:    rows,
 		})
 	}
@@ -899,13 +1039,19 @@ func getConfig(ctx context.Context, stack backend.Stack, key config.Key, path, j
 		return err
 	}
 
+	// Layer the stack's config over its base config files, leaving the secrets of the base files encrypted with their
+	// own providers until we know that the value is a secret.
+	cfg, _, err := mergeStackConfigBases(stack, project, ps, nil /* encrypter */)
+	if err != nil {
+		return err
+	}
+
 	stackName := stack.Ref().Name().String()
 	// when asking for a configuration value, include values from the project config
-	err = workspace.ApplyProjectConfig(stackName, project, ps.Config)
+	err = workspace.ApplyProjectConfig(stackName, project, cfg)
 	if err != nil {
 		return err
 	}
-	cfg := ps.Config
 
 	v, ok, err := cfg.Get(key, path)
 	if err != nil {
@@ -914,9 +1060,8 @@ func getConfig(ctx context.Context, stack backend.Stack, key config.Key, path, j
 	if ok {
 		var d config.Decrypter
 		if v.Secure() {
-			var err error
-			var needsSave bool
-			if d, needsSave, err = getStackDecrypter(stack, ps); err != nil {
+			sm, needsSave, err := getStackSecretsManager(stack, ps)
+			if err != nil {
 				return fmt.Errorf("could not create a decrypter: %w", err)
 			}
 			// This may have setup the stack's secrets provider, so save the stack if needed.
@@ -925,6 +1070,23 @@ func getConfig(ctx context.Context, stack backend.Stack, key config.Key, path, j
 					return fmt.Errorf("save stack config: %w", err)
 				}
 			}
+			// The value may come from a base file, so re-encrypt the secrets of the base files with the stack's
+			// secrets provider.
+			if len(ps.Base) > 0 {
+				encrypter, err := sm.Encrypter()
+				if err != nil {
+					return fmt.Errorf("could not create an encrypter: %w", err)
+				}
+				if cfg, _, err = mergeStackConfigBases(stack, project, ps, encrypter); err != nil {
+					return err
+				}
+				if v, _, err = cfg.Get(key, path); err != nil {
+					return err
+				}
+			}
+			if d, err = sm.Decrypter(); err != nil {
+				return fmt.Errorf("could not create a decrypter: %w", err)
+			}
 		} else {
 			d = config.NewPanicCrypter()
 		}
@@ -1009,6 +1171,50 @@ func getAndSaveSecretsManager(stack backend.Stack, workspaceStack *workspace.Pro
 	return sm, nil
 }
 
+// validateStackConfig validates the stack's configuration against the project's config schema, applying the project's
+// config defaults to it. Errors name the stack's config file, if it has one, or the base config files that an invalid
+// value comes from.
+func validateStackConfig(
+	stack backend.Stack,
+	project *workspace.Project,
+	cfg config.Map,
+	decrypter config.Decrypter,
+) error {
+	err := workspace.ValidateStackConfigAndApplyProjectConfig(stack.Ref().Name().String(), project, cfg, decrypter)
+	if err == nil {
+		return nil
+	}
+	path, pathErr := getProjectStackPath(stack)
+	if pathErr != nil {
+		return err
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		return err
+	}
+
+	var valueErr *workspace.InvalidStackConfigValueError
+	if errors.As(err, &valueErr) {
+		if paths := stackConfigValueOrigins(stack, project, valueErr.Key); len(paths) > 0 {
+			return fmt.Errorf("%s: %w", strings.Join(paths, ", "), err)
+		}
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// stackConfigValueOrigins returns the files among the stack's config file and its base files that the stack's value
+// for the given key comes from. It returns nil if the stack has no base files, or if the files can't be loaded.
+func stackConfigValueOrigins(stack backend.Stack, project *workspace.Project, key config.Key) []string {
+	ps, err := loadProjectStack(project, stack)
+	if err != nil {
+		return nil
+	}
+	_, origins, err := mergeStackConfigBases(stack, project, ps, nil /* encrypter */)
+	if err != nil {
+		return nil
+	}
+	return origins[key]
+}
+
 // getStackConfiguration loads configuration information for a given stack. If stackConfigFile is non empty,
 // it is uses instead of the default configuration file for the stack
 func getStackConfiguration(
@@ -1074,12 +1280,25 @@ func getStackConfigurationWithFallback(
 		}
 	}
 
+	// Layer the stack's config over its base config files, re-encrypting any secrets they have with the stack's
+	// secrets provider.
+	cfg := workspaceStack.Config
+	if len(workspaceStack.Base) > 0 {
+		encrypter, err := sm.Encrypter()
+		if err != nil {
+			return defaultStackConfig, nil, fmt.Errorf("getting configuration encrypter: %w", err)
+		}
+		if cfg, _, err = mergeStackConfigBases(stack, project, workspaceStack, encrypter); err != nil {
+			return defaultStackConfig, nil, err
+		}
+	}
+
 	// If there are no secrets in the configuration, we should never use the decrypter, so it is safe to return
 	// one which panics if it is used. This provides for some nice UX in the common case (since, for example, building
 	// the correct decrypter for the local backend would involve prompting for a passphrase)
-	if !workspaceStack.Config.HasSecureValue() {
+	if !cfg.HasSecureValue() {
 		return backend.StackConfiguration{
-			Config:    workspaceStack.Config,
+			Config:    cfg,
 			Decrypter: config.NewPanicCrypter(),
 		}, sm, nil
 	}
@@ -1090,7 +1309,7 @@ func getStackConfigurationWithFallback(
 	}
 
 	return backend.StackConfiguration{
-		Config:    workspaceStack.Config,
+		Config:    cfg,
 		Decrypter: crypter,
 	}, sm, nil
 }
@@ -0,0 +1,307 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+type configDiffCmd struct {
+	stdout io.Writer
+
+	jsonOut bool
+}
+
+func newConfigDiffCmd() *cobra.Command {
+	var cdcmd configDiffCmd
+	cmd := &cobra.Command{
+		Use:   "diff <from-stack> <to-stack>",
+		Args:  cmdutil.ExactArgs(2),
+		Short: "Compare the configuration of two stacks",
+		Long: "Compare the configuration of two stacks.\n" +
+			"\n" +
+			"This command shows the configuration keys that were added, removed or changed between\n" +
+			"two stacks of the current project. Secret values are masked. Each masked value includes a\n" +
+			"hash of the secret, so that secrets that are equal in both stacks can be told apart from\n" +
+			"secrets that differ. The hashes are only comparable within a single run of the command.",
+		Example: "pulumi config diff dev prod",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			return cdcmd.Run(ctx, args[0], args[1])
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&cdcmd.jsonOut, "json", "j", false, "Emit output as JSON")
+	return cmd
+}
+
+func (cmd *configDiffCmd) Run(ctx context.Context, fromStackName, toStackName string) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	if stackConfigFile != "" {
+		return errors.New("--config-file cannot be used when comparing the configuration of two stacks")
+	}
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	project, _, err := readProject()
+	if err != nil {
+		return err
+	}
+
+	loadConfig := func(stackName string) (config.Map, error) {
+		s, err := requireStack(ctx, stackName, stackLoadOnly, opts)
+		if err != nil {
+			return nil, err
+		}
+		cfg, _, err := getStackConfiguration(ctx, s, project, nil)
+		if err != nil {
+			return nil, fmt.Errorf("loading configuration of stack %s: %w", stackName, err)
+		}
+		// Decrypt the secrets, so that the values of both stacks can be compared.
+		plaintext, err := cfg.Config.Copy(cfg.Decrypter, config.NopEncrypter)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting configuration of stack %s: %w", stackName, err)
+		}
+		return plaintext, nil
+	}
+
+	from, err := loadConfig(fromStackName)
+	if err != nil {
+		return err
+	}
+	to, err := loadConfig(toStackName)
+	if err != nil {
+		return err
+	}
+
+	mask, err := newConfigSecretMasker()
+	if err != nil {
+		return err
+	}
+	changes, err := diffConfig(from, to)
+	if err != nil {
+		return err
+	}
+	if cmd.jsonOut {
+		return fprintJSON(stdout, makeConfigDiffJSON(fromStackName, toStackName, changes, mask))
+	}
+
+	fmt.Fprint(stdout, opts.Color.Colorize(renderConfigDiff(fromStackName, toStackName, changes, mask)))
+	return nil
+}
+
+// newConfigSecretMasker returns a function that masks a secret value. The masked value includes a keyed hash of the
+// secret, so that equal secrets can be recognized without revealing them. The key is random, so that the hashes
+// can't be used to guess secrets, which means that they are only comparable within the same masker.
+func newConfigSecretMasker() (func(plaintext string) string, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating key for hashing secrets: %w", err)
+	}
+	return func(plaintext string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(plaintext))
+		return fmt.Sprintf("[secret:%s]", hex.EncodeToString(mac.Sum(nil))[:8])
+	}, nil
+}
+
+// configKeyDiff is a configuration key that differs between two stacks.
+type configKeyDiff struct {
+	Key  config.Key
+	Kind stackDiffKind
+	Old  config.Value // unset if the key was added.
+	New  config.Value // unset if the key was removed.
+}
+
+// diffConfig returns the keys that differ between the configuration of two stacks, in order. The secrets of both
+// configurations must already be decrypted.
+func diffConfig(from, to config.Map) ([]configKeyDiff, error) {
+	var keys config.KeyArray
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, has := from[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	sort.Sort(keys)
+
+	var changes []configKeyDiff
+	for _, key := range keys {
+		oldValue, hasOld := from[key]
+		newValue, hasNew := to[key]
+		switch {
+		case !hasOld:
+			changes = append(changes, configKeyDiff{Key: key, Kind: stackDiffAdded, New: newValue})
+		case !hasNew:
+			changes = append(changes, configKeyDiff{Key: key, Kind: stackDiffRemoved, Old: oldValue})
+		default:
+			equal, err := configValuesEqual(oldValue, newValue)
+			if err != nil {
+				return nil, fmt.Errorf("comparing values of %s: %w", key, err)
+			}
+			if !equal {
+				changes = append(changes, configKeyDiff{Key: key, Kind: stackDiffChanged, Old: oldValue, New: newValue})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// configValuesEqual returns true if two decrypted configuration values are equal. Object values are compared
+// structurally, and a secret is never equal to a value that isn't secret.
+func configValuesEqual(a, b config.Value) (bool, error) {
+	if a.Secure() != b.Secure() || a.Object() != b.Object() {
+		return false, nil
+	}
+	if !a.Object() {
+		return a == b, nil
+	}
+
+	aObj, err := a.ToObject()
+	if err != nil {
+		return false, err
+	}
+	bObj, err := b.ToObject()
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(aObj, bObj), nil
+}
+
+// displayConfigValue returns the given decrypted configuration value for display, masking it if it is a secret.
+func displayConfigValue(v config.Value, mask func(plaintext string) string) string {
+	plaintext, err := v.Value(config.NopDecrypter)
+	if err != nil {
+		return "[unreadable]"
+	}
+	if v.Secure() {
+		return mask(plaintext)
+	}
+	return plaintext
+}
+
+// renderConfigDiff renders the differences between the configuration of two stacks for display.
+func renderConfigDiff(from, to string, changes []configKeyDiff, mask func(plaintext string) string) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Comparing the configuration of stack %s with stack %s\n\n", from, to)
+	if len(changes) == 0 {
+		fmt.Fprintf(&b, "No differences\n")
+		return b.String()
+	}
+
+	counts := make(map[stackDiffKind]int)
+	for _, change := range changes {
+		counts[change.Kind]++
+
+		switch change.Kind {
+		case stackDiffAdded:
+			fmt.Fprintf(&b, "%s%s: %s%s\n", deploy.Prefix(deploy.OpCreate, true /*done*/),
+				prettyKey(change.Key), displayConfigValue(change.New, mask), colors.Reset)
+		case stackDiffRemoved:
+			fmt.Fprintf(&b, "%s%s: %s%s\n", deploy.Prefix(deploy.OpDelete, true /*done*/),
+				prettyKey(change.Key), displayConfigValue(change.Old, mask), colors.Reset)
+		case stackDiffChanged:
+			fmt.Fprintf(&b, "%s%s: %s => %s%s\n", deploy.Prefix(deploy.OpUpdate, true /*done*/),
+				prettyKey(change.Key), displayConfigValue(change.Old, mask), displayConfigValue(change.New, mask),
+				colors.Reset)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nKeys:\n")
+	for _, summary := range []struct {
+		kind stackDiffKind
+		op   string
+	}{
+		{stackDiffAdded, deploy.Prefix(deploy.OpCreate, true /*done*/)},
+		{stackDiffChanged, deploy.Prefix(deploy.OpUpdate, true /*done*/)},
+		{stackDiffRemoved, deploy.Prefix(deploy.OpDelete, true /*done*/)},
+	} {
+		if n := counts[summary.kind]; n > 0 {
+			fmt.Fprintf(&b, "    %s%d %s%s\n", summary.op, n, summary.kind, colors.Reset)
+		}
+	}
+	return b.String()
+}
+
+// configDiffJSON is the JSON form of the differences between the configuration of two stacks.
+type configDiffJSON struct {
+	From    string                `json:"from"`
+	To      string                `json:"to"`
+	Summary map[stackDiffKind]int `json:"summary"`
+	Keys    []configDiffKeyJSON   `json:"keys"`
+}
+
+type configDiffKeyJSON struct {
+	Key    string        `json:"key"`
+	Kind   stackDiffKind `json:"kind"`
+	Old    *string       `json:"old,omitempty"`
+	New    *string       `json:"new,omitempty"`
+	Secret bool          `json:"secret"`
+}
+
+func makeConfigDiffJSON(from, to string, changes []configKeyDiff, mask func(plaintext string) string) configDiffJSON {
+	result := configDiffJSON{
+		From:    from,
+		To:      to,
+		Summary: make(map[stackDiffKind]int),
+		Keys:    []configDiffKeyJSON{},
+	}
+	for _, change := range changes {
+		result.Summary[change.Kind]++
+
+		key := configDiffKeyJSON{
+			Key:    change.Key.String(),
+			Kind:   change.Kind,
+			Secret: change.Old.Secure() || change.New.Secure(),
+		}
+		if change.Kind != stackDiffAdded {
+			oldValue := displayConfigValue(change.Old, mask)
+			key.Old = &oldValue
+		}
+		if change.Kind != stackDiffRemoved {
+			newValue := displayConfigValue(change.New, mask)
+			key.New = &newValue
+		}
+		result.Keys = append(result.Keys, key)
+	}
+	return result
+}
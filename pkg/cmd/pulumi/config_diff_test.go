@@ -0,0 +1,75 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+func TestDiffConfig(t *testing.T) {
+	t.Parallel()
+
+	from := config.Map{
+		config.MustMakeKey("proj", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "password"): config.NewSecureValue("hunter2"),
+		config.MustMakeKey("proj", "token"):    config.NewSecureValue("abc"),
+		config.MustMakeKey("proj", "db"):       config.NewObjectValue(`{"host":"db","port":5432}`),
+		config.MustMakeKey("proj", "removed"):  config.NewValue("gone"),
+	}
+	to := config.Map{
+		config.MustMakeKey("proj", "region"):   config.NewValue("eu-west-1"),
+		config.MustMakeKey("proj", "password"): config.NewSecureValue("hunter2"),
+		config.MustMakeKey("proj", "token"):    config.NewSecureValue("xyz"),
+		config.MustMakeKey("proj", "db"):       config.NewObjectValue(`{"port":5432,"host":"db"}`),
+		config.MustMakeKey("proj", "added"):    config.NewValue("new"),
+	}
+
+	changes, err := diffConfig(from, to)
+	require.NoError(t, err)
+
+	// Secrets are masked with a hash of their value, so that equal secrets have equal masks.
+	mask, err := newConfigSecretMasker()
+	require.NoError(t, err)
+	assert.Equal(t, mask("hunter2"), mask("hunter2"))
+	assert.NotEqual(t, mask("abc"), mask("xyz"))
+	assert.NotContains(t, mask("hunter2"), "hunter2")
+
+	str := func(s string) *string { return &s }
+	result := makeConfigDiffJSON("dev", "prod", changes, mask)
+	assert.Equal(t, map[stackDiffKind]int{"added": 1, "changed": 2, "removed": 1}, result.Summary)
+	assert.Equal(t, []configDiffKeyJSON{
+		{Key: "proj:added", Kind: stackDiffAdded, New: str("new")},
+		{Key: "proj:region", Kind: stackDiffChanged, Old: str("us-west-2"), New: str("eu-west-1")},
+		{Key: "proj:removed", Kind: stackDiffRemoved, Old: str("gone")},
+		{Key: "proj:token", Kind: stackDiffChanged, Old: str(mask("abc")), New: str(mask("xyz")), Secret: true},
+	}, result.Keys)
+}
+
+func TestDiffConfigSecretness(t *testing.T) {
+	t.Parallel()
+
+	// A value that becomes secret is a change, even if the value is the same.
+	changes, err := diffConfig(
+		config.Map{config.MustMakeKey("proj", "key"): config.NewValue("value")},
+		config.Map{config.MustMakeKey("proj", "key"): config.NewSecureValue("value")})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, stackDiffChanged, changes[0].Kind)
+}
@@ -0,0 +1,337 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// The formats that configuration can be exported to and imported from.
+const (
+	configFormatJSON   = "json"
+	configFormatYAML   = "yaml"
+	configFormatDotenv = "dotenv"
+)
+
+func newConfigExportCmd(stack *string) *cobra.Command {
+	var format string
+	var file string
+	var showSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a stack's configuration",
+		Long: "Export a stack's configuration to standard out or a file.\n" +
+			"\n" +
+			"The configuration includes the values inherited from the stack's base config files.\n" +
+			"Configuration that contains secrets is only exported with --show-secrets. Secret values are\n" +
+			"then decrypted and written in plaintext as `{\"secure\": \"<value>\"}`, so that they can be\n" +
+			"encrypted again for another stack by `pulumi config import`. Files are created so that only\n" +
+			"the current user can read them.\n" +
+			"\n" +
+			"The json and yaml formats have the same shape as the `config` section of a stack's config\n" +
+			"file. The dotenv format writes one `<key>=<value>` line per configuration key, with each\n" +
+			"value encoded as JSON.",
+		Example: "pulumi config export --format yaml --file dev-config.yaml --show-secrets",
+		Args:    cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return err
+			}
+
+			s, err := requireStack(ctx, *stack, stackOfferNew|stackSetCurrent, opts)
+			if err != nil {
+				return err
+			}
+
+			cfg, _, err := getStackConfiguration(ctx, s, project, nil)
+			if err != nil {
+				return err
+			}
+			if cfg.Config.HasSecureValue() && !showSecrets {
+				return errors.New("the configuration contains secrets; pass --show-secrets to export them in plaintext")
+			}
+			plaintext, err := cfg.Config.Copy(cfg.Decrypter, config.NopEncrypter)
+			if err != nil {
+				return fmt.Errorf("could not decrypt configuration: %w", err)
+			}
+
+			b, err := encodeConfig(plaintext, format)
+			if err != nil {
+				return err
+			}
+
+			if file != "" {
+				err = writeConfigFile(file, b)
+			} else {
+				_, err = os.Stdout.Write(b)
+			}
+			if err != nil {
+				return fmt.Errorf("could not export configuration: %w", err)
+			}
+
+			if plaintext.HasSecureValue() {
+				log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi config export")
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(
+		&format, "format", configFormatJSON,
+		"The format to export the configuration in: json, yaml or dotenv")
+	cmd.Flags().StringVar(
+		&file, "file", "",
+		"A filename to write the configuration to. Defaults to standard out")
+	cmd.Flags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Export secret values in plaintext. Required if the configuration contains secrets")
+
+	return cmd
+}
+
+// writeConfigFile writes exported configuration to the file at the given path. The file may contain plaintext
+// secrets, so it is created so that only the current user can read it.
+func writeConfigFile(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+	_, err = f.Write(b)
+	return err
+}
+
+func newConfigImportCmd(stack *string) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import configuration into a stack",
+		Long: "Import configuration into a stack from standard in or a file.\n" +
+			"\n" +
+			"The configuration must be in one of the formats written by `pulumi config export`. Secret\n" +
+			"values are encrypted with the stack's secrets provider. Imported values replace the stack's\n" +
+			"values for the same keys, and the stack's other configuration is left as is.\n" +
+			"\n" +
+			"If --format is not given, it is inferred from the extension of the file, and defaults to json.",
+		Example: "pulumi config export --stack dev --show-secrets | pulumi config import --stack prod\n" +
+			"pulumi config import dev-config.yaml",
+		Args: cmdutil.MaximumNArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return err
+			}
+
+			s, err := requireStack(ctx, *stack, stackOfferNew|stackSetCurrent, opts)
+			if err != nil {
+				return err
+			}
+
+			// Read from stdin or a specified file.
+			var b []byte
+			if len(args) > 0 {
+				if format == "" {
+					format = inferConfigFormat(args[0])
+				}
+				b, err = os.ReadFile(args[0])
+			} else {
+				b, err = io.ReadAll(os.Stdin)
+			}
+			if err != nil {
+				return fmt.Errorf("could not read configuration: %w", err)
+			}
+			if format == "" {
+				format = configFormatJSON
+			}
+
+			plaintext, err := decodeConfig(b, format)
+			if err != nil {
+				return err
+			}
+
+			ps, err := loadProjectStack(project, s)
+			if err != nil {
+				return err
+			}
+
+			stackName := s.Ref().Name().String()
+			keys := make(config.KeyArray, 0, len(plaintext))
+			for key := range plaintext {
+				if err := workspace.ValidateStackConfigValue(
+					stackName, project, plaintext, key, false /*path*/, config.NopDecrypter); err != nil {
+					return err
+				}
+				keys = append(keys, key)
+			}
+			sort.Sort(keys)
+
+			// Encrypt the secrets again with the stack's secrets provider.
+			var encrypter config.Encrypter = config.NewPanicCrypter()
+			if plaintext.HasSecureValue() {
+				enc, _, err := getStackEncrypter(s, ps)
+				if err != nil {
+					return err
+				}
+				encrypter = enc
+			}
+			imported, err := plaintext.Copy(config.NopDecrypter, encrypter)
+			if err != nil {
+				return fmt.Errorf("could not encrypt configuration: %w", err)
+			}
+
+			for _, key := range keys {
+				if err = ps.Config.Set(key, imported[key], false /*path*/); err != nil {
+					return err
+				}
+			}
+
+			return saveProjectStack(s, ps)
+		}),
+	}
+
+	cmd.Flags().StringVar(
+		&format, "format", "",
+		"The format of the configuration: json, yaml or dotenv")
+
+	return cmd
+}
+
+// inferConfigFormat returns the format of the configuration file at the given path, based on its extension. Returns
+// an empty string if the extension isn't recognized.
+func inferConfigFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return configFormatJSON
+	case ".yaml", ".yml":
+		return configFormatYAML
+	case ".env":
+		return configFormatDotenv
+	default:
+		return ""
+	}
+}
+
+// encodeConfig encodes the given configuration in the given format.
+func encodeConfig(cfg config.Map, format string) ([]byte, error) {
+	switch format {
+	case configFormatJSON:
+		b, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case configFormatYAML:
+		return yaml.Marshal(cfg)
+	case configFormatDotenv:
+		var keys config.KeyArray
+		for key := range cfg {
+			keys = append(keys, key)
+		}
+		sort.Sort(keys)
+
+		var b bytes.Buffer
+		for _, key := range keys {
+			v, err := json.Marshal(cfg[key])
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&b, "%s=%s\n", key, v)
+		}
+		return b.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown configuration format '%s' (supported values: json, yaml, dotenv)", format)
+	}
+}
+
+// decodeConfig decodes configuration in the given format.
+func decodeConfig(b []byte, format string) (config.Map, error) {
+	cfg := make(config.Map)
+	switch format {
+	case configFormatJSON:
+		if err := json.Unmarshal(b, &cfg); err != nil {
+			return nil, fmt.Errorf("could not parse configuration: %w", err)
+		}
+	case configFormatYAML:
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return nil, fmt.Errorf("could not parse configuration: %w", err)
+		}
+	case configFormatDotenv:
+		scanner := bufio.NewScanner(bytes.NewReader(b))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+
+			k, v, ok := strings.Cut(text, "=")
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected <key>=<value>", line)
+			}
+			key, err := config.ParseKey(strings.TrimSpace(k))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			// Values are written as JSON, but we also accept unquoted strings, as are common in .env files.
+			v = strings.TrimSpace(v)
+			var value config.Value
+			if strings.HasPrefix(v, `"`) || strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[") {
+				if err := json.Unmarshal([]byte(v), &value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+			} else {
+				value = config.NewValue(v)
+			}
+			cfg[key] = value
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown configuration format '%s' (supported values: json, yaml, dotenv)", format)
+	}
+	return cfg, nil
+}
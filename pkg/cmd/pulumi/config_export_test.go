@@ -0,0 +1,91 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+func TestConfigExportRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Map{
+		config.MustMakeKey("proj", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "password"): config.NewSecureValue("hunter2"),
+		config.MustMakeKey("proj", "db"):       config.NewSecureObjectValue(`{"password":{"secure":"x"},"port":5432}`),
+	}
+
+	for _, format := range []string{configFormatJSON, configFormatYAML, configFormatDotenv} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := encodeConfig(cfg, format)
+			require.NoError(t, err)
+			decoded, err := decodeConfig(b, format)
+			require.NoError(t, err)
+			assert.Equal(t, cfg, decoded)
+		})
+	}
+}
+
+func TestDecodeDotenvConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := decodeConfig([]byte(`
+# A comment.
+proj:region=us-west-2
+proj:name="quoted value"
+proj:password={"secure":"hunter2"}
+`), configFormatDotenv)
+	require.NoError(t, err)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("proj", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "name"):     config.NewValue("quoted value"),
+		config.MustMakeKey("proj", "password"): config.NewSecureValue("hunter2"),
+	}, cfg)
+
+	_, err = decodeConfig([]byte("proj:region\n"), configFormatDotenv)
+	assert.ErrorContains(t, err, "line 1: expected <key>=<value>")
+
+	_, err = decodeConfig([]byte("{}"), "toml")
+	assert.ErrorContains(t, err, "unknown configuration format 'toml'")
+}
+
+func TestWriteConfigFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, writeConfigFile(path, []byte(`{"proj:password":{"secure":"hunter2"}}`)))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"proj:password":{"secure":"hunter2"}}`, string(b))
+
+	// Windows doesn't have Unix file permissions.
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
@@ -16,9 +16,12 @@ package main
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
@@ -112,3 +115,68 @@ func TestGetStackConfigurationOrLatest(t *testing.T) {
 		t.Fatalf("GetLatestConfiguration should be called in getStackConfigurationOrLatest.")
 	}
 }
+
+//nolint:paralleltest // changes directory for process
+func TestValidateStackConfigNamesTheFileOfInvalidValues(t *testing.T) {
+	// Resolve the temporary directory's symlinks so that its paths match those found from the working directory.
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	writeFile("Pulumi.yaml", `
+name: test
+runtime: go
+config:
+  instanceCount:
+    type: integer
+  instanceSize:
+    type: string
+    enum: [small, large]`)
+	basePath := writeFile("Pulumi.base.yaml", `
+config:
+  test:instanceCount: many
+  test:instanceSize: small`)
+	stackPath := writeFile("Pulumi.dev.yaml", `
+base:
+- Pulumi.base.yaml
+config:
+  test:instanceSize: huge`)
+	chdir(t, dir)
+
+	stack := &backend.MockStack{
+		RefF: func() backend.StackReference {
+			return &backend.MockStackReference{
+				StringV:             "org/test/dev",
+				NameV:               "dev",
+				ProjectV:            "test",
+				FullyQualifiedNameV: tokens.QName("org/test/dev"),
+			}
+		},
+	}
+	project, err := workspace.LoadProject(filepath.Join(dir, "Pulumi.yaml"))
+	require.NoError(t, err)
+	ps, err := loadProjectStack(project, stack)
+	require.NoError(t, err)
+
+	validate := func() error {
+		cfg, _, err := mergeStackConfigBases(stack, project, ps, nil /* encrypter */)
+		require.NoError(t, err)
+		return validateStackConfig(stack, project, cfg, config.NopDecrypter)
+	}
+
+	// The invalid count comes from the base file, so the error names it rather than the stack's config file.
+	err = validate()
+	assert.ErrorContains(t, err, basePath+": ")
+	assert.NotContains(t, err.Error(), stackPath)
+	assert.ErrorContains(t, err, "configuration key 'instanceCount' must be of type 'integer'")
+
+	// The invalid size is set by the stack's config file over the base file's value.
+	require.NoError(t, ps.Config.Set(config.MustMakeKey("test", "instanceCount"), config.NewValue("3"), false))
+	err = validate()
+	assert.ErrorContains(t, err, stackPath+": ")
+	assert.NotContains(t, err.Error(), basePath)
+	assert.ErrorContains(t, err, "configuration key 'instanceSize'")
+}
@@ -17,8 +17,10 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
@@ -32,6 +34,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/codegen/dotnet"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/verify"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/version"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
@@ -56,20 +59,27 @@ func newConvertCmd() *cobra.Command {
 	var generateOnly bool
 	var mappings []string
 	var strict bool
+	var verify bool
 
 	cmd := &cobra.Command{
 		Use:   "convert",
 		Short: "Convert Pulumi programs from a supported source program into other supported languages",
 		Long: "Convert Pulumi programs from a supported source program into other supported languages.\n" +
 			"\n" +
-			"The source program to convert will default to the current working directory.\n",
+			"The source program to convert will default to the current working directory.\n" +
+			"\n" +
+			"With --verify, the converted program is checked against the intermediate PCL program that\n" +
+			"it was generated from: both must declare the same resources, and each resource must set the\n" +
+			"same input properties and depend on the same resources. The converted program is read back\n" +
+			"from the output directory: PCL and YAML programs are bound, and the resource declarations of\n" +
+			"Go, TypeScript, Python, C# and Java programs are read from their source files.\n",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			cwd, err := os.Getwd()
 			if err != nil {
 				return fmt.Errorf("get current working directory: %w", err)
 			}
 
-			return runConvert(env.Global(), args, cwd, mappings, from, language, outDir, generateOnly, strict)
+			return runConvert(env.Global(), args, cwd, mappings, from, language, outDir, generateOnly, strict, verify)
 		}),
 	}
 
@@ -99,6 +109,10 @@ func newConvertCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&strict, "strict", false, "If strict is set the conversion will fail on errors such as missing variables")
 
+	cmd.PersistentFlags().BoolVar(
+		&verify, "verify", false,
+		"Check that the converted program declares the same resources, inputs and dependencies as the source program")
+
 	return cmd
 }
 
@@ -198,11 +212,91 @@ func generatorWrapper(generator projectGeneratorFunc, targetLanguage string) pro
 	}
 }
 
+// verifyConversion checks that the program that was generated into targetDirectory is equivalent to the PCL program
+// in sourceDirectory that it was generated from. PCL and YAML programs are read back and compared with the source
+// program. The resource declarations of programs in other languages are read back from their source files by
+// verify.Program. Each difference is returned as an error diagnostic.
+func verifyConversion(
+	sourceDirectory, targetDirectory, language string, loader schema.ReferenceLoader, strict bool,
+) (hcl.Diagnostics, error) {
+	source, diagnostics, err := safePclBindDirectory(sourceDirectory, loader, strict)
+	if err != nil {
+		return diagnostics, fmt.Errorf("bind source program: %w", err)
+	}
+	if source == nil || diagnostics.HasErrors() {
+		return diagnostics, errors.New("source program does not bind")
+	}
+
+	var target *pcl.Program
+	var targetDiagnostics hcl.Diagnostics
+	switch language {
+	case "pulumi", "pcl":
+		target, targetDiagnostics, err = safePclBindDirectory(targetDirectory, loader, strict)
+	case "yaml":
+		_, target, err = yamlgen.Eject(targetDirectory, loader)
+	default:
+		ext, ok := verify.Extensions[language]
+		if !ok {
+			return diagnostics, fmt.Errorf("%s programs can't be verified", language)
+		}
+		files, err := readProgramFiles(targetDirectory, ext)
+		if err != nil {
+			return diagnostics, fmt.Errorf("read converted program: %w", err)
+		}
+		programDiagnostics, err := verify.Program(source, language, files)
+		return append(diagnostics, programDiagnostics...), err
+	}
+	diagnostics = append(diagnostics, targetDiagnostics...)
+	if err != nil {
+		return diagnostics, fmt.Errorf("bind converted program: %w", err)
+	}
+	if target == nil || targetDiagnostics.HasErrors() {
+		return diagnostics, errors.New("converted program does not bind")
+	}
+
+	return append(diagnostics, pcl.VerifyEquivalent(source, target)...), nil
+}
+
+// readProgramFiles reads the source files with the given extension in directory and its subdirectories, keyed by
+// their paths relative to directory. Hidden directories and those that hold installed dependencies or build outputs
+// are skipped.
+func readProgramFiles(directory, ext string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch name := d.Name(); {
+			case path == directory:
+				return nil
+			case strings.HasPrefix(name, "."), name == "node_modules", name == "venv", name == "bin", name == "obj":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ext {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = contents
+		return nil
+	})
+	return files, err
+}
+
 func runConvert(
 	e env.Env,
 	args []string,
 	cwd string, mappings []string, from string, language string,
-	outDir string, generateOnly bool, strict bool,
+	outDir string, generateOnly bool, strict bool, verify bool,
 ) error {
 	pCtx, err := newPluginContext(cwd)
 	if err != nil {
@@ -302,7 +396,7 @@ func runConvert(
 		return version
 	}
 
-	loader := schema.NewPluginLoader(pCtx.Host)
+	loader := newCachingPluginLoader(pCtx.Host)
 	mapper, err := convert.NewPluginMapper(
 		convert.DefaultWorkspace(), convert.ProviderFactoryFromHost(pCtx.Host),
 		from, mappings, installProvider)
@@ -448,6 +542,18 @@ func runConvert(
 		printDiagnostics(pCtx.Diag, diagnostics)
 	}
 
+	if verify {
+		pCtx.Diag.Infof(diag.Message("", "Verifying the converted program..."))
+		diagnostics, err := verifyConversion(pclDirectory, outDir, language, loader, strict)
+		printDiagnostics(pCtx.Diag, diagnostics)
+		if err != nil {
+			return fmt.Errorf("could not verify converted program: %w", err)
+		}
+		if diagnostics.HasErrors() {
+			return errors.New("the converted program is not equivalent to the source program")
+		}
+	}
+
 	// Project should now exist at outDir. Run installDependencies in that directory (if requested)
 	if !generateOnly {
 		// Change the working directory to the specified directory.
@@ -7,7 +7,19 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
+	javagen "github.com/pulumi/pulumi-java/pkg/codegen/java"
+	yamlgen "github.com/pulumi/pulumi-yaml/pkg/pulumiyaml/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v3/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/utils"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/verify"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +47,7 @@ func TestYamlConvert(t *testing.T) {
 
 	result := runConvert(
 		env.Global(), []string{}, "convert_testdata", []string{},
-		"yaml", "go", "convert_testdata/go", true, true)
+		"yaml", "go", "convert_testdata/go", true, true, false)
 	require.Nil(t, result, "convert failed: %v", result)
 }
 
@@ -47,7 +59,7 @@ func TestPclConvert(t *testing.T) {
 
 	result := runConvert(
 		env.Global(), []string{}, "pcl_convert_testdata",
-		[]string{}, "pcl", "pcl", tmp, true, true)
+		[]string{}, "pcl", "pcl", tmp, true, true, false)
 	assert.Nil(t, result)
 
 	// Check that we made one file
@@ -66,3 +78,156 @@ output result {
 }`
 	assert.Equal(t, expectedPclCode, pclCode)
 }
+
+func TestConvertVerifyGeneratedLanguage(t *testing.T) {
+	t.Parallel()
+
+	// The program declares no resources, so it can be converted and verified without any provider plugins.
+	tmp := t.TempDir()
+	result := runConvert(
+		env.Global(), []string{}, "pcl_convert_testdata",
+		[]string{}, "pcl", "csharp", tmp, true, true, true)
+	assert.Nil(t, result)
+	assert.FileExists(t, filepath.Join(tmp, "Program.cs"))
+}
+
+const verifyConvertProgram = `resource pet "random:index/randomPet:RandomPet" {
+    length = 2
+}
+
+resource suffix "random:index/randomString:RandomString" {
+    length = 8
+    keepers = {
+        pet = pet.id
+    }
+}
+`
+
+func summaries(diags hcl.Diagnostics) []string {
+	var s []string
+	for _, d := range diags {
+		s = append(s, d.Summary)
+	}
+	return s
+}
+
+func TestVerifyConversion(t *testing.T) {
+	t.Parallel()
+
+	// Use the cached schemas of the codegen tests so that no provider plugins are needed.
+	host := utils.NewHost(filepath.Join("..", "..", "codegen", "testing", "test", "testdata"))
+	loader := schema.NewPluginLoader(host)
+
+	sourceDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(sourceDir, "main.pp"), []byte(verifyConvertProgram), 0o600)
+	require.NoError(t, err)
+
+	t.Run("pcl", func(t *testing.T) {
+		t.Parallel()
+
+		targetDir := t.TempDir()
+		_, err := pclGenerateProject(sourceDir, targetDir, nil, loader, true)
+		require.NoError(t, err)
+
+		diags, err := verifyConversion(sourceDir, targetDir, "pcl", loader, true)
+		require.NoError(t, err)
+		assert.Empty(t, diags)
+	})
+
+	t.Run("pcl with differences", func(t *testing.T) {
+		t.Parallel()
+
+		targetDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(targetDir, "main.pp"), []byte(`resource pet "random:index/randomPet:RandomPet" {
+    length = 2
+}
+
+resource suffix "random:index/randomString:RandomString" {
+    length = 8
+}
+`), 0o600)
+		require.NoError(t, err)
+
+		diags, err := verifyConversion(sourceDir, targetDir, "pcl", loader, true)
+		require.NoError(t, err)
+		assert.True(t, diags.HasErrors())
+		assert.Equal(t, []string{
+			"resource 'suffix' does not set the input properties 'keepers'",
+			"resource 'suffix' does not depend on the resources 'pet'",
+		}, summaries(diags))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+
+		targetDir := t.TempDir()
+		generate := generatorWrapper(yamlgen.GenerateProject, "yaml")
+		proj := &workspace.Project{Name: "verify", Runtime: workspace.NewProjectRuntimeInfo("yaml", nil)}
+		diags, err := generate(sourceDir, targetDir, proj, loader, true)
+		require.NoError(t, err)
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		diags, err = verifyConversion(sourceDir, targetDir, "yaml", loader, true)
+		require.NoError(t, err)
+		assert.False(t, diags.HasErrors(), diags.Error())
+	})
+
+	t.Run("other languages", func(t *testing.T) {
+		t.Parallel()
+
+		withoutDependencies := func(
+			generate func(string, workspace.Project, *pcl.Program, map[string]string) error,
+		) projectGeneratorFunc {
+			return func(directory string, project workspace.Project, program *pcl.Program) error {
+				return generate(directory, project, program, nil /*localDependencies*/)
+			}
+		}
+		generators := map[string]projectGeneratorFunc{
+			"go":     withoutDependencies(gogen.GenerateProject),
+			"nodejs": withoutDependencies(nodejs.GenerateProject),
+			"python": withoutDependencies(python.GenerateProject),
+			"dotnet": withoutDependencies(dotnet.GenerateProject),
+			"java":   javagen.GenerateProject,
+		}
+		for language, generator := range generators {
+			language, generator := language, generator
+			t.Run(language, func(t *testing.T) {
+				t.Parallel()
+
+				targetDir := t.TempDir()
+				generate := generatorWrapper(generator, language)
+				proj := &workspace.Project{Name: "verify", Runtime: workspace.NewProjectRuntimeInfo(language, nil)}
+				diags, err := generate(sourceDir, targetDir, proj, loader, true)
+				require.NoError(t, err)
+				require.False(t, diags.HasErrors(), diags.Error())
+
+				diags, err = verifyConversion(sourceDir, targetDir, language, loader, true)
+				require.NoError(t, err)
+				assert.Empty(t, summaries(diags))
+
+				// Rename the suffix resource in the generated program.
+				files, err := readProgramFiles(targetDir, verify.Extensions[language])
+				require.NoError(t, err)
+				require.NotEmpty(t, files)
+				for filename, contents := range files {
+					contents = []byte(strings.Replace(string(contents), `"suffix"`, `"other"`, 1))
+					require.NoError(t, os.WriteFile(filepath.Join(targetDir, filename), contents, 0o600))
+				}
+
+				diags, err = verifyConversion(sourceDir, targetDir, language, loader, true)
+				require.NoError(t, err)
+				assert.Equal(t, []string{
+					"resource 'suffix' is missing from the converted program",
+					"resource 'other' is not declared by the source program",
+				}, summaries(diags))
+			})
+		}
+	})
+
+	t.Run("unknown languages", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := verifyConversion(sourceDir, t.TempDir(), "cobol", loader, true)
+		assert.ErrorContains(t, err, "cobol programs can't be verified")
+	})
+}
@@ -16,12 +16,14 @@ package main
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/external"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/deepcopy"
@@ -57,15 +59,8 @@ func getStackDecrypter(s backend.Stack, ps *workspace.ProjectStack) (config.Decr
 func getStackSecretsManager(s backend.Stack, ps *workspace.ProjectStack) (secrets.Manager, bool, error) {
 	oldConfig := deepcopy.Copy(ps).(*workspace.ProjectStack)
 
-	var sm secrets.Manager
-	var err error
-	if ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "" {
-		sm, err = cloud.NewCloudSecretsManager(
-			ps, ps.SecretsProvider, false /* rotateSecretsProvider */)
-	} else if ps.EncryptionSalt != "" {
-		sm, err = passphrase.NewPromptingPassphraseSecretsManager(
-			ps, false /* rotateSecretsProvider */)
-	} else {
+	sm, err := getConfiguredSecretsManager(ps)
+	if err == nil && sm == nil {
 		sm, err = s.DefaultSecretManager(ps)
 	}
 	if err != nil {
@@ -77,6 +72,48 @@ func getStackSecretsManager(s backend.Stack, ps *workspace.ProjectStack) (secret
 	return stack.NewCachingSecretsManager(sm), needsSave, nil
 }
 
+// getConfiguredSecretsManager returns a secrets manager for the secrets provider that a config file configures, or nil
+// if the file doesn't configure one and so uses the default secrets provider of its stack's backend.
+func getConfiguredSecretsManager(ps *workspace.ProjectStack) (secrets.Manager, error) {
+	if external.IsExternalSecretsProvider(ps.SecretsProvider) {
+		return external.NewExternalSecretsManager(ps, ps.SecretsProvider)
+	} else if ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "" {
+		return cloud.NewCloudSecretsManager(
+			ps, ps.SecretsProvider, false /* rotateSecretsProvider */)
+	} else if ps.EncryptionSalt != "" {
+		return passphrase.NewPromptingPassphraseSecretsManager(
+			ps, false /* rotateSecretsProvider */)
+	}
+	return nil, nil
+}
+
+// trustStackSecretsProvider trusts the external secrets provider configured for a stack, if it has one, so that the
+// stack's state can be decrypted by commands that don't otherwise load the stack's config. The command named in the
+// stack's state is never run unless it is trusted this way.
+func trustStackSecretsProvider(project *workspace.Project, stack backend.Stack) {
+	if project == nil {
+		return
+	}
+	// Commands that need the stack's config report any errors loading it themselves.
+	ps, err := loadProjectStack(project, stack)
+	if err == nil && external.IsExternalSecretsProvider(ps.SecretsProvider) {
+		external.Trust(ps.SecretsProvider)
+	}
+}
+
+// getBaseConfigDecrypter returns a decrypter for the secrets in a base config file, using the file's own secrets
+// provider. Base files can't use the default secrets provider of a backend, because that belongs to a stack.
+func getBaseConfigDecrypter(layer workspace.ProjectStackLayer) (config.Decrypter, error) {
+	sm, err := getConfiguredSecretsManager(layer.Stack)
+	if err != nil {
+		return nil, fmt.Errorf("base config file %s: %w", layer.Path, err)
+	}
+	if sm == nil {
+		return nil, fmt.Errorf("base config file %s has secrets but no secrets provider", layer.Path)
+	}
+	return sm.Decrypter()
+}
+
 func needsSaveProjectStackAfterSecretManger(stack backend.Stack,
 	old *workspace.ProjectStack, new *workspace.ProjectStack,
 ) bool {
@@ -87,7 +124,8 @@ func needsSaveProjectStackAfterSecretManger(stack backend.Stack,
 	// this is not the desired behaviour.
 	if old.EncryptedKey != new.EncryptedKey ||
 		old.EncryptionSalt != new.EncryptionSalt ||
-		old.SecretsProvider != new.SecretsProvider {
+		old.SecretsProvider != new.SecretsProvider ||
+		!reflect.DeepEqual(old.SecretsRecipients, new.SecretsRecipients) {
 		return true
 	}
 	return false
@@ -95,7 +133,7 @@ func needsSaveProjectStackAfterSecretManger(stack backend.Stack,
 
 func validateSecretsProvider(typ string) error {
 	kind := strings.SplitN(typ, ":", 2)[0]
-	supportedKinds := []string{"default", "passphrase", "awskms", "azurekeyvault", "gcpkms", "hashivault"}
+	supportedKinds := []string{"default", "passphrase", "awskms", "azurekeyvault", "gcpkms", "hashivault", "exec"}
 	for _, supportedKind := range supportedKinds {
 		if kind == supportedKind {
 			return nil
@@ -105,3 +143,17 @@ func validateSecretsProvider(typ string) error {
 		kind,
 		strings.Join(supportedKinds, ","))
 }
+
+// validateSecretsRecipient checks that a secrets recipient is a cloud secrets provider URL.
+func validateSecretsRecipient(url string) error {
+	kind := strings.SplitN(url, ":", 2)[0]
+	supportedKinds := []string{"awskms", "azurekeyvault", "gcpkms", "hashivault"}
+	for _, supportedKind := range supportedKinds {
+		if kind == supportedKind {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown secrets recipient type '%s' (supported values: %s)",
+		kind,
+		strings.Join(supportedKinds, ","))
+}
@@ -0,0 +1,74 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// TestBaseConfigDecrypterMatchesStack checks that the secrets of a base config file are decrypted with the same
+// secrets provider that a stack with the same config would use, except that base files can't use the backend's
+// default secrets provider.
+//
+//nolint:paralleltest // sets environment variables
+func TestBaseConfigDecrypterMatchesStack(t *testing.T) {
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "base config passphrase")
+	ctx := context.Background()
+
+	ps := &workspace.ProjectStack{}
+	sm, err := passphrase.NewPromptingPassphraseSecretsManager(ps, false /* rotateSecretsProvider */)
+	require.NoError(t, err)
+	require.NotEmpty(t, ps.EncryptionSalt)
+	enc, err := sm.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(ctx, "plaintext")
+	require.NoError(t, err)
+
+	defaultUsed := false
+	stack := &backend.MockStack{
+		DefaultSecretManagerF: func(info *workspace.ProjectStack) (secrets.Manager, error) {
+			defaultUsed = true
+			return nil, nil
+		},
+	}
+	stackDecrypter, _, err := getStackDecrypter(stack, ps)
+	require.NoError(t, err)
+	plaintext, err := stackDecrypter.DecryptValue(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+
+	baseDecrypter, err := getBaseConfigDecrypter(workspace.ProjectStackLayer{Path: "Pulumi.base.yaml", Stack: ps})
+	require.NoError(t, err)
+	plaintext, err = baseDecrypter.DecryptValue(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+	assert.False(t, defaultUsed)
+
+	_, err = getBaseConfigDecrypter(workspace.ProjectStackLayer{
+		Path:  "Pulumi.base.yaml",
+		Stack: &workspace.ProjectStack{},
+	})
+	assert.ErrorContains(t, err, "base config file Pulumi.base.yaml has secrets but no secrets provider")
+	assert.False(t, defaultUsed)
+}
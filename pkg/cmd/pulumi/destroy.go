@@ -55,7 +55,9 @@ func newDestroyCmd() *cobra.Command {
 	var jsonDisplay bool
 	var diffDisplay bool
 	var eventLogPath string
+	var summaryFilePath string
 	var parallel int
+	var concurrencyLimits []string
 	var refresh string
 	var showConfig bool
 	var showReplacementSteps bool
@@ -123,6 +125,7 @@ func newDestroyCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				SummaryFilePath:      summaryFilePath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
@@ -217,8 +220,7 @@ func newDestroyCmd() *cobra.Command {
 				return result.FromError(fmt.Errorf("getting stack decrypter: %w", err))
 			}
 
-			stackName := s.Ref().Name().String()
-			configError := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configError := validateStackConfig(s, proj, cfg.Config, decrypter)
 			if configError != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configError))
 			}
@@ -227,6 +229,14 @@ func newDestroyCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+			if err != nil {
+				return result.FromError(err)
+			}
+			retryPolicy, err := backend.GetProjectRetryPolicy(proj)
+			if err != nil {
+				return result.FromError(err)
+			}
 
 			if len(*targets) > 0 && excludeProtected {
 				return result.FromError(errors.New("You cannot specify --target and --exclude-protected"))
@@ -253,6 +263,8 @@ func newDestroyCmd() *cobra.Command {
 
 			opts.Engine = engine.UpdateOptions{
 				Parallel:                  parallel,
+				ConcurrencyLimits:         limits,
+				RetryPolicy:               retryPolicy,
 				Debug:                     debug,
 				Refresh:                   refreshOption,
 				Targets:                   deploy.NewUrnTargets(targetUrns),
@@ -342,6 +354,10 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", nil,
+		"Limit the number of concurrent operations on resources of a package or type, given as <package-or-type>=<n>. "+
+			"May be specified multiple times")
 	cmd.PersistentFlags().StringVarP(
 		&refresh, "refresh", "r", "",
 		"Refresh the state of the stack's resources before this update")
@@ -361,6 +377,9 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringVar(
+		&summaryFilePath, "summary-file", "",
+		"Write a JSON summary of the operation to a file at this path once it ends")
 	cmd.PersistentFlags().StringVar(
 		&suppressPermalink, "suppress-permalink", "",
 		"Suppress display of the state permalink")
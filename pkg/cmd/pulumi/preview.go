@@ -31,7 +31,6 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 func newPreviewCmd() *cobra.Command {
@@ -56,7 +55,9 @@ func newPreviewCmd() *cobra.Command {
 	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
+	var summaryFilePath string
 	var parallel int
+	var concurrencyLimits []string
 	var refresh string
 	var showConfig bool
 	var showReplacementSteps bool
@@ -109,6 +110,7 @@ func newPreviewCmd() *cobra.Command {
 				Type:                 displayType,
 				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
+				SummaryFilePath:      summaryFilePath,
 				Debug:                debug,
 			}
 
@@ -181,8 +183,7 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(fmt.Errorf("getting stack decrypter: %w", err))
 			}
 
-			stackName := s.Ref().Name().String()
-			configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configErr := validateStackConfig(s, proj, cfg.Config, decrypter)
 			if configErr != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 			}
@@ -202,11 +203,21 @@ func newPreviewCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+			if err != nil {
+				return result.FromError(err)
+			}
+			retryPolicy, err := backend.GetProjectRetryPolicy(proj)
+			if err != nil {
+				return result.FromError(err)
+			}
 
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
 					LocalPolicyPacks:          engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 					Parallel:                  parallel,
+					ConcurrencyLimits:         limits,
+					RetryPolicy:               retryPolicy,
 					Debug:                     debug,
 					Refresh:                   refreshOption,
 					ReplaceTargets:            deploy.NewUrnTargets(replaceURNs),
@@ -332,6 +343,10 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", nil,
+		"Limit the number of concurrent operations on resources of a package or type, given as <package-or-type>=<n>. "+
+			"May be specified multiple times")
 	cmd.PersistentFlags().StringVarP(
 		&refresh, "refresh", "r", "",
 		"Refresh the state of the stack's resources before this update")
@@ -352,6 +367,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringVar(
+		&summaryFilePath, "summary-file", "",
+		"Write a JSON summary of the operation to a file at this path once it ends")
 
 	cmd.PersistentFlags().StringVar(
 		&suppressPermalink, "suppress-permalink", "",
@@ -35,7 +35,6 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 func newRefreshCmd() *cobra.Command {
@@ -53,7 +52,9 @@ func newRefreshCmd() *cobra.Command {
 	var jsonDisplay bool
 	var diffDisplay bool
 	var eventLogPath string
+	var summaryFilePath string
 	var parallel int
+	var concurrencyLimits []string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -120,6 +121,7 @@ func newRefreshCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				SummaryFilePath:      summaryFilePath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
@@ -184,8 +186,7 @@ func newRefreshCmd() *cobra.Command {
 				return result.FromError(fmt.Errorf("getting stack decrypter: %w", err))
 			}
 
-			stackName := s.Ref().Name().String()
-			configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configErr := validateStackConfig(s, proj, cfg.Config, decrypter)
 			if configErr != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 			}
@@ -243,8 +244,14 @@ func newRefreshCmd() *cobra.Command {
 			targetUrns := []string{}
 			targetUrns = append(targetUrns, *targets...)
 
+			limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			opts.Engine = engine.UpdateOptions{
 				Parallel:                  parallel,
+				ConcurrencyLimits:         limits,
 				Debug:                     debug,
 				UseLegacyDiff:             useLegacyDiff(),
 				DisableProviderPreview:    disableProviderPreview(),
@@ -309,6 +316,10 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", nil,
+		"Limit the number of concurrent operations on resources of a package or type, given as <package-or-type>=<n>. "+
+			"May be specified multiple times")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
@@ -321,6 +332,9 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringVar(
+		&summaryFilePath, "summary-file", "",
+		"Write a JSON summary of the operation to a file at this path once it ends")
 	cmd.PersistentFlags().StringVar(
 		&suppressPermalink, "suppress-permalink", "",
 		"Suppress display of the state permalink")
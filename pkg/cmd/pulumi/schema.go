@@ -31,5 +31,8 @@ package schemas for errors.`,
 	}
 
 	cmd.AddCommand(newSchemaCheckCommand())
+	cmd.AddCommand(newSchemaDiffCommand())
+	cmd.AddCommand(newSchemaLintCommand())
+	cmd.AddCommand(newSchemaCacheCmd())
 	return cmd
 }
@@ -0,0 +1,235 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blang/semver"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+func newSchemaCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache of provider schemas",
+		Long: "Manage the cache of provider schemas.\n" +
+			"\n" +
+			"Commands that generate code, such as `pulumi convert` and `pulumi import --out`, cache\n" +
+			"the schemas of the resource plugins that they use in ~/.pulumi/schemas, so that each\n" +
+			"plugin is only asked for its schema once. Schemas are keyed by the name and version of\n" +
+			"the plugin and a checksum of the plugin itself, so a schema is refreshed automatically\n" +
+			"when its plugin is reinstalled. These commands list and remove cached schemas.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newSchemaCacheLsCmd())
+	cmd.AddCommand(newSchemaCacheRmCmd())
+	return cmd
+}
+
+// newCachingPluginLoader returns a schema loader for commands that generate code, which caches the schemas of the
+// plugins that it loads in the schema cache.
+func newCachingPluginLoader(host plugin.Host) schema.ReferenceLoader {
+	cache, err := schema.DefaultSchemaCache()
+	if err != nil {
+		logging.V(5).Infof("schema cache is disabled: %v", err)
+		return schema.NewPluginLoader(host)
+	}
+	return schema.NewPluginLoaderWithCache(host, cache)
+}
+
+type schemaCacheCmd struct {
+	stdout io.Writer
+	cache  *schema.SchemaCache
+}
+
+func (cmd *schemaCacheCmd) init() error {
+	if cmd.stdout == nil {
+		cmd.stdout = os.Stdout
+	}
+	if cmd.cache == nil {
+		cache, err := schema.DefaultSchemaCache()
+		if err != nil {
+			return fmt.Errorf("locating the schema cache: %w", err)
+		}
+		cmd.cache = cache
+	}
+	return nil
+}
+
+func newSchemaCacheLsCmd() *cobra.Command {
+	var sccmd schemaCacheCmd
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List cached provider schemas",
+		Args:  cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			return sccmd.List(jsonOut)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+	return cmd
+}
+
+// schemaCacheEntryJSON is the shape of the --json output of `pulumi schema cache ls`. While we can add fields to
+// this structure in the future, we should not change existing fields.
+type schemaCacheEntryJSON struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Checksum   string `json:"checksum"`
+	Path       string `json:"path"`
+	Size       int64  `json:"size"`
+	CachedTime string `json:"cachedTime"`
+}
+
+func (cmd *schemaCacheCmd) List(jsonOut bool) error {
+	if err := cmd.init(); err != nil {
+		return err
+	}
+
+	entries, err := cmd.cache.Entries()
+	if err != nil {
+		return fmt.Errorf("loading cached schemas: %w", err)
+	}
+
+	if jsonOut {
+		jsonEntries := make([]schemaCacheEntryJSON, len(entries))
+		for i, e := range entries {
+			var version string
+			if e.Version != nil {
+				version = e.Version.String()
+			}
+			jsonEntries[i] = schemaCacheEntryJSON{
+				Name:       e.Name,
+				Version:    version,
+				Checksum:   e.Checksum,
+				Path:       e.Path,
+				Size:       e.Size,
+				CachedTime: e.ModTime.UTC().Format(timeFormat),
+			}
+		}
+		return fprintJSON(cmd.stdout, jsonEntries)
+	}
+
+	var totalSize uint64
+	rows := []cmdutil.TableRow{}
+	for _, e := range entries {
+		version := naString
+		if e.Version != nil {
+			version = e.Version.String()
+		}
+		rows = append(rows, cmdutil.TableRow{
+			Columns: []string{e.Name, version, e.Checksum, humanize.Bytes(uint64(e.Size)), humanize.Time(e.ModTime)},
+		})
+		totalSize += uint64(e.Size)
+	}
+	if err := cmdutil.FprintTable(cmd.stdout, cmdutil.Table{
+		Headers: []string{"NAME", "VERSION", "CHECKSUM", "SIZE", "CACHED"},
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.stdout, "\n")
+	fmt.Fprintf(cmd.stdout, "TOTAL schema cache size: %s\n", humanize.Bytes(totalSize))
+	return nil
+}
+
+func newSchemaCacheRmCmd() *cobra.Command {
+	var sccmd schemaCacheCmd
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "rm [NAME [VERSION]]",
+		Args:  cmdutil.MaximumNArgs(2),
+		Short: "Remove provider schemas from the cache",
+		Long: "Remove provider schemas from the cache.\n" +
+			"\n" +
+			"Specify NAME and/or VERSION to narrow down which plugins' schemas will be removed.\n" +
+			"If only NAME is specified, the schemas of all versions of the plugin will be removed.\n" +
+			"Pass --all to clear the entire cache.\n" +
+			"\n" +
+			"Removed schemas are fetched from their plugins again the next time that they are\n" +
+			"needed.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			var name string
+			var version *semver.Version
+			if len(args) > 0 {
+				name = args[0]
+			} else if !all {
+				return errors.New("please pass --all if you'd like to remove all cached schemas")
+			}
+			if len(args) > 1 {
+				v, err := semver.ParseTolerant(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid plugin semver: %w", err)
+				}
+				version = &v
+			}
+			return sccmd.Remove(name, version)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&all, "all", "a", false,
+		"Remove all cached schemas")
+	return cmd
+}
+
+// Remove removes the schemas of the plugins with the given name and version from the cache. If the name is empty, all
+// schemas are removed.
+func (cmd *schemaCacheCmd) Remove(name string, version *semver.Version) error {
+	if err := cmd.init(); err != nil {
+		return err
+	}
+
+	entries, err := cmd.cache.Entries()
+	if err != nil {
+		return fmt.Errorf("loading cached schemas: %w", err)
+	}
+
+	if name == "" {
+		if err := cmd.cache.Clear(); err != nil {
+			return fmt.Errorf("clearing the schema cache: %w", err)
+		}
+		fmt.Fprintf(cmd.stdout, "removed %d cached schemas\n", len(entries))
+		return nil
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if e.Name == name && (version == nil || e.Version != nil && e.Version.EQ(*version)) {
+			removed++
+		}
+	}
+	if err := cmd.cache.Remove(name, version); err != nil {
+		return fmt.Errorf("removing cached schemas: %w", err)
+	}
+	fmt.Fprintf(cmd.stdout, "removed %d cached schemas\n", removed)
+	return nil
+}
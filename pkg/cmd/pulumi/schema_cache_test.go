@@ -0,0 +1,99 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func newTestSchemaCache(t *testing.T) *schema.SchemaCache {
+	dir := t.TempDir()
+	for _, path := range []string{
+		"aws/5.16.2/0123456789abcdef.json",
+		"aws/6.0.0/fedcba9876543210.json",
+		"random/unversioned/00112233aabbccdd.json",
+	} {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(`{"name": "test"}`), 0o600))
+	}
+	return schema.NewSchemaCache(dir)
+}
+
+func TestSchemaCacheLs(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	cmd := schemaCacheCmd{stdout: &stdout, cache: newTestSchemaCache(t)}
+	require.NoError(t, cmd.List(false))
+	out := stdout.String()
+	assert.Contains(t, out, "NAME")
+	assert.Regexp(t, `aws +5\.16\.2 +0123456789abcdef +16 B`, out)
+	assert.Regexp(t, `random +n/a +00112233aabbccdd`, out)
+	assert.Contains(t, out, "TOTAL schema cache size: 48 B")
+
+	stdout.Reset()
+	require.NoError(t, cmd.List(true))
+	var entries []schemaCacheEntryJSON
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "aws", entries[0].Name)
+	assert.Equal(t, "5.16.2", entries[0].Version)
+	assert.Equal(t, "0123456789abcdef", entries[0].Checksum)
+	assert.Equal(t, int64(16), entries[0].Size)
+	assert.Equal(t, "", entries[2].Version)
+}
+
+func TestSchemaCacheRm(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	cache := newTestSchemaCache(t)
+	cmd := schemaCacheCmd{stdout: &stdout, cache: cache}
+	names := func() []string {
+		entries, err := cache.Entries()
+		require.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+
+	v6 := semver.MustParse("6.0.0")
+	require.NoError(t, cmd.Remove("aws", &v6))
+	assert.Equal(t, "removed 1 cached schemas\n", stdout.String())
+	assert.Equal(t, []string{"aws", "random"}, names())
+
+	stdout.Reset()
+	require.NoError(t, cmd.Remove("random", nil))
+	assert.Equal(t, "removed 1 cached schemas\n", stdout.String())
+	assert.Equal(t, []string{"aws"}, names())
+
+	stdout.Reset()
+	require.NoError(t, cmd.Remove("", nil))
+	assert.Equal(t, "removed 1 cached schemas\n", stdout.String())
+	assert.Empty(t, names())
+}
@@ -0,0 +1,158 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+type schemaDiffCmd struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	jsonOut   bool
+	languages []string
+}
+
+func newSchemaDiffCommand() *cobra.Command {
+	var sdcmd schemaDiffCmd
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Args:  cmdutil.ExactArgs(2),
+		Short: "Compare two versions of a Pulumi package schema",
+		Long: "Compare two versions of a Pulumi package schema.\n" +
+			"\n" +
+			"This command reports the changes between two versions of a package schema that\n" +
+			"affect the package's SDKs, such as removed resources, functions or properties,\n" +
+			"properties that became required, changed types, removed enum values, renamed\n" +
+			"tokens and changes to replaceOnChanges. Each change has a severity: errors break\n" +
+			"programs that use the SDKs, warnings may change how existing stacks are deployed,\n" +
+			"and informational changes are safe to release in a minor version.\n" +
+			"\n" +
+			"The command fails if any change is an error, so that it can be used to block\n" +
+			"accidental breaking releases.",
+		Example: "pulumi schema diff schema-v1.json schema-v2.json --language go --language python",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			return sdcmd.Run(args[0], args[1])
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&sdcmd.jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.Flags().StringSliceVar(&sdcmd.languages, "language", nil,
+		"Only report the changes that affect the SDKs for the given languages")
+	return cmd
+}
+
+func (cmd *schemaDiffCmd) Run(oldFile, newFile string) error {
+	stdout, stderr := cmd.stdout, cmd.stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	bind := func(file string) (*schema.Package, error) {
+		spec, err := readPackageSpec(file)
+		if err != nil {
+			return nil, err
+		}
+		pkg, diags, err := schema.BindSpec(spec, nil)
+		diagWriter := hcl.NewDiagnosticTextWriter(stderr, nil, 0, true)
+		contract.IgnoreError(diagWriter.WriteDiagnostics(diags))
+		if err == nil && diags.HasErrors() {
+			return nil, fmt.Errorf("schema %v is invalid", file)
+		}
+		return pkg, err
+	}
+
+	oldPkg, err := bind(oldFile)
+	if err != nil {
+		return err
+	}
+	newPkg, err := bind(newFile)
+	if err != nil {
+		return err
+	}
+
+	changes := cmd.filterChanges(schema.DiffPackages(oldPkg, newPkg))
+	if cmd.jsonOut {
+		if err := fprintJSON(stdout, changes); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(stdout, cmdutil.GetGlobalColorization().Colorize(renderSchemaDiff(changes)))
+	}
+
+	for _, c := range changes {
+		if c.Severity == schema.SeverityError {
+			return errors.New("the new schema contains breaking changes")
+		}
+	}
+	return nil
+}
+
+// filterChanges returns the changes that affect any of the requested languages.
+func (cmd *schemaDiffCmd) filterChanges(changes []schema.PackageChange) []schema.PackageChange {
+	filtered := []schema.PackageChange{}
+	for _, c := range changes {
+		if len(cmd.languages) == 0 {
+			filtered = append(filtered, c)
+			continue
+		}
+		for _, l := range cmd.languages {
+			if c.AffectsLanguage(l) {
+				filtered = append(filtered, c)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func renderSchemaDiff(changes []schema.PackageChange) string {
+	if len(changes) == 0 {
+		return "No changes.\n"
+	}
+
+	severityColors := map[schema.ChangeSeverity]string{
+		schema.SeverityError:   colors.SpecError,
+		schema.SeverityWarning: colors.SpecWarning,
+		schema.SeverityInfo:    colors.SpecInfo,
+	}
+	counts := map[schema.ChangeSeverity]int{}
+	var out strings.Builder
+	for _, c := range changes {
+		counts[c.Severity]++
+		severity := string(c.Severity)
+		fmt.Fprintf(&out, "%s%s%s%s\n",
+			severityColors[c.Severity], severity, colors.Reset, strings.TrimPrefix(c.String(), severity))
+	}
+	fmt.Fprintf(&out, "\n%d errors, %d warnings, %d informational changes\n",
+		counts[schema.SeverityError], counts[schema.SeverityWarning], counts[schema.SeverityInfo])
+	return out.String()
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeSchema := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	oldFile := writeSchema("old.json", `{
+		"name": "test",
+		"resources": {
+			"test:index:Bucket": {
+				"inputProperties": {"size": {"type": "integer"}},
+				"properties": {"size": {"type": "integer"}}
+			}
+		}
+	}`)
+	newFile := writeSchema("new.yaml", `
+name: test
+resources:
+  test:index:Bucket:
+    inputProperties:
+      size:
+        type: number
+    properties:
+      size:
+        type: number
+`)
+
+	var stdout bytes.Buffer
+	cmd := schemaDiffCmd{stdout: &stdout, jsonOut: true}
+	err := cmd.Run(oldFile, newFile)
+	assert.ErrorContains(t, err, "breaking changes")
+
+	var changes []schema.PackageChange
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &changes))
+	assert.Len(t, changes, 2)
+	for _, c := range changes {
+		assert.Equal(t, schema.SeverityError, c.Severity)
+		assert.Equal(t, []string{"dotnet", "go"}, c.Languages)
+	}
+
+	// Integer and number properties are interchangeable in Python.
+	stdout.Reset()
+	cmd = schemaDiffCmd{stdout: &stdout, languages: []string{"python"}}
+	require.NoError(t, cmd.Run(oldFile, newFile))
+	assert.Equal(t, "No changes.\n", stdout.String())
+}
+
+func TestRenderSchemaDiff(t *testing.T) {
+	t.Parallel()
+
+	out := colors.Never.Colorize(renderSchemaDiff([]schema.PackageChange{
+		{Severity: schema.SeverityError, Token: "test:index:Queue", Message: "resource was removed"},
+		{Severity: schema.SeverityInfo, Token: "test:index:Topic", Message: "resource was added"},
+	}))
+	assert.Equal(t, "error: test:index:Queue: resource was removed\n"+
+		"info: test:index:Topic: resource was added\n"+
+		"\n"+
+		"1 errors, 0 warnings, 1 informational changes\n", out)
+}
@@ -0,0 +1,152 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema/lint"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+type schemaLintCmd struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	configFile string
+	jsonOut    bool
+}
+
+func newSchemaLintCommand() *cobra.Command {
+	var slcmd schemaLintCmd
+	cmd := &cobra.Command{
+		Use:   "lint <schema>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Check a Pulumi package schema for quality problems",
+		Long: "Check a Pulumi package schema for quality problems.\n" +
+			"\n" +
+			"In addition to the errors reported by `pulumi schema check`, this command reports\n" +
+			"problems such as missing descriptions, property names that aren't camelCase, secrets\n" +
+			"that aren't marked secret, unused types, duplicate enum values and functions without\n" +
+			"output-versioned forms. The problems are grouped by the token of the resource,\n" +
+			"function or type that has them.\n" +
+			"\n" +
+			"Rules can be configured and their problems suppressed with a YAML configuration file:\n" +
+			"\n" +
+			"    rules:\n" +
+			"      missing-description: off\n" +
+			"      unmarked-secret: error\n" +
+			"    ignore:\n" +
+			"      - rule: property-casing\n" +
+			"        token: mypkg:index:*\n" +
+			"        property: legacy_name\n" +
+			"\n" +
+			"The command fails if any problem has the severity error.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			return slcmd.Run(args[0])
+		}),
+	}
+
+	cmd.Flags().StringVar(&slcmd.configFile, "config", "", "The path to a lint configuration file")
+	cmd.Flags().BoolVarP(&slcmd.jsonOut, "json", "j", false, "Emit output as JSON")
+	return cmd
+}
+
+func (cmd *schemaLintCmd) Run(file string) error {
+	stdout, stderr := cmd.stdout, cmd.stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	var config *lint.Config
+	if cmd.configFile != "" {
+		c, err := lint.LoadConfig(cmd.configFile)
+		if err != nil {
+			return err
+		}
+		config = c
+	}
+
+	pkgSpec, err := readPackageSpec(file)
+	if err != nil {
+		return err
+	}
+	pkg, diags, err := schema.BindSpec(pkgSpec, nil)
+	diagWriter := hcl.NewDiagnosticTextWriter(stderr, nil, 0, true)
+	contract.IgnoreError(diagWriter.WriteDiagnostics(diags))
+	if err == nil && diags.HasErrors() {
+		return errors.New("schema validation failed")
+	}
+	if err != nil {
+		return err
+	}
+
+	problems, err := lint.Lint(pkg, lint.DefaultRules(), config)
+	if err != nil {
+		return err
+	}
+	groups := lint.GroupByToken(problems)
+	if cmd.jsonOut {
+		if err := fprintJSON(stdout, groups); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(stdout, cmdutil.GetGlobalColorization().Colorize(renderSchemaLint(groups)))
+	}
+
+	for _, p := range problems {
+		if p.Severity == lint.SeverityError {
+			return errors.New("the schema has lint errors")
+		}
+	}
+	return nil
+}
+
+func renderSchemaLint(groups []lint.TokenDiagnostics) string {
+	if len(groups) == 0 {
+		return "No problems found.\n"
+	}
+
+	severityColors := map[lint.Severity]string{
+		lint.SeverityError:   colors.SpecError,
+		lint.SeverityWarning: colors.SpecWarning,
+		lint.SeverityInfo:    colors.SpecInfo,
+	}
+	var out strings.Builder
+	for i, g := range groups {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "%s%s%s\n", colors.Bold, g.Token, colors.Reset)
+		for _, d := range g.Diagnostics {
+			fmt.Fprintf(&out, "    %s%s%s [%s]: %s\n",
+				severityColors[d.Severity], d.Severity, colors.Reset, d.Rule, d.Message)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema/lint"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+)
+
+func TestSchemaLint(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	schemaFile := filepath.Join(dir, "schema.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(`{
+		"name": "test",
+		"types": {
+			"test:index:Size": {
+				"description": "A size.",
+				"type": "string",
+				"enum": [{"name": "Small", "value": "small"}, {"name": "Tiny", "value": "small"}]
+			}
+		},
+		"resources": {
+			"test:index:Bucket": {
+				"description": "A bucket.",
+				"inputProperties": {
+					"size": {"$ref": "#/types/test:index:Size", "description": "The size."},
+					"apiToken": {"type": "string", "description": "A token."}
+				}
+			}
+		}
+	}`), 0o600))
+
+	var stdout bytes.Buffer
+	cmd := schemaLintCmd{stdout: &stdout}
+	err := cmd.Run(schemaFile)
+	assert.ErrorContains(t, err, "lint errors")
+	out := colors.Never.Colorize(stdout.String())
+	assert.Contains(t, out, "test:index:Bucket\n    warning [unmarked-secret]:")
+	assert.Contains(t, out, "test:index:Size\n    error [duplicate-enum-value]:")
+
+	// Suppressing the duplicate enum value leaves only warnings, which don't fail the command.
+	configFile := filepath.Join(dir, "lint.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+ignore:
+  - rule: duplicate-enum-value
+    token: test:index:Size
+`), 0o600))
+	stdout.Reset()
+	cmd = schemaLintCmd{stdout: &stdout, configFile: configFile}
+	require.NoError(t, cmd.Run(schemaFile))
+	assert.NotContains(t, stdout.String(), "duplicate-enum-value")
+}
+
+func TestRenderSchemaLint(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "No problems found.\n", renderSchemaLint(nil))
+
+	out := colors.Never.Colorize(renderSchemaLint(lint.GroupByToken([]lint.Diagnostic{
+		{Rule: "a", Severity: lint.SeverityError, Token: "test:index:A", Message: "first"},
+		{Rule: "b", Severity: lint.SeverityInfo, Token: "test:index:A", Message: "second"},
+		{Rule: "a", Severity: lint.SeverityWarning, Token: "test:index:B", Message: "third"},
+	})))
+	assert.Equal(t, "test:index:A\n"+
+		"    error [a]: first\n"+
+		"    info [b]: second\n"+
+		"\n"+
+		"test:index:B\n"+
+		"    warning [a]: third\n", out)
+}
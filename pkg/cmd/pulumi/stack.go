@@ -179,6 +179,7 @@ func newStackCmd() *cobra.Command {
 	cmd.Flags().BoolVar(
 		&showStackName, "show-name", false, "Display only the stack name")
 
+	cmd.AddCommand(newStackDiffCmd())
 	cmd.AddCommand(newStackExportCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
@@ -190,6 +191,7 @@ func newStackCmd() *cobra.Command {
 	cmd.AddCommand(newStackTagCmd())
 	cmd.AddCommand(newStackRenameCmd())
 	cmd.AddCommand(newStackChangeSecretsProviderCmd())
+	cmd.AddCommand(newStackRotatePassphraseCmd())
 	cmd.AddCommand(newStackHistoryCmd())
 	cmd.AddCommand(newStackUnselectCmd())
 
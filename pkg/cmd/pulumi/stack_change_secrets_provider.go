@@ -17,6 +17,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -24,6 +25,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -35,17 +39,20 @@ import (
 type stackChangeSecretsProviderCmd struct {
 	stdout io.Writer
 
-	stack string
+	stack            string
+	addRecipients    []string
+	removeRecipients []string
 }
 
 func newStackChangeSecretsProviderCmd() *cobra.Command {
 	var scspcmd stackChangeSecretsProviderCmd
 	cmd := &cobra.Command{
-		Use:   "change-secrets-provider <new-secrets-provider>",
-		Args:  cmdutil.ExactArgs(1),
+		Use:   "change-secrets-provider [new-secrets-provider]",
+		Args:  cmdutil.MaximumNArgs(1),
 		Short: "Change the secrets provider for a stack",
 		Long: "Change the secrets provider for a stack. " +
-			"Valid secret providers types are `default`, `passphrase`, `awskms`, `azurekeyvault`, `gcpkms`, `hashivault`.\n\n" +
+			"Valid secret providers types are `default`, `passphrase`, `awskms`, `azurekeyvault`, `gcpkms`, `hashivault`, " +
+			"`exec`.\n\n" +
 			"To change to using the Pulumi Default Secrets Provider, use the following:\n" +
 			"\n" +
 			"pulumi stack change-secrets-provider default" +
@@ -61,7 +68,19 @@ func newStackChangeSecretsProviderCmd() *cobra.Command {
 			"\"azurekeyvault://mykeyvaultname.vault.azure.net/keys/mykeyname\"`\n" +
 			"* `pulumi stack change-secrets-provider " +
 			"\"gcpkms://projects/<p>/locations/<l>/keyRings/<r>/cryptoKeys/<k>\"`\n" +
-			"* `pulumi stack change-secrets-provider \"hashivault://mykey\"`",
+			"* `pulumi stack change-secrets-provider \"hashivault://mykey\"`\n" +
+			"\n" +
+			"To change the stack to delegate encryption to an external command, use the following:\n" +
+			"\n" +
+			"* `pulumi stack change-secrets-provider \"exec:///path/to/command\"`\n" +
+			"\n" +
+			"A stack that uses a cloud secrets backend can also have its data key encrypted by further cloud\n" +
+			"secrets backends, called recipients, so that its secrets can be decrypted with any one of them.\n" +
+			"Adding or removing a recipient does not encrypt the stack's secrets again. Recipients are\n" +
+			"recorded in the stack's config file, so base64key:// URLs can't be used as recipients:\n" +
+			"\n" +
+			"* `pulumi stack change-secrets-provider --add-recipient \"awskms://alias/break-glass\"`\n" +
+			"* `pulumi stack change-secrets-provider --remove-recipient \"awskms://alias/break-glass\"`",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			ctx := commandContext()
 			return scspcmd.Run(ctx, args)
@@ -71,6 +90,12 @@ func newStackChangeSecretsProviderCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&scspcmd.stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringArrayVar(
+		&scspcmd.addRecipients, "add-recipient", nil,
+		"A cloud secrets provider URL to also encrypt the stack's data key with. May be specified multiple times")
+	cmd.Flags().StringArrayVar(
+		&scspcmd.removeRecipients, "remove-recipient", nil,
+		"A cloud secrets provider URL to stop encrypting the stack's data key with. May be specified multiple times")
 
 	return cmd
 }
@@ -85,6 +110,16 @@ func (cmd *stackChangeSecretsProviderCmd) Run(ctx context.Context, args []string
 		Color: cmdutil.GetGlobalColorization(),
 	}
 
+	changeRecipients := len(cmd.addRecipients) > 0 || len(cmd.removeRecipients) > 0
+	switch {
+	case len(args) == 0 && !changeRecipients:
+		return errors.New("a new secrets provider or a recipient to add or remove must be specified")
+	case len(args) > 0 && changeRecipients:
+		return errors.New("the secrets provider and its recipients cannot be changed at the same time")
+	case changeRecipients:
+		return cmd.changeRecipients(ctx, opts)
+	}
+
 	if err := validateSecretsProvider(args[0]); err != nil {
 		return err
 	}
@@ -135,6 +170,110 @@ func (cmd *stackChangeSecretsProviderCmd) Run(ctx context.Context, args []string
 	return migrateOldConfigAndCheckpointToNewSecretsProvider(ctx, project, currentStack, currentProjectStack, decrypter)
 }
 
+// changeRecipients adds and removes the secrets recipients of a stack that uses a cloud secrets provider. The data key
+// stays the same, so the stack's secrets are not encrypted again. Only the secrets manager state in the config file
+// and the checkpoint changes.
+func (cmd *stackChangeSecretsProviderCmd) changeRecipients(ctx context.Context, opts display.Options) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	for _, url := range cmd.addRecipients {
+		if err := validateSecretsRecipient(url); err != nil {
+			return err
+		}
+	}
+
+	project, _, err := readProject()
+	if err != nil {
+		return err
+	}
+
+	currentStack, err := requireStack(ctx, cmd.stack, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+
+	currentProjectStack, err := loadProjectStack(project, currentStack)
+	if err != nil {
+		return err
+	}
+	switch currentProjectStack.SecretsProvider {
+	case "", "default", passphrase.Type:
+		return fmt.Errorf("stack %s does not use a cloud secrets provider, so it can't have secrets recipients",
+			currentStack.Ref())
+	}
+
+	for _, url := range cmd.removeRecipients {
+		if err := cloud.RemoveSecretsRecipient(currentProjectStack, url); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Removed secrets recipient %s\n", url)
+	}
+	for _, url := range cmd.addRecipients {
+		if err := cloud.AddSecretsRecipient(currentProjectStack, url); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Added secrets recipient %s\n", url)
+	}
+
+	secretsManager, _, err := getStackSecretsManager(currentStack, currentProjectStack)
+	if err != nil {
+		return err
+	}
+	if err := saveProjectStack(currentStack, currentProjectStack); err != nil {
+		return err
+	}
+
+	// Record the new recipients in the checkpoint too, so that its secrets can be decrypted with them.
+	checkpoint, err := currentStack.ExportDeployment(ctx)
+	if err != nil {
+		return err
+	}
+	updated, err := setDeploymentSecretsManagerState(checkpoint, secretsManager)
+	if err != nil {
+		return checkDeploymentVersionError(err, currentStack.Ref().Name().String())
+	}
+	if updated == nil {
+		return nil
+	}
+	return currentStack.ImportDeployment(ctx, updated)
+}
+
+// setDeploymentSecretsManagerState returns a copy of a deployment whose secrets manager state is replaced by the
+// state of the given secrets manager, leaving its secrets as they are. Returns nil if the deployment has no secrets
+// manager of the same type, in which case there is nothing to update.
+func setDeploymentSecretsManagerState(
+	deployment *apitype.UntypedDeployment, sm secrets.Manager,
+) (*apitype.UntypedDeployment, error) {
+	switch {
+	case deployment.Version > apitype.DeploymentSchemaVersionCurrent:
+		return nil, stack.ErrDeploymentSchemaVersionTooNew
+	case deployment.Version < apitype.DeploymentSchemaVersionCurrent:
+		// Older deployments predate secrets managers.
+		return nil, nil
+	}
+
+	var v3deployment apitype.DeploymentV3
+	if err := json.Unmarshal(deployment.Deployment, &v3deployment); err != nil {
+		return nil, err
+	}
+	if v3deployment.SecretsProviders == nil || v3deployment.SecretsProviders.Type != sm.Type() {
+		return nil, nil
+	}
+	v3deployment.SecretsProviders.State = sm.State()
+
+	bytes, err := json.Marshal(v3deployment)
+	if err != nil {
+		return nil, err
+	}
+	return &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: bytes,
+	}, nil
+}
+
 func migrateOldConfigAndCheckpointToNewSecretsProvider(ctx context.Context,
 	project *workspace.Project,
 	currentStack backend.Stack,
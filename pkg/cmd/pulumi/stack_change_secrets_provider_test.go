@@ -47,9 +47,76 @@ func TestChangeSecretsProvider_Invalid(t *testing.T) {
 	err := cmd.Run(context.Background(), []string{"not_a_secret"})
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "unknown secrets provider type 'not_a_secret' "+
-		"(supported values: default,passphrase,awskms,azurekeyvault,gcpkms,hashivault)")
+		"(supported values: default,passphrase,awskms,azurekeyvault,gcpkms,hashivault,exec)")
 }
 
+func TestChangeSecretsProvider_InvalidRecipients(t *testing.T) {
+	t.Parallel()
+
+	cmd := stackChangeSecretsProviderCmd{stack: "test"}
+	err := cmd.Run(context.Background(), nil)
+	assert.ErrorContains(t, err, "a new secrets provider or a recipient to add or remove must be specified")
+
+	cmd.addRecipients = []string{"not_a_secret"}
+	err = cmd.Run(context.Background(), []string{"passphrase"})
+	assert.ErrorContains(t, err, "cannot be changed at the same time")
+
+	err = cmd.Run(context.Background(), nil)
+	assert.ErrorContains(t, err, "unknown secrets recipient type 'not_a_secret' "+
+		"(supported values: awskms,azurekeyvault,gcpkms,hashivault)")
+}
+
+func TestSetDeploymentSecretsManagerState(t *testing.T) {
+	t.Parallel()
+
+	sm := b64.NewBase64SecretsManager()
+	snap := &deploy.Snapshot{
+		SecretsManager: sm,
+		Resources: []*resource.State{{
+			URN:     resource.NewURN("testStack", "testProject", "", resource.RootStackType, "testStack"),
+			Type:    resource.RootStackType,
+			Outputs: resource.PropertyMap{"foo": resource.MakeSecret(resource.NewStringProperty("bar"))},
+		}},
+	}
+	deployment, err := stack.SerializeDeployment(snap, nil, false /*showSecrets*/)
+	require.NoError(t, err)
+	data, err := json.Marshal(deployment)
+	require.NoError(t, err)
+
+	newState := json.RawMessage(`{"recipients":["a","b"]}`)
+	updated, err := setDeploymentSecretsManagerState(&apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: data,
+	}, &stateSecretsManager{Manager: sm, typ: sm.Type(), state: newState})
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+
+	var v3deployment apitype.DeploymentV3
+	require.NoError(t, json.Unmarshal(updated.Deployment, &v3deployment))
+	assert.JSONEq(t, string(newState), string(v3deployment.SecretsProviders.State))
+	// The secrets themselves are left as they are.
+	assert.Equal(t, deployment.Resources, v3deployment.Resources)
+
+	// Nothing is updated if the deployment uses another type of secrets manager.
+	updated, err = setDeploymentSecretsManagerState(&apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: data,
+	}, &stateSecretsManager{Manager: sm, typ: "other", state: newState})
+	require.NoError(t, err)
+	assert.Nil(t, updated)
+}
+
+// stateSecretsManager is a secrets manager with a given type and state.
+type stateSecretsManager struct {
+	secrets.Manager
+
+	typ   string
+	state json.RawMessage
+}
+
+func (sm *stateSecretsManager) Type() string           { return sm.typ }
+func (sm *stateSecretsManager) State() json.RawMessage { return sm.state }
+
 func mockStdin(t *testing.T, input string) {
 	t.Helper()
 	r, w, err := os.Pipe()
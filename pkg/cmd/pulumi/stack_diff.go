@@ -0,0 +1,468 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+type stackDiffCmd struct {
+	stdout io.Writer
+
+	stackName   string
+	from        stackDeploymentRef
+	to          stackDeploymentRef
+	showSecrets bool
+	jsonOut     bool
+}
+
+func newStackDiffCmd() *cobra.Command {
+	var sdcmd stackDiffCmd
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Args:  cmdutil.NoArgs,
+		Short: "Compare two deployments of a stack",
+		Long: "Compare two deployments of a stack.\n" +
+			"\n" +
+			"This command shows the resources that were added, removed or changed between two\n" +
+			"deployments of a stack, along with the changes to the inputs and outputs of each\n" +
+			"changed resource.\n" +
+			"\n" +
+			"Pass a version from `pulumi stack history` to --from and --to, or the path to a file\n" +
+			"written by `pulumi stack export` to --from-file and --to-file. By default the deployment\n" +
+			"before the most recent update is compared with the current deployment.",
+		Example: "pulumi stack diff --from 3 --to 5\n" +
+			"pulumi stack diff --from-file exported.json",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			return sdcmd.Run(ctx)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&sdcmd.stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringVar(&sdcmd.from.version, "from", "",
+		"The version to compare from. Defaults to the version before the latest")
+	cmd.Flags().StringVar(&sdcmd.from.file, "from-file", "",
+		"A file written by `pulumi stack export` to compare from, instead of a version")
+	cmd.Flags().StringVar(&sdcmd.to.version, "to", "",
+		"The version to compare to. Defaults to the current deployment")
+	cmd.Flags().StringVar(&sdcmd.to.file, "to-file", "",
+		"A file written by `pulumi stack export` to compare to, instead of a version")
+	cmd.Flags().BoolVar(&sdcmd.showSecrets, "show-secrets", false,
+		"Show secret values in plaintext instead of masking them")
+	cmd.Flags().BoolVarP(&sdcmd.jsonOut, "json", "j", false, "Emit output as JSON")
+	return cmd
+}
+
+func (cmd *stackDiffCmd) Run(ctx context.Context) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	if cmd.from.version != "" && cmd.from.file != "" {
+		return errors.New("only one of --from and --from-file may be set")
+	}
+	if cmd.to.version != "" && cmd.to.file != "" {
+		return errors.New("only one of --to and --to-file may be set")
+	}
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	s, err := requireStack(ctx, cmd.stackName, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+
+	from := cmd.from
+	if from.isCurrent() {
+		// Default to the version before the latest update, so that we show what the latest update changed.
+		updates, err := s.Backend().GetHistory(ctx, s.Ref(), 2, 1)
+		if err != nil {
+			return fmt.Errorf("getting history: %w", err)
+		}
+		if len(updates) < 2 {
+			return errors.New("the stack has no previous version to compare with; pass --from to choose one")
+		}
+		from.version = strconv.Itoa(updates[1].Version)
+	}
+
+	fromSnap, err := loadStackDeployment(ctx, s, from)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", from, err)
+	}
+	toSnap, err := loadStackDeployment(ctx, s, cmd.to)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", cmd.to, err)
+	}
+
+	if cmd.showSecrets {
+		revealSnapshotSecrets(fromSnap)
+		revealSnapshotSecrets(toSnap)
+		log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi stack diff")
+	}
+
+	changes := diffSnapshots(fromSnap, toSnap)
+	if cmd.jsonOut {
+		return fprintJSON(stdout, makeStackDiffJSON(from, cmd.to, changes))
+	}
+
+	fmt.Fprint(stdout, opts.Color.Colorize(renderStackDiff(from, cmd.to, changes)))
+	return nil
+}
+
+// stackDeploymentRef identifies a deployment of a stack: a version from the stack's history, a file written by
+// `pulumi stack export`, or the stack's current deployment if neither is set.
+type stackDeploymentRef struct {
+	version string
+	file    string
+}
+
+func (ref stackDeploymentRef) isCurrent() bool {
+	return ref.version == "" && ref.file == ""
+}
+
+// String describes the deployment, for use in output.
+func (ref stackDeploymentRef) String() string {
+	switch {
+	case ref.file != "":
+		return ref.file
+	case ref.version != "":
+		return "version " + ref.version
+	default:
+		return "the current deployment"
+	}
+}
+
+// loadStackDeployment loads the given deployment of a stack. A stack that has never been deployed has an empty
+// snapshot.
+func loadStackDeployment(ctx context.Context, s backend.Stack, ref stackDeploymentRef) (*deploy.Snapshot, error) {
+	var deployment *apitype.UntypedDeployment
+	switch {
+	case ref.isCurrent():
+		snap, err := s.Snapshot(ctx, stack.DefaultSecretsProvider)
+		if err != nil || snap != nil {
+			return snap, err
+		}
+		return &deploy.Snapshot{}, nil
+	case ref.file != "":
+		f, err := os.ReadFile(ref.file)
+		if err != nil {
+			return nil, err
+		}
+		deployment = &apitype.UntypedDeployment{}
+		if err = json.Unmarshal(f, deployment); err != nil {
+			return nil, fmt.Errorf("could not read deployment: %w", err)
+		}
+	default:
+		return loadStackVersion(ctx, s, ref.version)
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(ctx, deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, checkDeploymentVersionError(err, s.Ref().Name().String())
+	}
+	return snap, nil
+}
+
+// loadStackVersion loads the deployment saved with the given version of the stack's history.
+func loadStackVersion(ctx context.Context, s backend.Stack, version string) (*deploy.Snapshot, error) {
+	be := s.Backend()
+	exporter, ok := be.(backend.SpecificDeploymentExporter)
+	if !ok {
+		return nil, fmt.Errorf("the current backend (%s) does not provide the ability to export previous deployments",
+			be.Name())
+	}
+	deployment, err := exporter.ExportDeploymentForVersion(ctx, s, version)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(ctx, deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, checkDeploymentVersionError(err, s.Ref().Name().String())
+	}
+	return snap, nil
+}
+
+// revealSnapshotSecrets replaces the secrets in the inputs and outputs of every resource with their plaintext values,
+// so that they are displayed rather than masked.
+func revealSnapshotSecrets(snap *deploy.Snapshot) {
+	for _, res := range snap.Resources {
+		res.Inputs = revealSecrets(resource.NewObjectProperty(res.Inputs)).ObjectValue()
+		res.Outputs = revealSecrets(resource.NewObjectProperty(res.Outputs)).ObjectValue()
+	}
+}
+
+func revealSecrets(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsSecret():
+		return revealSecrets(v.SecretValue().Element)
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			arr[i] = revealSecrets(e)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		obj := make(resource.PropertyMap, len(v.ObjectValue()))
+		for k, e := range v.ObjectValue() {
+			obj[k] = revealSecrets(e)
+		}
+		return resource.NewObjectProperty(obj)
+	default:
+		return v
+	}
+}
+
+// stackDiffKind is how a resource differs between two deployments.
+type stackDiffKind string
+
+const (
+	stackDiffAdded   stackDiffKind = "added"
+	stackDiffRemoved stackDiffKind = "removed"
+	stackDiffChanged stackDiffKind = "changed"
+)
+
+// stackResourceDiff is a resource that differs between two deployments.
+type stackResourceDiff struct {
+	URN  resource.URN
+	Kind stackDiffKind
+	Old  *resource.State // nil if the resource was added.
+	New  *resource.State // nil if the resource was removed.
+
+	Inputs  *resource.ObjectDiff // changes to the inputs of a changed resource, if any.
+	Outputs *resource.ObjectDiff // changes to the outputs of a changed resource, if any.
+}
+
+// diffSnapshots returns the resources that differ between two snapshots. Added and changed resources are returned in
+// the order of the new snapshot, followed by removed resources in the order of the old snapshot. Resources that are
+// pending deletion are ignored.
+func diffSnapshots(from, to *deploy.Snapshot) []stackResourceDiff {
+	olds := make(map[resource.URN]*resource.State)
+	for _, res := range from.Resources {
+		if !res.Delete {
+			olds[res.URN] = res
+		}
+	}
+	news := make(map[resource.URN]*resource.State)
+	for _, res := range to.Resources {
+		if !res.Delete {
+			news[res.URN] = res
+		}
+	}
+
+	var changes []stackResourceDiff
+	for _, res := range to.Resources {
+		if res.Delete {
+			continue
+		}
+		old, has := olds[res.URN]
+		if !has {
+			changes = append(changes, stackResourceDiff{URN: res.URN, Kind: stackDiffAdded, New: res})
+			continue
+		}
+
+		inputs := old.Inputs.Diff(res.Inputs, resource.IsInternalPropertyKey)
+		outputs := old.Outputs.Diff(res.Outputs, resource.IsInternalPropertyKey)
+		if inputs != nil || outputs != nil || old.ID != res.ID {
+			changes = append(changes, stackResourceDiff{
+				URN:     res.URN,
+				Kind:    stackDiffChanged,
+				Old:     old,
+				New:     res,
+				Inputs:  inputs,
+				Outputs: outputs,
+			})
+		}
+	}
+	for _, res := range from.Resources {
+		if _, has := news[res.URN]; !has && !res.Delete {
+			changes = append(changes, stackResourceDiff{URN: res.URN, Kind: stackDiffRemoved, Old: res})
+		}
+	}
+	return changes
+}
+
+// renderStackDiff renders the differences between two deployments for display, using the same property diff format
+// as previews.
+func renderStackDiff(from, to stackDeploymentRef, changes []stackResourceDiff) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Comparing %s with %s\n\n", from, to)
+	if len(changes) == 0 {
+		fmt.Fprintf(&b, "No differences\n")
+		return b.String()
+	}
+
+	counts := make(map[stackDiffKind]int)
+	for _, change := range changes {
+		counts[change.Kind]++
+
+		op := deploy.OpUpdate
+		switch change.Kind {
+		case stackDiffAdded:
+			op = deploy.OpCreate
+		case stackDiffRemoved:
+			op = deploy.OpDelete
+		}
+		fmt.Fprintf(&b, "%s%s%s\n", deploy.Prefix(op, true /*done*/), change.URN, colors.Reset)
+
+		if change.Kind != stackDiffChanged {
+			continue
+		}
+		if change.Old.ID != change.New.ID {
+			fmt.Fprintf(&b, "%s    ~ id: %q => %q%s\n", deploy.Color(op), change.Old.ID, change.New.ID, colors.Reset)
+		}
+		if change.Inputs != nil {
+			fmt.Fprintf(&b, "    inputs:\n")
+			display.PrintObjectDiff(&b, *change.Inputs, nil, false /*planning*/, 2, /*indent*/
+				false /*summary*/, false /*truncateOutput*/, false /*debug*/)
+		}
+		if change.Outputs != nil {
+			fmt.Fprintf(&b, "    outputs:\n")
+			display.PrintObjectDiff(&b, *change.Outputs, nil, false /*planning*/, 2, /*indent*/
+				false /*summary*/, false /*truncateOutput*/, false /*debug*/)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nResources:\n")
+	for _, summary := range []struct {
+		kind stackDiffKind
+		op   string
+	}{
+		{stackDiffAdded, deploy.Prefix(deploy.OpCreate, true /*done*/)},
+		{stackDiffChanged, deploy.Prefix(deploy.OpUpdate, true /*done*/)},
+		{stackDiffRemoved, deploy.Prefix(deploy.OpDelete, true /*done*/)},
+	} {
+		if n := counts[summary.kind]; n > 0 {
+			fmt.Fprintf(&b, "    %s%d %s%s\n", summary.op, n, summary.kind, colors.Reset)
+		}
+	}
+	return b.String()
+}
+
+// stackDiffJSON is the JSON form of the differences between two deployments.
+type stackDiffJSON struct {
+	From      string                  `json:"from,omitempty"`
+	FromFile  string                  `json:"fromFile,omitempty"`
+	To        string                  `json:"to,omitempty"`
+	ToFile    string                  `json:"toFile,omitempty"`
+	Summary   map[stackDiffKind]int   `json:"summary"`
+	Resources []stackDiffResourceJSON `json:"resources"`
+}
+
+type stackDiffResourceJSON struct {
+	URN     resource.URN            `json:"urn"`
+	Type    string                  `json:"type"`
+	Kind    stackDiffKind           `json:"kind"`
+	OldID   resource.ID             `json:"oldID,omitempty"`
+	NewID   resource.ID             `json:"newID,omitempty"`
+	Inputs  []stackDiffPropertyJSON `json:"inputs,omitempty"`
+	Outputs []stackDiffPropertyJSON `json:"outputs,omitempty"`
+}
+
+// stackDiffPropertyJSON is a top-level property that differs between two deployments of a resource.
+type stackDiffPropertyJSON struct {
+	Key  resource.PropertyKey `json:"key"`
+	Kind stackDiffKind        `json:"kind"`
+	Old  interface{}          `json:"old,omitempty"`
+	New  interface{}          `json:"new,omitempty"`
+}
+
+func makeStackDiffJSON(from, to stackDeploymentRef, changes []stackResourceDiff) stackDiffJSON {
+	result := stackDiffJSON{
+		From:      from.version,
+		FromFile:  from.file,
+		To:        to.version,
+		ToFile:    to.file,
+		Summary:   make(map[stackDiffKind]int),
+		Resources: []stackDiffResourceJSON{},
+	}
+	for _, change := range changes {
+		result.Summary[change.Kind]++
+
+		res := stackDiffResourceJSON{
+			URN:  change.URN,
+			Type: string(change.URN.Type()),
+			Kind: change.Kind,
+		}
+		if change.Old != nil {
+			res.OldID = change.Old.ID
+		}
+		if change.New != nil {
+			res.NewID = change.New.ID
+		}
+		res.Inputs = makeStackDiffPropertiesJSON(change.Inputs)
+		res.Outputs = makeStackDiffPropertiesJSON(change.Outputs)
+		result.Resources = append(result.Resources, res)
+	}
+	return result
+}
+
+func makeStackDiffPropertiesJSON(diff *resource.ObjectDiff) []stackDiffPropertyJSON {
+	if diff == nil {
+		return nil
+	}
+
+	// Secrets that haven't been revealed with --show-secrets are masked.
+	mappable := func(v resource.PropertyValue) interface{} {
+		return v.MapRepl(nil, func(v resource.PropertyValue) (interface{}, bool) {
+			if v.IsSecret() {
+				return "[secret]", true
+			}
+			return nil, false
+		})
+	}
+
+	var props []stackDiffPropertyJSON
+	for _, k := range diff.Keys() {
+		switch {
+		case diff.Added(k):
+			props = append(props, stackDiffPropertyJSON{Key: k, Kind: stackDiffAdded, New: mappable(diff.Adds[k])})
+		case diff.Deleted(k):
+			props = append(props, stackDiffPropertyJSON{Key: k, Kind: stackDiffRemoved, Old: mappable(diff.Deletes[k])})
+		case diff.Updated(k):
+			update := diff.Updates[k]
+			props = append(props, stackDiffPropertyJSON{
+				Key:  k,
+				Kind: stackDiffChanged,
+				Old:  mappable(update.Old),
+				New:  mappable(update.New),
+			})
+		}
+	}
+	return props
+}
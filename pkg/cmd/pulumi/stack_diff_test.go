@@ -0,0 +1,102 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	makeSnapshots := func() (*deploy.Snapshot, *deploy.Snapshot) {
+		from := &deploy.Snapshot{Resources: []*resource.State{
+			{URN: "urn:pulumi:dev::proj::pkg:m:T::changed", ID: "1", Inputs: resource.PropertyMap{
+				"plain":  resource.NewStringProperty("a"),
+				"secret": resource.MakeSecret(resource.NewStringProperty("old")),
+			}},
+			{URN: "urn:pulumi:dev::proj::pkg:m:T::same", ID: "2"},
+			{URN: "urn:pulumi:dev::proj::pkg:m:T::removed", ID: "3"},
+		}}
+		to := &deploy.Snapshot{Resources: []*resource.State{
+			{URN: "urn:pulumi:dev::proj::pkg:m:T::changed", ID: "1", Inputs: resource.PropertyMap{
+				"plain":  resource.NewStringProperty("b"),
+				"secret": resource.MakeSecret(resource.NewStringProperty("new")),
+			}},
+			{URN: "urn:pulumi:dev::proj::pkg:m:T::same", ID: "2"},
+			{URN: "urn:pulumi:dev::proj::pkg:m:T::added", ID: "4"},
+		}}
+		return from, to
+	}
+
+	t.Run("masked", func(t *testing.T) {
+		t.Parallel()
+
+		from, to := makeSnapshots()
+		changes := diffSnapshots(from, to)
+		require.Len(t, changes, 3)
+		assert.Equal(t, stackDiffChanged, changes[0].Kind)
+		assert.Equal(t, resource.URN("urn:pulumi:dev::proj::pkg:m:T::added"), changes[1].URN)
+		assert.Equal(t, stackDiffAdded, changes[1].Kind)
+		assert.Equal(t, resource.URN("urn:pulumi:dev::proj::pkg:m:T::removed"), changes[2].URN)
+		assert.Equal(t, stackDiffRemoved, changes[2].Kind)
+
+		result := makeStackDiffJSON(stackDeploymentRef{version: "1"}, stackDeploymentRef{}, changes)
+		assert.Equal(t, map[stackDiffKind]int{"added": 1, "changed": 1, "removed": 1}, result.Summary)
+		assert.Equal(t, []stackDiffPropertyJSON{
+			{Key: "plain", Kind: stackDiffChanged, Old: "a", New: "b"},
+			{Key: "secret", Kind: stackDiffChanged, Old: "[secret]", New: "[secret]"},
+		}, result.Resources[0].Inputs)
+	})
+
+	t.Run("show secrets", func(t *testing.T) {
+		t.Parallel()
+
+		from, to := makeSnapshots()
+		revealSnapshotSecrets(from)
+		revealSnapshotSecrets(to)
+		result := makeStackDiffJSON(stackDeploymentRef{version: "1"}, stackDeploymentRef{}, diffSnapshots(from, to))
+		assert.Equal(t, []stackDiffPropertyJSON{
+			{Key: "plain", Kind: stackDiffChanged, Old: "a", New: "b"},
+			{Key: "secret", Kind: stackDiffChanged, Old: "old", New: "new"},
+		}, result.Resources[0].Inputs)
+	})
+}
+
+func TestStackDiffVersionOrFile(t *testing.T) {
+	t.Parallel()
+
+	cmd := stackDiffCmd{from: stackDeploymentRef{version: "3", file: "3"}}
+	assert.ErrorContains(t, cmd.Run(context.Background()), "only one of --from and --from-file may be set")
+	cmd = stackDiffCmd{to: stackDeploymentRef{version: "3", file: "3"}}
+	assert.ErrorContains(t, cmd.Run(context.Background()), "only one of --to and --to-file may be set")
+
+	assert.Equal(t, "version 3", stackDeploymentRef{version: "3"}.String())
+	assert.Equal(t, "3", stackDeploymentRef{file: "3"}.String())
+	assert.Equal(t, "the current deployment", stackDeploymentRef{}.String())
+
+	result := makeStackDiffJSON(stackDeploymentRef{file: "3"}, stackDeploymentRef{version: "3"}, nil)
+	assert.Equal(t, "", result.From)
+	assert.Equal(t, "3", result.FromFile)
+	assert.Equal(t, "3", result.To)
+	assert.Equal(t, "", result.ToFile)
+}
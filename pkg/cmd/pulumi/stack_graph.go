@@ -15,14 +15,22 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/graph"
 	"github.com/pulumi/pulumi/pkg/v3/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/v3/graph/graphmlconv"
+	"github.com/pulumi/pulumi/pkg/v3/graph/mermaidconv"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	resourcegraph "github.com/pulumi/pulumi/pkg/v3/resource/graph"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -44,6 +52,80 @@ var parentEdgeColor string
 // Whether or not to return resource name as the node label for each node of the graph.
 var shortNodeName bool
 
+// The format to write the graph in.
+var graphFormat = stackGraphFormatDOT
+
+// If set, only the resource with this URN and the resources connected to it in graphDirection are included.
+var graphURN string
+
+// Which resources connected to graphURN are included in the graph.
+var graphDirection = stackGraphDirectionBoth
+
+// Whether or not to leave provider resources out of the graph.
+var ignoreProviders bool
+
+// Whether or not to group the children of each component resource together.
+var groupByParent bool
+
+type stackGraphFormat string
+
+const (
+	stackGraphFormatDOT     stackGraphFormat = "dot"
+	stackGraphFormatMermaid stackGraphFormat = "mermaid"
+	stackGraphFormatJSON    stackGraphFormat = "json"
+	stackGraphFormatGraphML stackGraphFormat = "graphml"
+)
+
+// String is used both by fmt.Print and by Cobra in help text
+func (f *stackGraphFormat) String() string {
+	return string(*f)
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (f *stackGraphFormat) Set(v string) error {
+	switch v {
+	case "dot", "mermaid", "json", "graphml":
+		*f = stackGraphFormat(v)
+		return nil
+	default:
+		return errors.New(`must be one of "dot", "mermaid", "json", or "graphml"`)
+	}
+}
+
+// Type is only used in help text
+func (f *stackGraphFormat) Type() string {
+	return "format"
+}
+
+type stackGraphDirection string
+
+const (
+	stackGraphDirectionDependencies stackGraphDirection = "dependencies"
+	stackGraphDirectionDependents   stackGraphDirection = "dependents"
+	stackGraphDirectionBoth         stackGraphDirection = "both"
+)
+
+// String is used both by fmt.Print and by Cobra in help text
+func (d *stackGraphDirection) String() string {
+	return string(*d)
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (d *stackGraphDirection) Set(v string) error {
+	switch v {
+	case "dependencies", "dependents", "both":
+		*d = stackGraphDirection(v)
+		return nil
+	default:
+		return errors.New(`must be one of "dependencies", "dependents", or "both"`)
+	}
+}
+
+// Type is only used in help text
+func (d *stackGraphDirection) Type() string {
+	return "direction"
+}
+
 func newStackGraphCmd() *cobra.Command {
 	var stackName string
 
@@ -54,8 +136,14 @@ func newStackGraphCmd() *cobra.Command {
 		Long: "Export a stack's dependency graph to a file.\n" +
 			"\n" +
 			"This command can be used to view the dependency graph that a Pulumi program\n" +
-			"emitted when it was run. This graph is output in the DOT format. This command operates\n" +
-			"on your stack's most recent deployment.",
+			"emitted when it was run. This command operates on your stack's most recent deployment.\n" +
+			"\n" +
+			"The graph is output in the DOT format by default. Use --format to output a Mermaid\n" +
+			"flowchart that can be embedded in Markdown, a GraphML document, or JSON listing each\n" +
+			"resource and the kind of each edge between them.\n" +
+			"\n" +
+			"Use --urn to only include a single resource and the resources it depends on or that\n" +
+			"depend on it.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			ctx := commandContext()
 			opts := display.Options{
@@ -76,13 +164,22 @@ func newStackGraphCmd() *cobra.Command {
 				return fmt.Errorf("unable to find snapshot for stack %q", stackName)
 			}
 
-			dg := makeDependencyGraph(snap)
+			resources, err := selectGraphResources(snap, stackGraphFilter{
+				urn:             graphURN,
+				direction:       graphDirection,
+				ignoreProviders: ignoreProviders,
+			})
+			if err != nil {
+				return err
+			}
+
+			dg := makeDependencyGraph(resources)
 			file, err := os.Create(args[0])
 			if err != nil {
 				return err
 			}
 
-			if err := dotconv.Print(dg, file); err != nil {
+			if err := printDependencyGraph(dg, file); err != nil {
 				_ = file.Close()
 				return err
 			}
@@ -104,9 +201,92 @@ func newStackGraphCmd() *cobra.Command {
 		"Sets the color of parent edges in the graph")
 	cmd.PersistentFlags().BoolVar(&shortNodeName, "short-node-name", false,
 		"Sets the resource name as the node label for each node of the graph")
+	cmd.PersistentFlags().Var(&graphFormat, "format",
+		"The format to output the graph in: dot, mermaid, json or graphml")
+	cmd.PersistentFlags().StringVar(&graphURN, "urn", "",
+		"Only include the resource with this URN and the resources connected to it")
+	cmd.PersistentFlags().Var(&graphDirection, "direction",
+		"Which resources connected to --urn to include: dependencies, dependents or both")
+	cmd.PersistentFlags().BoolVar(&ignoreProviders, "ignore-providers", false,
+		"Leaves provider resources out of the graph")
+	cmd.PersistentFlags().BoolVar(&groupByParent, "group-by-parent", false,
+		"Groups the children of each component resource together")
 	return cmd
 }
 
+// printDependencyGraph writes the graph to w in the format given by --format.
+func printDependencyGraph(dg *dependencyGraph, w io.Writer) error {
+	switch graphFormat {
+	case stackGraphFormatMermaid:
+		return mermaidconv.Print(dg, w)
+	case stackGraphFormatGraphML:
+		return graphmlconv.Print(dg, w)
+	case stackGraphFormatJSON:
+		return printJSONGraph(dg, w)
+	default:
+		return dotconv.Print(dg, w)
+	}
+}
+
+// stackGraphFilter selects the resources to include in the graph.
+type stackGraphFilter struct {
+	// If set, only the resource with this URN and the resources connected to it in direction are included.
+	urn       string
+	direction stackGraphDirection
+	// Whether or not to leave provider resources out.
+	ignoreProviders bool
+}
+
+// selectGraphResources returns the resources in the snapshot that should be included in the graph, in snapshot order.
+func selectGraphResources(snap *deploy.Snapshot, filter stackGraphFilter) ([]*resource.State, error) {
+	resources := snap.Resources
+
+	if filter.urn != "" {
+		var target *resource.State
+		for _, res := range resources {
+			if string(res.URN) == filter.urn {
+				target = res
+			}
+		}
+		if target == nil {
+			return nil, fmt.Errorf("no resource with URN %q in the stack", filter.urn)
+		}
+
+		dg := resourcegraph.NewDependencyGraph(resources)
+		selected := resourcegraph.ResourceSet{target: true}
+		if filter.direction != stackGraphDirectionDependents {
+			for res := range dg.TransitiveDependenciesOf(target) {
+				selected[res] = true
+			}
+		}
+		if filter.direction != stackGraphDirectionDependencies {
+			for _, res := range dg.DependingOn(target, nil, true /* includeChildren */) {
+				selected[res] = true
+			}
+		}
+
+		var filtered []*resource.State
+		for _, res := range resources {
+			if selected[res] {
+				filtered = append(filtered, res)
+			}
+		}
+		resources = filtered
+	}
+
+	if filter.ignoreProviders {
+		var filtered []*resource.State
+		for _, res := range resources {
+			if !providers.IsProviderType(res.Type) {
+				filtered = append(filtered, res)
+			}
+		}
+		resources = filtered
+	}
+
+	return resources, nil
+}
+
 // All of the types and code within this file are to provide implementations of the interfaces
 // in the `graph` package, so that we can use the `dotconv` package to output our graph in the
 // DOT format.
@@ -116,15 +296,33 @@ func newStackGraphCmd() *cobra.Command {
 type dependencyEdge struct {
 	to     *dependencyVertex
 	from   *dependencyVertex
+	kind   dependencyEdgeKind
 	labels []string
 }
 
+// dependencyEdgeKind is the relationship between two resources that an edge represents.
+type dependencyEdgeKind string
+
+const (
+	// The resource is a child of the other resource.
+	dependencyEdgeKindParent dependencyEdgeKind = "parent"
+	// The resource depends on the other resource.
+	dependencyEdgeKindDependency dependencyEdgeKind = "dependency"
+	// One or more of the resource's properties depend on the other resource.
+	dependencyEdgeKindProperty dependencyEdgeKind = "propertyDependency"
+	// The resource is deleted along with the other resource.
+	dependencyEdgeKindDeletedWith dependencyEdgeKind = "deletedWith"
+)
+
 // In this simple case, edges have no data.
 func (edge *dependencyEdge) Data() interface{} {
 	return nil
 }
 
 func (edge *dependencyEdge) Label() string {
+	if edge.kind == dependencyEdgeKindDeletedWith {
+		return "deleted with"
+	}
 	return strings.Join(edge.labels, ", ")
 }
 
@@ -201,17 +399,32 @@ func (vertex *dependencyVertex) Outs() []graph.Edge {
 	return vertex.outgoingEdges
 }
 
+// Group returns the vertex of the component resource this resource is a child of, if --group-by-parent was passed.
+// The stack resource is the parent of everything else, so its children aren't grouped.
+func (vertex *dependencyVertex) Group() graph.Vertex {
+	if !groupByParent || vertex.resource.Parent == "" {
+		return nil
+	}
+	parent, ok := vertex.graph.vertices[vertex.resource.Parent]
+	if !ok || parent.resource.Custom || parent.resource.Type == resource.RootStackType {
+		return nil
+	}
+	return parent
+}
+
 // A dependencyGraph is a thin wrapper around a map of URNs to vertices in
 // the graph. It is constructed directly from a snapshot.
 type dependencyGraph struct {
 	vertices map[resource.URN]*dependencyVertex
+	// The vertices in the order of the resources they were built from, so that output is deterministic.
+	order []*dependencyVertex
 }
 
 // Roots are edges that point to the root set of our graph. In our case,
 // for simplicity, we define the root set of our dependency graph to be everything.
 func (dg *dependencyGraph) Roots() []graph.Edge {
 	rootEdges := []graph.Edge{}
-	for _, vertex := range dg.vertices {
+	for _, vertex := range dg.order {
 		edge := &dependencyEdge{
 			to:   vertex,
 			from: nil,
@@ -223,40 +436,74 @@ func (dg *dependencyGraph) Roots() []graph.Edge {
 	return rootEdges
 }
 
-// Makes a dependency graph from a deployment snapshot, allocating a vertex
-// for every resource in the graph.
-func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
+// Makes a dependency graph from the resources of a deployment snapshot, allocating a vertex
+// for every resource in the graph. Edges to resources that aren't in the graph are left out.
+func makeDependencyGraph(resources []*resource.State) *dependencyGraph {
 	dg := &dependencyGraph{
 		vertices: make(map[resource.URN]*dependencyVertex),
 	}
 
-	for _, resource := range snapshot.Resources {
+	for _, resource := range resources {
 		vertex := &dependencyVertex{
 			graph:    dg,
 			resource: resource,
 		}
 
 		dg.vertices[resource.URN] = vertex
+		dg.order = append(dg.order, vertex)
 	}
 
-	for _, vertex := range dg.vertices {
+	// addDependencyEdge adds an edge to vertex from a resource it depends on.
+	addDependencyEdge := func(vertex *dependencyVertex, dep resource.URN, kind dependencyEdgeKind, labels []string) {
+		vertexWeDependOn, ok := dg.vertices[dep]
+		if !ok {
+			return
+		}
+		edge := &dependencyEdge{to: vertex, from: vertexWeDependOn, kind: kind, labels: labels}
+		vertex.incomingEdges = append(vertex.incomingEdges, edge)
+		vertexWeDependOn.outgoingEdges = append(vertexWeDependOn.outgoingEdges, edge)
+	}
+
+	for _, vertex := range dg.order {
 		if !ignoreDependencyEdges {
 			// If we have per-property dependency information, annotate the dependency edges
 			// we generate with the names of the properties associated with each dependency.
 			depBlame := make(map[resource.URN][]string)
+			var propertyDeps []resource.URN
 			for k, deps := range vertex.resource.PropertyDependencies {
 				for _, dep := range deps {
+					if _, has := depBlame[dep]; !has {
+						propertyDeps = append(propertyDeps, dep)
+					}
 					depBlame[dep] = append(depBlame[dep], string(k))
 				}
 			}
+			for _, props := range depBlame {
+				sort.Strings(props)
+			}
+			sort.Slice(propertyDeps, func(i, j int) bool { return propertyDeps[i] < propertyDeps[j] })
 
 			// Incoming edges are directly stored within the checkpoint file; they represent
 			// resources on which this vertex immediately depends upon.
+			seen := make(map[resource.URN]bool)
 			for _, dep := range vertex.resource.Dependencies {
-				vertexWeDependOn := vertex.graph.vertices[dep]
-				edge := &dependencyEdge{to: vertex, from: vertexWeDependOn, labels: depBlame[dep]}
-				vertex.incomingEdges = append(vertex.incomingEdges, edge)
-				vertexWeDependOn.outgoingEdges = append(vertexWeDependOn.outgoingEdges, edge)
+				seen[dep] = true
+				kind := dependencyEdgeKindDependency
+				if len(depBlame[dep]) > 0 {
+					kind = dependencyEdgeKindProperty
+				}
+				addDependencyEdge(vertex, dep, kind, depBlame[dep])
+			}
+			// Property dependencies are normally also listed in the resource's dependencies, but older
+			// checkpoints may not list them there.
+			for _, dep := range propertyDeps {
+				if !seen[dep] {
+					addDependencyEdge(vertex, dep, dependencyEdgeKindProperty, depBlame[dep])
+				}
+			}
+
+			if vertex.resource.DeletedWith != "" {
+				addDependencyEdge(vertex, vertex.resource.DeletedWith, dependencyEdgeKindDeletedWith, nil)
 			}
 		}
 
@@ -265,14 +512,88 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 		// edges.
 		if !ignoreParentEdges {
 			if parent := vertex.resource.Parent; parent != resource.URN("") {
-				parentVertex := dg.vertices[parent]
-				vertex.outgoingEdges = append(vertex.outgoingEdges, &parentEdge{
-					to:   parentVertex,
-					from: vertex,
-				})
+				if parentVertex, ok := dg.vertices[parent]; ok {
+					vertex.outgoingEdges = append(vertex.outgoingEdges, &parentEdge{
+						to:   parentVertex,
+						from: vertex,
+					})
+				}
 			}
 		}
 	}
 
 	return dg
 }
+
+// jsonGraph is the JSON form of a dependency graph.
+type jsonGraph struct {
+	Nodes []jsonGraphNode `json:"nodes"`
+	Edges []jsonGraphEdge `json:"edges"`
+}
+
+type jsonGraphNode struct {
+	URN      resource.URN `json:"urn"`
+	Type     string       `json:"type"`
+	Name     string       `json:"name"`
+	Custom   bool         `json:"custom"`
+	Parent   resource.URN `json:"parent,omitempty"`
+	Provider string       `json:"provider,omitempty"`
+	Protect  bool         `json:"protect"`
+	Delete   bool         `json:"delete,omitempty"`
+}
+
+// jsonGraphEdge is a relationship between two resources. From is the resource that has the relationship, for example
+// the child or the dependent resource, and To is the resource it refers to, such as the parent or the dependency.
+type jsonGraphEdge struct {
+	From       resource.URN       `json:"from"`
+	To         resource.URN       `json:"to"`
+	Kind       dependencyEdgeKind `json:"kind"`
+	Properties []string           `json:"properties,omitempty"`
+}
+
+// printJSONGraph writes the graph as JSON listing each resource and each edge between resources.
+func printJSONGraph(dg *dependencyGraph, w io.Writer) error {
+	result := jsonGraph{
+		Nodes: []jsonGraphNode{},
+		Edges: []jsonGraphEdge{},
+	}
+	for _, vertex := range dg.order {
+		res := vertex.resource
+		result.Nodes = append(result.Nodes, jsonGraphNode{
+			URN:      res.URN,
+			Type:     string(res.Type),
+			Name:     string(res.URN.Name()),
+			Custom:   res.Custom,
+			Parent:   res.Parent,
+			Provider: res.Provider,
+			Protect:  res.Protect,
+			Delete:   res.Delete,
+		})
+	}
+	for _, vertex := range dg.order {
+		// Dependency edges point from a dependency to its dependents, so listing each vertex's incoming edges
+		// lists every dependency edge once, in the order of the dependent resources.
+		for _, in := range vertex.incomingEdges {
+			edge := in.(*dependencyEdge)
+			result.Edges = append(result.Edges, jsonGraphEdge{
+				From:       edge.to.resource.URN,
+				To:         edge.from.resource.URN,
+				Kind:       edge.kind,
+				Properties: edge.labels,
+			})
+		}
+		for _, out := range vertex.outgoingEdges {
+			if edge, ok := out.(*parentEdge); ok {
+				result.Edges = append(result.Edges, jsonGraphEdge{
+					From: edge.from.resource.URN,
+					To:   edge.to.resource.URN,
+					Kind: dependencyEdgeKindParent,
+				})
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
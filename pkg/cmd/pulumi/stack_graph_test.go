@@ -0,0 +1,167 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+const (
+	graphTestProvider  = resource.URN("urn:pulumi:dev::proj::pulumi:providers:pkg::default")
+	graphTestA         = resource.URN("urn:pulumi:dev::proj::pkg:m:T::a")
+	graphTestB         = resource.URN("urn:pulumi:dev::proj::pkg:m:T::b")
+	graphTestC         = resource.URN("urn:pulumi:dev::proj::pkg:m:T::c")
+	graphTestComponent = resource.URN("urn:pulumi:dev::proj::my:C::comp")
+	graphTestChild     = resource.URN("urn:pulumi:dev::proj::my:C$pkg:m:T::child")
+)
+
+// newGraphTestSnapshot returns a snapshot in which c depends on b, which depends on a, which uses the default
+// provider. A component and its child are unrelated to the others.
+func newGraphTestSnapshot() *deploy.Snapshot {
+	return &deploy.Snapshot{Resources: []*resource.State{
+		{URN: graphTestProvider, Type: "pulumi:providers:pkg", Custom: true, ID: "p"},
+		{URN: graphTestA, Type: "pkg:m:T", Custom: true, Provider: string(graphTestProvider) + "::p"},
+		{
+			URN: graphTestB, Type: "pkg:m:T", Custom: true,
+			Dependencies:         []resource.URN{graphTestA},
+			PropertyDependencies: map[resource.PropertyKey][]resource.URN{"input": {graphTestA}},
+		},
+		{URN: graphTestC, Type: "pkg:m:T", Custom: true, Dependencies: []resource.URN{graphTestB}, Protect: true},
+		{URN: graphTestComponent, Type: "my:C"},
+		{URN: graphTestChild, Type: "pkg:m:T", Custom: true, Parent: graphTestComponent},
+	}}
+}
+
+func TestSelectGraphResources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter stackGraphFilter
+		want   []resource.URN
+	}{
+		{
+			name: "everything",
+			want: []resource.URN{
+				graphTestProvider, graphTestA, graphTestB, graphTestC, graphTestComponent, graphTestChild,
+			},
+		},
+		{
+			name:   "ignore providers",
+			filter: stackGraphFilter{ignoreProviders: true},
+			want:   []resource.URN{graphTestA, graphTestB, graphTestC, graphTestComponent, graphTestChild},
+		},
+		{
+			name:   "urn dependencies",
+			filter: stackGraphFilter{urn: string(graphTestB), direction: stackGraphDirectionDependencies},
+			want:   []resource.URN{graphTestProvider, graphTestA, graphTestB},
+		},
+		{
+			name:   "urn dependents",
+			filter: stackGraphFilter{urn: string(graphTestB), direction: stackGraphDirectionDependents},
+			want:   []resource.URN{graphTestB, graphTestC},
+		},
+		{
+			name:   "urn both",
+			filter: stackGraphFilter{urn: string(graphTestB), direction: stackGraphDirectionBoth},
+			want:   []resource.URN{graphTestProvider, graphTestA, graphTestB, graphTestC},
+		},
+		{
+			name:   "urn dependents include children",
+			filter: stackGraphFilter{urn: string(graphTestComponent), direction: stackGraphDirectionDependents},
+			want:   []resource.URN{graphTestComponent, graphTestChild},
+		},
+		{
+			name: "urn and ignore providers",
+			filter: stackGraphFilter{
+				urn: string(graphTestB), direction: stackGraphDirectionBoth, ignoreProviders: true,
+			},
+			want: []resource.URN{graphTestA, graphTestB, graphTestC},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resources, err := selectGraphResources(newGraphTestSnapshot(), tt.filter)
+			require.NoError(t, err)
+			var urns []resource.URN
+			for _, res := range resources {
+				urns = append(urns, res.URN)
+			}
+			assert.Equal(t, tt.want, urns)
+		})
+	}
+
+	t.Run("unknown urn", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := selectGraphResources(newGraphTestSnapshot(), stackGraphFilter{
+			urn: "urn:pulumi:dev::proj::pkg:m:T::missing", direction: stackGraphDirectionBoth,
+		})
+		assert.ErrorContains(t, err, `no resource with URN "urn:pulumi:dev::proj::pkg:m:T::missing" in the stack`)
+	})
+}
+
+func TestPrintJSONGraph(t *testing.T) {
+	t.Parallel()
+
+	resources, err := selectGraphResources(newGraphTestSnapshot(), stackGraphFilter{ignoreProviders: true})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, printJSONGraph(makeDependencyGraph(resources), &buf))
+
+	var result jsonGraph
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result.Nodes, 5)
+	assert.Equal(t, jsonGraphNode{
+		URN:      graphTestA,
+		Type:     "pkg:m:T",
+		Name:     "a",
+		Custom:   true,
+		Provider: string(graphTestProvider) + "::p",
+	}, result.Nodes[0])
+	assert.True(t, result.Nodes[2].Protect)
+	assert.Equal(t, jsonGraphNode{URN: graphTestChild, Type: "pkg:m:T", Name: "child", Custom: true,
+		Parent: graphTestComponent}, result.Nodes[4])
+
+	// Edges to resources that were filtered out, such as the provider, are left out.
+	assert.Equal(t, []jsonGraphEdge{
+		{From: graphTestB, To: graphTestA, Kind: dependencyEdgeKindProperty, Properties: []string{"input"}},
+		{From: graphTestC, To: graphTestB, Kind: dependencyEdgeKindDependency},
+		{From: graphTestChild, To: graphTestComponent, Kind: dependencyEdgeKindParent},
+	}, result.Edges)
+
+	// The field names are part of the output format.
+	var raw map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	assert.Equal(t, map[string]interface{}{
+		"from": string(graphTestB), "to": string(graphTestA), "kind": "propertyDependency",
+		"properties": []interface{}{"input"},
+	}, raw["edges"][0])
+	assert.Equal(t, map[string]interface{}{
+		"urn": string(graphTestC), "type": "pkg:m:T", "name": "c", "custom": true, "protect": true,
+	}, raw["nodes"][2])
+}
@@ -0,0 +1,124 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+type stackHistoryPruneCmd struct {
+	stdout io.Writer
+
+	stackName string
+	keep      int
+	maxAge    time.Duration
+	yes       bool
+}
+
+func newStackHistoryPruneCmd(stackName *string) *cobra.Command {
+	var shpcmd stackHistoryPruneCmd
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Delete old entries from a stack's history",
+		Long: "Delete old entries from a stack's history.\n" +
+			"\n" +
+			"This command applies the retention policy of a stack in a self-managed backend, deleting\n" +
+			"the update history, checkpoint backups and retained checkpoints that fall outside of it.\n" +
+			"The policy is also applied automatically after every update.\n" +
+			"\n" +
+			"The policy is read from the `backend.retention` section of Pulumi.yaml or, if that isn't set,\n" +
+			"the `retention` section of the backend's .pulumi/meta.yaml file:\n" +
+			"\n" +
+			"    retention:\n" +
+			"      keep: 50       # keep the 50 most recent entries\n" +
+			"      maxAge: 720h   # keep entries newer than 30 days\n" +
+			"\n" +
+			"An entry is kept if any of the configured limits keeps it. Use --keep and --max-age to\n" +
+			"apply a different policy once. Versions in the stack's history are renumbered after pruning.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			shpcmd.stackName = *stackName
+			shpcmd.yes = shpcmd.yes || skipConfirmations()
+			return shpcmd.Run(ctx)
+		}),
+	}
+
+	cmd.Flags().IntVar(&shpcmd.keep, "keep", 0,
+		"Keep this many of the most recent entries, instead of the configured policy")
+	cmd.Flags().DurationVar(&shpcmd.maxAge, "max-age", 0,
+		"Keep entries newer than this duration (e.g. 720h), instead of the configured policy")
+	cmd.Flags().BoolVarP(&shpcmd.yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+func (cmd *stackHistoryPruneCmd) Run(ctx context.Context) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	if cmd.keep < 0 {
+		return errors.New("--keep must not be negative")
+	}
+	if cmd.maxAge < 0 {
+		return errors.New("--max-age must not be negative")
+	}
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	s, err := requireStack(ctx, cmd.stackName, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+	b, ok := s.Backend().(filestate.Backend)
+	if !ok {
+		return errors.New("pruning stack history is only supported by self-managed backends")
+	}
+
+	var pruneOpts filestate.PruneOptions
+	if cmd.keep != 0 || cmd.maxAge != 0 {
+		pruneOpts.Policy = &filestate.RetentionPolicy{
+			Keep:   cmd.keep,
+			MaxAge: cmd.maxAge,
+		}
+	}
+
+	prompt := fmt.Sprintf("This will permanently delete old history entries and backups of the '%s' stack!", s.Ref())
+	if !cmd.yes && !confirmPrompt(prompt, s.Ref().String(), opts) {
+		return result.FprintBailf(stdout, "confirmation declined")
+	}
+
+	pruned, err := b.PruneHistory(ctx, s.Ref(), &pruneOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Pruned %d entries from the history of stack %s\n", pruned, s.Ref())
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	surveycore "github.com/AlecAivazis/survey/v2/core"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+type stackHistoryRestoreCmd struct {
+	stdout io.Writer
+
+	stackName string
+	yes       bool
+}
+
+func newStackHistoryRestoreCmd(stackName *string) *cobra.Command {
+	var shrcmd stackHistoryRestoreCmd
+	cmd := &cobra.Command{
+		Use:   "restore <version>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Restore a stack's state to a previous version",
+		Long: "Restore a stack's state to a previous version.\n" +
+			"\n" +
+			"This command makes the deployment saved with a version from `pulumi stack history` the\n" +
+			"stack's current deployment. It only changes the stack's state, not any cloud resources;\n" +
+			"run `pulumi refresh` or `pulumi up` afterwards to reconcile the two.\n" +
+			"\n" +
+			"The changes to the state are shown before they are made, and the restore is recorded\n" +
+			"as a new update in the stack's history.",
+		Example: "pulumi stack history restore 3",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			shrcmd.stackName = *stackName
+			shrcmd.yes = shrcmd.yes || skipConfirmations()
+			return shrcmd.Run(ctx, args[0])
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&shrcmd.yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+func (cmd *stackHistoryRestoreCmd) Run(ctx context.Context, version string) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	s, err := requireStack(ctx, cmd.stackName, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+
+	// Versions are only ever looked up in the stack's history, even if a file of the same name exists.
+	restored, err := loadStackVersion(ctx, s, version)
+	if err != nil {
+		return fmt.Errorf("loading version %s: %w", version, err)
+	}
+	if err := restored.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("version %s has an invalid state: %w", version, err)
+	}
+	current, err := loadStackDeployment(ctx, s, stackDeploymentRef{})
+	if err != nil {
+		return fmt.Errorf("loading the current deployment: %w", err)
+	}
+
+	changes := diffSnapshots(current, restored)
+	diff := renderStackDiff(stackDeploymentRef{}, stackDeploymentRef{version: version}, changes)
+	fmt.Fprint(stdout, opts.Color.Colorize(diff))
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if !cmd.yes && cmdutil.Interactive() {
+		confirm := false
+		surveycore.DisableColor = true
+		prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
+		prompt += fmt.Sprintf("This will replace the state of stack %s with version %s. Confirm?", s.Ref(), version)
+		if err = survey.AskOne(&survey.Confirm{
+			Message: prompt,
+		}, &confirm, surveyIcons(opts.Color)); err != nil || !confirm {
+			return result.FprintBailf(stdout, "confirmation declined")
+		}
+	}
+
+	if err := s.Backend().RestoreDeployment(ctx, s, version); err != nil {
+		return fmt.Errorf("restoring version %s: %w", version, err)
+	}
+
+	fmt.Fprintf(stdout, "Restored stack %s to version %s\n", s.Ref(), version)
+	return nil
+}
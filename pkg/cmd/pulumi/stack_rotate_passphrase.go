@@ -0,0 +1,311 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+type stackRotatePassphraseCmd struct {
+	stdout io.Writer
+
+	stack             string
+	oldPassphraseFile string
+	newPassphraseFile string
+}
+
+func newStackRotatePassphraseCmd() *cobra.Command {
+	var srpcmd stackRotatePassphraseCmd
+	cmd := &cobra.Command{
+		Use:   "rotate-passphrase",
+		Args:  cmdutil.NoArgs,
+		Short: "Change the passphrase of a stack that uses the passphrase secrets provider",
+		Long: "Change the passphrase of a stack that uses the passphrase secrets provider.\n" +
+			"\n" +
+			"The secrets in the stack's config and state are encrypted again with a key derived from\n" +
+			"the new passphrase, and a new encryption salt is written to the stack's config file. Before\n" +
+			"anything is saved, every secret is checked to decrypt to its original value with the new key.\n" +
+			"\n" +
+			"The old passphrase is read from --old-passphrase-file, PULUMI_CONFIG_PASSPHRASE or the file\n" +
+			"named by PULUMI_CONFIG_PASSPHRASE_FILE. The new passphrase is read from --new-passphrase-file,\n" +
+			"PULUMI_NEW_CONFIG_PASSPHRASE or the file named by PULUMI_NEW_CONFIG_PASSPHRASE_FILE.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			return srpcmd.Run(ctx)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&srpcmd.stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringVar(
+		&srpcmd.oldPassphraseFile, "old-passphrase-file", "",
+		"A file containing the current passphrase of the stack")
+	cmd.Flags().StringVar(
+		&srpcmd.newPassphraseFile, "new-passphrase-file", "",
+		"A file containing the new passphrase for the stack")
+
+	return cmd
+}
+
+func (cmd *stackRotatePassphraseCmd) Run(ctx context.Context) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	project, _, err := readProject()
+	if err != nil {
+		return err
+	}
+
+	s, err := requireStack(ctx, cmd.stack, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+	ps, err := loadProjectStack(project, s)
+	if err != nil {
+		return err
+	}
+	if (ps.SecretsProvider != "" && ps.SecretsProvider != passphrase.Type) || ps.EncryptionSalt == "" {
+		return fmt.Errorf("stack %s does not use the passphrase secrets provider", s.Ref())
+	}
+
+	oldPhrase, err := readRotationPassphrase(
+		"old", cmd.oldPassphraseFile, "PULUMI_CONFIG_PASSPHRASE", "PULUMI_CONFIG_PASSPHRASE_FILE")
+	if err != nil {
+		return err
+	}
+	newPhrase, err := readRotationPassphrase(
+		"new", cmd.newPassphraseFile, "PULUMI_NEW_CONFIG_PASSPHRASE", "PULUMI_NEW_CONFIG_PASSPHRASE_FILE")
+	if err != nil {
+		return err
+	}
+
+	deployment, err := s.ExportDeployment(ctx)
+	if err != nil {
+		return err
+	}
+
+	rotated, err := rotatePassphraseSecrets(ctx, ps.Config, deployment, ps.EncryptionSalt, oldPhrase, newPhrase)
+	switch {
+	case errors.Is(err, stack.ErrDeploymentSchemaVersionTooOld), errors.Is(err, stack.ErrDeploymentSchemaVersionTooNew):
+		return checkDeploymentVersionError(err, s.Ref().Name().String())
+	case err != nil:
+		return err
+	}
+
+	// Everything has been verified, so commit the rotation: first the state, then the config. If the config can't be
+	// saved, put back the original state, so that the state and the config keep using the same key.
+	if err = s.ImportDeployment(ctx, rotated.deployment); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+	ps.EncryptionSalt = rotated.state
+	for key, val := range rotated.config {
+		if err = ps.Config.Set(key, val, false /*path*/); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = saveProjectStack(s, ps)
+	}
+	if err != nil {
+		if restoreErr := s.ImportDeployment(ctx, deployment); restoreErr != nil {
+			return fmt.Errorf("saving config: %w; restoring the original state also failed: %v", err, restoreErr)
+		}
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "Rotated the passphrase of stack %s\n", s.Ref())
+	return nil
+}
+
+// readRotationPassphrase reads the old or new passphrase for a rotation, from the given file if there is one, or else
+// from the given environment variables.
+func readRotationPassphrase(which, file, phraseVar, fileVar string) (string, error) {
+	if file != "" {
+		phrase, err := passphrase.ReadPassphraseFile(file)
+		if err != nil {
+			return "", fmt.Errorf("reading %s passphrase: %w", which, err)
+		}
+		return phrase, nil
+	}
+
+	phrase, ok, err := passphrase.LookupPassphraseEnv(phraseVar, fileVar)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("the %s passphrase must be set with --%s-passphrase-file, %s or %s",
+			which, which, phraseVar, fileVar)
+	}
+	return phrase, nil
+}
+
+// rotatedPassphraseSecrets holds the secrets of a stack after its passphrase has been rotated.
+type rotatedPassphraseSecrets struct {
+	state      string                     // the new encryption salt of the stack.
+	config     config.Map                 // the stack's config, encrypted with the new key.
+	deployment *apitype.UntypedDeployment // the stack's state, encrypted with the new key.
+}
+
+// rotatePassphraseSecrets encrypts the secrets of a stack's config and state again with a key derived from a new
+// passphrase. The result is verified by decrypting every secret with a key derived afresh from the new passphrase and
+// comparing it with the original value.
+func rotatePassphraseSecrets(ctx context.Context, cfg config.Map, deployment *apitype.UntypedDeployment,
+	oldState, oldPhrase, newPhrase string,
+) (*rotatedPassphraseSecrets, error) {
+	oldSecretsManager, err := passphrase.GetPassphraseSecretsManager(oldPhrase, oldState)
+	if errors.Is(err, passphrase.ErrIncorrectPassphrase) {
+		return nil, errors.New("the old passphrase is incorrect")
+	} else if err != nil {
+		return nil, err
+	}
+	newState, newSecretsManager, err := passphrase.NewPassphraseSecretsManager(newPhrase)
+	if err != nil {
+		return nil, err
+	}
+
+	oldDecrypter, err := oldSecretsManager.Decrypter()
+	if err != nil {
+		return nil, err
+	}
+	newEncrypter, err := newSecretsManager.Encrypter()
+	if err != nil {
+		return nil, err
+	}
+	newConfig, err := cfg.Copy(oldDecrypter, newEncrypter)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting config: %w", err)
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(ctx, deployment, passphraseSecretsProvider{phrase: oldPhrase})
+	if err != nil {
+		return nil, err
+	}
+	newDeployment, err := stack.SerializeDeployment(snap, newSecretsManager, false /*showSecrets*/)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting state: %w", err)
+	}
+
+	// Check that every secret decrypts to its original value with the new key.
+	verifier, err := passphrase.GetPassphraseSecretsManager(newPhrase, newState)
+	if err != nil {
+		return nil, fmt.Errorf("verifying new passphrase: %w", err)
+	}
+	verifyDecrypter, err := verifier.Decrypter()
+	if err != nil {
+		return nil, err
+	}
+	oldValues, err := cfg.Decrypt(oldDecrypter)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := newConfig.Decrypt(verifyDecrypter)
+	if err != nil {
+		return nil, fmt.Errorf("verifying config: %w", err)
+	}
+	if !reflect.DeepEqual(oldValues, newValues) {
+		return nil, errors.New("verifying config: secrets do not decrypt to their original values")
+	}
+
+	b, err := json.Marshal(newDeployment)
+	if err != nil {
+		return nil, err
+	}
+	untypedDeployment := &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: b,
+	}
+
+	// Verify the state as it will be imported, since secrets are only deserialized from their JSON form.
+	verifySnap, err := stack.DeserializeUntypedDeployment(ctx, untypedDeployment,
+		passphraseSecretsProvider{phrase: newPhrase})
+	if err != nil {
+		return nil, fmt.Errorf("verifying state: %w", err)
+	}
+	if equal, err := snapshotPlaintextsEqual(snap, verifySnap); err != nil {
+		return nil, fmt.Errorf("verifying state: %w", err)
+	} else if !equal {
+		return nil, errors.New("verifying state: secrets do not decrypt to their original values")
+	}
+
+	return &rotatedPassphraseSecrets{
+		state:      newState,
+		config:     newConfig,
+		deployment: untypedDeployment,
+	}, nil
+}
+
+// snapshotPlaintextsEqual returns true if the resources of two snapshots are the same once their secrets are
+// decrypted.
+func snapshotPlaintextsEqual(a, b *deploy.Snapshot) (bool, error) {
+	plaintext := func(snap *deploy.Snapshot) ([]byte, error) {
+		deployment, err := stack.SerializeDeployment(snap, nil, true /*showSecrets*/)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(deployment.Resources)
+	}
+
+	aBytes, err := plaintext(a)
+	if err != nil {
+		return false, err
+	}
+	bBytes, err := plaintext(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(aBytes, bBytes), nil
+}
+
+// passphraseSecretsProvider is a secrets provider that creates passphrase secrets managers with a given passphrase,
+// rather than one from the environment. Other types of secrets managers are created by the default provider.
+type passphraseSecretsProvider struct {
+	phrase string
+}
+
+func (p passphraseSecretsProvider) OfType(ty string, state json.RawMessage) (secrets.Manager, error) {
+	if ty != passphrase.Type {
+		return stack.DefaultSecretsProvider.OfType(ty, state)
+	}
+	sm, err := passphrase.NewPassphraseSecretsManagerFromState(p.phrase, state)
+	if err != nil {
+		return nil, fmt.Errorf("constructing secrets manager of type %q: %w", ty, err)
+	}
+	return sm, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+func TestRotatePassphraseSecrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	oldState, oldSecretsManager, err := passphrase.NewPassphraseSecretsManager("old-passphrase")
+	require.NoError(t, err)
+	oldEncrypter, err := oldSecretsManager.Encrypter()
+	require.NoError(t, err)
+
+	ciphertext, err := oldEncrypter.EncryptValue(ctx, "hunter2")
+	require.NoError(t, err)
+	cfg := config.Map{
+		config.MustMakeKey("testProject", "password"): config.NewSecureValue(ciphertext),
+		config.MustMakeKey("testProject", "region"):   config.NewValue("us-west-2"),
+	}
+
+	snap := &deploy.Snapshot{
+		SecretsManager: oldSecretsManager,
+		Resources: []*resource.State{{
+			URN:  resource.NewURN("testStack", "testProject", "", resource.RootStackType, "testStack"),
+			Type: resource.RootStackType,
+			Outputs: resource.PropertyMap{
+				"foo": resource.MakeSecret(resource.NewStringProperty("bar")),
+			},
+		}},
+	}
+	serialized, err := stack.SerializeDeployment(snap, nil, false /*showSecrets*/)
+	require.NoError(t, err)
+	data, err := json.Marshal(serialized)
+	require.NoError(t, err)
+	deployment := &apitype.UntypedDeployment{Version: apitype.DeploymentSchemaVersionCurrent, Deployment: data}
+
+	// The old passphrase must be correct.
+	_, err = rotatePassphraseSecrets(ctx, cfg, deployment, oldState, "wrong-passphrase", "new-passphrase")
+	assert.ErrorContains(t, err, "the old passphrase is incorrect")
+
+	rotated, err := rotatePassphraseSecrets(ctx, cfg, deployment, oldState, "old-passphrase", "new-passphrase")
+	require.NoError(t, err)
+	assert.NotEqual(t, oldState, rotated.state)
+
+	// The config and state secrets decrypt with the new passphrase, and not the old one.
+	newSecretsManager, err := passphrase.GetPassphraseSecretsManager("new-passphrase", rotated.state)
+	require.NoError(t, err)
+	newDecrypter, err := newSecretsManager.Decrypter()
+	require.NoError(t, err)
+	values, err := rotated.config.Decrypt(newDecrypter)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", values[config.MustMakeKey("testProject", "password")])
+	assert.Equal(t, "us-west-2", values[config.MustMakeKey("testProject", "region")])
+
+	rotatedSnap, err := stack.DeserializeUntypedDeployment(ctx, rotated.deployment,
+		passphraseSecretsProvider{phrase: "new-passphrase"})
+	require.NoError(t, err)
+	assert.Equal(t, "bar", rotatedSnap.Resources[0].Outputs["foo"].SecretValue().Element.StringValue())
+
+	// Passphrase secrets managers are cached by their state, so check that the old key can't decrypt the rotated
+	// secrets with the old secrets manager itself rather than by the old passphrase.
+	oldDecrypter, err := oldSecretsManager.Decrypter()
+	require.NoError(t, err)
+	_, err = rotated.config.Decrypt(oldDecrypter)
+	assert.Error(t, err)
+
+	var rotatedDeployment apitype.DeploymentV3
+	require.NoError(t, json.Unmarshal(rotated.deployment.Deployment, &rotatedDeployment))
+	secret, ok := rotatedDeployment.Resources[0].Outputs["foo"].(map[string]interface{})
+	require.True(t, ok)
+	_, err = oldDecrypter.DecryptValue(ctx, secret["ciphertext"].(string))
+	assert.Error(t, err)
+}
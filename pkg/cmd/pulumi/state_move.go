@@ -0,0 +1,248 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	surveycore "github.com/AlecAivazis/survey/v2/core"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+type stateMoveCmd struct {
+	stdout io.Writer
+
+	source string
+	dest   string
+	force  bool
+	yes    bool
+}
+
+func newStateMoveCommand() *cobra.Command {
+	var smcmd stateMoveCmd
+	cmd := &cobra.Command{
+		Use:   "move [resource URN...]",
+		Short: "Move resources from one stack to another",
+		Long: `Move resources from one stack to another
+
+This command moves resources, along with their children and the providers they use, from the state of one stack to
+the state of another. The URNs of the moved resources are rewritten to belong to the destination stack and project,
+and references to them are updated accordingly. Secrets are encrypted again with the destination stack's secrets
+provider.
+
+Resources can't be moved if resources left behind in the source stack depend on them, or if they depend on resources
+that are not being moved, unless the --force flag is passed. With --force such dependencies are dropped.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.
+`,
+		Example: "pulumi state move --source dev --dest prod 'urn:pulumi:dev::demo::aws:s3/bucket:Bucket::my-bucket'",
+		Args:    cmdutil.MinimumNArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			smcmd.yes = smcmd.yes || skipConfirmations()
+
+			urns := make([]resource.URN, len(args))
+			for i, arg := range args {
+				urns[i] = resource.URN(arg)
+				if !urns[i].IsValid() {
+					return fmt.Errorf("invalid URN %q", arg)
+				}
+			}
+			return smcmd.Run(ctx, urns)
+		}),
+	}
+
+	cmd.Flags().StringVar(&smcmd.source, "source", "",
+		"The name of the stack to move resources from. Defaults to the current stack")
+	cmd.Flags().StringVar(&smcmd.dest, "dest", "", "The name of the stack to move resources to")
+	cmd.Flags().BoolVar(&smcmd.force, "force", false,
+		"Move the resources even if that leaves dependencies between the two stacks, dropping those dependencies")
+	cmd.Flags().BoolVarP(&smcmd.yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+func (cmd *stateMoveCmd) Run(ctx context.Context, urns []resource.URN) error {
+	stdout := cmd.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	if cmd.dest == "" {
+		return errors.New("a destination stack must be given with --dest")
+	}
+
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	sourceStack, err := requireStack(ctx, cmd.source, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+	destStack, err := requireStack(ctx, cmd.dest, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+	if sourceStack.Ref().FullyQualifiedName() == destStack.Ref().FullyQualifiedName() {
+		return errors.New("the source and destination stacks must be different")
+	}
+
+	sourceSnap, err := sourceStack.Snapshot(ctx, stack.DefaultSecretsProvider)
+	if err != nil {
+		return err
+	} else if sourceSnap == nil || len(sourceSnap.Resources) == 0 {
+		return fmt.Errorf("stack %s has no resources to move", sourceStack.Ref())
+	}
+	destSnap, err := destStack.Snapshot(ctx, stack.DefaultSecretsProvider)
+	if err != nil {
+		return err
+	}
+	if destSnap == nil || destSnap.SecretsManager == nil {
+		sm, err := getMoveDestSecretsManager(destStack)
+		if err != nil {
+			return err
+		}
+		if destSnap == nil {
+			destSnap = deploy.NewSnapshot(deploy.Manifest{}, sm, nil, nil)
+		} else {
+			destSnap.SecretsManager = sm
+		}
+	}
+
+	if err := sourceSnap.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("source stack %s has an invalid state: %w", sourceStack.Ref(), err)
+	}
+	if err := destSnap.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("destination stack %s has an invalid state: %w", destStack.Ref(), err)
+	}
+
+	destProject := sourceSnap.Resources[0].URN.Project()
+	if project, ok := destStack.Ref().Project(); ok {
+		destProject = tokens.PackageName(project)
+	}
+
+	moved, err := edit.MoveResources(
+		sourceSnap, destSnap, urns, destStack.Ref().Name(), destProject, cmd.force)
+	if err != nil {
+		var depErr edit.ResourceMoveDependenciesError
+		if errors.As(err, &depErr) {
+			message := "the resources can't be safely moved because of the following dependencies:\n"
+			for _, dep := range depErr.Dependencies {
+				message += fmt.Sprintf(" * %s depends on %s\n", dep.Dependent, dep.Dependency)
+			}
+			message += "\nMove those resources as well or pass --force to drop these dependencies."
+			return errors.New(message)
+		}
+		return err
+	}
+
+	fmt.Fprintf(stdout, "The following resources will be moved from %s to %s:\n", sourceStack.Ref(), destStack.Ref())
+	for _, res := range moved {
+		fmt.Fprintf(stdout, "  - %s\n", res.URN)
+	}
+
+	if !cmd.yes && cmdutil.Interactive() {
+		confirm := false
+		surveycore.DisableColor = true
+		prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
+		prompt += "This command will edit the state of both stacks directly. Confirm?"
+		if err = survey.AskOne(&survey.Confirm{
+			Message: prompt,
+		}, &confirm, surveyIcons(opts.Color)); err != nil || !confirm {
+			return result.FprintBailf(stdout, "confirmation declined")
+		}
+	}
+
+	if err := sourceSnap.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("moving resources would leave the source stack in an invalid state: %w", err)
+	}
+	if err := destSnap.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("moving resources would leave the destination stack in an invalid state: %w", err)
+	}
+
+	// Write the destination first, so that a failure part way through leaves the resources in both stacks rather
+	// than in neither. Each snapshot is serialized with its own stack's secrets manager, so secrets are encrypted
+	// again for the destination.
+	if err := importSnapshot(ctx, destStack, destSnap); err != nil {
+		return fmt.Errorf("writing destination stack %s: %w", destStack.Ref(), err)
+	}
+	if err := importSnapshot(ctx, sourceStack, sourceSnap); err != nil {
+		return fmt.Errorf("writing source stack %s: %w", sourceStack.Ref(), err)
+	}
+
+	fmt.Fprintf(stdout, "Successfully moved %d resources\n", len(moved))
+	return nil
+}
+
+// getMoveDestSecretsManager returns the secrets manager configured for a destination stack that doesn't have one
+// recorded in its state yet.
+func getMoveDestSecretsManager(s backend.Stack) (secrets.Manager, error) {
+	project, _, err := readProject()
+	if err != nil {
+		return nil, err
+	}
+	ps, err := loadProjectStack(project, s)
+	if err != nil {
+		return nil, err
+	}
+	sm, needsSave, err := getStackSecretsManager(s, ps)
+	if err != nil {
+		return nil, err
+	}
+	if needsSave {
+		if err := saveProjectStack(s, ps); err != nil {
+			return nil, err
+		}
+	}
+	return sm, nil
+}
+
+// importSnapshot serializes the given snapshot with its secrets manager and imports it into the given stack.
+func importSnapshot(ctx context.Context, s backend.Stack, snap *deploy.Snapshot) error {
+	sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager, false /* showSecrets */)
+	if err != nil {
+		return fmt.Errorf("serializing deployment: %w", err)
+	}
+
+	bytes, err := json.Marshal(sdep)
+	if err != nil {
+		return err
+	}
+	return s.ImportDeployment(ctx, &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: bytes,
+	})
+}
@@ -65,7 +65,9 @@ func newUpCmd() *cobra.Command {
 	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
+	var summaryFilePath string
 	var parallel int
+	var concurrencyLimits []string
 	var refresh string
 	var showConfig bool
 	var showReplacementSteps bool
@@ -115,8 +117,7 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(fmt.Errorf("getting stack decrypter: %w", err))
 		}
 
-		stackName := s.Ref().Name().String()
-		configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+		configErr := validateStackConfig(s, proj, cfg.Config, decrypter)
 		if configErr != nil {
 			return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 		}
@@ -134,9 +135,19 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(err)
 		}
+		limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+		if err != nil {
+			return result.FromError(err)
+		}
+		retryPolicy, err := backend.GetProjectRetryPolicy(proj)
+		if err != nil {
+			return result.FromError(err)
+		}
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPacks:          engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 			Parallel:                  parallel,
+			ConcurrencyLimits:         limits,
+			RetryPolicy:               retryPolicy,
 			Debug:                     debug,
 			Refresh:                   refreshOption,
 			ReplaceTargets:            deploy.NewUrnTargets(replaceURNs),
@@ -339,8 +350,7 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(fmt.Errorf("getting stack decrypter: %w", err))
 		}
 
-		stackName := s.Ref().String()
-		configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+		configErr := validateStackConfig(s, proj, cfg.Config, decrypter)
 		if configErr != nil {
 			return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 		}
@@ -349,12 +359,22 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(err)
 		}
+		limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+		if err != nil {
+			return result.FromError(err)
+		}
+		retryPolicy, err := backend.GetProjectRetryPolicy(proj)
+		if err != nil {
+			return result.FromError(err)
+		}
 
 		opts.Engine = engine.UpdateOptions{
-			LocalPolicyPacks: engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
-			Parallel:         parallel,
-			Debug:            debug,
-			Refresh:          refreshOption,
+			LocalPolicyPacks:  engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
+			Parallel:          parallel,
+			ConcurrencyLimits: limits,
+			RetryPolicy:       retryPolicy,
+			Debug:             debug,
+			Refresh:           refreshOption,
 			// If we're in experimental mode then we trigger a plan to be generated during the preview phase
 			// which will be constrained to during the update phase.
 			GeneratePlan: hasExperimentalCommands(),
@@ -446,6 +466,7 @@ func newUpCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				SummaryFilePath:      summaryFilePath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
@@ -513,7 +534,7 @@ func newUpCmd() *cobra.Command {
 		"Config keys contain a path to a property in a map or list to set")
 	cmd.PersistentFlags().StringVar(
 		&secretsProvider, "secrets-provider", "default", "The type of the provider that should be used to encrypt and "+
-			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault). Only "+
+			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault, exec). Only "+
 			"used when creating a new stack from an existing template")
 
 	cmd.PersistentFlags().StringVar(
@@ -557,6 +578,10 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", nil,
+		"Limit the number of concurrent operations on resources of a package or type, given as <package-or-type>=<n>. "+
+			"May be specified multiple times")
 	cmd.PersistentFlags().StringVarP(
 		&refresh, "refresh", "r", "",
 		"Refresh the state of the stack's resources before this update")
@@ -581,6 +606,9 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().StringVar(
+		&summaryFilePath, "summary-file", "",
+		"Write a JSON summary of the operation to a file at this path once it ends")
 	cmd.PersistentFlags().BoolVar(
 		&showFullOutput, "show-full-output", true,
 		"Display full length of stack outputs")
@@ -46,6 +46,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/external"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/pulumi/pulumi/pkg/v3/util/tracing"
 	"github.com/pulumi/pulumi/pkg/v3/version"
@@ -206,6 +207,8 @@ func createSecretsManager(
 		_, err = stack.DefaultSecretManager(ps)
 	} else if secretsProvider == passphrase.Type {
 		_, err = passphrase.NewPromptingPassphraseSecretsManager(ps, rotateSecretsProvider)
+	} else if external.IsExternalSecretsProvider(secretsProvider) {
+		_, err = external.NewExternalSecretsManager(ps, secretsProvider)
 	} else {
 		// All other non-default secrets providers are handled by the cloud secrets provider which
 		// uses a URL schema to identify the provider
@@ -313,6 +316,7 @@ func requireStack(ctx context.Context,
 		return nil, err
 	}
 	if stack != nil {
+		trustStackSecretsProvider(project, stack)
 		return stack, err
 	}
 
@@ -348,6 +352,7 @@ func requireCurrentStack(ctx context.Context, lopt stackLoadOption, opts display
 	if err != nil {
 		return nil, err
 	} else if stack != nil {
+		trustStackSecretsProvider(project, stack)
 		return stack, nil
 	}
 
@@ -481,6 +486,7 @@ func chooseStack(ctx context.Context,
 	if stack == nil {
 		return nil, fmt.Errorf("no stack named '%s' found", stackRef)
 	}
+	trustStackSecretsProvider(proj, stack)
 
 	// If setCurrent is true, we'll persist this choice so it'll be used for future CLI operations.
 	if lopt.SetCurrent() {
@@ -965,6 +971,30 @@ func getRefreshOption(proj *workspace.Project, refresh string) (bool, error) {
 	return false, nil
 }
 
+// getConcurrencyLimits returns the limits on concurrent resource operations, keyed by package or type token. Limits
+// given on the command line as "<package-or-type>=<n>" take precedence over those set in the project's options.
+func getConcurrencyLimits(proj *workspace.Project, flags []string) (map[string]int, error) {
+	limits, err := backend.GetProjectConcurrencyLimits(proj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		limit, err := strconv.Atoi(value)
+		if !ok || key == "" || err != nil || limit < 1 {
+			return nil, fmt.Errorf(
+				"invalid concurrency limit %q: expected <package-or-type>=<n>, where n is a positive integer", flag)
+		}
+		limits[key] = limit
+	}
+
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	return limits, nil
+}
+
 func writePlan(path string, plan *deploy.Plan, enc config.Encrypter, showSecrets bool) error {
 	f, err := os.Create(path)
 	if err != nil {
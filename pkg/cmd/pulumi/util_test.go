@@ -341,6 +341,35 @@ func TestGetRefreshOption(t *testing.T) {
 	}
 }
 
+func TestGetConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	project := &workspace.Project{
+		Name: "limited",
+		Options: &workspace.ProjectOptions{
+			ConcurrencyLimits: map[string]int{"aws": 10, "aws:iam/role:Role": 2},
+		},
+	}
+
+	limits, err := getConcurrencyLimits(&workspace.Project{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, limits)
+
+	limits, err = getConcurrencyLimits(project, []string{"aws:iam/role:Role=1", "dns=3"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"aws": 10, "aws:iam/role:Role": 1, "dns": 3}, limits)
+
+	for _, flag := range []string{"dns", "=3", "dns=zero", "dns=0"} {
+		_, err = getConcurrencyLimits(nil, []string{flag})
+		assert.ErrorContains(t, err, "invalid concurrency limit", flag)
+	}
+
+	_, err = getConcurrencyLimits(&workspace.Project{
+		Options: &workspace.ProjectOptions{ConcurrencyLimits: map[string]int{"dns": 0}},
+	}, nil)
+	assert.ErrorContains(t, err, `concurrency limit for "dns" must be a positive integer`)
+}
+
 func TestStackLoadOption(t *testing.T) {
 	t.Parallel()
 
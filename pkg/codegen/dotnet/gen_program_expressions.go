@@ -335,6 +335,16 @@ var functionNamespaces = map[string][]string{
 	"toBase64":         {"System"},
 	"fromBase64":       {"System"},
 	"sha1":             {"System.Security.Cryptography", "System.Text"},
+	"sha256":           {"System", "System.Security.Cryptography", "System.Text"},
+	"fromJSON":         {"System.Text.Json.Nodes"},
+	"format":           {"System.Text.RegularExpressions"},
+	"merge":            {"System.Collections.Generic"},
+	"concat":           {"System.Linq"},
+	"distinct":         {"System.Linq"},
+	"keys":             {"System", "System.Linq"},
+	"values":           {"System", "System.Linq"},
+	"flatten":          {"System.Linq"},
+	"zipmap":           {"System.Linq"},
 	"singleOrNone":     {"System.Linq"},
 }
 
@@ -624,6 +634,52 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	case "sha1":
 		// Assuming the existence of the following helper method located earlier in the preamble
 		g.Fgenf(w, "ComputeSHA1(%v)", expr.Args[0])
+	case "sha256":
+		// Assuming the existence of the following helper method located earlier in the preamble
+		g.Fgenf(w, "ComputeSHA256(%v)", expr.Args[0])
+	case "fromJSON":
+		g.Fgenf(w, "JsonNode.Parse(%v)", expr.Args[0])
+	case "lower":
+		g.Fgenf(w, "%.20v.ToLowerInvariant()", expr.Args[0])
+	case "upper":
+		g.Fgenf(w, "%.20v.ToUpperInvariant()", expr.Args[0])
+	case "replace":
+		g.Fgenf(w, "%.20v.Replace(%v, %v)", expr.Args[0], expr.Args[1], expr.Args[2])
+	case "format":
+		// Assuming the existence of the following helper method located earlier in the preamble
+		g.Fgenf(w, "Format(%v", expr.Args[0])
+		for _, arg := range expr.Args[1:] {
+			g.Fgenf(w, ", %v", arg)
+		}
+		g.Fgen(w, ")")
+	case "merge":
+		// Assuming the existence of the following helper method located earlier in the preamble
+		g.Fgen(w, "Merge(")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.genDictionaryOrTuple(w, arg)
+		}
+		g.Fgen(w, ")")
+	case "concat":
+		g.Fgenf(w, "%.20v", expr.Args[0])
+		for _, arg := range expr.Args[1:] {
+			g.Fgenf(w, ".Concat(%v)", arg)
+		}
+		g.Fgen(w, ".ToList()")
+	case "distinct":
+		g.Fgenf(w, "%.20v.Distinct().ToList()", expr.Args[0])
+	case "flatten":
+		g.Fgenf(w, "%.20v.SelectMany(list => list).ToList()", expr.Args[0])
+	case "keys":
+		// Keys are sorted lexically, as in the other languages, rather than kept in insertion order.
+		g.Fgenf(w, "%.20v.Keys.OrderBy(key => key, StringComparer.Ordinal).ToList()", expr.Args[0])
+	case "values":
+		g.Fgenf(w, "%.20v.OrderBy(pair => pair.Key, StringComparer.Ordinal).Select(pair => pair.Value).ToList()",
+			expr.Args[0])
+	case "zipmap":
+		g.Fgenf(w, "%.20v.Zip(%v).ToDictionary(pair => pair.First, pair => pair.Second)", expr.Args[0], expr.Args[1])
 	case "stack":
 		g.Fgen(w, "Deployment.Instance.StackName")
 	case "project":
@@ -140,6 +140,29 @@ func getHelperMethodIfNeeded(functionName string, indent string) (string, bool)
 			SHA1.Create().ComputeHash(Encoding.UTF8.GetBytes(input))
 		).Replace("-","").ToLowerInvariant());
 	}`, true
+	case "sha256":
+		return `private static string ComputeSHA256(string input) {
+		return BitConverter.ToString(
+			SHA256.Create().ComputeHash(Encoding.UTF8.GetBytes(input))
+		).Replace("-","").ToLowerInvariant();
+	}`, true
+	case "format":
+		return `private static string Format(string format, params object?[] args) {
+		var index = 0;
+		return Regex.Replace(format, "%[sd%]", match => match.Value == "%%" ? "%" : $"{args[index++]}");
+	}`, true
+	case "merge":
+		return `private static Dictionary<string, T> Merge<T>(params IDictionary<string, T>[] maps) {
+		var result = new Dictionary<string, T>();
+		foreach (var map in maps)
+		{
+			foreach (var entry in map)
+			{
+				result[entry.Key] = entry.Value;
+			}
+		}
+		return result;
+	}`, true
 	case "notImplemented":
 		return fmt.Sprintf(`
 %sstatic object NotImplemented(string errorMessage) 
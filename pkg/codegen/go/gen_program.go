@@ -1270,9 +1270,67 @@ func (g *generator) genComponent(w io.Writer, r *pcl.Component) {
 func (g *generator) genOutputAssignment(w io.Writer, v *pcl.OutputVariable) {
 	expr, temps := g.lowerExpression(v.Value, v.Type())
 	g.genTemps(w, temps)
+	// ctx.Export takes a pulumi.Input, so plain values such as the results of builtin functions must be wrapped.
+	wrapper, ok := plainInputWrapper(expr.Type())
+	if !ok && isPlainDynamicValue(expr) {
+		wrapper, ok = "pulumi.Any", true
+	}
+	if ok {
+		g.Fgenf(w, "ctx.Export(%q, %s(%.v))\n", v.LogicalName(), wrapper, expr)
+		return
+	}
 	g.Fgenf(w, "ctx.Export(%q, %.3v)\n", v.LogicalName(), expr)
 }
 
+// plainInputWrapper returns the function that converts a plain value of the given type to a pulumi.Input, if values
+// of the type are plain.
+func plainInputWrapper(t model.Type) (string, bool) {
+	if cns, ok := t.(*model.ConstType); ok {
+		t = cns.Type
+	}
+	switch t := t.(type) {
+	case *model.ListType:
+		if t.ElementType.Equals(model.StringType) {
+			return "pulumi.ToStringArray", true
+		}
+		_, ok := plainInputWrapper(t.ElementType)
+		return "pulumi.Any", ok
+	case *model.MapType:
+		if t.ElementType.Equals(model.StringType) {
+			return "pulumi.ToStringMap", true
+		}
+		_, ok := plainInputWrapper(t.ElementType)
+		return "pulumi.Any", ok
+	}
+	switch {
+	case t.Equals(model.StringType):
+		return "pulumi.String", true
+	case t.Equals(model.NumberType):
+		return "pulumi.Float64", true
+	case t.Equals(model.IntType):
+		return "pulumi.Int", true
+	case t.Equals(model.BoolType):
+		return "pulumi.Bool", true
+	}
+	return "", false
+}
+
+// isPlainDynamicValue returns true if the given dynamically typed expression is known to be a plain value: the result
+// of fromJSON, or a local variable. Other dynamic values, such as the properties of unknown resources, are outputs.
+func isPlainDynamicValue(expr model.Expression) bool {
+	if !expr.Type().Equals(model.DynamicType) {
+		return false
+	}
+	switch expr := expr.(type) {
+	case *model.FunctionCallExpression:
+		return expr.Name == "fromJSON"
+	case *model.ScopeTraversalExpression:
+		_, isLocal := expr.Parts[0].(*pcl.LocalVariable)
+		return isLocal && len(expr.Traversal) == 1
+	}
+	return false
+}
+
 func (g *generator) genTemps(w io.Writer, temps []interface{}) {
 	singleReturn := ""
 	g.genTempsMultiReturn(w, temps, singleReturn)
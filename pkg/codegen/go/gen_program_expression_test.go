@@ -230,6 +230,31 @@ func TestTupleConsExpression(t *testing.T) {
 	}
 }
 
+func TestFormatFunctionCall(t *testing.T) {
+	t.Parallel()
+
+	env := environment(map[string]interface{}{
+		"format": model.NewFunction(model.StaticFunctionSignature{
+			Parameters:       []model.Parameter{{Name: "format", Type: model.StringType}},
+			VarargsParameter: &model.Parameter{Name: "args", Type: model.DynamicType},
+			ReturnType:       model.StringType,
+		}),
+		"name":  model.StringType,
+		"count": model.NumberType,
+	})
+	scope := env.scope()
+	cases := []exprTestCase{
+		{hcl2Expr: `format("%s-%d", name, count)`, goCode: `fmt.Sprintf("%s-%d", name, int(count))`},
+		{hcl2Expr: `format("%d%%", 3)`, goCode: `fmt.Sprintf("%d%%", 3)`},
+		{hcl2Expr: `format("%s", count)`, goCode: `fmt.Sprintf("%s", count)`},
+		{hcl2Expr: `format(name, count)`, goCode: `fmt.Sprintf(name, count)`},
+	}
+	for _, c := range cases {
+		c := c
+		testGenerateExpression(t, c.hcl2Expr, c.goCode, scope, nil)
+	}
+}
+
 func testGenerateExpression(
 	t *testing.T,
 	hcl2Expr, goCode string,
@@ -351,6 +351,60 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "mime.TypeByExtension(path.Ext(%.v))", expr.Args[0])
 	case "sha1":
 		g.Fgenf(w, "sha1Hash(%v)", expr.Args[0])
+	case "sha256":
+		g.Fgenf(w, "sha256Hash(%v)", expr.Args[0])
+	case "fromJSON":
+		// Assuming the existence of the following helper method located earlier in the preamble
+		g.Fgenf(w, "fromJSONOrPanic(%v)", expr.Args[0])
+	case "lower":
+		g.Fgenf(w, "strings.ToLower(%v)", expr.Args[0])
+	case "upper":
+		g.Fgenf(w, "strings.ToUpper(%v)", expr.Args[0])
+	case "replace":
+		g.Fgenf(w, "strings.ReplaceAll(%v, %v, %v)", expr.Args[0], expr.Args[1], expr.Args[2])
+	case "format":
+		// Numbers are generated as float64, which %d can't format, so the arguments of %d verbs are converted to int.
+		verbs, _, _ := pcl.FormatVerbs(expr.Args[0])
+		g.Fgenf(w, "fmt.Sprintf(%v", expr.Args[0])
+		for i, arg := range expr.Args[1:] {
+			if i < len(verbs) && verbs[i] == 'd' && isFloatExpression(arg) {
+				g.Fgenf(w, ", int(%v)", arg)
+				continue
+			}
+			g.Fgenf(w, ", %v", arg)
+		}
+		g.Fgen(w, ")")
+	case "merge", "concat":
+		// Assuming the existence of the following helper methods located earlier in the preamble. Object literals
+		// would be generated as map[string]interface{}, so type them as the result to keep its element type.
+		g.Fgenf(w, "%s(", collectionHelpers[expr.Name])
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			if obj, ok := arg.(*model.ObjectConsExpression); ok && expr.Name == "merge" {
+				g.genObjectConsExpression(w, obj, expr.Signature.ReturnType, false)
+				continue
+			}
+			g.Fgenf(w, "%v", arg)
+		}
+		g.Fgen(w, ")")
+	case "distinct", "keys", "values":
+		// Assuming the existence of the following helper methods located earlier in the preamble
+		g.Fgenf(w, "%s(%v)", collectionHelpers[expr.Name], expr.Args[0])
+	case "flatten":
+		// Assuming the existence of the following helper method located earlier in the preamble. A tuple of lists
+		// would be generated as []interface{}, which doesn't let Go infer the element type, so type it explicitly.
+		if tuple, ok := expr.Args[0].(*model.TupleConsExpression); ok {
+			g.Fgen(w, "flattenLists(")
+			g.genTupleConsExpression(w, tuple, model.NewListType(expr.Signature.ReturnType))
+			g.Fgen(w, ")")
+			return
+		}
+		g.Fgenf(w, "flattenLists(%v)", expr.Args[0])
+	case "zipmap":
+		// Assuming the existence of the following helper method located earlier in the preamble
+		g.Fgenf(w, "zipToMap(%v, %v)", expr.Args[0], expr.Args[1])
 	case "goOptionalFloat64":
 		g.Fgenf(w, "pulumi.Float64Ref(%.v)", expr.Args[0])
 	case "goOptionalBool":
@@ -1216,11 +1270,38 @@ var functionPackages = map[string][]string{
 	"fromBase64":       {"encoding/base64"},
 	"toJSON":           {"encoding/json"},
 	"sha1":             {"fmt", "crypto/sha1"},
+	"sha256":           {"crypto/sha256", "encoding/hex"},
+	"fromJSON":         {"encoding/json"},
+	"lower":            {"strings"},
+	"upper":            {"strings"},
+	"replace":          {"strings"},
+	"format":           {"fmt"},
+	"keys":             {"sort"},
+	"values":           {"sort"},
 	"filebase64sha256": {"fmt", "crypto/sha256", "os"},
 	"cwd":              {"os"},
 	"singleOrNone":     {"fmt"},
 }
 
+// collectionHelpers maps the collection functions to the names of the helper methods that implement them. The helpers
+// are not named after the functions, so that program variables of the same names don't shadow them.
+var collectionHelpers = map[string]string{
+	"merge":    "mergeMaps",
+	"concat":   "concatLists",
+	"distinct": "distinctElements",
+	"keys":     "mapKeys",
+	"values":   "mapValues",
+}
+
 func (g *generator) genFunctionPackages(x *model.FunctionCallExpression) []string {
 	return functionPackages[x.Name]
 }
+
+// isFloatExpression returns true if the given expression is generated as a float64 value. Number literals are
+// generated as untyped constants, so they are not.
+func isFloatExpression(expr model.Expression) bool {
+	if _, ok := expr.(*model.LiteralValueExpression); ok {
+		return false
+	}
+	return model.ResolveOutputs(expr.Type()) == model.NumberType
+}
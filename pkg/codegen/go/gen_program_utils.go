@@ -91,6 +91,88 @@ func getHelperMethodIfNeeded(functionName string, indent string) (string, bool)
 				hash := sha1.Sum([]byte(input))
 				return hex.EncodeToString(hash[:])
 			}`, true
+	case "sha256":
+		return `func sha256Hash(input string) string {
+				hash := sha256.Sum256([]byte(input))
+				return hex.EncodeToString(hash[:])
+			}`, true
+	case "fromJSON":
+		return `func fromJSONOrPanic(data string) interface{} {
+				var value interface{}
+				if err := json.Unmarshal([]byte(data), &value); err != nil {
+					panic(err.Error())
+				}
+				return value
+			}`, true
+	case "merge":
+		return `func mergeMaps[V any](maps ...map[string]V) map[string]V {
+				result := map[string]V{}
+				for _, m := range maps {
+					for k, v := range m {
+						result[k] = v
+					}
+				}
+				return result
+			}`, true
+	case "concat":
+		return `func concatLists[T any](lists ...[]T) []T {
+				var result []T
+				for _, list := range lists {
+					result = append(result, list...)
+				}
+				return result
+			}`, true
+	case "distinct":
+		return `func distinctElements[T comparable](list []T) []T {
+				var result []T
+				seen := map[T]bool{}
+				for _, v := range list {
+					if !seen[v] {
+						seen[v] = true
+						result = append(result, v)
+					}
+				}
+				return result
+			}`, true
+	case "flatten":
+		return `func flattenLists[T any](lists [][]T) []T {
+				var result []T
+				for _, list := range lists {
+					result = append(result, list...)
+				}
+				return result
+			}`, true
+	case "keys":
+		return `func mapKeys[V any](m map[string]V) []string {
+				result := make([]string, 0, len(m))
+				for k := range m {
+					result = append(result, k)
+				}
+				sort.Strings(result)
+				return result
+			}`, true
+	case "values":
+		// The values are ordered by their keys, as in the mapKeys helper.
+		return `func mapValues[V any](m map[string]V) []V {
+				ks := make([]string, 0, len(m))
+				for k := range m {
+					ks = append(ks, k)
+				}
+				sort.Strings(ks)
+				result := make([]V, 0, len(m))
+				for _, k := range ks {
+					result = append(result, m[k])
+				}
+				return result
+			}`, true
+	case "zipmap":
+		return `func zipToMap[V any](keys []string, values []V) map[string]V {
+				result := make(map[string]V, len(keys))
+				for i, k := range keys {
+					result[k] = values[i]
+				}
+				return result
+			}`, true
 	case "notImplemented":
 		return fmt.Sprintf(`
 %sfunc notImplemented(message string) pulumi.AnyOutput {
@@ -288,6 +288,8 @@ var functionImports = map[string][]string{
 	"readFile":           {"fs"},
 	"readDir":            {"fs"},
 	"sha1":               {"crypto"},
+	"sha256":             {"crypto"},
+	"format":             {"util"},
 }
 
 func (g *generator) getFunctionImports(x *model.FunctionCallExpression) []string {
@@ -476,6 +478,41 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "JSON.stringify(%v)", expr.Args[0])
 	case "sha1":
 		g.Fgenf(w, "crypto.createHash('sha1').update(%v).digest('hex')", expr.Args[0])
+	case "sha256":
+		g.Fgenf(w, "crypto.createHash('sha256').update(%v).digest('hex')", expr.Args[0])
+	case "fromJSON":
+		g.Fgenf(w, "JSON.parse(%v)", expr.Args[0])
+	case "lower":
+		g.Fgenf(w, "%.20v.toLowerCase()", expr.Args[0])
+	case "upper":
+		g.Fgenf(w, "%.20v.toUpperCase()", expr.Args[0])
+	case "replace":
+		// String.prototype.replaceAll is not available in ES2016.
+		g.Fgenf(w, "%.20v.split(%v).join(%v)", expr.Args[0], expr.Args[1], expr.Args[2])
+	case "format":
+		g.Fgenf(w, "util.format(%v", expr.Args[0])
+		for _, arg := range expr.Args[1:] {
+			g.Fgenf(w, ", %v", arg)
+		}
+		g.Fgen(w, ")")
+	case "merge":
+		g.genSpread(w, "{", expr.Args, "}")
+	case "concat":
+		g.genSpread(w, "[", expr.Args, "]")
+	case "distinct":
+		g.Fgenf(w, "Array.from(new Set(%v))", expr.Args[0])
+	case "flatten":
+		// Assuming the existence of the following helper method
+		g.Fgenf(w, "flatten(%v)", expr.Args[0])
+	case "keys":
+		// Keys are sorted lexically, as in the other languages, rather than kept in insertion order.
+		g.Fgenf(w, "Object.keys(%v).sort()", expr.Args[0])
+	case "values":
+		// Assuming the existence of the following helper method
+		g.Fgenf(w, "objectValues(%v)", expr.Args[0])
+	case "zipmap":
+		// Assuming the existence of the following helper method
+		g.Fgenf(w, "zipmap(%v, %v)", expr.Args[0], expr.Args[1])
 	case "stack":
 		g.Fgenf(w, "pulumi.getStack()")
 	case "project":
@@ -492,6 +529,18 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	}
 }
 
+// genSpread generates an array or object literal that spreads each of the given values.
+func (g *generator) genSpread(w io.Writer, start string, values []model.Expression, end string) {
+	g.Fgen(w, start)
+	for i, v := range values {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		g.Fgenf(w, "...%.20v", v)
+	}
+	g.Fgen(w, end)
+}
+
 func (g *generator) GenIndexExpression(w io.Writer, expr *model.IndexExpression) {
 	g.Fgenf(w, "%.20v[%.v]", expr.Collection, expr.Key)
 }
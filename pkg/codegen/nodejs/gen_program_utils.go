@@ -26,6 +26,23 @@ func getHelperMethodIfNeeded(functionName string, indent string) (string, bool)
 %s    }
 %s    return elements[0];
 %s}`, indent, indent, indent, indent, indent, indent), true
+	case "flatten":
+		return fmt.Sprintf(
+			`%sfunction flatten<T>(lists: T[][]): T[] {
+%s    return ([] as T[]).concat(...lists);
+%s}`, indent, indent, indent), true
+	case "values":
+		return fmt.Sprintf(
+			`%sfunction objectValues<T>(obj: Record<string, T>): T[] {
+%s    return Object.keys(obj).sort().map(key => obj[key]);
+%s}`, indent, indent, indent), true
+	case "zipmap":
+		return fmt.Sprintf(
+			`%sfunction zipmap<T>(keys: string[], values: T[]): Record<string, T> {
+%s    const result: Record<string, T> = {};
+%s    keys.forEach((key, i) => result[key] = values[i]);
+%s    return result;
+%s}`, indent, indent, indent, indent, indent), true
 	default:
 		return "", false
 	}
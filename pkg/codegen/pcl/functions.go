@@ -15,6 +15,10 @@
 package pcl
 
 import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/model"
 )
@@ -50,6 +54,138 @@ func getEntriesSignature(
 	return signature, diagnostics
 }
 
+// listElementType returns the element type of a list or tuple type, or false if the type is not a list or tuple.
+func listElementType(t model.Type) (model.Type, bool) {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.ListType:
+		return t.ElementType, true
+	case *model.TupleType:
+		_, elementType := model.UnifyTypes(t.ElementTypes...)
+		return elementType, true
+	default:
+		return model.DynamicType, t == model.DynamicType
+	}
+}
+
+// flattenElementType returns the element type of the lists in a list or tuple of lists, or false if the type is not a
+// list of lists. The lists in a tuple may have different lengths, so their element types are unified one by one.
+func flattenElementType(t model.Type) (model.Type, bool) {
+	tuple, ok := model.ResolveOutputs(t).(*model.TupleType)
+	if !ok {
+		t, ok := listElementType(t)
+		if !ok {
+			return model.DynamicType, false
+		}
+		return listElementType(t)
+	}
+
+	var unifiedType model.Type
+	for _, t := range tuple.ElementTypes {
+		t, ok := listElementType(t)
+		if !ok {
+			return model.DynamicType, false
+		}
+		_, unifiedType = model.UnifyTypes(unifiedType, t)
+	}
+	if unifiedType == nil {
+		unifiedType = model.DynamicType
+	}
+	return unifiedType, true
+}
+
+// mapElementType returns the element type of a map or object type, or false if the type is not a map or object.
+func mapElementType(t model.Type) (model.Type, bool) {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.MapType:
+		return t.ElementType, true
+	case *model.ObjectType:
+		var unifiedType model.Type
+		for _, t := range t.Properties {
+			_, unifiedType = model.UnifyTypes(unifiedType, t)
+		}
+		if unifiedType == nil {
+			unifiedType = model.DynamicType
+		}
+		return unifiedType, true
+	default:
+		return model.DynamicType, t == model.DynamicType
+	}
+}
+
+func argumentTypeError(arg model.Expression, format string, args ...interface{}) *hcl.Diagnostic {
+	rng := arg.SyntaxNode().Range()
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf(format, args...),
+		Subject:  &rng,
+	}
+}
+
+// FormatVerbs returns the verbs in the format string of a call to 'format' that each consume an argument, in order.
+// It returns false if the format string is not a string literal, as its verbs aren't known until the program runs,
+// and an error if the format string uses a verb other than %s, %d or %%.
+func FormatVerbs(format model.Expression) ([]rune, bool, error) {
+	f, ok := extractStringValue(format)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var verbs []rune
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' {
+			continue
+		}
+		i++
+		if i == len(f) {
+			return nil, true, errors.New("the format string ends with '%'")
+		}
+		verb, size := utf8.DecodeRuneInString(f[i:])
+		switch verb {
+		case '%':
+		case 's', 'd':
+			verbs = append(verbs, verb)
+		default:
+			return nil, true, fmt.Errorf("unsupported verb '%%%c'", verb)
+		}
+		i += size - 1
+	}
+	return verbs, true, nil
+}
+
+// getCollectionsSignature returns the signature of a function that accepts any number of collections, such as
+// merge or concat. The returned signature has a single variadic parameter of the given kind of collection.
+func getCollectionsSignature(
+	function string,
+	args []model.Expression,
+	elementType func(t model.Type) (model.Type, bool),
+	collectionType func(elementType model.Type) model.Type,
+	expected string,
+) (model.StaticFunctionSignature, hcl.Diagnostics) {
+	var diagnostics hcl.Diagnostics
+
+	elementTypes := make([]model.Type, 0, len(args))
+	for _, arg := range args {
+		t, ok := elementType(arg.Type())
+		if !ok {
+			diagnostics = append(diagnostics,
+				argumentTypeError(arg, "the arguments to '%s' must be %s", function, expected))
+		}
+		elementTypes = append(elementTypes, t)
+	}
+	unifiedType := model.Type(model.DynamicType)
+	if len(elementTypes) > 0 {
+		_, unifiedType = model.UnifyTypes(elementTypes...)
+	}
+
+	return model.StaticFunctionSignature{
+		VarargsParameter: &model.Parameter{
+			Name: "values",
+			Type: model.DynamicType,
+		},
+		ReturnType: collectionType(unifiedType),
+	}, diagnostics
+}
+
 func pulumiBuiltins(options bindOptions) map[string]*model.Function {
 	return map[string]*model.Function{
 		"element": model.NewFunction(model.GenericFunctionSignature(
@@ -87,11 +223,62 @@ func pulumiBuiltins(options bindOptions) map[string]*model.Function {
 					ReturnType: returnType,
 				}, diagnostics
 			})),
+		"distinct": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				var diagnostics hcl.Diagnostics
+
+				listType, elementType := model.Type(model.DynamicType), model.Type(model.DynamicType)
+				if len(args) > 0 {
+					t, ok := listElementType(args[0].Type())
+					if !ok {
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[0],
+							"the first argument to 'distinct' must be a list or tuple")}
+					}
+					listType, elementType = args[0].Type(), t
+				}
+				return model.StaticFunctionSignature{
+					Parameters: []model.Parameter{{
+						Name: "list",
+						Type: listType,
+					}},
+					ReturnType: model.NewListType(elementType),
+				}, diagnostics
+			})),
 		"entries": model.NewFunction(model.GenericFunctionSignature(
 			func(arguments []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
 				return getEntriesSignature(arguments, options)
 			})),
 
+		// Returns the concatenation of the given lists.
+		"concat": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				return getCollectionsSignature("concat", args, listElementType, func(t model.Type) model.Type {
+					return model.NewListType(t)
+				}, "lists or tuples")
+			})),
+		// Flattens a list of lists into a single list.
+		"flatten": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				var diagnostics hcl.Diagnostics
+
+				listType, elementType := model.Type(model.DynamicType), model.Type(model.DynamicType)
+				if len(args) > 0 {
+					listType = args[0].Type()
+					t, ok := flattenElementType(listType)
+					if !ok {
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[0],
+							"the first argument to 'flatten' must be a list of lists")}
+					}
+					elementType = t
+				}
+				return model.StaticFunctionSignature{
+					Parameters: []model.Parameter{{
+						Name: "lists",
+						Type: listType,
+					}},
+					ReturnType: model.NewListType(elementType),
+				}, diagnostics
+			})),
 		"fileArchive": model.NewFunction(model.StaticFunctionSignature{
 			Parameters: []model.Parameter{{
 				Name: "path",
@@ -219,6 +406,84 @@ func pulumiBuiltins(options bindOptions) map[string]*model.Function {
 					ReturnType: elementType,
 				}, diagnostics
 			})),
+		// Returns the keys of a map or object.
+		"keys": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				var diagnostics hcl.Diagnostics
+
+				mapType := model.Type(model.DynamicType)
+				if len(args) > 0 {
+					if _, ok := mapElementType(args[0].Type()); !ok {
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[0],
+							"the first argument to 'keys' must be a map or object")}
+					}
+					mapType = args[0].Type()
+				}
+				return model.StaticFunctionSignature{
+					Parameters: []model.Parameter{{
+						Name: "map",
+						Type: mapType,
+					}},
+					ReturnType: model.NewListType(model.StringType),
+				}, diagnostics
+			})),
+		// Returns the values of a map or object.
+		"values": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				var diagnostics hcl.Diagnostics
+
+				mapType, elementType := model.Type(model.DynamicType), model.Type(model.DynamicType)
+				if len(args) > 0 {
+					t, ok := mapElementType(args[0].Type())
+					if !ok {
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[0],
+							"the first argument to 'values' must be a map or object")}
+					}
+					mapType, elementType = args[0].Type(), t
+				}
+				return model.StaticFunctionSignature{
+					Parameters: []model.Parameter{{
+						Name: "map",
+						Type: mapType,
+					}},
+					ReturnType: model.NewListType(elementType),
+				}, diagnostics
+			})),
+		// Returns a single map that contains the entries of all of the given maps. Later maps take precedence.
+		"merge": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				return getCollectionsSignature("merge", args, mapElementType, func(t model.Type) model.Type {
+					return model.NewMapType(t)
+				}, "maps or objects")
+			})),
+		// Returns a map from a list of keys and a list of the corresponding values.
+		"zipmap": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				var diagnostics hcl.Diagnostics
+
+				valuesType, elementType := model.Type(model.DynamicType), model.Type(model.DynamicType)
+				if len(args) > 1 {
+					t, ok := listElementType(args[1].Type())
+					if !ok {
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[1],
+							"the second argument to 'zipmap' must be a list or tuple")}
+					}
+					valuesType, elementType = args[1].Type(), t
+				}
+				return model.StaticFunctionSignature{
+					Parameters: []model.Parameter{
+						{
+							Name: "keys",
+							Type: model.NewListType(model.StringType),
+						},
+						{
+							Name: "values",
+							Type: valuesType,
+						},
+					},
+					ReturnType: model.NewMapType(elementType),
+				}, diagnostics
+			})),
 		"mimeType": model.NewFunction(model.StaticFunctionSignature{
 			Parameters: []model.Parameter{{
 				Name: "path",
@@ -304,6 +569,13 @@ func pulumiBuiltins(options bindOptions) map[string]*model.Function {
 			}},
 			ReturnType: model.StringType,
 		}),
+		"sha256": model.NewFunction(model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "input",
+				Type: model.StringType,
+			}},
+			ReturnType: model.StringType,
+		}),
 		"split": model.NewFunction(model.StaticFunctionSignature{
 			Parameters: []model.Parameter{
 				{
@@ -317,6 +589,66 @@ func pulumiBuiltins(options bindOptions) map[string]*model.Function {
 			},
 			ReturnType: model.NewListType(model.StringType),
 		}),
+		"lower": model.NewFunction(model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "string",
+				Type: model.StringType,
+			}},
+			ReturnType: model.StringType,
+		}),
+		"upper": model.NewFunction(model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "string",
+				Type: model.StringType,
+			}},
+			ReturnType: model.StringType,
+		}),
+		// Replaces each occurrence of substring in string with replacement.
+		"replace": model.NewFunction(model.StaticFunctionSignature{
+			Parameters: []model.Parameter{
+				{
+					Name: "string",
+					Type: model.StringType,
+				},
+				{
+					Name: "substring",
+					Type: model.StringType,
+				},
+				{
+					Name: "replacement",
+					Type: model.StringType,
+				},
+			},
+			ReturnType: model.StringType,
+		}),
+		// Formats the arguments according to the format string. Only the %s, %d and %% verbs are supported, as those are
+		// the verbs that every language can render.
+		"format": model.NewFunction(model.GenericFunctionSignature(
+			func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+				var diagnostics hcl.Diagnostics
+				if len(args) > 0 {
+					verbs, ok, err := FormatVerbs(args[0])
+					switch {
+					case err != nil:
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[0],
+							"the format string of 'format' only supports the %%s, %%d and %%%% verbs: %v", err)}
+					case ok && len(verbs) != len(args)-1:
+						diagnostics = hcl.Diagnostics{argumentTypeError(args[0],
+							"the format string of 'format' expects %d arguments, but %d were given", len(verbs), len(args)-1)}
+					}
+				}
+				return model.StaticFunctionSignature{
+					Parameters: []model.Parameter{{
+						Name: "format",
+						Type: model.StringType,
+					}},
+					VarargsParameter: &model.Parameter{
+						Name: "args",
+						Type: model.DynamicType,
+					},
+					ReturnType: model.StringType,
+				}, diagnostics
+			})),
 		"toBase64": model.NewFunction(model.StaticFunctionSignature{
 			Parameters: []model.Parameter{{
 				Name: "value",
@@ -338,6 +670,13 @@ func pulumiBuiltins(options bindOptions) map[string]*model.Function {
 			}},
 			ReturnType: model.StringType,
 		}),
+		"fromJSON": model.NewFunction(model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "value",
+				Type: model.StringType,
+			}},
+			ReturnType: model.DynamicType,
+		}),
 		// Returns the name of the current stack
 		"stack": model.NewFunction(model.StaticFunctionSignature{
 			ReturnType: model.StringType,
@@ -7,6 +7,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSingleOrNoneErrorsWithoutArguments(t *testing.T) {
@@ -54,3 +55,91 @@ func TestSingleOrNoneBindsCorrectlyWhenFirstArgumentIsList(t *testing.T) {
 	elementType := pcl.UnwrapOption(variableType)
 	assert.True(t, model.IsConstType(elementType), "element type must the type of one element in the list")
 }
+
+func TestCollectionFunctionsBindWithElementTypes(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]model.Type{
+		`concat(["a"], ["b", "c"])`:                   model.NewListType(model.StringType),
+		`distinct([1, 2, 1])`:                         model.NewListType(model.NumberType),
+		`flatten([["a"], ["b"]])`:                     model.NewListType(model.StringType),
+		`flatten([["a", "b"], ["c"]])`:                model.NewListType(model.StringType),
+		`keys({ a = 1 })`:                             model.NewListType(model.StringType),
+		`values({ a = "x", b = "y" })`:                model.NewListType(model.StringType),
+		`merge({ a = "x" }, { b = "y" })`:             model.NewMapType(model.StringType),
+		`zipmap(["a", "b"], [true, false])`:           model.NewMapType(model.BoolType),
+		`format("%s-%d", "a", 1)`:                     model.StringType,
+		`replace(upper(lower("a-b")), "-", "_")`:      model.StringType,
+		`sha256(toJSON(fromJSON("{}")))`:              model.StringType,
+		`lookup(merge({ a = 1 }, { b = 2 }), "a", 0)`: model.NumberType,
+	}
+	for expr, expected := range cases {
+		expr, expected := expr, expected
+		t.Run(expr, func(t *testing.T) {
+			t.Parallel()
+
+			program, diags, err := ParseAndBindProgram(t, "value = "+expr, "program.pp")
+			require.NoError(t, err)
+			require.False(t, diags.HasErrors(), diags.Error())
+			localVariable, ok := program.Nodes[0].(*pcl.LocalVariable)
+			require.True(t, ok)
+			assert.True(t, expected.AssignableFrom(localVariable.Type()),
+				"expected %v to be assignable from %v", expected, localVariable.Type())
+		})
+	}
+}
+
+func TestCollectionFunctionsErrorOnWrongArgumentTypes(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		`concat(["a"], "b")`:       "the arguments to 'concat' must be lists or tuples",
+		`merge({ a = 1 }, [1])`:    "the arguments to 'merge' must be maps or objects",
+		`distinct("a")`:            "the first argument to 'distinct' must be a list or tuple",
+		`flatten(["a"])`:           "the first argument to 'flatten' must be a list of lists",
+		`keys(["a"])`:              "the first argument to 'keys' must be a map or object",
+		`values("a")`:              "the first argument to 'values' must be a map or object",
+		`zipmap(["a"], { a = 1 })`: "the second argument to 'zipmap' must be a list or tuple",
+	}
+	for expr, expected := range cases {
+		expr, expected := expr, expected
+		t.Run(expr, func(t *testing.T) {
+			t.Parallel()
+
+			program, diags, err := ParseAndBindProgram(t, "value = "+expr, "program.pp")
+			contract.Ignore(diags)
+			assert.Nil(t, program, "The program doesn't bind")
+			assert.ErrorContains(t, err, expected)
+		})
+	}
+}
+
+func TestFormatChecksVerbs(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		`value = format("%s-%d", "a", 1)`: "",
+		`value = format("100%%")`:         "",
+		`value = format("%03d", 1)`:       "only supports the %s, %d and %% verbs: unsupported verb '%0'",
+		`value = format("%v", 1)`:         "only supports the %s, %d and %% verbs: unsupported verb '%v'",
+		`value = format("50%")`:           "only supports the %s, %d and %% verbs: the format string ends with '%'",
+		`value = format("%s-%d", "a")`:    "the format string of 'format' expects 2 arguments, but 1 were given",
+		`value = format("%s", "a", "b")`:  "the format string of 'format' expects 1 arguments, but 2 were given",
+	}
+	for source, expected := range cases {
+		source, expected := source, expected
+		t.Run(source, func(t *testing.T) {
+			t.Parallel()
+
+			program, diags, err := ParseAndBindProgram(t, source, "program.pp")
+			if expected == "" {
+				require.NoError(t, err)
+				assert.NotNil(t, program)
+				return
+			}
+			contract.Ignore(diags)
+			assert.Nil(t, program, "The program doesn't bind")
+			assert.ErrorContains(t, err, expected)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+)
+
+// ResourceDeclaration describes a resource declared by a program: its type, the input properties that it sets and
+// the resources that it depends on.
+type ResourceDeclaration struct {
+	// Name is the logical name of the resource.
+	Name string
+	// Token is the type token of the resource.
+	Token string
+	// Inputs are the names of the input properties that the resource sets.
+	Inputs codegen.StringSet
+	// Dependencies are the logical names of the resources that the resource depends on.
+	Dependencies codegen.StringSet
+	// Range is where the resource is declared.
+	Range hcl.Range
+}
+
+// ResourceDeclarations returns the declarations of the resources in the given program.
+func ResourceDeclarations(p *Program) []ResourceDeclaration {
+	var declarations []ResourceDeclaration
+	for _, n := range p.Nodes {
+		r, ok := n.(*Resource)
+		if !ok {
+			continue
+		}
+		inputs := codegen.NewStringSet()
+		for _, attr := range r.Inputs {
+			inputs.Add(attr.Name)
+		}
+		declarations = append(declarations, ResourceDeclaration{
+			Name:         r.LogicalName(),
+			Token:        r.Token,
+			Inputs:       inputs,
+			Dependencies: resourceDependencies(r),
+			Range:        resourceRange(r),
+		})
+	}
+	return declarations
+}
+
+// VerifyEquivalent checks that the target program declares the same resources as the source program, and that each
+// of those resources has the same type, sets the same input properties and depends on the same resources. Resources
+// are matched by their logical names. Each difference is reported as an error diagnostic.
+//
+// VerifyEquivalent is used to check that a program survives a round trip through another representation, such as
+// the output of a converter.
+func VerifyEquivalent(source, target *Program) hcl.Diagnostics {
+	return VerifyDeclarations(ResourceDeclarations(source), ResourceDeclarations(target))
+}
+
+// VerifyDeclarations is like VerifyEquivalent, but compares the declarations of the resources of two programs. It is
+// used to check programs that can't be bound, such as programs generated in other languages, after their resource
+// declarations have been read back.
+func VerifyDeclarations(source, target []ResourceDeclaration) hcl.Diagnostics {
+	sourceResources, targetResources := declarationsByName(source), declarationsByName(target)
+
+	var diagnostics hcl.Diagnostics
+	for _, name := range sortedResourceNames(sourceResources) {
+		s := sourceResources[name]
+		t, ok := targetResources[name]
+		if !ok {
+			diagnostics = append(diagnostics,
+				errorf(s.Range, "resource '%s' is missing from the converted program", name))
+			continue
+		}
+		diagnostics = append(diagnostics, verifyResource(name, s, t)...)
+	}
+	for _, name := range sortedResourceNames(targetResources) {
+		if _, ok := sourceResources[name]; !ok {
+			diagnostics = append(diagnostics, errorf(targetResources[name].Range,
+				"resource '%s' is not declared by the source program", name))
+		}
+	}
+	return diagnostics
+}
+
+func verifyResource(name string, source, target ResourceDeclaration) hcl.Diagnostics {
+	var diagnostics hcl.Diagnostics
+	rng := target.Range
+
+	if source.Token != target.Token {
+		diagnostics = append(diagnostics, errorf(rng,
+			"resource '%s' has type '%s', but has type '%s' in the source program", name, target.Token, source.Token))
+	}
+
+	if missing := source.Inputs.Subtract(target.Inputs); len(missing) > 0 {
+		diagnostics = append(diagnostics, errorf(rng,
+			"resource '%s' does not set the input properties %s", name, quoteAll(missing)))
+	}
+	if extra := target.Inputs.Subtract(source.Inputs); len(extra) > 0 {
+		diagnostics = append(diagnostics, errorf(rng,
+			"resource '%s' sets the input properties %s, which the source program does not set", name, quoteAll(extra)))
+	}
+
+	if missing := source.Dependencies.Subtract(target.Dependencies); len(missing) > 0 {
+		diagnostics = append(diagnostics, errorf(rng,
+			"resource '%s' does not depend on the resources %s", name, quoteAll(missing)))
+	}
+	if extra := target.Dependencies.Subtract(source.Dependencies); len(extra) > 0 {
+		diagnostics = append(diagnostics, errorf(rng,
+			"resource '%s' depends on the resources %s, which it does not depend on in the source program",
+			name, quoteAll(extra)))
+	}
+	return diagnostics
+}
+
+// resourceDependencies returns the logical names of the resources that the given resource depends on, either
+// directly or through locals and other intermediate values.
+func resourceDependencies(r *Resource) codegen.StringSet {
+	deps := codegen.NewStringSet()
+	visited := map[Node]bool{}
+	var visit func(n Node)
+	visit = func(n Node) {
+		for _, dep := range n.getDependencies() {
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			if dep, ok := dep.(*Resource); ok {
+				deps.Add(dep.LogicalName())
+				continue
+			}
+			visit(dep)
+		}
+	}
+	visit(r)
+	return deps
+}
+
+func declarationsByName(declarations []ResourceDeclaration) map[string]ResourceDeclaration {
+	byName := map[string]ResourceDeclaration{}
+	for _, d := range declarations {
+		byName[d.Name] = d
+	}
+	return byName
+}
+
+func sortedResourceNames(resources map[string]ResourceDeclaration) []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func quoteAll(names codegen.StringSet) string {
+	quoted := names.SortedValues()
+	for i, name := range quoted {
+		quoted[i] = "'" + name + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func resourceRange(r *Resource) hcl.Range {
+	if r.syntax == nil {
+		return hcl.Range{}
+	}
+	return r.syntax.Range()
+}
@@ -0,0 +1,155 @@
+package pcl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+)
+
+const verifySourceProgram = `
+resource pet "random:index/randomPet:RandomPet" {
+	length = 2
+	prefix = "pet"
+}
+
+prefix = pet.id
+
+resource suffix "random:index/randomString:RandomString" {
+	length = 8
+	special = false
+	keepers = {
+		prefix = prefix
+	}
+}
+`
+
+func bindVerifyProgram(t *testing.T, source string) *pcl.Program {
+	program, diags, err := ParseAndBindProgram(t, source, "program.pp")
+	require.NoError(t, err)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return program
+}
+
+func TestVerifyEquivalent(t *testing.T) {
+	t.Parallel()
+
+	source := bindVerifyProgram(t, verifySourceProgram)
+
+	cases := []struct {
+		name     string
+		target   string
+		expected []string
+	}{
+		{
+			name: "equivalent",
+			// The dependency is direct rather than through a local, and the input values differ.
+			target: `
+resource pet "random:index/randomPet:RandomPet" {
+	length = 3
+	prefix = "cat"
+}
+
+resource suffix "random:index/randomString:RandomString" {
+	length = 8
+	special = true
+	keepers = {
+		prefix = pet.id
+	}
+}
+`,
+		},
+		{
+			name: "renamed variable",
+			target: `
+resource pet "random:index/randomPet:RandomPet" {
+	length = 2
+	prefix = "pet"
+}
+
+resource randomSuffix "random:index/randomString:RandomString" {
+	__logicalName = "suffix"
+	length = 8
+	special = false
+	keepers = {
+		prefix = pet.id
+	}
+}
+`,
+		},
+		{
+			name: "differences",
+			target: `
+resource pet "random:index/randomId:RandomId" {
+	byteLength = 2
+}
+
+resource suffix "random:index/randomString:RandomString" {
+	length = 8
+	upper = false
+}
+
+resource extra "random:index/randomPet:RandomPet" {
+}
+`,
+			expected: []string{
+				"resource 'pet' has type 'random::RandomId', but has type 'random::RandomPet' in the source program",
+				"resource 'pet' does not set the input properties 'length', 'prefix'",
+				"resource 'pet' sets the input properties 'byteLength', which the source program does not set",
+				"resource 'suffix' does not set the input properties 'keepers', 'special'",
+				"resource 'suffix' sets the input properties 'upper', which the source program does not set",
+				"resource 'suffix' does not depend on the resources 'pet'",
+				"resource 'extra' is not declared by the source program",
+			},
+		},
+		{
+			name: "missing resource",
+			target: `
+resource pet "random:index/randomPet:RandomPet" {
+	length = 2
+	prefix = "pet"
+}
+`,
+			expected: []string{
+				"resource 'suffix' is missing from the converted program",
+			},
+		},
+		{
+			name: "extra dependency",
+			target: `
+resource pet "random:index/randomPet:RandomPet" {
+	length = 2
+	prefix = suffix.result
+}
+
+resource suffix "random:index/randomString:RandomString" {
+	length = 8
+	special = false
+	keepers = {
+		prefix = "static"
+	}
+}
+`,
+			expected: []string{
+				"resource 'pet' depends on the resources 'suffix', which it does not depend on in the source program",
+				"resource 'suffix' does not depend on the resources 'pet'",
+			},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := bindVerifyProgram(t, c.target)
+			diags := pcl.VerifyEquivalent(source, target)
+			summaries := make([]string, 0, len(diags))
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary)
+			}
+			assert.ElementsMatch(t, c.expected, summaries)
+		})
+	}
+}
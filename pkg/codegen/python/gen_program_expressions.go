@@ -67,8 +67,13 @@ func (g *generator) GetPrecedence(expr model.Expression) int {
 		}
 	case *model.UnaryOpExpression:
 		return 13
-	case *model.FunctionCallExpression, *model.IndexExpression, *model.RelativeTraversalExpression,
-		*model.TemplateJoinExpression:
+	case *model.FunctionCallExpression:
+		// format is generated as a binary % expression.
+		if expr.Name == "format" {
+			return 12
+		}
+		return 16
+	case *model.IndexExpression, *model.RelativeTraversalExpression, *model.TemplateJoinExpression:
 		return 16
 	case *model.ForExpression, *model.ObjectConsExpression, *model.SplatExpression, *model.TupleConsExpression:
 		return 17
@@ -202,6 +207,8 @@ var functionImports = map[string][]string{
 	"fromBase64":       {"base64"},
 	"toJSON":           {"json"},
 	"sha1":             {"hashlib"},
+	"sha256":           {"hashlib"},
+	"fromJSON":         {"json"},
 	"stack":            {"pulumi"},
 	"project":          {"pulumi"},
 	"cwd":              {"os"},
@@ -404,6 +411,59 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "json.dumps(%.v)", expr.Args[0])
 	case "sha1":
 		g.Fgenf(w, "hashlib.sha1(%v.encode()).hexdigest()", expr.Args[0])
+	case "sha256":
+		g.Fgenf(w, "hashlib.sha256(%.16v.encode()).hexdigest()", expr.Args[0])
+	case "fromJSON":
+		g.Fgenf(w, "json.loads(%.v)", expr.Args[0])
+	case "lower":
+		g.Fgenf(w, "%.16v.lower()", expr.Args[0])
+	case "upper":
+		g.Fgenf(w, "%.16v.upper()", expr.Args[0])
+	case "replace":
+		g.Fgenf(w, "%.16v.replace(%.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
+	case "format":
+		g.Fgenf(w, "%.12v %% (", expr.Args[0])
+		for i, arg := range expr.Args[1:] {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "%.v", arg)
+		}
+		if len(expr.Args) == 2 {
+			// A single argument must still be passed as a tuple.
+			g.Fgen(w, ",")
+		}
+		g.Fgen(w, ")")
+	case "merge":
+		g.Fgen(w, "{")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "**%.16v", arg)
+		}
+		g.Fgen(w, "}")
+	case "concat":
+		g.Fgen(w, "[")
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "*%.16v", arg)
+		}
+		g.Fgen(w, "]")
+	case "distinct":
+		// dict.fromkeys preserves the order of the elements.
+		g.Fgenf(w, "list(dict.fromkeys(%.v))", expr.Args[0])
+	case "flatten":
+		g.Fgenf(w, "[item for sublist in %.v for item in sublist]", expr.Args[0])
+	case "keys":
+		// Keys are sorted lexically, as in the other languages, rather than kept in insertion order.
+		g.Fgenf(w, "sorted(%.16v.keys())", expr.Args[0])
+	case "values":
+		g.Fgenf(w, "[value for _, value in sorted(%.16v.items())]", expr.Args[0])
+	case "zipmap":
+		g.Fgenf(w, "dict(zip(%.v, %.v))", expr.Args[0], expr.Args[1])
 	case "project":
 		g.Fgen(w, "pulumi.get_project()")
 	case "stack":
@@ -0,0 +1,255 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/natefinch/atomic"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// unversioned is the name of the cache directory that holds the schemas of plugins that don't have a version.
+const unversioned = "unversioned"
+
+// checksumsDir is the name of the cache directory that holds the checksums of plugin executables.
+const checksumsDir = ".checksums"
+
+// SchemaCache is a persistent cache of the schemas returned by resource plugins. Each schema is keyed by the name and
+// version of the plugin that returned it, and by a checksum of the plugin's executable, so a schema is never used with
+// a plugin other than the one that returned it.
+//
+// Schemas are stored as <dir>/<name>/<version>/<checksum>.json. Hashing a plugin is expensive, so the checksum of each
+// executable is stored in <dir>/.checksums, keyed by the path, size and modification time of the executable.
+type SchemaCache struct {
+	dir string
+}
+
+// SchemaCacheEntry describes a schema in a SchemaCache.
+type SchemaCacheEntry struct {
+	// Name is the name of the plugin that returned the schema.
+	Name string
+	// Version is the version of the plugin that returned the schema, if it has one.
+	Version *semver.Version
+	// Checksum is the checksum of the plugin that returned the schema.
+	Checksum string
+	// Path is the path of the file that holds the schema.
+	Path string
+	// Size is the size of the schema in bytes.
+	Size int64
+	// ModTime is the time that the schema was cached.
+	ModTime time.Time
+}
+
+// NewSchemaCache returns a schema cache that is stored in the given directory.
+func NewSchemaCache(dir string) *SchemaCache {
+	return &SchemaCache{dir: dir}
+}
+
+// DefaultSchemaCache returns the schema cache that is stored in the '.pulumi/schemas' directory.
+func DefaultSchemaCache() (*SchemaCache, error) {
+	dir, err := workspace.GetPulumiPath(workspace.SchemaDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchemaCache(dir), nil
+}
+
+// Dir returns the directory that the cache is stored in.
+func (c *SchemaCache) Dir() string {
+	return c.dir
+}
+
+func versionDir(version *semver.Version) string {
+	if version == nil {
+		return unversioned
+	}
+	return version.String()
+}
+
+// path returns the path of the file that holds the schema of the given plugin.
+func (c *SchemaCache) path(name string, version *semver.Version, checksum string) string {
+	return filepath.Join(c.dir, name, versionDir(version), checksum+".json")
+}
+
+// write adds the schema of the given plugin to the cache, replacing the schemas of any other plugins with the same
+// name and version.
+func (c *SchemaCache) write(name string, version *semver.Version, checksum string, schemaBytes []byte) error {
+	path := c.path(name, version, checksum)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	if err := atomic.WriteFile(path, bytes.NewReader(schemaBytes)); err != nil {
+		return err
+	}
+
+	// The plugin that returned a stale schema has been replaced, so the stale schema can never be used again.
+	stale, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range stale {
+		if f.Name() != filepath.Base(path) {
+			if err := os.Remove(filepath.Join(dir, f.Name())); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Entries returns the schemas in the cache, sorted by plugin name and version.
+func (c *SchemaCache) Entries() ([]SchemaCacheEntry, error) {
+	var entries []SchemaCacheEntry
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == c.dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() && d.Name() == checksumsDir {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(c.dir, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) != 3 {
+			return nil
+		}
+		entry := SchemaCacheEntry{
+			Name:     parts[0],
+			Checksum: strings.TrimSuffix(parts[2], ".json"),
+			Path:     path,
+		}
+		if parts[1] != unversioned {
+			v, err := semver.Parse(parts[1])
+			if err != nil {
+				return nil
+			}
+			entry.Version = &v
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry.Size, entry.ModTime = info.Size(), info.ModTime()
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		vi, vj := entries[i].Version, entries[j].Version
+		if vi == nil || vj == nil {
+			return vi == nil && vj != nil
+		}
+		return vi.LT(*vj)
+	})
+	return entries, nil
+}
+
+// Remove removes the schemas of the plugins with the given name from the cache. If a version is given, only the
+// schemas of that version of the plugin are removed.
+func (c *SchemaCache) Remove(name string, version *semver.Version) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." || name == checksumsDir {
+		return fmt.Errorf("invalid plugin name %q", name)
+	}
+	path := filepath.Join(c.dir, name)
+	if version != nil {
+		path = filepath.Join(path, versionDir(version))
+	}
+	return os.RemoveAll(path)
+}
+
+// Clear removes all schemas from the cache.
+func (c *SchemaCache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// pluginChecksum returns a checksum of the executable of the given plugin. It returns false if the executable can't
+// be found, in which case schemas of the plugin can't be cached. The checksum is computed once for each path, size
+// and modification time of the executable, and read from the cache after that.
+func (c *SchemaCache) pluginChecksum(info *workspace.PluginInfo) (string, bool) {
+	if info.Path == "" {
+		return "", false
+	}
+	exts := []string{""}
+	if runtime.GOOS == "windows" {
+		exts = []string{".exe", ".cmd"}
+	}
+	var path string
+	var stat fs.FileInfo
+	for _, ext := range exts {
+		candidate := filepath.Join(info.Path, info.Spec().File()) + ext
+		if s, err := os.Stat(candidate); err == nil && !s.IsDir() {
+			path, stat = candidate, s
+			break
+		}
+	}
+	if stat == nil {
+		return "", false
+	}
+
+	key := sha256.New()
+	fmt.Fprintf(key, "%s\x00%d\x00%d", path, stat.Size(), stat.ModTime().UnixNano())
+	keyPath := filepath.Join(c.dir, checksumsDir, hex.EncodeToString(key.Sum(nil))[:16])
+	if checksum, err := os.ReadFile(keyPath); err == nil {
+		return string(checksum), true
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer contract.IgnoreClose(f)
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", false
+	}
+	checksum := hex.EncodeToString(h.Sum(nil))[:16]
+
+	// The checksum is only memoized to save time, so one that can't be stored is still used.
+	if err := os.MkdirAll(filepath.Dir(keyPath), 0o700); err == nil {
+		contract.IgnoreError(atomic.WriteFile(keyPath, strings.NewReader(checksum)))
+	}
+	return checksum, true
+}
@@ -0,0 +1,415 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeSeverity describes how a change between two versions of a package affects the users of its SDKs.
+type ChangeSeverity string
+
+const (
+	// SeverityError marks a breaking change: programs that compiled against the old SDK may no longer compile or run
+	// against the new one.
+	SeverityError ChangeSeverity = "error"
+	// SeverityWarning marks a change that doesn't break programs, but may change how existing stacks are deployed.
+	SeverityWarning ChangeSeverity = "warning"
+	// SeverityInfo marks a change that is safe to release in a minor version, such as an addition.
+	SeverityInfo ChangeSeverity = "info"
+)
+
+// PackageChange is a single change between two versions of a package.
+type PackageChange struct {
+	// Severity is the severity of the change.
+	Severity ChangeSeverity `json:"severity"`
+	// Token is the token of the resource, function or type that changed.
+	Token string `json:"token"`
+	// Property is the name of the property that changed, if any.
+	Property string `json:"property,omitempty"`
+	// Message describes the change.
+	Message string `json:"message"`
+	// Languages lists the SDK languages affected by the change. An empty list means that all of them are affected.
+	Languages []string `json:"languages,omitempty"`
+}
+
+func (c PackageChange) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s: %s", c.Severity, c.Token, c.Message)
+	if len(c.Languages) != 0 {
+		fmt.Fprintf(&sb, " (affects %s)", strings.Join(c.Languages, ", "))
+	}
+	return sb.String()
+}
+
+// AffectsLanguage returns true if the change affects the SDK for the given language.
+func (c PackageChange) AffectsLanguage(language string) bool {
+	if len(c.Languages) == 0 {
+		return true
+	}
+	for _, l := range c.Languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffPackages returns the changes between two versions of a package, sorted by token. The changes that break the
+// package's SDKs have SeverityError.
+func DiffPackages(oldPkg, newPkg *Package) []PackageChange {
+	d := &packageDiff{}
+
+	if oldPkg.Provider != nil && newPkg.Provider != nil {
+		d.diffResource(oldPkg.Provider, newPkg.Provider)
+	}
+	d.diffResources(oldPkg.Resources, newPkg.Resources)
+	d.diffFunctions(oldPkg.Functions, newPkg.Functions)
+	d.diffTypes(oldPkg.Types, newPkg.Types)
+
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if d.changes[i].Token != d.changes[j].Token {
+			return d.changes[i].Token < d.changes[j].Token
+		}
+		return d.changes[i].Property < d.changes[j].Property
+	})
+	return d.changes
+}
+
+type packageDiff struct {
+	changes []PackageChange
+}
+
+func (d *packageDiff) add(severity ChangeSeverity, token, property string, languages []string,
+	format string, args ...interface{},
+) {
+	d.changes = append(d.changes, PackageChange{
+		Severity:  severity,
+		Token:     token,
+		Property:  property,
+		Message:   fmt.Sprintf(format, args...),
+		Languages: languages,
+	})
+}
+
+// tokenName returns the name part of a token, which is what a rename that only moves a member between modules keeps.
+func tokenName(token string) string {
+	return strings.ToLower(token[strings.LastIndex(token, ":")+1:])
+}
+
+// renamedTo returns the token among the given added tokens that the removed token was most likely renamed to.
+func renamedTo(removed string, added []string) (string, bool) {
+	for _, token := range added {
+		if tokenName(token) == tokenName(removed) {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+func (d *packageDiff) diffResources(oldResources, newResources []*Resource) {
+	newTable := map[string]*Resource{}
+	aliasTable := map[string]*Resource{}
+	for _, r := range newResources {
+		newTable[r.Token] = r
+		for _, a := range r.Aliases {
+			if a.Type != nil {
+				aliasTable[*a.Type] = r
+			}
+		}
+	}
+
+	oldTable := map[string]*Resource{}
+	for _, r := range oldResources {
+		oldTable[r.Token] = r
+	}
+	var added []string
+	for _, r := range newResources {
+		if _, has := oldTable[r.Token]; !has {
+			added = append(added, r.Token)
+		}
+	}
+
+	for _, oldRes := range oldResources {
+		newRes, has := newTable[oldRes.Token]
+		if has {
+			d.diffResource(oldRes, newRes)
+			continue
+		}
+
+		if aliased, has := aliasTable[oldRes.Token]; has {
+			d.add(SeverityError, oldRes.Token, "", nil,
+				"resource was renamed to %s; programs must be updated, but existing stacks will migrate through "+
+					"its alias", aliased.Token)
+			d.diffResource(oldRes, aliased)
+		} else if token, has := renamedTo(oldRes.Token, added); has {
+			d.add(SeverityError, oldRes.Token, "", nil,
+				"resource appears to have been renamed to %s without an alias; existing stacks will replace it",
+				token)
+		} else {
+			d.add(SeverityError, oldRes.Token, "", nil, "resource was removed")
+		}
+	}
+
+	for _, token := range added {
+		d.add(SeverityInfo, token, "", nil, "resource was added")
+	}
+}
+
+func (d *packageDiff) diffResource(oldRes, newRes *Resource) {
+	d.diffProperties(oldRes.Token, "input property", oldRes.InputProperties, newRes.InputProperties, true, false)
+	d.diffProperties(oldRes.Token, "output property", oldRes.Properties, newRes.Properties, false, true)
+
+	// A property that triggers a replacement may be declared as either an input or an output.
+	replaceOnChanges := func(r *Resource) map[string]bool {
+		names := map[string]bool{}
+		for _, props := range [][]*Property{r.InputProperties, r.Properties} {
+			for _, p := range props {
+				if p.ReplaceOnChanges {
+					names[p.Name] = true
+				}
+			}
+		}
+		return names
+	}
+	oldReplaces, newReplaces := replaceOnChanges(oldRes), replaceOnChanges(newRes)
+	for _, name := range sortedKeys(newReplaces) {
+		if !oldReplaces[name] {
+			d.add(SeverityWarning, oldRes.Token, name, nil,
+				"changes to property %q now replace the resource", name)
+		}
+	}
+	for _, name := range sortedKeys(oldReplaces) {
+		if !newReplaces[name] {
+			d.add(SeverityInfo, oldRes.Token, name, nil,
+				"changes to property %q no longer replace the resource", name)
+		}
+	}
+}
+
+func (d *packageDiff) diffFunctions(oldFunctions, newFunctions []*Function) {
+	newTable := map[string]*Function{}
+	for _, f := range newFunctions {
+		newTable[f.Token] = f
+	}
+	oldTable := map[string]*Function{}
+	for _, f := range oldFunctions {
+		oldTable[f.Token] = f
+	}
+	var added []string
+	for _, f := range newFunctions {
+		if _, has := oldTable[f.Token]; !has {
+			added = append(added, f.Token)
+		}
+	}
+
+	for _, oldFn := range oldFunctions {
+		newFn, has := newTable[oldFn.Token]
+		if !has {
+			if token, has := renamedTo(oldFn.Token, added); has {
+				d.add(SeverityError, oldFn.Token, "", nil, "function appears to have been renamed to %s", token)
+			} else {
+				d.add(SeverityError, oldFn.Token, "", nil, "function was removed")
+			}
+			continue
+		}
+
+		d.diffProperties(oldFn.Token, "argument", objectProperties(oldFn.Inputs), objectProperties(newFn.Inputs),
+			true, false)
+		if oldFn.Outputs != nil && newFn.Outputs != nil {
+			d.diffProperties(oldFn.Token, "result property", oldFn.Outputs.Properties, newFn.Outputs.Properties,
+				false, true)
+		} else if oldFn.ReturnType != nil || newFn.ReturnType != nil {
+			d.diffType(oldFn.Token, "", "return type", oldFn.ReturnType, newFn.ReturnType)
+		}
+	}
+
+	for _, token := range added {
+		d.add(SeverityInfo, token, "", nil, "function was added")
+	}
+}
+
+func (d *packageDiff) diffTypes(oldTypes, newTypes []Type) {
+	typeToken := func(t Type) (string, bool) {
+		switch t := t.(type) {
+		case *ObjectType:
+			// Every object type has a plain and an input shape, of which it is enough to compare the plain ones.
+			return t.Token, !t.IsOverlay && t.IsPlainShape()
+		case *EnumType:
+			return t.Token, !t.IsOverlay
+		default:
+			return "", false
+		}
+	}
+
+	newTable := map[string]Type{}
+	for _, t := range newTypes {
+		if token, ok := typeToken(t); ok {
+			newTable[token] = t
+		}
+	}
+
+	for _, oldType := range oldTypes {
+		token, ok := typeToken(oldType)
+		if !ok {
+			continue
+		}
+		newType, has := newTable[token]
+		if !has {
+			d.add(SeverityError, token, "", nil, "type was removed")
+			continue
+		}
+
+		switch oldType := oldType.(type) {
+		case *ObjectType:
+			newType, ok := newType.(*ObjectType)
+			if !ok {
+				d.add(SeverityError, token, "", nil, "object type was changed to an enum")
+				continue
+			}
+			// Object types may be used as both inputs and outputs, so they must remain compatible as both.
+			d.diffProperties(token, "property", oldType.Properties, newType.Properties, true, true)
+		case *EnumType:
+			newType, ok := newType.(*EnumType)
+			if !ok {
+				d.add(SeverityError, token, "", nil, "enum type was changed to an object type")
+				continue
+			}
+			d.diffEnum(oldType, newType)
+		}
+	}
+}
+
+func (d *packageDiff) diffEnum(oldType, newType *EnumType) {
+	d.diffType(oldType.Token, "", "element type", oldType.ElementType, newType.ElementType)
+
+	newValues := map[string]bool{}
+	for _, e := range newType.Elements {
+		newValues[fmt.Sprint(e.Value)] = true
+	}
+	for _, e := range oldType.Elements {
+		if value := fmt.Sprint(e.Value); !newValues[value] {
+			d.add(SeverityError, oldType.Token, value, nil, "enum value %q was removed", value)
+		}
+	}
+}
+
+// diffProperties compares two lists of properties of the same member. Properties that are inputs must not become
+// required, and properties that are outputs must not become optional.
+func (d *packageDiff) diffProperties(token, kind string, oldProps, newProps []*Property, input, output bool) {
+	newTable := map[string]*Property{}
+	for _, p := range newProps {
+		newTable[p.Name] = p
+	}
+	oldTable := map[string]*Property{}
+	for _, p := range oldProps {
+		oldTable[p.Name] = p
+	}
+
+	for _, oldProp := range oldProps {
+		newProp, has := newTable[oldProp.Name]
+		if !has {
+			d.add(SeverityError, token, oldProp.Name, nil, "%s %q was removed", kind, oldProp.Name)
+			continue
+		}
+
+		d.diffType(token, oldProp.Name, fmt.Sprintf("%s %q", kind, oldProp.Name), oldProp.Type, newProp.Type)
+		switch {
+		case input && !oldProp.IsRequired() && newProp.IsRequired():
+			d.add(SeverityError, token, oldProp.Name, nil, "%s %q became required", kind, oldProp.Name)
+		case output && oldProp.IsRequired() && !newProp.IsRequired():
+			// Optional outputs are pointers in Go and possibly undefined in TypeScript.
+			d.add(SeverityError, token, oldProp.Name, []string{"go", "nodejs"},
+				"%s %q became optional", kind, oldProp.Name)
+		}
+	}
+
+	for _, newProp := range newProps {
+		if _, has := oldTable[newProp.Name]; has {
+			continue
+		}
+		if input && newProp.IsRequired() {
+			d.add(SeverityError, token, newProp.Name, nil, "required %s %q was added", kind, newProp.Name)
+		} else {
+			d.add(SeverityInfo, token, newProp.Name, nil, "%s %q was added", kind, newProp.Name)
+		}
+	}
+}
+
+// diffType reports a change to the type of the given member.
+func (d *packageDiff) diffType(token, property, what string, oldType, newType Type) {
+	oldString, newString := typeIdentity(oldType), typeIdentity(newType)
+	if oldString == newString {
+		return
+	}
+
+	// Integers and numbers are both numbers in JavaScript and interchangeable in Python.
+	var languages []string
+	if isNumeric(oldType) && isNumeric(newType) {
+		languages = []string{"dotnet", "go"}
+	}
+	d.add(SeverityError, token, property, languages, "%s changed type from %s to %s", what, oldString, newString)
+}
+
+// typeIdentity returns a string that identifies the given type regardless of whether it is optional or accepts
+// outputs, which are covered by the checks on required properties.
+func typeIdentity(t Type) string {
+	switch t := t.(type) {
+	case nil:
+		return "none"
+	case *InputType:
+		return typeIdentity(t.ElementType)
+	case *OptionalType:
+		return typeIdentity(t.ElementType)
+	case *ArrayType:
+		return fmt.Sprintf("Array<%s>", typeIdentity(t.ElementType))
+	case *MapType:
+		return fmt.Sprintf("Map<%s>", typeIdentity(t.ElementType))
+	case *UnionType:
+		elements := make([]string, 0, len(t.ElementTypes))
+		seen := map[string]bool{}
+		for _, e := range t.ElementTypes {
+			if s := typeIdentity(e); !seen[s] {
+				seen[s] = true
+				elements = append(elements, s)
+			}
+		}
+		return fmt.Sprintf("Union<%s>", strings.Join(elements, ", "))
+	case *ObjectType:
+		return t.Token
+	default:
+		return t.String()
+	}
+}
+
+func isNumeric(t Type) bool {
+	switch t := t.(type) {
+	case *InputType:
+		return isNumeric(t.ElementType)
+	case *OptionalType:
+		return isNumeric(t.ElementType)
+	default:
+		return t == IntType || t == NumberType
+	}
+}
+
+func objectProperties(t *ObjectType) []*Property {
+	if t == nil {
+		return nil
+	}
+	return t.Properties
+}
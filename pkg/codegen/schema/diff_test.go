@@ -0,0 +1,320 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffTestPackage(t *testing.T, mutate func(spec *PackageSpec)) *Package {
+	spec := PackageSpec{
+		Name:    "test",
+		Version: "1.0.0",
+		Resources: map[string]ResourceSpec{
+			"test:index:Bucket": {
+				ObjectTypeSpec: ObjectTypeSpec{
+					Type: "object",
+					Properties: map[string]PropertySpec{
+						"name": {TypeSpec: TypeSpec{Type: "string"}},
+						"size": {TypeSpec: TypeSpec{Type: "integer"}},
+					},
+					Required: []string{"name", "size"},
+				},
+				InputProperties: map[string]PropertySpec{
+					"name":  {TypeSpec: TypeSpec{Type: "string"}},
+					"size":  {TypeSpec: TypeSpec{Type: "integer"}},
+					"class": {TypeSpec: TypeSpec{Ref: "#/types/test:index:StorageClass"}},
+					"rules": {TypeSpec: TypeSpec{Type: "array", Items: &TypeSpec{Ref: "#/types/test:index:Rule"}}},
+				},
+			},
+			"test:index:Queue": {
+				ObjectTypeSpec: ObjectTypeSpec{Type: "object"},
+			},
+		},
+		Functions: map[string]FunctionSpec{
+			"test:index:getBucket": {
+				Inputs: &ObjectTypeSpec{
+					Properties: map[string]PropertySpec{
+						"name": {TypeSpec: TypeSpec{Type: "string"}},
+					},
+				},
+			},
+		},
+		Types: map[string]ComplexTypeSpec{
+			"test:index:StorageClass": {
+				ObjectTypeSpec: ObjectTypeSpec{Type: "string"},
+				Enum: []EnumValueSpec{
+					{Name: "Standard", Value: "STANDARD"},
+					{Name: "Archive", Value: "ARCHIVE"},
+				},
+			},
+			"test:index:Rule": {
+				ObjectTypeSpec: ObjectTypeSpec{
+					Type: "object",
+					Properties: map[string]PropertySpec{
+						"days": {TypeSpec: TypeSpec{Type: "integer"}},
+					},
+				},
+			},
+		},
+	}
+	if mutate != nil {
+		mutate(&spec)
+	}
+
+	pkg, diags, err := BindSpec(spec, nil)
+	require.NoError(t, err)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return pkg
+}
+
+func TestDiffPackages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mutate   func(spec *PackageSpec)
+		expected []PackageChange
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name: "resource removed",
+			mutate: func(spec *PackageSpec) {
+				delete(spec.Resources, "test:index:Queue")
+			},
+			expected: []PackageChange{
+				{Severity: SeverityError, Token: "test:index:Queue", Message: "resource was removed"},
+			},
+		},
+		{
+			name: "resource renamed without an alias",
+			mutate: func(spec *PackageSpec) {
+				spec.Resources["test:messaging:Queue"] = spec.Resources["test:index:Queue"]
+				delete(spec.Resources, "test:index:Queue")
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:Queue",
+					Message: "resource appears to have been renamed to test:messaging:Queue without an alias; " +
+						"existing stacks will replace it",
+				},
+				{Severity: SeverityInfo, Token: "test:messaging:Queue", Message: "resource was added"},
+			},
+		},
+		{
+			name: "resource renamed with an alias",
+			mutate: func(spec *PackageSpec) {
+				oldToken := "test:index:Queue"
+				queue := spec.Resources[oldToken]
+				queue.Aliases = []AliasSpec{{Type: &oldToken}}
+				spec.Resources["test:messaging:Queue"] = queue
+				delete(spec.Resources, oldToken)
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:Queue",
+					Message: "resource was renamed to test:messaging:Queue; programs must be updated, " +
+						"but existing stacks will migrate through its alias",
+				},
+				{Severity: SeverityInfo, Token: "test:messaging:Queue", Message: "resource was added"},
+			},
+		},
+		{
+			name: "function removed",
+			mutate: func(spec *PackageSpec) {
+				delete(spec.Functions, "test:index:getBucket")
+			},
+			expected: []PackageChange{
+				{Severity: SeverityError, Token: "test:index:getBucket", Message: "function was removed"},
+			},
+		},
+		{
+			name: "input became required",
+			mutate: func(spec *PackageSpec) {
+				bucket := spec.Resources["test:index:Bucket"]
+				bucket.RequiredInputs = []string{"name"}
+				spec.Resources["test:index:Bucket"] = bucket
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:Bucket",
+					Property: "name",
+					Message:  `input property "name" became required`,
+				},
+			},
+		},
+		{
+			name: "output became optional",
+			mutate: func(spec *PackageSpec) {
+				bucket := spec.Resources["test:index:Bucket"]
+				bucket.Required = []string{"size"}
+				spec.Resources["test:index:Bucket"] = bucket
+			},
+			expected: []PackageChange{
+				{
+					Severity:  SeverityError,
+					Token:     "test:index:Bucket",
+					Property:  "name",
+					Message:   `output property "name" became optional`,
+					Languages: []string{"go", "nodejs"},
+				},
+			},
+		},
+		{
+			name: "type changes",
+			mutate: func(spec *PackageSpec) {
+				bucket := spec.Resources["test:index:Bucket"]
+				bucket.InputProperties = map[string]PropertySpec{
+					"name":  {TypeSpec: TypeSpec{Type: "array", Items: &TypeSpec{Type: "string"}}},
+					"size":  {TypeSpec: TypeSpec{Type: "number"}},
+					"class": {TypeSpec: TypeSpec{Ref: "#/types/test:index:StorageClass"}},
+					"rules": {TypeSpec: TypeSpec{Type: "array", Items: &TypeSpec{Ref: "#/types/test:index:Rule"}}},
+				}
+				spec.Resources["test:index:Bucket"] = bucket
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:Bucket",
+					Property: "name",
+					Message:  `input property "name" changed type from string to Array<string>`,
+				},
+				{
+					Severity:  SeverityError,
+					Token:     "test:index:Bucket",
+					Property:  "size",
+					Message:   `input property "size" changed type from integer to number`,
+					Languages: []string{"dotnet", "go"},
+				},
+			},
+		},
+		{
+			name: "enum value removed",
+			mutate: func(spec *PackageSpec) {
+				class := spec.Types["test:index:StorageClass"]
+				class.Enum = class.Enum[:1]
+				spec.Types["test:index:StorageClass"] = class
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:StorageClass",
+					Property: "ARCHIVE",
+					Message:  `enum value "ARCHIVE" was removed`,
+				},
+			},
+		},
+		{
+			name: "object type property removed",
+			mutate: func(spec *PackageSpec) {
+				rule := spec.Types["test:index:Rule"]
+				rule.Properties = nil
+				spec.Types["test:index:Rule"] = rule
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:Rule",
+					Property: "days",
+					Message:  `property "days" was removed`,
+				},
+			},
+		},
+		{
+			name: "replaceOnChanges added",
+			mutate: func(spec *PackageSpec) {
+				bucket := spec.Resources["test:index:Bucket"]
+				bucket.Properties = map[string]PropertySpec{
+					"name": {TypeSpec: TypeSpec{Type: "string"}, ReplaceOnChanges: true},
+					"size": {TypeSpec: TypeSpec{Type: "integer"}},
+				}
+				spec.Resources["test:index:Bucket"] = bucket
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityWarning,
+					Token:    "test:index:Bucket",
+					Property: "name",
+					Message:  `changes to property "name" now replace the resource`,
+				},
+			},
+		},
+		{
+			name: "additions",
+			mutate: func(spec *PackageSpec) {
+				bucket := spec.Resources["test:index:Bucket"]
+				bucket.InputProperties = map[string]PropertySpec{
+					"name":   {TypeSpec: TypeSpec{Type: "string"}},
+					"size":   {TypeSpec: TypeSpec{Type: "integer"}},
+					"class":  {TypeSpec: TypeSpec{Ref: "#/types/test:index:StorageClass"}},
+					"rules":  {TypeSpec: TypeSpec{Type: "array", Items: &TypeSpec{Ref: "#/types/test:index:Rule"}}},
+					"region": {TypeSpec: TypeSpec{Type: "string"}},
+				}
+				bucket.RequiredInputs = []string{"region"}
+				spec.Resources["test:index:Bucket"] = bucket
+
+				getBucket := spec.Functions["test:index:getBucket"]
+				getBucket.Inputs.Properties = map[string]PropertySpec{
+					"name":  {TypeSpec: TypeSpec{Type: "string"}},
+					"owner": {TypeSpec: TypeSpec{Type: "string"}},
+				}
+				spec.Functions["test:index:getBucket"] = getBucket
+			},
+			expected: []PackageChange{
+				{
+					Severity: SeverityError,
+					Token:    "test:index:Bucket",
+					Property: "region",
+					Message:  `required input property "region" was added`,
+				},
+				{
+					Severity: SeverityInfo,
+					Token:    "test:index:getBucket",
+					Property: "owner",
+					Message:  `argument "owner" was added`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changes := DiffPackages(diffTestPackage(t, nil), diffTestPackage(t, tt.mutate))
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestPackageChangeAffectsLanguage(t *testing.T) {
+	t.Parallel()
+
+	all := PackageChange{Severity: SeverityError}
+	assert.True(t, all.AffectsLanguage("python"))
+
+	some := PackageChange{Severity: SeverityError, Languages: []string{"dotnet", "go"}}
+	assert.True(t, some.AffectsLanguage("go"))
+	assert.False(t, some.AffectsLanguage("python"))
+}
@@ -0,0 +1,124 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config configures the rules that lint a package. For example:
+//
+//	rules:
+//	  missing-description: off
+//	  unmarked-secret: error
+//	ignore:
+//	  - rule: property-casing
+//	    token: aws:s3/bucket:Bucket
+//	    property: acl_policy
+type Config struct {
+	// Rules overrides the severity of rules by name. A rule whose severity is "off" doesn't run.
+	Rules map[string]Severity `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Ignore suppresses the diagnostics that match any of its entries.
+	Ignore []Suppression `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+}
+
+// Suppression matches the diagnostics to suppress. Empty fields match any diagnostic.
+type Suppression struct {
+	// Rule is the name of the rule whose diagnostics to suppress.
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// Token is a pattern for the tokens of the members whose diagnostics to suppress. In the pattern, '*' matches any
+	// sequence of characters, including ':' and '/', and '?' matches any single character.
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+	// Property is the name of the property whose diagnostics to suppress.
+	Property string `json:"property,omitempty" yaml:"property,omitempty"`
+}
+
+// LoadConfig loads a lint configuration from the given YAML or JSON file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read lint configuration: %w", err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("could not parse lint configuration %v: %w", file, err)
+	}
+	return &config, nil
+}
+
+// validate checks that the config only refers to the given rules.
+func (c *Config) validate(rules []Rule) error {
+	names := map[string]bool{}
+	for _, r := range rules {
+		names[r.Name()] = true
+	}
+
+	for name, severity := range c.Rules {
+		if !names[name] {
+			return fmt.Errorf("unknown lint rule %q", name)
+		}
+		if err := severity.validate(); err != nil {
+			return fmt.Errorf("lint rule %q: %w", name, err)
+		}
+	}
+	for _, s := range c.Ignore {
+		if s.Rule != "" && !names[s.Rule] {
+			return fmt.Errorf("unknown lint rule %q", s.Rule)
+		}
+	}
+	return nil
+}
+
+func (c *Config) suppressed(d Diagnostic) bool {
+	for _, s := range c.Ignore {
+		if s.Rule != "" && s.Rule != d.Rule {
+			continue
+		}
+		if s.Property != "" && s.Property != d.Property {
+			continue
+		}
+		if s.Token != "" {
+			if !tokenPattern(s.Token).MatchString(d.Token) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// tokenPattern returns a regexp that matches the tokens that the given pattern matches. Tokens are matched as flat
+// text, so unlike path.Match, '*' also matches the '/' in a token such as "aws:s3/bucket:Bucket".
+func tokenPattern(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
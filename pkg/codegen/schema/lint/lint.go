@@ -0,0 +1,153 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks Pulumi package schemas for quality problems that the metaschema doesn't catch, such as missing
+// descriptions or secrets that aren't marked as such.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// Severity is the severity of a lint diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	// SeverityOff disables a rule.
+	SeverityOff Severity = "off"
+)
+
+func (s Severity) validate() error {
+	switch s {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return nil
+	default:
+		return fmt.Errorf("unknown severity %q; expected one of error, warning, info or off", s)
+	}
+}
+
+// ReportFunc reports a problem with the member of a package that has the given token. The property is empty if the
+// problem isn't specific to one of the member's properties.
+type ReportFunc func(token, property, message string)
+
+// Rule is a lint rule that checks a package for a single kind of problem.
+type Rule interface {
+	// Name returns the name of the rule, which identifies it in configuration and diagnostics.
+	Name() string
+	// Description returns a short description of the problems that the rule reports.
+	Description() string
+	// DefaultSeverity returns the severity of the rule's diagnostics, unless it is configured otherwise.
+	DefaultSeverity() Severity
+	// Check checks the given package, reporting each problem it finds.
+	Check(pkg *schema.Package, report ReportFunc)
+}
+
+// NewRule returns a rule that runs the given check.
+func NewRule(name, description string, severity Severity, check func(pkg *schema.Package, report ReportFunc)) Rule {
+	return &rule{name: name, description: description, severity: severity, check: check}
+}
+
+type rule struct {
+	name        string
+	description string
+	severity    Severity
+	check       func(pkg *schema.Package, report ReportFunc)
+}
+
+func (r *rule) Name() string                                 { return r.name }
+func (r *rule) Description() string                          { return r.description }
+func (r *rule) DefaultSeverity() Severity                    { return r.severity }
+func (r *rule) Check(pkg *schema.Package, report ReportFunc) { r.check(pkg, report) }
+
+// Diagnostic is a problem that a rule found in a package.
+type Diagnostic struct {
+	// Rule is the name of the rule that reported the problem.
+	Rule string `json:"rule"`
+	// Severity is the severity of the problem.
+	Severity Severity `json:"severity"`
+	// Token is the token of the member that has the problem, or the name of the package for package-wide problems.
+	Token string `json:"token"`
+	// Property is the name of the property that has the problem, if any.
+	Property string `json:"property,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Lint checks the package with the given rules, as configured by the given config, which may be nil. The diagnostics
+// are sorted by token.
+func Lint(pkg *schema.Package, rules []Rule, config *Config) ([]Diagnostic, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	if err := config.validate(rules); err != nil {
+		return nil, err
+	}
+
+	var diags []Diagnostic
+	for _, r := range rules {
+		severity := r.DefaultSeverity()
+		if s, has := config.Rules[r.Name()]; has {
+			severity = s
+		}
+		if severity == SeverityOff {
+			continue
+		}
+
+		// The same problem may be found through both the inputs and the outputs of a resource, but is only reported once.
+		seen := map[Diagnostic]bool{}
+		r.Check(pkg, func(token, property, message string) {
+			d := Diagnostic{Rule: r.Name(), Severity: severity, Token: token, Property: property, Message: message}
+			if !seen[d] && !config.suppressed(d) {
+				seen[d] = true
+				diags = append(diags, d)
+			}
+		})
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Token != diags[j].Token {
+			return diags[i].Token < diags[j].Token
+		}
+		if diags[i].Property != diags[j].Property {
+			return diags[i].Property < diags[j].Property
+		}
+		return diags[i].Rule < diags[j].Rule
+	})
+	return diags, nil
+}
+
+// TokenDiagnostics are the diagnostics of a single member of a package.
+type TokenDiagnostics struct {
+	Token       string       `json:"token"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// GroupByToken groups sorted diagnostics by the token of the member that they apply to.
+func GroupByToken(diags []Diagnostic) []TokenDiagnostics {
+	groups := []TokenDiagnostics{}
+	for _, d := range diags {
+		if n := len(groups); n == 0 || groups[n-1].Token != d.Token {
+			groups = append(groups, TokenDiagnostics{Token: d.Token})
+		}
+		group := &groups[len(groups)-1]
+		group.Diagnostics = append(group.Diagnostics, d)
+	}
+	return groups
+}
@@ -0,0 +1,274 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func bindTestPackage(t *testing.T) *schema.Package {
+	spec := schema.PackageSpec{
+		Name: "test",
+		Language: map[string]schema.RawMessage{
+			"go": schema.RawMessage(`{"disableFunctionOutputVersions": true}`),
+		},
+		Resources: map[string]schema.ResourceSpec{
+			"test:index:Database": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Description: "A database.",
+					Type:        "object",
+					Properties: map[string]schema.PropertySpec{
+						"adminPassword": {TypeSpec: schema.TypeSpec{Type: "string"}, Description: "The password."},
+						"secretArn":     {TypeSpec: schema.TypeSpec{Type: "string"}, Description: "The secret's ARN."},
+						"engine_name":   {TypeSpec: schema.TypeSpec{Type: "string"}, Description: "The engine."},
+					},
+				},
+				InputProperties: map[string]schema.PropertySpec{
+					"adminPassword": {TypeSpec: schema.TypeSpec{Type: "string"}, Description: "The password."},
+					"engine_name":   {TypeSpec: schema.TypeSpec{Type: "string"}},
+					"tier":          {TypeSpec: schema.TypeSpec{Ref: "#/types/test:index:Tier"}, Description: "The tier."},
+					"backup": {
+						TypeSpec:    schema.TypeSpec{Ref: "#/types/test:index:Backup"},
+						Description: "The backup policy.",
+					},
+				},
+			},
+		},
+		Functions: map[string]schema.FunctionSpec{
+			"test:index:getVersion": {
+				Description: "Gets the version.",
+				Outputs: &schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"version": {TypeSpec: schema.TypeSpec{Type: "string"}, Description: "The version."},
+					},
+				},
+			},
+		},
+		Types: map[string]schema.ComplexTypeSpec{
+			"test:index:Tier": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{Description: "A tier.", Type: "string"},
+				Enum: []schema.EnumValueSpec{
+					{Name: "Small", Value: "small"},
+					{Name: "Tiny", Value: "small"},
+				},
+			},
+			"test:index:Backup": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Description: "A backup policy.",
+					Type:        "object",
+					Properties: map[string]schema.PropertySpec{
+						"days": {TypeSpec: schema.TypeSpec{Type: "integer"}, Description: "The retention."},
+					},
+				},
+			},
+			"test:index:Unused": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{Description: "Not used.", Type: "object"},
+			},
+		},
+	}
+
+	pkg, diags, err := schema.BindSpec(spec, nil)
+	require.NoError(t, err)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return pkg
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	pkg := bindTestPackage(t)
+	diags, err := Lint(pkg, DefaultRules(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Diagnostic{
+		{
+			Rule:     "missing-output-version",
+			Severity: SeverityInfo,
+			Token:    "test",
+			Message:  "output-versioned functions are disabled for the Go SDK",
+		},
+		{
+			Rule:     "unmarked-secret",
+			Severity: SeverityWarning,
+			Token:    "test:index:Database",
+			Property: "adminPassword",
+			Message:  `property "adminPassword" looks like a secret, but is not marked secret`,
+		},
+		{
+			Rule:     "missing-description",
+			Severity: SeverityWarning,
+			Token:    "test:index:Database",
+			Property: "engine_name",
+			Message:  `property "engine_name" has no description`,
+		},
+		{
+			Rule:     "property-casing",
+			Severity: SeverityWarning,
+			Token:    "test:index:Database",
+			Property: "engine_name",
+			Message:  `property "engine_name" is not camelCase`,
+		},
+		{
+			Rule:     "duplicate-enum-value",
+			Severity: SeverityError,
+			Token:    "test:index:Tier",
+			Property: "Tiny",
+			Message:  `enum value "small" of "Tiny" duplicates the value of "Small"`,
+		},
+		{
+			Rule:     "unused-type",
+			Severity: SeverityWarning,
+			Token:    "test:index:Unused",
+			Message:  "type is not referenced by any resource, function or other type",
+		},
+		{
+			Rule:     "missing-output-version",
+			Severity: SeverityInfo,
+			Token:    "test:index:getVersion",
+			Message:  "function takes no arguments, so it has no output-versioned form",
+		},
+	}, diags)
+
+	groups := GroupByToken(diags)
+	require.Len(t, groups, 5)
+	assert.Equal(t, "test:index:Database", groups[1].Token)
+	assert.Len(t, groups[1].Diagnostics, 3)
+}
+
+func TestLintConfig(t *testing.T) {
+	t.Parallel()
+
+	pkg := bindTestPackage(t)
+	config := &Config{
+		Rules: map[string]Severity{
+			"missing-output-version": SeverityOff,
+			"unmarked-secret":        SeverityError,
+		},
+		Ignore: []Suppression{
+			{Rule: "property-casing", Token: "test:index:*", Property: "engine_name"},
+			{Token: "test:index:Tier"},
+			{Rule: "unused-type"},
+		},
+	}
+	diags, err := Lint(pkg, DefaultRules(), config)
+	require.NoError(t, err)
+
+	var rules []string
+	for _, d := range diags {
+		rules = append(rules, d.Rule)
+		if d.Rule == "unmarked-secret" {
+			assert.Equal(t, SeverityError, d.Severity)
+		}
+	}
+	assert.Equal(t, []string{"unmarked-secret", "missing-description"}, rules)
+}
+
+func TestLintConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	pkg := bindTestPackage(t)
+
+	_, err := Lint(pkg, DefaultRules(), &Config{Rules: map[string]Severity{"no-such-rule": SeverityError}})
+	assert.ErrorContains(t, err, `unknown lint rule "no-such-rule"`)
+
+	_, err = Lint(pkg, DefaultRules(), &Config{Rules: map[string]Severity{"unused-type": "fatal"}})
+	assert.ErrorContains(t, err, `unknown severity "fatal"`)
+}
+
+func TestLintConfigTokenPatterns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern    string
+		token      string
+		suppressed bool
+	}{
+		{"aws:s3/bucket:Bucket", "aws:s3/bucket:Bucket", true},
+		{"aws:*", "aws:s3/bucket:Bucket", true},
+		{"aws:s3*", "aws:s3/bucket:Bucket", true},
+		{"aws:s3/*:Bucket", "aws:s3/bucket:Bucket", true},
+		{"*:Bucket", "aws:s3/bucket:Bucket", true},
+		{"aws:s3/bucket:Bucke?", "aws:s3/bucket:Bucket", true},
+		{"aws:*", "azure:s3/bucket:Bucket", false},
+		{"aws:s3/*", "aws:ec2/instance:Instance", false},
+		{"aws:s3?bucket:Bucket", "aws:s3.bucket:Bucket", true},
+		{"aws:s3.bucket:Bucket", "aws:s3/bucket:Bucket", false},
+		{"aws:[s]3/bucket:Bucket", "aws:s3/bucket:Bucket", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.pattern+" "+tt.token, func(t *testing.T) {
+			t.Parallel()
+
+			config := &Config{Ignore: []Suppression{{Token: tt.pattern}}}
+			assert.Equal(t, tt.suppressed, config.suppressed(Diagnostic{Rule: "property-casing", Token: tt.token}))
+		})
+	}
+}
+
+func TestLintCustomRule(t *testing.T) {
+	t.Parallel()
+
+	noFunctions := NewRule("no-functions", "Packages should not have functions", SeverityError,
+		func(pkg *schema.Package, report ReportFunc) {
+			for _, f := range pkg.Functions {
+				report(f.Token, "", "function is not allowed")
+			}
+		})
+
+	diags, err := Lint(bindTestPackage(t), []Rule{noFunctions}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Diagnostic{{
+		Rule:     "no-functions",
+		Severity: SeverityError,
+		Token:    "test:index:getVersion",
+		Message:  "function is not allowed",
+	}}, diags)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "lint.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(`
+rules:
+  missing-description: off
+ignore:
+  - rule: property-casing
+    token: test:index:Database
+`), 0o600))
+	config, err := LoadConfig(yamlFile)
+	require.NoError(t, err)
+	assert.Equal(t, &Config{
+		Rules:  map[string]Severity{"missing-description": SeverityOff},
+		Ignore: []Suppression{{Rule: "property-casing", Token: "test:index:Database"}},
+	}, config)
+
+	jsonFile := filepath.Join(dir, "lint.json")
+	b, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(jsonFile, b, 0o600))
+	fromJSON, err := LoadConfig(jsonFile)
+	require.NoError(t, err)
+	assert.Equal(t, config, fromJSON)
+}
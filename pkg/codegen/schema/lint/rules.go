@@ -0,0 +1,274 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// DefaultRules returns the built-in lint rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		NewRule("missing-description",
+			"Resources, functions, types and properties should have descriptions",
+			SeverityWarning, checkMissingDescriptions),
+		NewRule("property-casing",
+			"Property names should be camelCase",
+			SeverityWarning, checkPropertyCasing),
+		NewRule("unmarked-secret",
+			"Properties that look like secrets, such as passwords or tokens, should be marked secret",
+			SeverityWarning, checkUnmarkedSecrets),
+		NewRule("unused-type",
+			"Object types should be referenced by a resource, a function or another type",
+			SeverityWarning, checkUnusedTypes),
+		NewRule("duplicate-enum-value",
+			"Enum values should be unique",
+			SeverityError, checkDuplicateEnumValues),
+		NewRule("missing-output-version",
+			"Functions should have output-versioned forms in every SDK",
+			SeverityInfo, checkMissingOutputVersions),
+	}
+}
+
+// member is a resource, function or type of a package.
+type member struct {
+	token      string
+	kind       string
+	comment    string
+	properties []*schema.Property
+}
+
+// members returns the members of a package that have properties, which excludes enum types.
+func members(pkg *schema.Package) []member {
+	var ms []member
+	if pkg.Provider != nil {
+		ms = append(ms, member{
+			token:      pkg.Provider.Token,
+			kind:       "provider",
+			comment:    pkg.Provider.Comment,
+			properties: concatProperties(pkg.Provider.InputProperties, pkg.Provider.Properties),
+		})
+	}
+	for _, r := range pkg.Resources {
+		ms = append(ms, member{
+			token:      r.Token,
+			kind:       "resource",
+			comment:    r.Comment,
+			properties: concatProperties(r.InputProperties, r.Properties),
+		})
+	}
+	for _, f := range pkg.Functions {
+		m := member{token: f.Token, kind: "function", comment: f.Comment}
+		if f.Inputs != nil {
+			m.properties = append(m.properties, f.Inputs.Properties...)
+		}
+		if f.Outputs != nil {
+			m.properties = append(m.properties, f.Outputs.Properties...)
+		}
+		ms = append(ms, m)
+	}
+	for _, t := range pkg.Types {
+		if t, ok := t.(*schema.ObjectType); ok && t.IsPlainShape() && !t.IsOverlay {
+			ms = append(ms, member{token: t.Token, kind: "type", comment: t.Comment, properties: t.Properties})
+		}
+	}
+	return ms
+}
+
+func concatProperties(lists ...[]*schema.Property) []*schema.Property {
+	var props []*schema.Property
+	for _, l := range lists {
+		props = append(props, l...)
+	}
+	return props
+}
+
+func enumTypes(pkg *schema.Package) []*schema.EnumType {
+	var enums []*schema.EnumType
+	for _, t := range pkg.Types {
+		if t, ok := t.(*schema.EnumType); ok && !t.IsOverlay {
+			enums = append(enums, t)
+		}
+	}
+	return enums
+}
+
+func checkMissingDescriptions(pkg *schema.Package, report ReportFunc) {
+	for _, m := range members(pkg) {
+		// Providers are documented by the package itself.
+		if m.kind != "provider" && strings.TrimSpace(m.comment) == "" {
+			report(m.token, "", m.kind+" has no description")
+		}
+		for _, p := range m.properties {
+			if strings.TrimSpace(p.Comment) == "" {
+				report(m.token, p.Name, fmt.Sprintf("property %q has no description", p.Name))
+			}
+		}
+	}
+	for _, e := range enumTypes(pkg) {
+		if strings.TrimSpace(e.Comment) == "" {
+			report(e.Token, "", "enum has no description")
+		}
+	}
+}
+
+var camelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+func checkPropertyCasing(pkg *schema.Package, report ReportFunc) {
+	for _, m := range members(pkg) {
+		for _, p := range m.properties {
+			if !camelCase.MatchString(p.Name) {
+				report(m.token, p.Name, fmt.Sprintf("property %q is not camelCase", p.Name))
+			}
+		}
+	}
+}
+
+var (
+	secretWords = []string{"password", "passphrase", "secret", "token", "apikey", "privatekey", "accesskey"}
+	// Properties that name or refer to secrets, rather than hold them.
+	secretReferenceSuffixes = []string{"id", "ids", "arn", "name", "type", "url", "version", "expiry"}
+)
+
+// looksSecret returns true if a property with the given name likely holds a secret.
+func looksSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range secretReferenceSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	for _, word := range secretWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkUnmarkedSecrets(pkg *schema.Package, report ReportFunc) {
+	for _, m := range members(pkg) {
+		for _, p := range m.properties {
+			if !p.Secret && looksSecret(p.Name) && isString(p.Type) {
+				report(m.token, p.Name, fmt.Sprintf("property %q looks like a secret, but is not marked secret", p.Name))
+			}
+		}
+	}
+}
+
+func isString(t schema.Type) bool {
+	switch t := t.(type) {
+	case *schema.InputType:
+		return isString(t.ElementType)
+	case *schema.OptionalType:
+		return isString(t.ElementType)
+	default:
+		return t == schema.StringType
+	}
+}
+
+func checkUnusedTypes(pkg *schema.Package, report ReportFunc) {
+	used := map[string]bool{}
+	var visit func(t schema.Type)
+	visitProperties := func(props []*schema.Property) {
+		for _, p := range props {
+			visit(p.Type)
+		}
+	}
+	visit = func(t schema.Type) {
+		switch t := t.(type) {
+		case *schema.InputType:
+			visit(t.ElementType)
+		case *schema.OptionalType:
+			visit(t.ElementType)
+		case *schema.ArrayType:
+			visit(t.ElementType)
+		case *schema.MapType:
+			visit(t.ElementType)
+		case *schema.UnionType:
+			for _, e := range t.ElementTypes {
+				visit(e)
+			}
+		case *schema.TokenType:
+			if t.UnderlyingType != nil {
+				visit(t.UnderlyingType)
+			}
+		case *schema.ObjectType:
+			if !used[t.Token] {
+				used[t.Token] = true
+				visitProperties(t.Properties)
+			}
+		}
+	}
+
+	visitProperties(pkg.Config)
+	for _, m := range members(pkg) {
+		if m.kind != "type" {
+			visitProperties(m.properties)
+		}
+	}
+	for _, f := range pkg.Functions {
+		if f.ReturnType != nil {
+			visit(f.ReturnType)
+		}
+	}
+
+	for _, m := range members(pkg) {
+		if m.kind == "type" && !used[m.token] {
+			report(m.token, "", "type is not referenced by any resource, function or other type")
+		}
+	}
+}
+
+func checkDuplicateEnumValues(pkg *schema.Package, report ReportFunc) {
+	for _, e := range enumTypes(pkg) {
+		seen := map[string]string{}
+		for _, v := range e.Elements {
+			value := fmt.Sprint(v.Value)
+			if first, has := seen[value]; has {
+				report(e.Token, v.Name, fmt.Sprintf("enum value %q of %q duplicates the value of %q", value, v.Name, first))
+				continue
+			}
+			seen[value] = v.Name
+		}
+	}
+}
+
+func checkMissingOutputVersions(pkg *schema.Package, report ReportFunc) {
+	// Go can opt out of output-versioned functions for the whole package.
+	if raw, ok := pkg.Language["go"].(json.RawMessage); ok {
+		var goInfo struct {
+			DisableFunctionOutputVersions bool `json:"disableFunctionOutputVersions"`
+		}
+		if err := json.Unmarshal(raw, &goInfo); err == nil && goInfo.DisableFunctionOutputVersions {
+			report(pkg.Name, "", "output-versioned functions are disabled for the Go SDK")
+		}
+	}
+
+	for _, f := range pkg.Functions {
+		switch {
+		case f.IsMethod || f.NeedsOutputVersion():
+		case f.ReturnType == nil:
+			report(f.Token, "", "function returns no value, so it has no output-versioned form")
+		default:
+			report(f.Token, "", "function takes no arguments, so it has no output-versioned form")
+		}
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
@@ -52,6 +53,7 @@ type pluginLoader struct {
 
 	host    plugin.Host
 	entries map[string]PackageReference
+	cache   *SchemaCache
 
 	cacheOptions pluginLoaderCacheOptions
 }
@@ -64,12 +66,22 @@ type pluginLoaderCacheOptions struct {
 	disableFileCache bool
 	// useMmap enables the use of memory mapped IO to avoid copying the JSON schema
 	disableMmap bool
+	// disablePluginSchemaFile disables the use of schema files that are stored alongside plugins, so that schemas are
+	// only read from the schema cache
+	disablePluginSchemaFile bool
 }
 
 func NewPluginLoader(host plugin.Host) ReferenceLoader {
+	return NewPluginLoaderWithCache(host, nil)
+}
+
+// NewPluginLoaderWithCache returns a loader that loads schemas from resource plugins and caches them in the given
+// schema cache, so that each plugin is only asked for its schema once. If the cache is nil, schemas are not cached.
+func NewPluginLoaderWithCache(host plugin.Host, cache *SchemaCache) ReferenceLoader {
 	return &pluginLoader{
 		host:    host,
 		entries: map[string]PackageReference{},
+		cache:   cache,
 	}
 }
 
@@ -246,19 +258,45 @@ func (l *pluginLoader) loadSchemaBytes(pkg string, version *semver.Version) ([]b
 		version = pluginInfo.Version
 	}
 
-	if pluginInfo.SchemaPath != "" && version != nil {
+	// Schemas that are stored alongside a plugin take precedence over the schema cache, as long as they're newer than
+	// the plugin.
+	if pluginInfo.SchemaPath != "" && version != nil && !l.cacheOptions.disablePluginSchemaFile {
 		schemaBytes, ok := l.loadCachedSchemaBytes(pkg, pluginInfo.SchemaPath, pluginInfo.SchemaTime)
 		if ok {
 			return schemaBytes, nil, nil
 		}
 	}
 
+	// Plugins whose executables can't be found have no checksum, so their schemas aren't cached.
+	cache := l.cache
+	var checksum string
+	if cache != nil {
+		var ok bool
+		if checksum, ok = cache.pluginChecksum(pluginInfo); !ok {
+			cache = nil
+		}
+	}
+	if cache != nil {
+		schemaBytes, ok := l.loadCachedSchemaBytes(pkg, cache.path(pkg, version, checksum), time.Time{})
+		if ok {
+			return schemaBytes, nil, nil
+		}
+	}
+
 	schemaBytes, provider, err := l.loadPluginSchemaBytes(pkg, version)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Error loading schema from plugin: %w", err)
 	}
 
-	if pluginInfo.SchemaPath != "" {
+	switch {
+	case l.cacheOptions.disableFileCache:
+		// Schemas are neither read from nor written to files.
+	case cache != nil:
+		// The cache is only an optimization, so a schema that can't be cached is still used.
+		if err := cache.write(pkg, version, checksum, schemaBytes); err != nil {
+			logging.V(5).Infof("failed to cache the schema of %s@%v: %v", pkg, version, err)
+		}
+	case pluginInfo.SchemaPath != "":
 		err = atomic.WriteFile(pluginInfo.SchemaPath, bytes.NewReader(schemaBytes))
 		if err != nil {
 			return nil, nil, fmt.Errorf("Error writing schema from plugin to cache: %w", err)
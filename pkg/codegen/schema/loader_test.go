@@ -2,13 +2,144 @@ package schema
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/testing/diagtest"
-	"github.com/stretchr/testify/require"
 )
 
+const cachedSchema = `{
+	"name": "cached",
+	"resources": {
+		"cached:index:Resource": {
+			"inputProperties": {"value": {"type": "string"}}
+		}
+	}
+}`
+
+// newCountingHost returns a plugin host with a single provider that counts the number of times that it is asked for
+// its schema. The provider's executable is written to dir with the given contents.
+func newCountingHost(t *testing.T, dir, version, executable string, calls *int) plugin.Host {
+	err := os.WriteFile(filepath.Join(dir, "pulumi-resource-cached"), []byte(executable), 0o700)
+	require.NoError(t, err)
+
+	return deploytest.NewPluginHost(nil, nil, nil,
+		deploytest.NewProviderLoader("cached", semver.MustParse(version), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				GetSchemaF: func(int) ([]byte, error) {
+					*calls++
+					return []byte(cachedSchema), nil
+				},
+			}, nil
+		}, deploytest.WithPath(dir)))
+}
+
+func TestPluginLoaderSchemaCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSchemaCache(t.TempDir())
+	version := semver.MustParse("1.0.0")
+	calls := 0
+	host := newCountingHost(t, t.TempDir(), "1.0.0", "plugin", &calls)
+
+	load := func() {
+		ref, err := NewPluginLoaderWithCache(host, cache).LoadPackageReference("cached", &version)
+		require.NoError(t, err)
+		res, ok, err := ref.Resources().Get("cached:index:Resource")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Len(t, res.InputProperties, 1)
+	}
+
+	// The first load asks the plugin for its schema and caches it.
+	load()
+	assert.Equal(t, 1, calls)
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "cached", entries[0].Name)
+	assert.Equal(t, &version, entries[0].Version)
+	assert.Equal(t, int64(len(cachedSchema)), entries[0].Size)
+
+	// Later loaders read the schema from the cache.
+	load()
+	load()
+	assert.Equal(t, 1, calls)
+
+	// A different plugin with the same name and version replaces the cached schema.
+	otherCalls := 0
+	otherHost := newCountingHost(t, t.TempDir(), "1.0.0", "other plugin", &otherCalls)
+	_, err = NewPluginLoaderWithCache(otherHost, cache).LoadPackageReference("cached", &version)
+	require.NoError(t, err)
+	assert.Equal(t, 1, otherCalls)
+	entries, err = cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// Removing the schema from the cache causes the plugin to be asked for it again.
+	require.NoError(t, cache.Remove("cached", &version))
+	load()
+	assert.Equal(t, 2, calls)
+}
+
+func TestPluginLoaderSchemaCacheChecksum(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSchemaCache(t.TempDir())
+	version := semver.MustParse("1.0.0")
+	dir := t.TempDir()
+	modTime := time.Now()
+	load := func(executable string) int {
+		calls := 0
+		host := newCountingHost(t, dir, "1.0.0", executable, &calls)
+		// Make sure that each write of the executable has a new modification time.
+		modTime = modTime.Add(time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "pulumi-resource-cached"), modTime, modTime))
+		_, err := NewPluginLoaderWithCache(host, cache).LoadPackageReference("cached", &version)
+		require.NoError(t, err)
+		return calls
+	}
+
+	assert.Equal(t, 1, load("plugin"))
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	checksum := entries[0].Checksum
+
+	// Reinstalling the same plugin doesn't change its checksum, so its schema is still read from the cache.
+	assert.Equal(t, 0, load("plugin"))
+
+	// A plugin that is rebuilt in place has a new checksum, so it is asked for its schema again.
+	assert.Equal(t, 1, load("rebuilt"))
+	entries, err = cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.NotEqual(t, checksum, entries[0].Checksum)
+
+	// Plugins without an executable have no checksum, so their schemas are not cached.
+	host := deploytest.NewPluginHost(nil, nil, nil,
+		deploytest.NewProviderLoader("uncached", version, func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				GetSchemaF: func(int) ([]byte, error) {
+					return []byte(`{"name": "uncached"}`), nil
+				},
+			}, nil
+		}, deploytest.WithPath(t.TempDir())))
+	_, err = NewPluginLoaderWithCache(host, cache).LoadPackageReference("uncached", &version)
+	require.NoError(t, err)
+	entries, err = cache.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "cached", entries[0].Name)
+}
+
 func initLoader(b *testing.B, options pluginLoaderCacheOptions) ReferenceLoader {
 	cwd, err := os.Getwd()
 	require.NoError(b, err)
@@ -84,6 +215,26 @@ func BenchmarkLoadPackageReference(b *testing.B) {
 		}
 	})
 
+	b.Run("schema-cache", func(b *testing.B) {
+		// Disables in-memory cache and schema files stored alongside plugins, so that schemas are read from the schema
+		// cache. Compare with no-cache, which asks the plugin for its schema each time:
+		loader := initLoader(b, pluginLoaderCacheOptions{
+			disableEntryCache:       true,
+			disablePluginSchemaFile: true,
+		})
+		loader.(*pluginLoader).cache = NewSchemaCache(b.TempDir())
+
+		b.StopTimer()
+		_, err := loader.LoadPackageReference("azure-native", nil)
+		require.NoError(b, err)
+		b.StartTimer()
+
+		for n := 0; n < b.N; n++ {
+			_, err := loader.LoadPackageReference("azure-native", nil)
+			require.NoError(b, err)
+		}
+	})
+
 	b.Run("no-cache", func(b *testing.B) {
 		// Disables in-memory cache, mmaping, and using schema files:
 		loader := initLoader(b, pluginLoaderCacheOptions{
@@ -375,6 +375,16 @@ var PulumiPulumiProgramTests = []ProgramTest{
 		Description: "Testing for expressions with typed target expressions in csharp",
 		Skip:        allProgLanguages.Except("dotnet"),
 	},
+	{
+		Directory:   "builtin-functions",
+		Description: "Tests the string, encoding and collection builtin functions",
+	},
+	{
+		Directory:   "builtin-functions-shadowed",
+		Description: "Locals named after the collection builtin functions don't shadow their Go helpers",
+		// Testing Go behavior exclusively:
+		Skip: allProgLanguages.Except("go"),
+	},
 }
 
 var PulumiPulumiYAMLProgramTests = []ProgramTest{
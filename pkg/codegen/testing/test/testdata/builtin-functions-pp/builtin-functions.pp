@@ -0,0 +1,49 @@
+config "environment" "string" {
+}
+
+tags = merge({
+  project = "builtins"
+  environment = environment
+}, { owner = "platform" })
+names = concat(["web", "api"], ["web", "worker"])
+settings = fromJSON("{\"replicas\": 3}")
+
+output "tagKeys" {
+  value = keys(tags)
+}
+
+output "tagValues" {
+  value = values(tags)
+}
+
+output "uniqueNames" {
+  value = distinct(names)
+}
+
+output "flattened" {
+  value = flatten([["a", "b"], ["c"]])
+}
+
+output "ports" {
+  value = zipmap(["http", "https"], [80, 443])
+}
+
+output "upperEnvironment" {
+  value = upper(environment)
+}
+
+output "lowerName" {
+  value = lower("Web-Server")
+}
+
+output "bucketName" {
+  value = replace(format("%s_bucket_%d", environment, 1), "_", "-")
+}
+
+output "parsedSettings" {
+  value = settings
+}
+
+output "checksum" {
+  value = sha256(environment)
+}
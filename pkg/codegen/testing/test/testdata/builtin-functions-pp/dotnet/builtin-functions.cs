@@ -0,0 +1,91 @@
+using System;
+using System.Collections.Generic;
+using System.Linq;
+using System.Security.Cryptography;
+using System.Text;
+using System.Text.Json.Nodes;
+using System.Text.RegularExpressions;
+using Pulumi;
+
+	private static Dictionary<string, T> Merge<T>(params IDictionary<string, T>[] maps) {
+		var result = new Dictionary<string, T>();
+		foreach (var map in maps)
+		{
+			foreach (var entry in map)
+			{
+				result[entry.Key] = entry.Value;
+			}
+		}
+		return result;
+	}
+
+	private static string ComputeSHA256(string input) {
+		return BitConverter.ToString(
+			SHA256.Create().ComputeHash(Encoding.UTF8.GetBytes(input))
+		).Replace("-","").ToLowerInvariant();
+	}
+
+	private static string Format(string format, params object?[] args) {
+		var index = 0;
+		return Regex.Replace(format, "%[sd%]", match => match.Value == "%%" ? "%" : $"{args[index++]}");
+	}
+
+return await Deployment.RunAsync(() => 
+{
+    var config = new Config();
+    var environment = config.Require("environment");
+    var tags = Merge(new Dictionary<string, object?>
+    {
+        ["project"] = "builtins",
+        ["environment"] = environment,
+    }, new Dictionary<string, object?>
+    {
+        ["owner"] = "platform",
+    });
+
+    var names = new[]
+    {
+        "web",
+        "api",
+    }.Concat(new[]
+    {
+        "web",
+        "worker",
+    }).ToList();
+
+    var settings = JsonNode.Parse("{\"replicas\": 3}");
+
+    return new Dictionary<string, object?>
+    {
+        ["tagKeys"] = tags.Keys.OrderBy(key => key, StringComparer.Ordinal).ToList(),
+        ["tagValues"] = tags.OrderBy(pair => pair.Key, StringComparer.Ordinal).Select(pair => pair.Value).ToList(),
+        ["uniqueNames"] = names.Distinct().ToList(),
+        ["flattened"] = new[]
+        {
+            new[]
+            {
+                "a",
+                "b",
+            },
+            new[]
+            {
+                "c",
+            },
+        }.SelectMany(list => list).ToList(),
+        ["ports"] = new[]
+        {
+            "http",
+            "https",
+        }.Zip(new[]
+        {
+            80,
+            443,
+        }).ToDictionary(pair => pair.First, pair => pair.Second),
+        ["upperEnvironment"] = environment.ToUpperInvariant(),
+        ["lowerName"] = "Web-Server".ToLowerInvariant(),
+        ["bucketName"] = Format("%s_bucket_%d", environment, 1).Replace("_", "-"),
+        ["parsedSettings"] = settings,
+        ["checksum"] = ComputeSHA256(environment),
+    };
+});
+
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+func concatLists[T any](lists ...[]T) []T {
+	var result []T
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+func distinctElements[T comparable](list []T) []T {
+	var result []T
+	seen := map[T]bool{}
+	for _, v := range list {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func flattenLists[T any](lists [][]T) []T {
+	var result []T
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+func fromJSONOrPanic(data string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func mapValues[V any](m map[string]V) []V {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	result := make([]V, 0, len(m))
+	for _, k := range ks {
+		result = append(result, m[k])
+	}
+	return result
+}
+
+func mergeMaps[V any](maps ...map[string]V) map[string]V {
+	result := map[string]V{}
+	for _, m := range maps {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func sha256Hash(input string) string {
+	hash := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(hash[:])
+}
+
+func zipToMap[V any](keys []string, values []V) map[string]V {
+	result := make(map[string]V, len(keys))
+	for i, k := range keys {
+		result[k] = values[i]
+	}
+	return result
+}
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		cfg := config.New(ctx, "")
+		environment := cfg.Require("environment")
+		tags := mergeMaps(map[string]string{
+			"project":     "builtins",
+			"environment": environment,
+		}, map[string]string{
+			"owner": "platform",
+		})
+		names := concatLists([]string{
+			"web",
+			"api",
+		}, []string{
+			"web",
+			"worker",
+		})
+		settings := fromJSONOrPanic("{\"replicas\": 3}")
+		ctx.Export("tagKeys", pulumi.ToStringArray(mapKeys(tags)))
+		ctx.Export("tagValues", pulumi.ToStringArray(mapValues(tags)))
+		ctx.Export("uniqueNames", pulumi.ToStringArray(distinctElements(names)))
+		ctx.Export("flattened", pulumi.ToStringArray(flattenLists([][]string{
+			[]string{
+				"a",
+				"b",
+			},
+			[]string{
+				"c",
+			},
+		})))
+		ctx.Export("ports", pulumi.Any(zipToMap([]string{
+			"http",
+			"https",
+		}, []float64{
+			80,
+			443,
+		})))
+		ctx.Export("upperEnvironment", pulumi.String(strings.ToUpper(environment)))
+		ctx.Export("lowerName", pulumi.String(strings.ToLower("Web-Server")))
+		ctx.Export("bucketName", pulumi.String(strings.ReplaceAll(fmt.Sprintf("%s_bucket_%d", environment, 1), "_", "-")))
+		ctx.Export("parsedSettings", pulumi.Any(settings))
+		ctx.Export("checksum", pulumi.String(sha256Hash(environment)))
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as crypto from "crypto";
+import * as util from "util";
+
+function flatten<T>(lists: T[][]): T[] {
+    return ([] as T[]).concat(...lists);
+}
+
+function objectValues<T>(obj: Record<string, T>): T[] {
+    return Object.keys(obj).sort().map(key => obj[key]);
+}
+
+function zipmap<T>(keys: string[], values: T[]): Record<string, T> {
+    const result: Record<string, T> = {};
+    keys.forEach((key, i) => result[key] = values[i]);
+    return result;
+}
+
+const config = new pulumi.Config();
+const environment = config.require("environment");
+const tags = {...{
+    project: "builtins",
+    environment: environment,
+}, ...{
+    owner: "platform",
+}};
+const names = [...[
+    "web",
+    "api",
+], ...[
+    "web",
+    "worker",
+]];
+const settings = JSON.parse("{\"replicas\": 3}");
+export const tagKeys = Object.keys(tags).sort();
+export const tagValues = objectValues(tags);
+export const uniqueNames = Array.from(new Set(names));
+export const flattened = flatten([
+    [
+        "a",
+        "b",
+    ],
+    ["c"],
+]);
+export const ports = zipmap([
+    "http",
+    "https",
+], [
+    80,
+    443,
+]);
+export const upperEnvironment = environment.toUpperCase();
+export const lowerName = "Web-Server".toLowerCase();
+export const bucketName = util.format("%s_bucket_%d", environment, 1).split("_").join("-");
+export const parsedSettings = settings;
+export const checksum = crypto.createHash('sha256').update(environment).digest('hex');
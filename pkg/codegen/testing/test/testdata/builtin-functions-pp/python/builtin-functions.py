@@ -0,0 +1,42 @@
+import pulumi
+import hashlib
+import json
+
+config = pulumi.Config()
+environment = config.require("environment")
+tags = {**{
+    "project": "builtins",
+    "environment": environment,
+}, **{
+    "owner": "platform",
+}}
+names = [*[
+    "web",
+    "api",
+], *[
+    "web",
+    "worker",
+]]
+settings = json.loads("{\"replicas\": 3}")
+pulumi.export("tagKeys", sorted(tags.keys()))
+pulumi.export("tagValues", [value for _, value in sorted(tags.items())])
+pulumi.export("uniqueNames", list(dict.fromkeys(names)))
+pulumi.export("flattened", [item for sublist in [
+    [
+        "a",
+        "b",
+    ],
+    ["c"],
+] for item in sublist])
+pulumi.export("ports", dict(zip([
+    "http",
+    "https",
+], [
+    80,
+    443,
+])))
+pulumi.export("upperEnvironment", environment.upper())
+pulumi.export("lowerName", "Web-Server".lower())
+pulumi.export("bucketName", ("%s_bucket_%d" % (environment, 1)).replace("_", "-"))
+pulumi.export("parsedSettings", settings)
+pulumi.export("checksum", hashlib.sha256(environment.encode()).hexdigest())
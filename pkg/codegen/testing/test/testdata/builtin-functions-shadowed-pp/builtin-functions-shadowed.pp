@@ -0,0 +1,31 @@
+keys = ["environment", "team"]
+values = ["dev", "platform"]
+concat = ["web", "api"]
+distinct = ["web", "worker"]
+flatten = ["c"]
+zipmap = ["http", "https"]
+merge = zipmap(keys, values)
+
+output "tags" {
+  value = merge(merge, { owner = "platform" })
+}
+
+output "tagKeys" {
+  value = keys(merge)
+}
+
+output "tagValues" {
+  value = values(merge)
+}
+
+output "names" {
+  value = distinct(concat(concat, distinct))
+}
+
+output "flattened" {
+  value = flatten([flatten, concat])
+}
+
+output "ports" {
+  value = zipmap(zipmap, [80, 443])
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func concatLists[T any](lists ...[]T) []T {
+	var result []T
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+func distinctElements[T comparable](list []T) []T {
+	var result []T
+	seen := map[T]bool{}
+	for _, v := range list {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func flattenLists[T any](lists [][]T) []T {
+	var result []T
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func mapValues[V any](m map[string]V) []V {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	result := make([]V, 0, len(m))
+	for _, k := range ks {
+		result = append(result, m[k])
+	}
+	return result
+}
+
+func mergeMaps[V any](maps ...map[string]V) map[string]V {
+	result := map[string]V{}
+	for _, m := range maps {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func zipToMap[V any](keys []string, values []V) map[string]V {
+	result := make(map[string]V, len(keys))
+	for i, k := range keys {
+		result[k] = values[i]
+	}
+	return result
+}
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		keys := []string{
+			"environment",
+			"team",
+		}
+		values := []string{
+			"dev",
+			"platform",
+		}
+		concat := []string{
+			"web",
+			"api",
+		}
+		distinct := []string{
+			"web",
+			"worker",
+		}
+		flatten := []string{
+			"c",
+		}
+		zipmap := []string{
+			"http",
+			"https",
+		}
+		merge := zipToMap(keys, values)
+		ctx.Export("tags", pulumi.ToStringMap(mergeMaps(merge, map[string]string{
+			"owner": "platform",
+		})))
+		ctx.Export("tagKeys", pulumi.ToStringArray(mapKeys(merge)))
+		ctx.Export("tagValues", pulumi.ToStringArray(mapValues(merge)))
+		ctx.Export("names", pulumi.ToStringArray(distinctElements(concatLists(concat, distinct))))
+		ctx.Export("flattened", pulumi.ToStringArray(flattenLists([][]string{
+			flatten,
+			concat,
+		})))
+		ctx.Export("ports", pulumi.Any(zipToMap(zipmap, []float64{
+			80,
+			443,
+		})))
+		return nil
+	})
+}
@@ -0,0 +1,229 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"strconv"
+	"strings"
+)
+
+// goReader reads the resource declarations of a Go program. Variables are tracked by the objects that the parser
+// resolves them to, so that each of the variables of the same name in different scopes, such as the __res of each
+// loop that creates resources, refers to its own resources.
+type goReader struct {
+	fset  *gotoken.FileSet
+	names resourceNames
+
+	// vars maps variables to the resources that their values refer to.
+	vars map[*ast.Object]map[string]bool
+	// read holds the constructions that have been read.
+	read         map[*ast.CallExpr]bool
+	declarations []declaration
+}
+
+// readGo reads the resource declarations of a Go program.
+func readGo(filename string, src []byte, sources []sourceResource) ([]declaration, error) {
+	fset := gotoken.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	r := &goReader{
+		fset:  fset,
+		names: newResourceNames(sources),
+		vars:  map[*ast.Object]map[string]bool{},
+		read:  map[*ast.CallExpr]bool{},
+	}
+	ast.Inspect(file, r.visit)
+	return r.declarations, nil
+}
+
+// visit reads the variable assignments and resource constructions in the program, in the order that they run.
+func (r *goReader) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		// `x, err := f()` assigns the result of f to x alone.
+		if len(n.Rhs) == 1 {
+			r.assign(n.Lhs[0], r.refs(n.Rhs[0], ""), n.Tok == gotoken.ASSIGN)
+		} else if len(n.Lhs) == len(n.Rhs) {
+			for i := range n.Lhs {
+				r.assign(n.Lhs[i], r.refs(n.Rhs[i], ""), n.Tok == gotoken.ASSIGN)
+			}
+		}
+	case *ast.ValueSpec:
+		for i, name := range n.Names {
+			if i < len(n.Values) {
+				r.assign(name, r.refs(n.Values[i], ""), false)
+			}
+		}
+	case *ast.RangeStmt:
+		refs := r.refs(n.X, "")
+		if n.Key != nil {
+			r.assign(n.Key, refs, false)
+		}
+		if n.Value != nil {
+			r.assign(n.Value, refs, false)
+		}
+	case *ast.CallExpr:
+		if _, ok := r.construction(n); ok {
+			r.construct(n)
+		}
+	}
+	return true
+}
+
+// assign records that the given variable refers to the given resources. If add is true, the variable keeps
+// referring to the resources that it referred to before, as it does for `xs = append(xs, x)`.
+func (r *goReader) assign(lhs ast.Expr, refs map[string]bool, add bool) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Obj == nil {
+		return
+	}
+	if add && r.vars[ident.Obj] != nil {
+		for ref := range refs {
+			r.vars[ident.Obj][ref] = true
+		}
+		return
+	}
+	r.vars[ident.Obj] = refs
+}
+
+// construction returns the name and type name of the resource that the given call constructs, if it constructs one:
+// `pkg.NewFoo(ctx, "name", ...)` or, for resources in a range, `pkg.NewFoo(ctx, fmt.Sprintf("name-%v", key), ...)`.
+func (r *goReader) construction(call *ast.CallExpr) (constructionAt, bool) {
+	var fun string
+	switch f := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		fun = f.Sel.Name
+	case *ast.Ident:
+		fun = f.Name
+	}
+	typeName, ok := strings.CutPrefix(fun, "New")
+	if !ok || typeName == "" || len(call.Args) < 2 {
+		return constructionAt{}, false
+	}
+	if ctx, ok := call.Args[0].(*ast.Ident); !ok || ctx.Name != "ctx" {
+		return constructionAt{}, false
+	}
+
+	var name string
+	prefix := false
+	switch arg := call.Args[1].(type) {
+	case *ast.BasicLit:
+		if arg.Kind != gotoken.STRING {
+			return constructionAt{}, false
+		}
+		name, _ = strconv.Unquote(arg.Value)
+	case *ast.CallExpr:
+		sel, ok := arg.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Sprintf" || len(arg.Args) == 0 {
+			return constructionAt{}, false
+		}
+		format, ok := arg.Args[0].(*ast.BasicLit)
+		if !ok || format.Kind != gotoken.STRING {
+			return constructionAt{}, false
+		}
+		name, _ = strconv.Unquote(format.Value)
+		name, _, prefix = strings.Cut(name, "%")
+	default:
+		return constructionAt{}, false
+	}
+
+	name, ok = r.names.match(typeName, name, prefix)
+	return constructionAt{name: name, typeName: typeName}, ok
+}
+
+// construct reads the resource construction of the given call.
+func (r *goReader) construct(call *ast.CallExpr) string {
+	c, _ := r.construction(call)
+	if r.read[call] {
+		return c.name
+	}
+	r.read[call] = true
+
+	d := declaration{
+		name:     c.name,
+		typeName: c.typeName,
+		deps:     map[string]bool{},
+		line:     r.fset.Position(call.Pos()).Line,
+	}
+	// The arguments are `&pkg.FooArgs{Bar: ...}` or nil.
+	if len(call.Args) > 2 {
+		args := call.Args[2]
+		if unary, ok := args.(*ast.UnaryExpr); ok {
+			args = unary.X
+		}
+		if lit, ok := args.(*ast.CompositeLit); ok {
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						d.inputs = append(d.inputs, key.Name)
+					}
+				}
+			}
+		}
+	}
+	for _, arg := range call.Args[1:] {
+		for ref := range r.refs(arg, c.name) {
+			d.deps[ref] = true
+		}
+	}
+	r.declarations = append(r.declarations, d)
+	return c.name
+}
+
+// refs returns the resources that the given expression refers to, either through variables or by constructing them.
+// Nested resource constructions are read as they are found. self is the name of a resource that the expression
+// belongs to, which is not a reference.
+func (r *goReader) refs(n ast.Node, self string) map[string]bool {
+	refs := map[string]bool{}
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if _, ok := r.construction(n); ok {
+				if name := r.construct(n); name != self {
+					refs[name] = true
+				}
+				return false
+			}
+		case *ast.SelectorExpr:
+			// Only the x of x.y can refer to a variable.
+			ast.Inspect(n.X, visit)
+			return false
+		case *ast.KeyValueExpr:
+			// The keys of struct literals are field names.
+			if _, ok := n.Key.(*ast.Ident); !ok {
+				ast.Inspect(n.Key, visit)
+			}
+			ast.Inspect(n.Value, visit)
+			return false
+		case *ast.Ident:
+			if n.Obj != nil {
+				for ref := range r.vars[n.Obj] {
+					if ref != self {
+						refs[ref] = true
+					}
+				}
+			}
+		}
+		return true
+	}
+	ast.Inspect(n, visit)
+	return refs
+}
@@ -0,0 +1,283 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	stringToken
+	numberToken
+	punctToken
+	// newlineToken ends a Python statement. It is only emitted for Python, outside of brackets.
+	newlineToken
+)
+
+// token is a token of a program in one of the languages that the lexer supports.
+type token struct {
+	kind tokenKind
+	// text is the identifier, number or punctuation. For strings, it is the text up to the first interpolation.
+	text string
+	line int
+	// interpolated is true for strings that contain interpolated expressions, whose tokens are parts.
+	interpolated bool
+	parts        []token
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+// lexicalSyntax describes the lexical syntax of a language.
+type lexicalSyntax struct {
+	// python is true for Python: comments start with '#', strings may have prefixes such as f and r, and newlines
+	// end statements.
+	python bool
+	// templates is true for TypeScript's `...${expr}...` template strings.
+	templates bool
+	// csharp is true for C#'s $"...{expr}..." and @"..." strings.
+	csharp bool
+}
+
+// operators are the punctuation tokens that are longer than one character, longest first.
+var operators = []string{
+	"===", "!==", "...", "**=", "//=", ">>=", "<<=",
+	"==", "!=", "<=", ">=", "=>", "->", "&&", "||", "??", "?.", "::", ":=", "+=", "-=", "*=", "/=", "%=", "++", "--",
+	"**",
+}
+
+type lexer struct {
+	lexicalSyntax
+	src  string
+	pos  int
+	line int
+}
+
+// lex splits the source of a program into tokens.
+func lex(src string, syntax lexicalSyntax) ([]token, error) {
+	l := &lexer{lexicalSyntax: syntax, src: src, line: 1}
+	tokens, err := l.tokens(false)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", l.line, err)
+	}
+	return tokens, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || '0' <= c && c <= '9'
+}
+
+func (l *lexer) peek(offset int) byte {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+// tokens lexes tokens until the end of the source or, if interpolation is true, until the '}' that closes an
+// interpolated expression.
+func (l *lexer) tokens(interpolation bool) ([]token, error) {
+	var tokens []token
+	depth := 0
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			if l.python && depth == 0 && !interpolation && len(tokens) > 0 && tokens[len(tokens)-1].kind != newlineToken {
+				tokens = append(tokens, token{kind: newlineToken, text: "\n", line: l.line})
+			}
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			l.pos++
+		case c == '\\' && l.peek(1) == '\n':
+			// A Python line continuation.
+			l.line++
+			l.pos += 2
+		case l.python && c == '#', !l.python && c == '/' && l.peek(1) == '/':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case !l.python && c == '/' && l.peek(1) == '*':
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			l.line += strings.Count(l.src[l.pos:l.pos+2+end], "\n")
+			l.pos += end + 4
+		case c == '"' || c == '\'' || c == '`' && l.templates:
+			t, err := l.string("", l.templates && c == '`')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+		case l.csharp && (c == '$' || c == '@') && (l.peek(1) == '"' || l.peek(1) == '$' || l.peek(1) == '@'):
+			prefix := ""
+			for l.src[l.pos] == '$' || l.src[l.pos] == '@' {
+				prefix += string(l.src[l.pos])
+				l.pos++
+			}
+			t, err := l.string(prefix, strings.Contains(prefix, "$"))
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+		case l.csharp && c == '@' && isIdentStart(l.peek(1)):
+			// A C# verbatim identifier such as @default.
+			l.pos++
+		case isIdentStart(c) && !(l.csharp && c == '$'):
+			start := l.pos
+			for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+				l.pos++
+			}
+			text := l.src[start:l.pos]
+			if quote := l.peek(0); l.python && (quote == '"' || quote == '\'') && isStringPrefix(text) {
+				t, err := l.string(strings.ToLower(text), strings.ContainsAny(text, "fF"))
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, t)
+				continue
+			}
+			tokens = append(tokens, token{kind: identToken, text: text, line: l.line})
+		case '0' <= c && c <= '9':
+			start := l.pos
+			for l.pos < len(l.src) && (isIdentPart(l.src[l.pos]) || l.src[l.pos] == '.') {
+				l.pos++
+			}
+			tokens = append(tokens, token{kind: numberToken, text: l.src[start:l.pos], line: l.line})
+		default:
+			text := string(c)
+			for _, op := range operators {
+				if strings.HasPrefix(l.src[l.pos:], op) {
+					text = op
+					break
+				}
+			}
+			switch text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				if depth == 0 && interpolation {
+					if text != "}" {
+						return nil, fmt.Errorf("unexpected '%s' in an interpolated expression", text)
+					}
+					l.pos++
+					return tokens, nil
+				}
+				depth--
+			}
+			tokens = append(tokens, token{kind: punctToken, text: text, line: l.line})
+			l.pos += len(text)
+		}
+	}
+	if interpolation {
+		return nil, fmt.Errorf("unterminated interpolated expression")
+	}
+	return tokens, nil
+}
+
+func isStringPrefix(text string) bool {
+	switch strings.ToLower(text) {
+	case "r", "u", "b", "f", "rb", "br", "fr", "rf":
+		return true
+	}
+	return false
+}
+
+// string lexes a string literal that starts at the current position. prefix holds the string's prefix characters,
+// such as Python's f and r or C#'s $ and @. If interpolated is true, expressions in the string are lexed into the
+// token's parts.
+func (l *lexer) string(prefix string, interpolated bool) (token, error) {
+	t := token{kind: stringToken, line: l.line}
+	quote := string(l.src[l.pos])
+	if (l.python || !l.csharp && !l.templates) && strings.HasPrefix(l.src[l.pos:], quote+quote+quote) {
+		// Python's triple-quoted strings and Java's text blocks.
+		quote = quote + quote + quote
+	}
+	l.pos += len(quote)
+
+	raw := strings.ContainsAny(prefix, "r@")
+	// In TypeScript templates, interpolations start with "${". Elsewhere they start with "{", and "{{" is a brace.
+	open := "{"
+	if quote == "`" {
+		open = "${"
+	}
+
+	var text strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return token{}, fmt.Errorf("unterminated string")
+		}
+		rest := l.src[l.pos:]
+		switch {
+		case strings.HasPrefix(rest, quote):
+			if l.csharp && raw && strings.HasPrefix(rest, quote+quote) {
+				// A quote in a C# verbatim string.
+				text.WriteString(quote)
+				l.pos += 2
+				continue
+			}
+			l.pos += len(quote)
+			if !t.interpolated {
+				t.text = text.String()
+			}
+			return t, nil
+		case rest[0] == '\\' && !(l.csharp && raw):
+			if len(rest) > 1 {
+				if rest[1] == '\n' {
+					l.line++
+				}
+				if !t.interpolated {
+					text.WriteString(rest[:2])
+				}
+			}
+			l.pos += 2
+		case interpolated && quote != "`" && (strings.HasPrefix(rest, "{{") || strings.HasPrefix(rest, "}}")):
+			if !t.interpolated {
+				text.WriteByte(rest[0])
+			}
+			l.pos += 2
+		case interpolated && strings.HasPrefix(rest, open):
+			if !t.interpolated {
+				t.text = text.String()
+				t.interpolated = true
+			}
+			l.pos += len(open)
+			parts, err := l.tokens(true)
+			if err != nil {
+				return token{}, err
+			}
+			t.parts = append(t.parts, parts...)
+		default:
+			if rest[0] == '\n' {
+				l.line++
+			}
+			if !t.interpolated {
+				text.WriteByte(rest[0])
+			}
+			l.pos++
+		}
+	}
+}
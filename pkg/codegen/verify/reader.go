@@ -0,0 +1,559 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sourceResource is a resource declared by the source program, as far as the readers need to know it.
+type sourceResource struct {
+	// name is the logical name of the resource.
+	name string
+	// typeName is the name of the resource's type without its package and module, e.g. Bucket.
+	typeName string
+	// ranged is true if the resource is declared once for each element of a range. Each of those resources is named
+	// after the resource, followed by a '-' and the element's key.
+	ranged bool
+}
+
+// declaration is a resource declaration read back from a generated program.
+type declaration struct {
+	// name is the logical name of the resource.
+	name string
+	// typeName is the name of the resource's class or constructor, without its package and module.
+	typeName string
+	// inputs are the names of the input properties that the declaration sets, as they are spelled in the program.
+	inputs []string
+	// deps are the logical names of the resources that the declaration refers to.
+	deps map[string]bool
+	line int
+}
+
+// normalize returns a spelling of a name that is the same in each of the languages, so that names can be compared
+// across them: e.g. bucketName, BucketName and bucket_name are all bucketname.
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// resourceNames matches the names of the resources that a generated program declares with those of the source
+// program.
+type resourceNames struct {
+	byName    map[string]sourceResource
+	typeNames map[string]bool
+}
+
+func newResourceNames(sources []sourceResource) resourceNames {
+	names := resourceNames{byName: map[string]sourceResource{}, typeNames: map[string]bool{}}
+	for _, s := range sources {
+		names.byName[s.name] = s
+		names.typeNames[normalize(s.typeName)] = true
+	}
+	return names
+}
+
+// match returns the logical name of a resource that is constructed with the given type name and name. If prefix is
+// true, name is only the start of the constructed resource's name, which is computed when the program runs. It
+// returns false if the construction is not one of a resource, as far as can be told: its name is not that of a
+// resource in the source program, and its type is not that of any of them either.
+func (n resourceNames) match(typeName, name string, prefix bool) (string, bool) {
+	if prefix {
+		if base, ok := strings.CutSuffix(name, "-"); ok {
+			if s, has := n.byName[base]; has && s.ranged {
+				return base, true
+			}
+		}
+	} else if _, has := n.byName[name]; has {
+		return name, true
+	}
+	return name, n.typeNames[normalize(typeName)]
+}
+
+// language describes how resources are declared in a language that the token reader supports.
+type language struct {
+	lexicalSyntax
+	// constructs are the keywords that construct an object, e.g. new. If there are none, resources are constructed by
+	// calling their class, as in Python.
+	constructs bool
+	// declares are the keywords that declare a variable, e.g. const and let.
+	declares []string
+	// appends are the methods that add an element to a list.
+	appends []string
+	// key is the punctuation that follows the name of a property in an object or argument list: ':' in TypeScript,
+	// '=' in Python and C#.
+	key string
+	// inputs returns the names of the input properties that are set by the arguments of a resource's constructor.
+	inputs func(r *tokenReader, args []span) []string
+}
+
+var (
+	typescript = &language{
+		lexicalSyntax: lexicalSyntax{templates: true},
+		constructs:    true,
+		declares:      []string{"const", "let", "var"},
+		appends:       []string{"push"},
+		key:           ":",
+		inputs: func(r *tokenReader, args []span) []string {
+			if len(args) < 2 {
+				return nil
+			}
+			return r.keys(args[1])
+		},
+	}
+	python = &language{
+		lexicalSyntax: lexicalSyntax{python: true},
+		appends:       []string{"append"},
+		key:           "=",
+		inputs: func(r *tokenReader, args []span) []string {
+			var inputs []string
+			for _, arg := range args[1:] {
+				if arg.len() > 1 && r.tokens[arg.start].kind == identToken && r.tokens[arg.start+1].is(punctToken, "=") {
+					if name := r.tokens[arg.start].text; name != "opts" {
+						inputs = append(inputs, name)
+					}
+				}
+			}
+			return inputs
+		},
+	}
+	csharp = &language{
+		lexicalSyntax: lexicalSyntax{csharp: true},
+		constructs:    true,
+		declares:      []string{"var"},
+		appends:       []string{"Add"},
+		key:           "=",
+		inputs: func(r *tokenReader, args []span) []string {
+			if len(args) < 2 {
+				return nil
+			}
+			return r.keys(args[1])
+		},
+	}
+	java = &language{
+		constructs: true,
+		declares:   []string{"var"},
+		appends:    []string{"add"},
+		inputs: func(r *tokenReader, args []span) []string {
+			// Inputs are set by the methods of a builder: FooArgs.builder().bar(...).baz(...).build().
+			if len(args) < 2 {
+				return nil
+			}
+			var inputs []string
+			for i := args[1].start; i < args[1].end; i++ {
+				t := r.tokens[i]
+				if t.is(punctToken, "(") || t.is(punctToken, "[") || t.is(punctToken, "{") {
+					i = r.closing(i)
+					continue
+				}
+				if t.kind == identToken && i > 0 && r.tokens[i-1].is(punctToken, ".") && i+1 < args[1].end &&
+					r.tokens[i+1].is(punctToken, "(") && t.text != "builder" && t.text != "build" {
+					inputs = append(inputs, t.text)
+				}
+			}
+			return inputs
+		},
+	}
+)
+
+// span is a range of tokens.
+type span struct {
+	start, end int
+}
+
+func (s span) len() int {
+	return s.end - s.start
+}
+
+// tokenReader reads the resource declarations of a program in one of the languages that can be lexed. It follows the
+// shapes of the programs that Pulumi's program generators write: it is not a parser for the language.
+type tokenReader struct {
+	*language
+	tokens []token
+	names  resourceNames
+
+	// vars maps the names of variables to the resources that their values refer to.
+	vars map[string]map[string]bool
+	// read holds the indexes of the constructions that have been read.
+	read         map[int]bool
+	declarations []declaration
+}
+
+// readTokens reads the resource declarations of a program in the given language.
+func readTokens(lang *language, src string, sources []sourceResource) ([]declaration, error) {
+	tokens, err := lex(src, lang.lexicalSyntax)
+	if err != nil {
+		return nil, err
+	}
+	r := &tokenReader{
+		language: lang,
+		tokens:   tokens,
+		names:    newResourceNames(sources),
+		vars:     map[string]map[string]bool{},
+		read:     map[int]bool{},
+	}
+	for i := range tokens {
+		r.statement(i)
+	}
+	return r.declarations, nil
+}
+
+// closing returns the index of the bracket that closes the one at i, or the end of the tokens if it isn't closed.
+func (r *tokenReader) closing(i int) int {
+	depth := 0
+	for ; i < len(r.tokens); i++ {
+		if r.tokens[i].kind != punctToken {
+			continue
+		}
+		switch r.tokens[i].text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(r.tokens)
+}
+
+// split splits the tokens between the bracket at open and the one that closes it at each top-level comma.
+func (r *tokenReader) split(open int) []span {
+	end := r.closing(open)
+	var spans []span
+	start := open + 1
+	for i := start; i < end; i++ {
+		t := r.tokens[i]
+		switch {
+		case t.is(punctToken, "(") || t.is(punctToken, "[") || t.is(punctToken, "{"):
+			i = r.closing(i)
+		case t.is(punctToken, ","):
+			spans = append(spans, span{start, i})
+			start = i + 1
+		}
+	}
+	if start < end {
+		spans = append(spans, span{start, end})
+	}
+	return spans
+}
+
+// statementEnd returns the index of the token that ends the statement or expression that starts at i.
+func (r *tokenReader) statementEnd(i int) int {
+	depth := 0
+	for ; i < len(r.tokens); i++ {
+		t := r.tokens[i]
+		switch {
+		case t.kind == newlineToken && depth == 0:
+			return i
+		case t.is(punctToken, ";") && depth == 0:
+			return i
+		case t.is(punctToken, "(") || t.is(punctToken, "[") || t.is(punctToken, "{"):
+			depth++
+		case t.is(punctToken, ")") || t.is(punctToken, "]") || t.is(punctToken, "}"):
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return len(r.tokens)
+}
+
+// statement reads the variable declaration, loop or resource construction that starts at i, if there is one.
+func (r *tokenReader) statement(i int) {
+	t := r.tokens[i]
+	if t.kind != identToken {
+		return
+	}
+	if i > 0 && (r.tokens[i-1].is(punctToken, ".") || r.tokens[i-1].is(punctToken, "?.")) {
+		return
+	}
+
+	// A resource construction.
+	if _, ok := r.construction(i); ok {
+		r.construct(i)
+		return
+	}
+
+	// A variable declaration: `const x = ...`, `var x = ...` or, in Python, `x = ...` at the start of a statement.
+	name, value := "", -1
+	if r.python {
+		if (i == 0 || r.tokens[i-1].kind == newlineToken) && r.at(i+1, punctToken, "=") {
+			name, value = t.text, i+2
+		}
+	} else if r.isDeclare(t.text) || t.text == "final" && r.at(i+1, identToken, "var") {
+		if t.text == "final" {
+			i++
+		}
+		if i+1 < len(r.tokens) && r.tokens[i+1].kind == identToken {
+			name = r.tokens[i+1].text
+			// Skip a type annotation, e.g. `const x: Foo[] = ...`.
+			for j := i + 2; j < len(r.tokens) && !r.tokens[j].is(punctToken, ";"); j++ {
+				if r.tokens[j].is(punctToken, "=") {
+					value = j + 1
+					break
+				}
+				if r.tokens[j].is(punctToken, "(") || r.tokens[j].is(punctToken, "{") || r.isLoopIn(j) {
+					break
+				}
+			}
+		}
+	}
+	if value >= 0 {
+		r.vars[name] = r.refs(span{value, r.statementEnd(value)}, "")
+		return
+	}
+
+	// A loop over a range: `for (const x of ...)`, `foreach (var x in ...)`, `for (var x : ...)` or `for x in ...:`.
+	if t.text == "for" || t.text == "foreach" {
+		j := i + 1
+		if !r.python {
+			if !r.at(j, punctToken, "(") {
+				return
+			}
+			j++
+			if r.at(j, identToken, "final") {
+				j++
+			}
+			if r.at(j, identToken, "") && r.isDeclare(r.tokens[j].text) {
+				j++
+			}
+		}
+		if !r.at(j, identToken, "") || !r.isLoopIn(j+1) {
+			return
+		}
+		name, start := r.tokens[j].text, j+2
+		end := start
+		if r.python {
+			// The range ends with the ':' that starts the body of the loop, or with the bracket that closes a
+			// comprehension.
+			for depth := 0; end < len(r.tokens); end++ {
+				t := r.tokens[end]
+				if t.is(punctToken, ":") && depth == 0 || t.kind == newlineToken {
+					break
+				}
+				switch {
+				case t.is(punctToken, "(") || t.is(punctToken, "[") || t.is(punctToken, "{"):
+					depth++
+				case t.is(punctToken, ")") || t.is(punctToken, "]") || t.is(punctToken, "}"):
+					depth--
+				}
+				if depth < 0 {
+					break
+				}
+			}
+		} else {
+			end = r.closing(i + 1)
+		}
+		r.vars[name] = r.refs(span{start, end}, "")
+		return
+	}
+
+	// An element added to a list: `xs.push(...)`, `xs.append(...)` or `xs.Add(...)`.
+	if r.at(i+1, punctToken, ".") && i+3 < len(r.tokens) && r.isAppend(r.tokens[i+2].text) &&
+		r.tokens[i+3].is(punctToken, "(") {
+		refs := r.refs(span{i + 4, r.closing(i + 3)}, "")
+		if r.vars[t.text] == nil {
+			r.vars[t.text] = map[string]bool{}
+		}
+		for ref := range refs {
+			r.vars[t.text][ref] = true
+		}
+	}
+}
+
+// at returns true if the token at i has the given kind and, unless text is empty, the given text.
+func (r *tokenReader) at(i int, kind tokenKind, text string) bool {
+	return i < len(r.tokens) && r.tokens[i].kind == kind && (text == "" || r.tokens[i].text == text)
+}
+
+func (r *tokenReader) isDeclare(text string) bool {
+	for _, d := range r.declares {
+		if text == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *tokenReader) isAppend(text string) bool {
+	for _, a := range r.appends {
+		if text == a {
+			return true
+		}
+	}
+	return false
+}
+
+// isLoopIn returns true if the token at i separates the variable of a loop from the range it loops over.
+func (r *tokenReader) isLoopIn(i int) bool {
+	return r.at(i, identToken, "in") || r.at(i, identToken, "of") || r.at(i, punctToken, ":") && !r.python
+}
+
+// constructionAt describes a call that constructs a resource.
+type constructionAt struct {
+	name     string
+	typeName string
+	// open is the index of the parenthesis that opens the constructor's arguments.
+	open int
+}
+
+// construction returns the resource construction that starts at i, if there is one: `new a.b.Foo("name", ...)` or, in
+// Python, `a.b.Foo("name", ...)`.
+func (r *tokenReader) construction(i int) (constructionAt, bool) {
+	j := i
+	if r.constructs {
+		if !r.tokens[i].is(identToken, "new") {
+			return constructionAt{}, false
+		}
+		j++
+	}
+	if !r.at(j, identToken, "") {
+		return constructionAt{}, false
+	}
+	typeName := r.tokens[j].text
+	for j++; r.at(j, punctToken, ".") && r.at(j+1, identToken, ""); j += 2 {
+		typeName = r.tokens[j+1].text
+	}
+	if !r.at(j, punctToken, "(") || !r.at(j+1, stringToken, "") {
+		return constructionAt{}, false
+	}
+	if !r.constructs && !unicode.IsUpper(rune(typeName[0])) {
+		return constructionAt{}, false
+	}
+
+	// A name that is computed when the program runs is either an interpolated string or, in Java, a string that
+	// something is appended to.
+	nameToken := r.tokens[j+1]
+	prefix := nameToken.interpolated || r.at(j+2, punctToken, "+")
+	name, ok := r.names.match(typeName, nameToken.text, prefix)
+	if !ok {
+		return constructionAt{}, false
+	}
+	return constructionAt{name: name, typeName: typeName, open: j}, true
+}
+
+// construct reads the resource construction at i.
+func (r *tokenReader) construct(i int) string {
+	c, _ := r.construction(i)
+	if r.read[i] {
+		return c.name
+	}
+	r.read[i] = true
+
+	args := r.split(c.open)
+	d := declaration{
+		name:     c.name,
+		typeName: c.typeName,
+		inputs:   r.inputs(r, args),
+		line:     r.tokens[i].line,
+	}
+	d.deps = r.refs(span{c.open + 1, r.closing(c.open)}, c.name)
+	r.declarations = append(r.declarations, d)
+	return c.name
+}
+
+// refs returns the resources that the tokens in the given span refer to, either through variables or by constructing
+// them. Nested resource constructions are read as they are found. self is the name of a resource that the span
+// belongs to, which is not a reference.
+func (r *tokenReader) refs(s span, self string) map[string]bool {
+	refs := map[string]bool{}
+	r.addRefs(refs, r.tokens, s, self)
+	return refs
+}
+
+func (r *tokenReader) addRefs(refs map[string]bool, tokens []token, s span, self string) {
+	for i := s.start; i < s.end; i++ {
+		t := tokens[i]
+		switch t.kind {
+		case stringToken:
+			if len(t.parts) > 0 {
+				r.addRefs(refs, t.parts, span{0, len(t.parts)}, self)
+			}
+		case identToken:
+			if sameTokens(tokens, r.tokens) {
+				if _, ok := r.construction(i); ok {
+					if name := r.construct(i); name != self {
+						refs[name] = true
+					}
+					c, _ := r.construction(i)
+					i = r.closing(c.open)
+					continue
+				}
+			}
+			if !isReference(tokens, i, r.key) {
+				continue
+			}
+			for ref := range r.vars[t.text] {
+				if ref != self {
+					refs[ref] = true
+				}
+			}
+		}
+	}
+}
+
+// sameTokens returns true if a and b are the same slice of tokens.
+func sameTokens(a, b []token) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// isReference returns true if the identifier at i may refer to a variable: it is not a member, like the y in x.y, or
+// the name of a property or argument, like the x in {x: 1} or f(x=1).
+func isReference(tokens []token, i int, key string) bool {
+	if i > 0 {
+		prev := tokens[i-1]
+		if prev.is(punctToken, ".") || prev.is(punctToken, "?.") || prev.is(punctToken, "::") {
+			return false
+		}
+		if key != "" && i+1 < len(tokens) && tokens[i+1].is(punctToken, key) &&
+			(prev.is(punctToken, "{") || prev.is(punctToken, ",") || prev.is(punctToken, "(")) {
+			return false
+		}
+	}
+	return true
+}
+
+// keys returns the names of the properties of the object that the given span constructs, e.g. x and y for
+// `{x: 1, y: 2}` in TypeScript or `new() { X = 1, Y = 2 }` in C#.
+func (r *tokenReader) keys(s span) []string {
+	open := -1
+	for i := s.start; i < s.end; i++ {
+		t := r.tokens[i]
+		if t.is(punctToken, "{") {
+			open = i
+			break
+		}
+		if t.is(punctToken, "(") || t.is(punctToken, "[") {
+			i = r.closing(i)
+		}
+	}
+	if open < 0 {
+		return nil
+	}
+
+	var keys []string
+	for _, property := range r.split(open) {
+		if property.len() > 1 && r.tokens[property.start+1].is(punctToken, r.key) {
+			switch name := r.tokens[property.start]; name.kind {
+			case identToken, stringToken:
+				keys = append(keys, name.text)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,211 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verify checks that programs generated from PCL in other languages are equivalent to the PCL programs that
+// they were generated from.
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/dotnet"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+)
+
+// Extensions maps the languages whose programs can be read back to the extensions of their source files.
+var Extensions = map[string]string{
+	"go":     ".go",
+	"nodejs": ".ts",
+	"python": ".py",
+	"dotnet": ".cs",
+	"java":   ".java",
+}
+
+// Program checks that a program generated in the given language declares the same resources as the PCL program that
+// it was generated from, and that each of those resources has the same type, sets the same input properties and
+// depends on the same resources, like pcl.VerifyEquivalent does for two PCL programs. files maps the paths of the
+// generated program's source files to their contents. Each difference is reported as an error diagnostic.
+//
+// The resource declarations are read back from the generated program's source. The readers follow the shapes of
+// the programs that Pulumi's program generators write: they don't parse the languages in full, and don't resolve
+// types or scopes beyond what the generators need.
+func Program(source *pcl.Program, language string, files map[string][]byte) (hcl.Diagnostics, error) {
+	ext, ok := Extensions[language]
+	if !ok {
+		return nil, fmt.Errorf("%s programs can't be verified", language)
+	}
+
+	expected := pcl.ResourceDeclarations(source)
+	sources := make([]sourceResource, 0, len(expected))
+	spellings := map[string]map[string]string{}
+	for _, n := range source.Nodes {
+		if r, ok := n.(*pcl.Resource); ok {
+			sources = append(sources, sourceResource{
+				name:     r.LogicalName(),
+				typeName: typeName(r.Token),
+				ranged:   r.Options != nil && r.Options.Range != nil,
+			})
+			spellings[r.LogicalName()] = inputSpellings(r, language)
+		}
+	}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		if filepath.Ext(filename) == ext {
+			filenames = append(filenames, filename)
+		}
+	}
+	sort.Strings(filenames)
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("the converted program has no %s files", ext)
+	}
+
+	var target []pcl.ResourceDeclaration
+	for _, filename := range filenames {
+		declarations, err := read(language, filename, files[filename], sources)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		for _, d := range declarations {
+			target = append(target, resolve(filename, d, expected, spellings[d.name]))
+		}
+	}
+	return pcl.VerifyDeclarations(expected, target), nil
+}
+
+func read(language, filename string, src []byte, sources []sourceResource) ([]declaration, error) {
+	switch language {
+	case "go":
+		return readGo(filename, src, sources)
+	case "nodejs":
+		return readTokens(typescript, string(src), sources)
+	case "python":
+		return readTokens(python, string(src), sources)
+	case "dotnet":
+		return readTokens(csharp, string(src), sources)
+	case "java":
+		return readTokens(java, string(src), sources)
+	default:
+		return nil, fmt.Errorf("%s programs can't be verified", language)
+	}
+}
+
+// inputSpellings returns the input properties of a resource that the given language spells other than by their
+// names, keyed by the normalized spelling: the C# names that a package's schema gives its properties, and Python's
+// stack_name for the name of a stack reference.
+func inputSpellings(r *pcl.Resource, language string) map[string]string {
+	spellings := map[string]string{}
+	switch language {
+	case "dotnet":
+		if r.Schema == nil {
+			break
+		}
+		for _, p := range r.Schema.InputProperties {
+			var info dotnet.CSharpPropertyInfo
+			switch csharp := p.Language["csharp"].(type) {
+			case dotnet.CSharpPropertyInfo:
+				info = csharp
+			case json.RawMessage:
+				if err := json.Unmarshal(csharp, &info); err != nil {
+					continue
+				}
+			}
+			if info.Name != "" {
+				spellings[normalize(info.Name)] = p.Name
+			}
+		}
+	case "python":
+		if r.Token == "pulumi:pulumi:StackReference" {
+			spellings[normalize("stack_name")] = "name"
+		}
+	}
+	return spellings
+}
+
+// sameType returns true if a type name read back from a generated program names the type of the given token.
+// Providers are constructed either as the package's Provider or, for packages without an SDK, by the package's name.
+func sameType(token, name string) bool {
+	if normalize(typeName(token)) == normalize(name) {
+		return true
+	}
+	pkg, isProvider := strings.CutPrefix(token, "pulumi:providers:")
+	return isProvider && normalize(pkg) == normalize(name)
+}
+
+// typeName returns the name of a resource type without its package and module, as the languages name the type's
+// class: e.g. Bucket for aws:s3/bucket:Bucket, and Provider for pulumi:providers:aws.
+func typeName(token string) string {
+	if strings.HasPrefix(token, "pulumi:providers:") {
+		return "Provider"
+	}
+	return token[strings.LastIndex(token, ":")+1:]
+}
+
+// resolve turns a declaration read back from a generated program into a resource declaration that can be compared
+// with those of the source program. Type and property names are spelled differently in each language, so those that
+// match the source program's are replaced with its spelling. spellings holds the source resource's inputs that the
+// language spells other than by their names.
+func resolve(
+	filename string, d declaration, expected []pcl.ResourceDeclaration, spellings map[string]string,
+) pcl.ResourceDeclaration {
+	var source *pcl.ResourceDeclaration
+	for i := range expected {
+		if expected[i].Name == d.name {
+			source = &expected[i]
+			break
+		}
+	}
+
+	resolved := pcl.ResourceDeclaration{
+		Name:         d.name,
+		Token:        d.typeName,
+		Inputs:       codegen.NewStringSet(),
+		Dependencies: codegen.NewStringSet(),
+		Range: hcl.Range{
+			Filename: filename,
+			Start:    hcl.Pos{Line: d.line},
+			End:      hcl.Pos{Line: d.line},
+		},
+	}
+	for dep := range d.deps {
+		resolved.Dependencies.Add(dep)
+	}
+
+	sourceInputs := map[string]string{}
+	if source != nil {
+		if sameType(source.Token, d.typeName) {
+			resolved.Token = source.Token
+		}
+		for input := range source.Inputs {
+			sourceInputs[normalize(input)] = input
+		}
+		for spelling, input := range spellings {
+			sourceInputs[spelling] = input
+		}
+	}
+	for _, input := range d.inputs {
+		if name, ok := sourceInputs[normalize(input)]; ok {
+			input = name
+		}
+		resolved.Inputs.Add(input)
+	}
+	return resolved
+}
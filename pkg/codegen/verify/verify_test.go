@@ -0,0 +1,218 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/utils"
+)
+
+var testdataPath = filepath.Join("..", "testing", "test", "testdata")
+
+func bindProgram(t *testing.T, name, source string, options ...pcl.BindOption) *pcl.Program {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(source), name)
+	require.NoError(t, err)
+	require.False(t, parser.Diagnostics.HasErrors(), parser.Diagnostics.Error())
+
+	options = append(options, pcl.PluginHost(utils.NewHost(testdataPath)))
+	program, diags, err := pcl.BindProgram(parser.Files, options...)
+	require.NoError(t, err)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return program
+}
+
+func summaries(diags hcl.Diagnostics) []string {
+	var s []string
+	for _, d := range diags {
+		s = append(s, d.Summary)
+	}
+	return s
+}
+
+// TestGeneratedPrograms checks that the programs generated for the program generator tests are equivalent to the PCL
+// programs that they were generated from.
+func TestGeneratedPrograms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		directory   string
+		bindOptions []pcl.BindOption
+	}{
+		{directory: "aws-eks"},
+		{directory: "aws-fargate"},
+		// C# names the policy property of an IAM policy PolicyDocument.
+		{directory: "aws-iam-policy"},
+		{directory: "aws-s3-logging"},
+		{directory: "aws-webserver"},
+		// Python names the name of a stack reference stack_name.
+		{directory: "pulumi-stack-reference"},
+		{directory: "simple-range"},
+		// The provider of a package without an SDK is constructed by the package's name.
+		{directory: "unknown-resource", bindOptions: []pcl.BindOption{pcl.SkipResourceTypechecking}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.directory, func(t *testing.T) {
+			t.Parallel()
+
+			dir := filepath.Join(testdataPath, tt.directory+"-pp")
+			source, err := os.ReadFile(filepath.Join(dir, tt.directory+".pp"))
+			require.NoError(t, err)
+			program := bindProgram(t, tt.directory+".pp", string(source), tt.bindOptions...)
+
+			for language, ext := range Extensions {
+				filename := filepath.Join(language, tt.directory+ext)
+				contents, err := os.ReadFile(filepath.Join(dir, filename))
+				if os.IsNotExist(err) {
+					continue
+				}
+				require.NoError(t, err)
+
+				diags, err := Program(program, language, map[string][]byte{filename: contents})
+				require.NoError(t, err)
+				assert.Empty(t, summaries(diags), language)
+			}
+		})
+	}
+}
+
+const verifySourceProgram = `resource pet "random:index/randomPet:RandomPet" {
+    length = 2
+}
+
+resource suffix "random:index/randomString:RandomString" {
+    length = 8
+    keepers = {
+        pet = pet.id
+    }
+}
+`
+
+func TestProgram(t *testing.T) {
+	t.Parallel()
+
+	program := bindProgram(t, "main.pp", verifySourceProgram)
+
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name:     "equivalent",
+			language: "nodejs",
+			files: map[string]string{"index.ts": `import * as random from "@pulumi/random";
+
+const pet = new random.RandomPet("pet", {length: 2});
+const suffix = new random.RandomString("suffix", {
+    length: 8,
+    keepers: {
+        pet: pet.id,
+    },
+});
+`},
+		},
+		{
+			name:     "differences",
+			language: "nodejs",
+			files: map[string]string{"index.ts": `import * as random from "@pulumi/random";
+
+const pet = new random.RandomPet("pet", {length: 2, prefix: "a"});
+const suffix = new random.RandomPassword("suffix", {length: 8});
+const other = new random.RandomString("other", {length: 8});
+`},
+			expected: []string{
+				"resource 'pet' sets the input properties 'prefix', which the source program does not set",
+				"resource 'suffix' has type 'RandomPassword', but has type 'random::RandomString' in the source program",
+				"resource 'suffix' does not set the input properties 'keepers'",
+				"resource 'suffix' does not depend on the resources 'pet'",
+				"resource 'other' is not declared by the source program",
+			},
+		},
+		{
+			name:     "dependencies through locals",
+			language: "python",
+			files: map[string]string{"__main__.py": `import pulumi_random as random
+
+pet = random.RandomPet("pet", length=2)
+keepers = {
+    "pet": pet.id,
+}
+suffix = random.RandomString("suffix", length=8, keepers=keepers)
+`},
+		},
+		{
+			name:     "missing resources",
+			language: "go",
+			files: map[string]string{"main.go": `package main
+
+import (
+	"github.com/pulumi/pulumi-random/sdk/v4/go/random"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		_, err := random.NewRandomPet(ctx, "pet", &random.RandomPetArgs{
+			Length: pulumi.Int(2),
+		})
+		return err
+	})
+}
+`},
+			expected: []string{"resource 'suffix' is missing from the converted program"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			files := map[string][]byte{}
+			for filename, contents := range tt.files {
+				files[filename] = []byte(contents)
+			}
+			diags, err := Program(program, tt.language, files)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, summaries(diags))
+		})
+	}
+
+	t.Run("no source files", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Program(program, "dotnet", map[string][]byte{"index.ts": nil})
+		assert.EqualError(t, err, "the converted program has no .cs files")
+	})
+
+	t.Run("unknown languages", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Program(program, "cobol", nil)
+		assert.EqualError(t, err, "cobol programs can't be verified")
+	})
+}
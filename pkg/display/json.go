@@ -82,3 +82,53 @@ type PreviewDiagnostic struct {
 	Message  string        `json:"message,omitempty"`
 	Severity diag.Severity `json:"severity,omitempty"`
 }
+
+// SummaryReport is a JSON-serializable report of a deployment operation, written once the operation ends.
+type SummaryReport struct {
+	// Preview is true if the operation was a preview.
+	Preview bool `json:"preview"`
+	// Result is the outcome of the operation: "succeeded", "failed" or "cancelled".
+	Result string `json:"result"`
+	// Duration records the amount of time it took to perform the operation.
+	Duration time.Duration `json:"duration,omitempty"`
+	// ResourceChanges contains a map of count per operation (create, update, etc).
+	ResourceChanges ResourceChanges `json:"resourceChanges,omitempty"`
+	// MaybeCorrupt indicates whether one or more resources may be corrupt.
+	MaybeCorrupt bool `json:"maybeCorrupt,omitempty"`
+
+	// Resources contains an entry for each step that changed a resource, in the order the steps started.
+	Resources []SummaryResource `json:"resources,omitempty"`
+	// PolicyViolations contains the policy violations reported during the operation.
+	PolicyViolations []SummaryPolicyViolation `json:"policyViolations,omitempty"`
+	// Diagnostics contains a record of all warnings/errors that took place during the operation. Note that
+	// ephemeral and debug messages are omitted from this list, as they are meant for display purposes only.
+	Diagnostics []PreviewDiagnostic `json:"diagnostics,omitempty"`
+	// Outputs contains the final outputs of the stack. Any secrets will be blinded.
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+}
+
+// SummaryResource describes a step that changed a resource during a deployment operation.
+type SummaryResource struct {
+	// URN is the resource being affected by this operation.
+	URN resource.URN `json:"urn"`
+	// Op is the kind of operation being performed.
+	Op StepOp `json:"op"`
+	// Diffs is a list of the properties that changed, for updating and replacement steps.
+	Diffs []string `json:"diffs,omitempty"`
+	// ReplaceReasons is a list of keys that are causing replacement (for replacement steps only).
+	ReplaceReasons []resource.PropertyKey `json:"replaceReasons,omitempty"`
+	// Duration records the amount of time the step took, if it finished.
+	Duration time.Duration `json:"duration,omitempty"`
+	// Failed is true if the step failed.
+	Failed bool `json:"failed,omitempty"`
+}
+
+// SummaryPolicyViolation is a policy violation reported during a deployment operation.
+type SummaryPolicyViolation struct {
+	URN               resource.URN             `json:"urn,omitempty"`
+	PolicyPackName    string                   `json:"policyPackName"`
+	PolicyPackVersion string                   `json:"policyPackVersion,omitempty"`
+	PolicyName        string                   `json:"policyName"`
+	EnforcementLevel  apitype.EnforcementLevel `json:"enforcementLevel"`
+	Message           string                   `json:"message,omitempty"`
+}
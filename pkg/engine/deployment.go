@@ -297,6 +297,8 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 			DisableResourceReferences: deployment.Options.DisableResourceReferences,
 			DisableOutputValues:       deployment.Options.DisableOutputValues,
 			GeneratePlan:              deployment.Options.UpdateOptions.GeneratePlan,
+			ConcurrencyLimits:         deployment.Options.ConcurrencyLimits,
+			RetryPolicy:               deployment.Options.RetryPolicy,
 		}
 		newPlan, walkResult = deployment.Deployment.Execute(ctx, opts, preview)
 		close(done)
@@ -326,8 +328,16 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 	duration := time.Since(start)
 	changes := actions.Changes()
 
+	cancelled := false
+	select {
+	case <-cancelCtx.Cancel.Canceled():
+		cancelled = true
+	default:
+	}
+
 	// Emit a summary event.
-	deployment.Options.Events.summaryEvent(preview, actions.MaybeCorrupt(), duration, changes, policyPacks)
+	deployment.Options.Events.summaryEvent(preview, actions.MaybeCorrupt(), duration, changes, policyPacks,
+		res != nil, cancelled)
 
 	return newPlan, changes, res
 }
@@ -132,6 +132,8 @@ type SummaryEventPayload struct {
 	Duration        time.Duration           // the duration of the entire update operation (zero values for previews)
 	ResourceChanges display.ResourceChanges // count of changed resources, useful for reporting
 	PolicyPacks     map[string]string       // {policy-pack: version} for each policy pack applied
+	Failed          bool                    // true if the operation failed
+	Cancelled       bool                    // true if the operation was cancelled
 }
 
 type ResourceOperationFailedPayload struct {
@@ -408,7 +410,7 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 }
 
 func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Duration,
-	resourceChanges display.ResourceChanges, policyPacks map[string]string,
+	resourceChanges display.ResourceChanges, policyPacks map[string]string, failed, cancelled bool,
 ) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -418,6 +420,8 @@ func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Du
 		Duration:        duration,
 		ResourceChanges: resourceChanges,
 		PolicyPacks:     policyPacks,
+		Failed:          failed,
+		Cancelled:       cancelled,
 	}))
 }
 
@@ -0,0 +1,226 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// concurrencyTracker records the highest number of concurrent calls seen.
+type concurrencyTracker struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (c *concurrencyTracker) enter() {
+	n := c.current.Add(1)
+	for {
+		m := c.max.Load()
+		if n <= m || c.max.CompareAndSwap(m, n) {
+			return
+		}
+	}
+}
+
+func (c *concurrencyTracker) exit() {
+	c.current.Add(-1)
+}
+
+// registerConcurrently registers the given number of resources of each type from separate goroutines.
+func registerConcurrently(t *testing.T, monitor *deploytest.ResourceMonitor, counts map[tokens.Type]int) {
+	var wg sync.WaitGroup
+	for typ, count := range counts {
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(typ tokens.Type, i int) {
+				defer wg.Done()
+				_, _, _, err := monitor.RegisterResource(typ, fmt.Sprintf("%s-%d", typ.Name(), i), true)
+				assert.NoError(t, err)
+			}(typ, i)
+		}
+	}
+	wg.Wait()
+}
+
+func TestConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	var typA, typB, pkgB concurrencyTracker
+	newLoader := func(pkg tokens.Package) *deploytest.ProviderLoader {
+		return deploytest.NewProviderLoader(pkg, semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool,
+				) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					trackers := []*concurrencyTracker{&typA}
+					if urn.Type().Package() == "pkgB" {
+						trackers = []*concurrencyTracker{&pkgB}
+						if urn.Type() == "pkgB:m:typB" {
+							trackers = append(trackers, &typB)
+						}
+					}
+					for _, tr := range trackers {
+						tr.enter()
+					}
+					time.Sleep(10 * time.Millisecond)
+					for _, tr := range trackers {
+						tr.exit()
+					}
+					return resource.ID(urn.Name()), news, resource.StatusOK, nil
+				},
+			}, nil
+		})
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		registerConcurrently(t, monitor, map[tokens.Type]int{
+			"pkgA:m:typA": 8,
+			"pkgB:m:typB": 8,
+			"pkgB:m:typC": 8,
+		})
+		return nil
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			Parallel: 16,
+			ConcurrencyLimits: map[string]int{
+				"pkgB":        3,
+				"pkgB:m:typB": 1,
+			},
+			Host: deploytest.NewPluginHost(nil, nil, program, newLoader("pkgA"), newLoader("pkgB")),
+		},
+	}
+
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 26) // two default providers and 24 resources
+
+	assert.LessOrEqual(t, pkgB.max.Load(), int32(3))
+	assert.Equal(t, int32(1), typB.max.Load())
+	// Resources without a limit still run concurrently.
+	assert.Greater(t, typA.max.Load(), int32(1))
+}
+
+// blockedTypeLoader returns a provider loader for pkgA whose first typA resource can only finish once typB has been
+// created. startedA is closed once that first resource has started.
+func blockedTypeLoader(startedA chan<- struct{}) *deploytest.ProviderLoader {
+	createdB := make(chan struct{})
+	var startedOnce sync.Once
+	return deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+		return &deploytest.Provider{
+			CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+				preview bool,
+			) (resource.ID, resource.PropertyMap, resource.Status, error) {
+				switch urn.Type() {
+				case "pkgA:m:typA":
+					startedOnce.Do(func() { close(startedA) })
+					select {
+					case <-createdB:
+					case <-time.After(10 * time.Second):
+						return "", nil, resource.StatusOK, errors.New("timed out waiting for typB")
+					}
+				case "pkgA:m:typB":
+					close(createdB)
+				}
+				return resource.ID(urn.Name()), news, resource.StatusOK, nil
+			},
+		}, nil
+	})
+}
+
+// blockedTypeProgram registers two typA resources and then, once the second one is waiting for the limit on typA, a
+// typB resource.
+func blockedTypeProgram(t *testing.T, startedA <-chan struct{}) plugin.LanguageRuntime {
+	return deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registerConcurrently(t, monitor, map[tokens.Type]int{"pkgA:m:typA": 2})
+		}()
+
+		// Give the second typA resource time to start waiting for the limit.
+		<-startedA
+		time.Sleep(100 * time.Millisecond)
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typB", "resB", true)
+		assert.NoError(t, err)
+
+		wg.Wait()
+		return nil
+	})
+}
+
+// Tests that steps waiting for a concurrency limit don't hold up steps that aren't limited.
+func TestConcurrencyLimitsDontBlockUnrelatedSteps(t *testing.T) {
+	t.Parallel()
+
+	// If the second typA resource took up the last parallelism slot while waiting for the limit, the update would
+	// never finish.
+	startedA := make(chan struct{})
+	program := blockedTypeProgram(t, startedA)
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			Parallel:          2,
+			ConcurrencyLimits: map[string]int{"pkgA:m:typA": 1},
+			Host:              deploytest.NewPluginHost(nil, nil, program, blockedTypeLoader(startedA)),
+		},
+	}
+
+	project := p.GetProject()
+	_, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+}
+
+// Tests that steps waiting for the limit on their type don't hold up other types in the same package.
+func TestConcurrencyLimitsDontBlockOtherTypesInPackage(t *testing.T) {
+	t.Parallel()
+
+	// If the second typA resource took up the last pkgA slot while waiting for the limit on typA, typB could never be
+	// created and the update would never finish.
+	startedA := make(chan struct{})
+	program := blockedTypeProgram(t, startedA)
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			Parallel: 16,
+			ConcurrencyLimits: map[string]int{
+				"pkgA":        2,
+				"pkgA:m:typA": 1,
+			},
+			Host: deploytest.NewPluginHost(nil, nil, program, blockedTypeLoader(startedA)),
+		},
+	}
+
+	project := p.GetProject()
+	_, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+}
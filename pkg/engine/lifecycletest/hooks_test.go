@@ -0,0 +1,398 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// hookRecorder records the resource hooks that run, in order.
+type hookRecorder struct {
+	lock  sync.Mutex
+	calls []string
+	args  map[string]resource.ResourceHookArgs
+}
+
+// hook returns a hook that records its calls under the given name and then returns err.
+func (r *hookRecorder) hook(name string, err error) resource.ResourceHookFunc {
+	return func(_ context.Context, args resource.ResourceHookArgs) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.calls = append(r.calls, name)
+		if r.args == nil {
+			r.args = map[string]resource.ResourceHookArgs{}
+		}
+		r.args[name] = args
+		return err
+	}
+}
+
+func (r *hookRecorder) reset() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	calls := r.calls
+	r.calls, r.args = nil, nil
+	return calls
+}
+
+// registerAllHooks registers one recording hook per lifecycle operation and returns the binding that refers to them.
+func registerAllHooks(t *testing.T, monitor *deploytest.ResourceMonitor,
+	r *hookRecorder,
+) *pulumirpc.RegisterResourceRequest_ResourceHooksBinding {
+	for _, name := range []string{
+		"beforeCreate", "afterCreate", "beforeUpdate", "afterUpdate", "beforeDelete", "afterDelete",
+	} {
+		require.NoError(t, monitor.RegisterResourceHook(name, r.hook(name, nil)))
+	}
+	return &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{
+		BeforeCreate: []string{"beforeCreate"},
+		AfterCreate:  []string{"afterCreate"},
+		BeforeUpdate: []string{"beforeUpdate"},
+		AfterUpdate:  []string{"afterUpdate"},
+		BeforeDelete: []string{"beforeDelete"},
+		AfterDelete:  []string{"afterDelete"},
+	}
+}
+
+// countHookWarnings returns the number of warnings about resource hooks in the given events.
+func countHookWarnings(events []Event) int {
+	count := 0
+	for _, evt := range events {
+		if evt.Type != DiagEvent {
+			continue
+		}
+		e := evt.Payload().(DiagEventPayload)
+		if e.Severity == diag.Warning && strings.Contains(colors.Never.Colorize(e.Message), "resource hook") {
+			count++
+		}
+	}
+	return count
+}
+
+func TestResourceHooksLifecycle(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool,
+				) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					return "created-id", news, resource.StatusOK, nil
+				},
+				DiffF: func(urn resource.URN, id resource.ID, oldInputs, oldOutputs, newInputs resource.PropertyMap,
+					ignoreChanges []string,
+				) (plugin.DiffResult, error) {
+					if !oldInputs["replace"].DeepEquals(newInputs["replace"]) {
+						return plugin.DiffResult{
+							Changes:             plugin.DiffSome,
+							ReplaceKeys:         []resource.PropertyKey{"replace"},
+							DeleteBeforeReplace: true,
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+			}, nil
+		}),
+	}
+
+	var r hookRecorder
+	inputs := resource.PropertyMap{"foo": resource.NewStringProperty("bar")}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		hooks := registerAllHooks(t, monitor, &r)
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputs,
+			Hooks:  hooks,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+	}
+	project := p.GetProject()
+
+	// Creating the resource runs the create hooks, but previewing it doesn't.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, true, p.BackendClient, nil)
+	require.Nil(t, res)
+	assert.Empty(t, r.reset())
+
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.Nil(t, res)
+	assert.Equal(t, resource.ID("created-id"), r.args["afterCreate"].ID)
+	assert.Equal(t, "bar", r.args["afterCreate"].NewOutputs["foo"].StringValue())
+	assert.Nil(t, r.args["beforeCreate"].NewOutputs)
+	assert.Equal(t, []string{"beforeCreate", "afterCreate"}, r.reset())
+
+	// Updating the resource runs the update hooks with both the old and the new state.
+	inputs = resource.PropertyMap{"foo": resource.NewStringProperty("baz")}
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.Nil(t, res)
+	assert.Equal(t, "bar", r.args["beforeUpdate"].OldInputs["foo"].StringValue())
+	assert.Equal(t, "baz", r.args["beforeUpdate"].NewInputs["foo"].StringValue())
+	assert.Equal(t, "baz", r.args["afterUpdate"].NewOutputs["foo"].StringValue())
+	assert.Equal(t, []string{"beforeUpdate", "afterUpdate"}, r.reset())
+
+	// Replacing the resource deletes it first, so the delete hooks run while the program is still running.
+	inputs = resource.PropertyMap{"foo": resource.NewStringProperty("baz"), "replace": resource.NewStringProperty("1")}
+	_, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.Nil(t, res)
+	assert.Equal(t, "baz", r.args["beforeDelete"].OldOutputs["foo"].StringValue())
+	assert.Equal(t, []string{"beforeDelete", "afterDelete", "beforeCreate", "afterCreate"}, r.reset())
+}
+
+func TestResourceHookFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc        string
+		binding     *pulumirpc.RegisterResourceRequest_ResourceHooksBinding
+		wantCreated bool
+	}{
+		{
+			desc:    "before hook fails the step",
+			binding: &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{BeforeCreate: []string{"failing"}},
+		},
+		{
+			desc:        "after hook only warns",
+			binding:     &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{AfterCreate: []string{"failing"}},
+			wantCreated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			created := false
+			loaders := []*deploytest.ProviderLoader{
+				deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+					return &deploytest.Provider{
+						CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+							preview bool,
+						) (resource.ID, resource.PropertyMap, resource.Status, error) {
+							created = true
+							return "created-id", news, resource.StatusOK, nil
+						},
+					}, nil
+				}),
+			}
+
+			var r hookRecorder
+			program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+				require.NoError(t, monitor.RegisterResourceHook("failing", r.hook("failing", errors.New("boom"))))
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+					Hooks: tt.binding,
+				})
+				return err
+			})
+
+			p := &TestPlan{
+				Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+				Steps: []TestStep{{
+					Op:            Update,
+					SkipPreview:   true,
+					ExpectFailure: !tt.wantCreated,
+					Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+						events []Event, res result.Result,
+					) result.Result {
+						if tt.wantCreated {
+							assert.Equal(t, 1, countHookWarnings(events))
+						}
+						return res
+					},
+				}},
+			}
+
+			p.Run(t, nil)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Equal(t, []string{"failing"}, r.reset())
+		})
+	}
+}
+
+func TestResourceHookUnknownName(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Hooks: &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{BeforeCreate: []string{"missing"}},
+		})
+		assert.ErrorContains(t, err, "unknown resource hook 'missing'")
+		return err
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+		Steps:   []TestStep{{Op: Update, SkipPreview: true, ExpectFailure: true}},
+	}
+	p.Run(t, nil)
+}
+
+// hookErrors returns the error diagnostics about resource hooks in the given events.
+func hookErrors(events []Event) []string {
+	var messages []string
+	for _, evt := range events {
+		if evt.Type != DiagEvent {
+			continue
+		}
+		e := evt.Payload().(DiagEventPayload)
+		if msg := colors.Never.Colorize(e.Message); e.Severity == diag.Error && strings.Contains(msg, "delete hooks") {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// TestResourceHooksUnrunnableDeletes checks that resources whose delete hooks can't run, because they're deleted once
+// the program has exited or without running it at all, aren't deleted until their delete hooks are removed.
+func TestResourceHooksUnrunnableDeletes(t *testing.T) {
+	t.Parallel()
+
+	deletes := 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool,
+				) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					return "created-id", news, resource.StatusOK, nil
+				},
+				DiffF: func(urn resource.URN, id resource.ID, oldInputs, oldOutputs, newInputs resource.PropertyMap,
+					ignoreChanges []string,
+				) (plugin.DiffResult, error) {
+					if !oldInputs["replace"].DeepEquals(newInputs["replace"]) {
+						return plugin.DiffResult{
+							Changes:     plugin.DiffSome,
+							ReplaceKeys: []resource.PropertyKey{"replace"},
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64,
+				) (resource.Status, error) {
+					deletes++
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	var r hookRecorder
+	register, withHooks := true, true
+	inputs := resource.PropertyMap{}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		hooks := registerAllHooks(t, monitor, &r)
+		if !withHooks {
+			hooks = nil
+		}
+		if register {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+				Inputs: inputs,
+				Hooks:  hooks,
+			})
+			return err
+		}
+		return nil
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+	}
+	project := p.GetProject()
+
+	var messages []string
+	validate := func(project workspace.Project, target deploy.Target, entries JournalEntries,
+		events []Event, res result.Result,
+	) result.Result {
+		messages = hookErrors(events)
+		return res
+	}
+
+	// The names of the delete hooks are persisted in the resource's state.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	require.Nil(t, res)
+	require.Len(t, snap.Resources, 2)
+	assert.Equal(t, &resource.DeleteHooks{Before: []string{"beforeDelete"}, After: []string{"afterDelete"}},
+		snap.Resources[1].DeleteHooks)
+	r.reset()
+
+	// Replacing the resource by creating its replacement first would delete it once the program has exited.
+	inputs = resource.PropertyMap{"replace": resource.NewStringProperty("1")}
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, validate)
+	assert.NotNil(t, res)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "unable to replace resource \"urn:pulumi:test::test::pkgA:m:typA::resA\"\n"+
+		"as it has delete hooks that can't run once the program has exited")
+	inputs = resource.PropertyMap{}
+
+	// Removing the resource from the program deletes it once the program has exited.
+	register = false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, validate)
+	assert.NotNil(t, res)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "unable to delete resource \"urn:pulumi:test::test::pkgA:m:typA::resA\"\n"+
+		"as it has delete hooks (beforeDelete, afterDelete) that can't run once the program has exited")
+	require.Len(t, snap.Resources, 2)
+
+	// Destroying the stack doesn't run the program at all.
+	snap, res = TestOp(Destroy).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, validate)
+	assert.NotNil(t, res)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "unable to delete resource \"urn:pulumi:test::test::pkgA:m:typA::resA\"")
+	require.Len(t, snap.Resources, 2)
+	assert.Equal(t, 0, deletes)
+	assert.Empty(t, r.reset())
+
+	// Once the delete hooks are removed from the resource, it can be destroyed.
+	register, withHooks = true, false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.Nil(t, res)
+	require.Len(t, snap.Resources, 2)
+	assert.Nil(t, snap.Resources[1].DeleteHooks)
+
+	snap, res = TestOp(Destroy).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.Nil(t, res)
+	assert.Empty(t, snap.Resources)
+	assert.Equal(t, 1, deletes)
+}
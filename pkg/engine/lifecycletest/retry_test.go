@@ -0,0 +1,209 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// countRetryWarnings returns the number of warnings reporting a retry in the given events.
+func countRetryWarnings(events []Event) int {
+	count := 0
+	for _, evt := range events {
+		if evt.Type != DiagEvent {
+			continue
+		}
+		e := evt.Payload().(DiagEventPayload)
+		if e.Severity == diag.Warning && strings.Contains(colors.Never.Colorize(e.Message), "retrying in") {
+			count++
+		}
+	}
+	return count
+}
+
+// newFailingCreateLoader returns a provider loader whose Create fails with the given error the first failures times.
+func newFailingCreateLoader(calls *atomic.Int32, failures int32, err error) *deploytest.ProviderLoader {
+	return deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+		return &deploytest.Provider{
+			CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+				preview bool,
+			) (resource.ID, resource.PropertyMap, resource.Status, error) {
+				if preview {
+					return "", news, resource.StatusOK, nil
+				}
+				if calls.Add(1) <= failures {
+					return "", nil, resource.StatusOK, err
+				}
+				return "created-id", news, resource.StatusOK, nil
+			},
+		}, nil
+	})
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	loader := newFailingCreateLoader(&calls, 2, rpcerror.New(codes.Unavailable, "throttled"))
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			RetryPolicy: &pulumirpc.RegisterResourceRequest_RetryPolicy{MaxAttempts: 3, Delay: "1ms"},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loader)},
+		Steps: []TestStep{{
+			Op:          Update,
+			SkipPreview: true,
+			Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+				events []Event, res result.Result,
+			) result.Result {
+				assert.Equal(t, 2, countRetryWarnings(events))
+				return res
+			},
+		}},
+	}
+
+	snap := p.Run(t, nil)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, resource.ID("created-id"), snap.Resources[1].ID)
+	assert.Equal(t, 3, snap.Resources[1].RetryPolicy.MaxAttempts)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc      string
+		err       error
+		wantCalls int32
+	}{
+		{desc: "non-retryable", err: rpcerror.New(codes.InvalidArgument, "bad input"), wantCalls: 1},
+		{desc: "out of attempts", err: rpcerror.New(codes.ResourceExhausted, "throttled"), wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			loader := newFailingCreateLoader(&calls, 10, tt.err)
+
+			program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+				assert.Error(t, err)
+				return err
+			})
+
+			p := &TestPlan{
+				Options: UpdateOptions{
+					Host:        deploytest.NewPluginHost(nil, nil, program, loader),
+					RetryPolicy: &resource.RetryPolicy{MaxAttempts: 3, Delay: 0.001},
+				},
+				Steps: []TestStep{{
+					Op:            Update,
+					SkipPreview:   true,
+					ExpectFailure: true,
+				}},
+			}
+
+			p.Run(t, nil)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+// Tests that deletes are retried according to the project's policy, using the retryable error patterns.
+func TestRetryDeleteWithErrorPatterns(t *testing.T) {
+	t.Parallel()
+
+	var deletes atomic.Int32
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64,
+				) (resource.Status, error) {
+					if deletes.Add(1) == 1 {
+						return resource.StatusOK, errors.New("Rate exceeded, slow down")
+					}
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	createResource := true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if createResource {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			Host: deploytest.NewPluginHost(nil, nil, program, loaders...),
+			RetryPolicy: &resource.RetryPolicy{
+				MaxAttempts:     2,
+				Delay:           0.001,
+				RetryableErrors: []string{"(?i)rate exceeded"},
+			},
+		},
+	}
+
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+
+	createResource = false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient,
+		func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			events []Event, res result.Result,
+		) result.Result {
+			assert.Equal(t, 1, countRetryWarnings(events))
+			return res
+		})
+	assert.Nil(t, res)
+	assert.Equal(t, int32(2), deletes.Load())
+	assert.Len(t, snap.Resources, 0)
+}
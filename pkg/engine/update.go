@@ -108,6 +108,12 @@ type UpdateOptions struct {
 	// the degree of parallelism for resource operations (<=1 for serial).
 	Parallel int
 
+	// caps on the number of concurrent resource operations, keyed by package or type token.
+	ConcurrencyLimits map[string]int
+
+	// an optional policy for retrying provider operations that fail with a transient error.
+	RetryPolicy *resource.RetryPolicy
+
 	// true if debugging output it enabled
 	Debug bool
 
@@ -0,0 +1,101 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+// Vertices returns every vertex reachable from the roots of the graph, in breadth-first order.
+func Vertices(g Graph) []Vertex {
+	var vertices []Vertex
+	queued := make(map[Vertex]bool)
+	for _, root := range g.Roots() {
+		if to := root.To(); !queued[to] {
+			queued[to] = true
+			vertices = append(vertices, to)
+		}
+	}
+	for i := 0; i < len(vertices); i++ {
+		for _, out := range vertices[i].Outs() {
+			if to := out.To(); !queued[to] {
+				queued[to] = true
+				vertices = append(vertices, to)
+			}
+		}
+	}
+	return vertices
+}
+
+// Cluster is a group of vertices that are drawn together, formed around the vertex that the others are grouped under.
+type Cluster struct {
+	Vertex   Vertex     // the vertex the cluster is formed around.
+	Members  []Vertex   // the vertices in this cluster that don't form clusters of their own, starting with Vertex.
+	Clusters []*Cluster // the clusters nested within this one.
+}
+
+// Clusters arranges the given vertices into clusters according to the groups given by GroupedVertex. It returns the
+// vertices that aren't part of any cluster along with the outermost clusters. Vertices whose group isn't one of the
+// given vertices are treated as ungrouped. The order of the given vertices is preserved throughout.
+func Clusters(vertices []Vertex) ([]Vertex, []*Cluster) {
+	present := make(map[Vertex]bool, len(vertices))
+	for _, v := range vertices {
+		present[v] = true
+	}
+	groupOf := func(v Vertex) Vertex {
+		if gv, ok := v.(GroupedVertex); ok {
+			if g := gv.Group(); g != nil && present[g] {
+				return g
+			}
+		}
+		return nil
+	}
+
+	isGroup := make(map[Vertex]bool)
+	for _, v := range vertices {
+		if g := groupOf(v); g != nil {
+			isGroup[g] = true
+		}
+	}
+
+	clusters := make(map[Vertex]*Cluster)
+	clusterOf := func(v Vertex) *Cluster {
+		c, ok := clusters[v]
+		if !ok {
+			c = &Cluster{Vertex: v}
+			clusters[v] = c
+		}
+		return c
+	}
+
+	var ungrouped []Vertex
+	var outermost []*Cluster
+	for _, v := range vertices {
+		g := groupOf(v)
+		switch {
+		case isGroup[v]:
+			c := clusterOf(v)
+			c.Members = append([]Vertex{v}, c.Members...)
+			if g != nil {
+				parent := clusterOf(g)
+				parent.Clusters = append(parent.Clusters, c)
+			} else {
+				outermost = append(outermost, c)
+			}
+		case g != nil:
+			c := clusterOf(g)
+			c.Members = append(c.Members, v)
+		default:
+			ungrouped = append(ungrouped, v)
+		}
+	}
+	return ungrouped, outermost
+}
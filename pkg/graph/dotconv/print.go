@@ -63,8 +63,18 @@ func Print(g graph.Graph, w io.Writer) error {
 		return id
 	}
 
-	// Now, until the frontier is empty, emit entries into the stream.
+	// Vertices that belong to groups are declared first, inside a cluster for each group, so that they are drawn
+	// together.
 	indent := "    "
+	declared := make(map[graph.Vertex]bool)
+	_, clusters := graph.Clusters(graph.Vertices(g))
+	for _, cluster := range clusters {
+		if err := printCluster(b, cluster, indent, getID, declared); err != nil {
+			return err
+		}
+	}
+
+	// Now, until the frontier is empty, emit entries into the stream.
 	emitted := make(map[graph.Vertex]bool)
 	for len(frontier) > 0 {
 		// Dequeue the head of the frontier.
@@ -78,17 +88,11 @@ func Print(g graph.Graph, w io.Writer) error {
 
 		// Print this vertex; first its "label" (type) and then its direct dependencies.
 		// IDEA: consider serializing properties on the node also.
-		if _, err := fmt.Fprintf(b, "%v%v", indent, id); err != nil {
-			return err
-		}
-		if label := v.Label(); label != "" {
-			if _, err := fmt.Fprintf(b, " [label=\"%v\"]", label); err != nil {
+		if !declared[v] {
+			if err := printVertex(b, v, id, indent); err != nil {
 				return err
 			}
 		}
-		if _, err := b.WriteString(";\n"); err != nil {
-			return err
-		}
 
 		// Now print out all dependencies as "ID -> {A ... Z}".
 		outs := v.Outs()
@@ -132,3 +136,46 @@ func Print(g graph.Graph, w io.Writer) error {
 	}
 	return b.Flush()
 }
+
+// printVertex prints the declaration of a single vertex.
+func printVertex(b *bufio.Writer, v graph.Vertex, id, indent string) error {
+	if _, err := fmt.Fprintf(b, "%v%v", indent, id); err != nil {
+		return err
+	}
+	if label := v.Label(); label != "" {
+		if _, err := fmt.Fprintf(b, " [label=\"%v\"]", label); err != nil {
+			return err
+		}
+	}
+	_, err := b.WriteString(";\n")
+	return err
+}
+
+// printCluster prints a cluster subgraph declaring the vertices in the given cluster and, recursively, its nested
+// clusters.
+func printCluster(b *bufio.Writer, cluster *graph.Cluster, indent string, getID func(graph.Vertex) string,
+	declared map[graph.Vertex]bool,
+) error {
+	if _, err := fmt.Fprintf(b, "%vsubgraph cluster_%v {\n", indent, getID(cluster.Vertex)); err != nil {
+		return err
+	}
+	inner := indent + "    "
+	if label := cluster.Vertex.Label(); label != "" {
+		if _, err := fmt.Fprintf(b, "%vlabel = \"%v\";\n", inner, label); err != nil {
+			return err
+		}
+	}
+	for _, v := range cluster.Members {
+		declared[v] = true
+		if err := printVertex(b, v, getID(v), inner); err != nil {
+			return err
+		}
+	}
+	for _, nested := range cluster.Clusters {
+		if err := printCluster(b, nested, inner, getID, declared); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(b, "%v}\n", indent)
+	return err
+}
@@ -46,3 +46,10 @@ type Edge interface {
 	From() Vertex      // the vertex this edge connects from.
 	Color() string     // an optional color for this edge, for when this graph is displayed.
 }
+
+// GroupedVertex is optionally implemented by vertices that belong to a group of related vertices, such as the children
+// of a component resource.  Printers that understand groups draw the members of each group together.
+type GroupedVertex interface {
+	Vertex
+	Group() Vertex // the vertex this vertex is grouped under, or nil if it isn't part of a group.
+}
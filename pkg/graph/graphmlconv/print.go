@@ -0,0 +1,150 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphmlconv converts a resource graph into a GraphML document, which can be loaded by graph tools such as
+// yEd, Gephi and NetworkX.  Please see http://graphml.graphdrawing.org/specification.html for the specification of
+// the GraphML format.
+package graphmlconv
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+)
+
+const namespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphML struct {
+	XMLName xml.Name  `xml:"graphml"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Keys    []key     `xml:"key"`
+	Graph   *subgraph `xml:"graph"`
+}
+
+type key struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type subgraph struct {
+	ID          string `xml:"id,attr"`
+	EdgeDefault string `xml:"edgedefault,attr"`
+	Nodes       []node `xml:"node"`
+	Edges       []edge `xml:"edge,omitempty"`
+}
+
+type node struct {
+	ID    string    `xml:"id,attr"`
+	Data  []data    `xml:"data"`
+	Graph *subgraph `xml:"graph,omitempty"`
+}
+
+type edge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Data   []data `xml:"data"`
+}
+
+type data struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Print prints a resource graph as a GraphML document.  Vertices that belong to a group are nested in a graph within
+// the node of the vertex they are grouped under.
+func Print(g graph.Graph, w io.Writer) error {
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string, len(vertices))
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	root := &subgraph{ID: "G", EdgeDefault: "directed"}
+	ungrouped, clusters := graph.Clusters(vertices)
+	for _, v := range ungrouped {
+		root.Nodes = append(root.Nodes, newNode(v, ids[v]))
+	}
+	for _, cluster := range clusters {
+		root.Nodes = append(root.Nodes, newClusterNode(cluster, ids))
+	}
+
+	// Edges are all kept in the outermost graph, which GraphML allows even if their ends are in nested graphs.
+	for _, v := range vertices {
+		for _, out := range v.Outs() {
+			e := edge{
+				ID:     "e" + strconv.Itoa(len(root.Edges)),
+				Source: ids[v],
+				Target: ids[out.To()],
+			}
+			if label := out.Label(); label != "" {
+				e.Data = append(e.Data, data{Key: "edgeLabel", Value: label})
+			}
+			if color := out.Color(); color != "" {
+				e.Data = append(e.Data, data{Key: "color", Value: color})
+			}
+			root.Edges = append(root.Edges, e)
+		}
+	}
+
+	doc := graphML{
+		Xmlns: namespace,
+		Keys: []key{
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "edgeLabel", For: "edge", AttrName: "label", AttrType: "string"},
+			{ID: "color", For: "edge", AttrName: "color", AttrType: "string"},
+		},
+		Graph: root,
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding GraphML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newNode(v graph.Vertex, id string) node {
+	n := node{ID: id}
+	if label := v.Label(); label != "" {
+		n.Data = append(n.Data, data{Key: "label", Value: label})
+	}
+	return n
+}
+
+// newClusterNode returns the node for the vertex a cluster is formed around, with the rest of the cluster nested in
+// it.
+func newClusterNode(cluster *graph.Cluster, ids map[graph.Vertex]string) node {
+	n := newNode(cluster.Vertex, ids[cluster.Vertex])
+	n.Graph = &subgraph{ID: n.ID + ":", EdgeDefault: "directed"}
+	for _, v := range cluster.Members {
+		if v != cluster.Vertex {
+			n.Graph.Nodes = append(n.Graph.Nodes, newNode(v, ids[v]))
+		}
+	}
+	for _, nested := range cluster.Clusters {
+		n.Graph.Nodes = append(n.Graph.Nodes, newClusterNode(nested, ids))
+	}
+	return n
+}
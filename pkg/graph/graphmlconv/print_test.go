@@ -0,0 +1,101 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphmlconv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+)
+
+type testVertex struct {
+	label string
+	group graph.Vertex
+	outs  []graph.Edge
+}
+
+func (v *testVertex) Data() interface{}   { return nil }
+func (v *testVertex) Label() string       { return v.label }
+func (v *testVertex) Ins() []graph.Edge   { return nil }
+func (v *testVertex) Outs() []graph.Edge  { return v.outs }
+func (v *testVertex) Group() graph.Vertex { return v.group }
+
+type testEdge struct {
+	from, to *testVertex
+	label    string
+	color    string
+}
+
+func (e *testEdge) Data() interface{}  { return nil }
+func (e *testEdge) Label() string      { return e.label }
+func (e *testEdge) To() graph.Vertex   { return e.to }
+func (e *testEdge) From() graph.Vertex { return e.from }
+func (e *testEdge) Color() string      { return e.color }
+
+type testGraph struct {
+	roots []*testVertex
+}
+
+func (g *testGraph) Roots() []graph.Edge {
+	var edges []graph.Edge
+	for _, r := range g.roots {
+		edges = append(edges, &testEdge{to: r})
+	}
+	return edges
+}
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+
+	component := &testVertex{label: "component"}
+	child := &testVertex{label: "child & co", group: component}
+	other := &testVertex{label: "other"}
+	child.outs = []graph.Edge{&testEdge{from: child, to: component, color: "#AA6639"}}
+	other.outs = []graph.Edge{&testEdge{from: other, to: child, label: "input"}}
+
+	var buf bytes.Buffer
+	err := Print(&testGraph{roots: []*testVertex{other, child, component}}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="label" for="node" attr.name="label" attr.type="string"></key>
+  <key id="edgeLabel" for="edge" attr.name="label" attr.type="string"></key>
+  <key id="color" for="edge" attr.name="color" attr.type="string"></key>
+  <graph id="G" edgedefault="directed">
+    <node id="Resource0">
+      <data key="label">other</data>
+    </node>
+    <node id="Resource2">
+      <data key="label">component</data>
+      <graph id="Resource2:" edgedefault="directed">
+        <node id="Resource1">
+          <data key="label">child &amp; co</data>
+        </node>
+      </graph>
+    </node>
+    <edge id="e0" source="Resource0" target="Resource1">
+      <data key="edgeLabel">input</data>
+    </edge>
+    <edge id="e1" source="Resource1" target="Resource2">
+      <data key="color">#AA6639</data>
+    </edge>
+  </graph>
+</graphml>
+`, buf.String())
+}
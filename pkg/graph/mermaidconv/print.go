@@ -0,0 +1,97 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mermaidconv converts a resource graph into a Mermaid flowchart.  Mermaid diagrams can be embedded directly in
+// Markdown documents, which are then rendered by sites such as GitHub.  Please see
+// https://mermaid.js.org/syntax/flowchart.html for a specification of the flowchart syntax.
+package mermaidconv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+)
+
+// Print prints a resource graph as a Mermaid flowchart.
+func Print(g graph.Graph, w io.Writer) error {
+	// As with dotconv, we ignore write errors until the final flush, which latches the first error.
+	b := bufio.NewWriter(w)
+	fmt.Fprint(b, "flowchart TD\n")
+
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string, len(vertices))
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	indent := "    "
+	ungrouped, clusters := graph.Clusters(vertices)
+	for _, v := range ungrouped {
+		printVertex(b, v, ids[v], indent)
+	}
+	for _, cluster := range clusters {
+		printCluster(b, cluster, ids, indent)
+	}
+
+	// Mermaid styles edges by their position in the chart, so keep track of those that need a color.
+	var styles []string
+	edge := 0
+	for _, v := range vertices {
+		for _, out := range v.Outs() {
+			if label := out.Label(); label != "" {
+				fmt.Fprintf(b, "%v%v -->|%v| %v\n", indent, ids[v], quote(label), ids[out.To()])
+			} else {
+				fmt.Fprintf(b, "%v%v --> %v\n", indent, ids[v], ids[out.To()])
+			}
+			if color := out.Color(); color != "" {
+				styles = append(styles, fmt.Sprintf("%vlinkStyle %v stroke:%v", indent, edge, color))
+			}
+			edge++
+		}
+	}
+	for _, style := range styles {
+		fmt.Fprintln(b, style)
+	}
+
+	return b.Flush()
+}
+
+func printVertex(b *bufio.Writer, v graph.Vertex, id, indent string) {
+	if label := v.Label(); label != "" {
+		fmt.Fprintf(b, "%v%v[%v]\n", indent, id, quote(label))
+	} else {
+		fmt.Fprintf(b, "%v%v\n", indent, id)
+	}
+}
+
+func printCluster(b *bufio.Writer, cluster *graph.Cluster, ids map[graph.Vertex]string, indent string) {
+	fmt.Fprintf(b, "%vsubgraph cluster_%v [%v]\n", indent, ids[cluster.Vertex], quote(cluster.Vertex.Label()))
+	for _, v := range cluster.Members {
+		printVertex(b, v, ids[v], indent+"    ")
+	}
+	for _, nested := range cluster.Clusters {
+		printCluster(b, nested, ids, indent+"    ")
+	}
+	fmt.Fprintf(b, "%vend\n", indent)
+}
+
+// quote returns text as a quoted Mermaid string.  Mermaid strings can't contain double quotes, so those are written
+// as entity codes instead.
+func quote(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
@@ -64,6 +64,14 @@ type Options struct {
 	DisableResourceReferences bool       // true to disable resource reference support.
 	DisableOutputValues       bool       // true to disable output value support.
 	GeneratePlan              bool       // true to enable plan generation.
+
+	// ConcurrencyLimits caps the number of concurrent resource operations on resources of a package (such as "aws")
+	// or a type (such as "aws:iam/role:Role"), on top of the degree of parallelism.
+	ConcurrencyLimits map[string]int
+
+	// RetryPolicy controls how provider operations that fail with a transient error are retried. Policies set on
+	// individual resources take precedence over it.
+	RetryPolicy *resource.RetryPolicy
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -0,0 +1,116 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// resourceHooksServer serves the resource hooks that a test program registers with the engine.
+type resourceHooksServer struct {
+	pulumirpc.UnimplementedResourceHooksServer
+
+	address string
+	cancel  chan bool
+	done    <-chan error
+
+	hooks     map[string]resource.ResourceHookFunc
+	hooksLock sync.Mutex
+}
+
+func startResourceHooksServer() (*resourceHooksServer, error) {
+	s := &resourceHooksServer{
+		cancel: make(chan bool),
+		hooks:  map[string]resource.ResourceHookFunc{},
+	}
+	handle, err := rpcutil.ServeWithOptions(rpcutil.ServeOptions{
+		Cancel: s.cancel,
+		Init: func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceHooksServer(srv, s)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.address, s.done = fmt.Sprintf("127.0.0.1:%d", handle.Port), handle.Done
+	return s, nil
+}
+
+func (s *resourceHooksServer) Close() error {
+	close(s.cancel)
+	return <-s.done
+}
+
+func (s *resourceHooksServer) add(name string, fn resource.ResourceHookFunc) {
+	s.hooksLock.Lock()
+	defer s.hooksLock.Unlock()
+	s.hooks[name] = fn
+}
+
+func (s *resourceHooksServer) InvokeResourceHook(ctx context.Context,
+	req *pulumirpc.InvokeResourceHookRequest,
+) (*pulumirpc.InvokeResourceHookResponse, error) {
+	s.hooksLock.Lock()
+	fn, has := s.hooks[req.GetName()]
+	s.hooksLock.Unlock()
+	if !has {
+		return nil, fmt.Errorf("unknown resource hook '%s'", req.GetName())
+	}
+
+	args := resource.ResourceHookArgs{
+		URN:  resource.URN(req.GetUrn()),
+		ID:   resource.ID(req.GetId()),
+		Type: tokens.Type(req.GetType()),
+	}
+	opts := plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true, KeepResources: true}
+	var err error
+	if args.NewInputs, err = unmarshalHookProperties(req.GetNewInputs(), opts); err != nil {
+		return nil, err
+	}
+	if args.OldInputs, err = unmarshalHookProperties(req.GetOldInputs(), opts); err != nil {
+		return nil, err
+	}
+	if args.NewOutputs, err = unmarshalHookProperties(req.GetNewOutputs(), opts); err != nil {
+		return nil, err
+	}
+	if args.OldOutputs, err = unmarshalHookProperties(req.GetOldOutputs(), opts); err != nil {
+		return nil, err
+	}
+
+	if err := fn(ctx, args); err != nil {
+		return &pulumirpc.InvokeResourceHookResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.InvokeResourceHookResponse{}, nil
+}
+
+// unmarshalHookProperties unmarshals the given properties of a hook's resource, which are nil if they are not set.
+func unmarshalHookProperties(props *structpb.Struct, opts plugin.MarshalOptions) (resource.PropertyMap, error) {
+	if props == nil {
+		return nil, nil
+	}
+	return plugin.UnmarshalProperties(props, opts)
+}
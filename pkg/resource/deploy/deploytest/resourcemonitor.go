@@ -39,6 +39,8 @@ type ResourceMonitor struct {
 
 	supportsSecrets            bool
 	supportsResourceReferences bool
+
+	hooks *resourceHooksServer
 }
 
 func dialMonitor(ctx context.Context, endpoint string) (*ResourceMonitor, error) {
@@ -112,6 +114,9 @@ func parseSourcePosition(raw string) (*pulumirpc.SourcePosition, error) {
 }
 
 func (rm *ResourceMonitor) Close() error {
+	if rm.hooks != nil {
+		contract.IgnoreClose(rm.hooks)
+	}
 	return rm.conn.Close()
 }
 
@@ -138,6 +143,8 @@ type ResourceOptions struct {
 	CustomTimeouts          *resource.CustomTimeouts
 	RetainOnDelete          bool
 	DeletedWith             resource.URN
+	RetryPolicy             *pulumirpc.RegisterResourceRequest_RetryPolicy
+	Hooks                   *pulumirpc.RegisterResourceRequest_ResourceHooksBinding
 	SupportsPartialValues   *bool
 	Remote                  bool
 	Providers               map[string]string
@@ -277,6 +284,8 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		DeletedWith:                string(opts.DeletedWith),
 		AliasSpecs:                 opts.AliasSpecs,
 		SourcePosition:             sourcePosition,
+		RetryPolicy:                opts.RetryPolicy,
+		Hooks:                      opts.Hooks,
 	}
 
 	ctx := context.Background()
@@ -306,6 +315,25 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 	return resource.URN(resp.Urn), resource.ID(resp.Id), outs, nil
 }
 
+// RegisterResourceHook registers a resource hook with the engine, which resources may then refer to by name in
+// ResourceOptions.Hooks. The hook is served until the monitor is closed.
+func (rm *ResourceMonitor) RegisterResourceHook(name string, fn resource.ResourceHookFunc) error {
+	if rm.hooks == nil {
+		hooks, err := startResourceHooksServer()
+		if err != nil {
+			return err
+		}
+		rm.hooks = hooks
+	}
+	rm.hooks.add(name, fn)
+
+	_, err := rm.resmon.RegisterResourceHook(context.Background(), &pulumirpc.RegisterResourceHookRequest{
+		Name:   name,
+		Target: rm.hooks.address,
+	})
+	return err
+}
+
 func (rm *ResourceMonitor) RegisterResourceOutputs(urn resource.URN, outputs resource.PropertyMap) error {
 	// marshal outputs
 	outs, err := plugin.MarshalProperties(outputs, plugin.MarshalOptions{
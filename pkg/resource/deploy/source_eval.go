@@ -17,6 +17,7 @@ package deploy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"path/filepath"
@@ -32,7 +33,9 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	interceptors "github.com/pulumi/pulumi/pkg/v3/util/rpcdebug"
@@ -149,7 +152,7 @@ func (src *evalSource) Iterate(
 }
 
 type evalSourceIterator struct {
-	mon         SourceResourceMonitor              // the resource monitor, per iterator.
+	mon         *resmon                            // the resource monitor, per iterator.
 	src         *evalSource                        // the owning eval source object.
 	regChan     chan *registerResourceEvent        // the channel that contains resource registrations.
 	regOutChan  chan *registerResourceOutputsEvent // the channel that contains resource completions.
@@ -251,8 +254,11 @@ func (iter *evalSourceIterator) forkRun(opts Options, config map[config.Key]stri
 			return err
 		}
 
-		// Communicate the error, if it exists, or nil if the program exited cleanly.
-		iter.finChan <- run()
+		// Communicate the error, if it exists, or nil if the program exited cleanly. The resource hooks that the program
+		// served have gone away with it.
+		err := run()
+		iter.mon.closeResourceHooks()
+		iter.finChan <- err
 	}()
 }
 
@@ -528,6 +534,10 @@ type resmon struct {
 	done                      <-chan error                       // a channel that resolves when the server completes.
 	disableResourceReferences bool                               // true if resource references are disabled.
 	disableOutputValues       bool                               // true if output values are disabled.
+	resourceHooks             map[string]resource.ResourceHook   // the resource hooks registered by the program.
+	resourceHookConns         map[string]*grpc.ClientConn        // connections to the servers of resource hooks.
+	resourceHooksClosed       bool                               // true once the program has exited.
+	resourceHooksLock         sync.Mutex                         // locks the resource hooks.
 }
 
 var _ SourceResourceMonitor = (*resmon)(nil)
@@ -558,6 +568,8 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 		sourcePositions:           newSourcePositions(src.runinfo.ProjectRoot),
 		resGoals:                  map[resource.URN]resource.Goal{},
 		componentProviders:        map[resource.URN]map[string]string{},
+		resourceHooks:             map[string]resource.ResourceHook{},
+		resourceHookConns:         map[string]*grpc.ClientConn{},
 		regChan:                   regChan,
 		regOutChan:                regOutChan,
 		regReadChan:               regReadChan,
@@ -602,6 +614,7 @@ func (rm *resmon) Address() string {
 
 // Cancel signals that the engine should be terminated, awaits its termination, and returns any errors that result.
 func (rm *resmon) Cancel() error {
+	rm.closeResourceHooks()
 	close(rm.cancel)
 	return <-rm.done
 }
@@ -1194,6 +1207,18 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("invalid DeletedWith URN: %s", err))
 	}
 	sourcePosition := rm.sourcePositions.getFromRequest(req)
+	hooks, err := rm.resolveResourceHooks(req.GetHooks())
+	if err != nil {
+		return nil, rpcerror.New(codes.InvalidArgument, err.Error())
+	}
+	var retryPolicy *resource.RetryPolicy
+	if rp := req.GetRetryPolicy(); rp != nil {
+		retryPolicy, err = resource.NewRetryPolicy(int(rp.MaxAttempts), rp.Delay, rp.Backoff, rp.MaxDelay,
+			rp.RetryableCodes, rp.RetryableErrors)
+		if err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("invalid retry policy: %s", err))
+		}
+	}
 
 	// Custom resources must have a three-part type so that we can 1) identify if they are providers and 2) retrieve the
 	// provider responsible for managing a particular resource (based on the type's Package).
@@ -1476,6 +1501,10 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			additionalSecretKeys, aliases, id, &timeouts, replaceOnChanges, retainOnDelete, deletedWith,
 			sourcePosition,
 		)
+		goal.RetryPolicy = retryPolicy
+		if custom {
+			goal.Hooks = hooks
+		}
 
 		if goal.Parent != "" {
 			rm.resGoalsLock.Lock()
@@ -1555,6 +1584,8 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	// • replaceOnChanges
 	// • retainOnDelete
 	// • deletedWith
+	// • retryPolicy
+	// • hooks
 	// Revisit these semantics in Pulumi v4.0
 	// See this issue for more: https://github.com/pulumi/pulumi/issues/9704
 	if !custom {
@@ -1582,6 +1613,12 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		rm.checkComponentOption(result.State.URN, "deletedWith", func() bool {
 			return deletedWith != ""
 		})
+		rm.checkComponentOption(result.State.URN, "retryPolicy", func() bool {
+			return retryPolicy != nil
+		})
+		rm.checkComponentOption(result.State.URN, "hooks", func() bool {
+			return hooks != nil
+		})
 	}
 
 	logging.V(5).Infof(
@@ -1670,6 +1707,167 @@ func (rm *resmon) RegisterResourceOutputs(ctx context.Context,
 	return &pbempty.Empty{}, nil
 }
 
+// errResourceHookUnavailable is returned by a resource hook that runs after the program that served it has exited.
+var errResourceHookUnavailable = errors.New("the program that registered the hook has exited")
+
+// RegisterResourceHook registers a resource hook that is served by the program at the given address. Resources that
+// are registered later may then refer to the hook by name.
+func (rm *resmon) RegisterResourceHook(ctx context.Context,
+	req *pulumirpc.RegisterResourceHookRequest,
+) (*pbempty.Empty, error) {
+	name, target := req.GetName(), req.GetTarget()
+	if name == "" {
+		return nil, rpcerror.New(codes.InvalidArgument, "resource hook name must not be empty")
+	}
+	if target == "" {
+		return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("resource hook '%s' has no target", name))
+	}
+
+	rm.resourceHooksLock.Lock()
+	defer rm.resourceHooksLock.Unlock()
+
+	if _, has := rm.resourceHooks[name]; has {
+		return nil, rpcerror.New(codes.AlreadyExists, fmt.Sprintf("resource hook '%s' is already registered", name))
+	}
+
+	conn, has := rm.resourceHookConns[target]
+	if !has {
+		var err error
+		conn, err = grpc.Dial(
+			target,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(rpcutil.OpenTracingClientInterceptor()),
+			rpcutil.GrpcChannelOptions(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to resource hook server at %s: %w", target, err)
+		}
+		rm.resourceHookConns[target] = conn
+	}
+
+	client := pulumirpc.NewResourceHooksClient(conn)
+	rm.resourceHooks[name] = resource.ResourceHook{
+		Name: name,
+		Callback: func(ctx context.Context, args resource.ResourceHookArgs) error {
+			return rm.invokeResourceHook(ctx, client, name, args)
+		},
+	}
+	logging.V(5).Infof("ResourceMonitor.RegisterResourceHook registered: name=%v, target=%v", name, target)
+	return &pbempty.Empty{}, nil
+}
+
+// resolveResourceHooks looks up the registered resource hooks that a resource refers to.
+func (rm *resmon) resolveResourceHooks(
+	binding *pulumirpc.RegisterResourceRequest_ResourceHooksBinding,
+) (*resource.ResourceHooks, error) {
+	if binding == nil {
+		return nil, nil
+	}
+
+	rm.resourceHooksLock.Lock()
+	defer rm.resourceHooksLock.Unlock()
+
+	resolve := func(names []string) ([]resource.ResourceHook, error) {
+		var hooks []resource.ResourceHook
+		for _, name := range names {
+			hook, has := rm.resourceHooks[name]
+			if !has {
+				return nil, fmt.Errorf("unknown resource hook '%s'", name)
+			}
+			hooks = append(hooks, hook)
+		}
+		return hooks, nil
+	}
+
+	var hooks resource.ResourceHooks
+	var err error
+	if hooks.BeforeCreate, err = resolve(binding.GetBeforeCreate()); err != nil {
+		return nil, err
+	}
+	if hooks.AfterCreate, err = resolve(binding.GetAfterCreate()); err != nil {
+		return nil, err
+	}
+	if hooks.BeforeUpdate, err = resolve(binding.GetBeforeUpdate()); err != nil {
+		return nil, err
+	}
+	if hooks.AfterUpdate, err = resolve(binding.GetAfterUpdate()); err != nil {
+		return nil, err
+	}
+	if hooks.BeforeDelete, err = resolve(binding.GetBeforeDelete()); err != nil {
+		return nil, err
+	}
+	if hooks.AfterDelete, err = resolve(binding.GetAfterDelete()); err != nil {
+		return nil, err
+	}
+	return &hooks, nil
+}
+
+// invokeResourceHook asks the program to run one of its resource hooks.
+func (rm *resmon) invokeResourceHook(ctx context.Context, client pulumirpc.ResourceHooksClient, name string,
+	args resource.ResourceHookArgs,
+) error {
+	rm.resourceHooksLock.Lock()
+	closed := rm.resourceHooksClosed
+	rm.resourceHooksLock.Unlock()
+	if closed {
+		return errResourceHookUnavailable
+	}
+
+	label := fmt.Sprintf("ResourceMonitor.InvokeResourceHook(%s)", name)
+	marshal := func(props resource.PropertyMap) (*structpb.Struct, error) {
+		if props == nil {
+			return nil, nil
+		}
+		return plugin.MarshalProperties(props, plugin.MarshalOptions{
+			Label:         label,
+			KeepUnknowns:  true,
+			KeepSecrets:   true,
+			KeepResources: true,
+		})
+	}
+	req := &pulumirpc.InvokeResourceHookRequest{
+		Name: name,
+		Urn:  string(args.URN),
+		Id:   string(args.ID),
+		Type: string(args.Type),
+	}
+	var err error
+	if req.NewInputs, err = marshal(args.NewInputs); err != nil {
+		return fmt.Errorf("cannot marshal new inputs: %w", err)
+	}
+	if req.OldInputs, err = marshal(args.OldInputs); err != nil {
+		return fmt.Errorf("cannot marshal old inputs: %w", err)
+	}
+	if req.NewOutputs, err = marshal(args.NewOutputs); err != nil {
+		return fmt.Errorf("cannot marshal new outputs: %w", err)
+	}
+	if req.OldOutputs, err = marshal(args.OldOutputs); err != nil {
+		return fmt.Errorf("cannot marshal old outputs: %w", err)
+	}
+
+	resp, err := client.InvokeResourceHook(ctx, req)
+	if err != nil {
+		return err
+	}
+	if resp.GetError() != "" {
+		return errors.New(resp.GetError())
+	}
+	return nil
+}
+
+// closeResourceHooks closes the connections to the servers of resource hooks once the program has exited. Any hook
+// that runs afterwards returns errResourceHookUnavailable.
+func (rm *resmon) closeResourceHooks() {
+	rm.resourceHooksLock.Lock()
+	defer rm.resourceHooksLock.Unlock()
+
+	rm.resourceHooksClosed = true
+	for target, conn := range rm.resourceHookConns {
+		contract.IgnoreClose(conn)
+		delete(rm.resourceHookConns, target)
+	}
+}
+
 type registerResourceEvent struct {
 	goal *resource.Goal       // the resource goal state produced by the iterator.
 	done chan *RegisterResult // the channel to communicate with after the resource state is available.
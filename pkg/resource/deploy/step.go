@@ -877,6 +877,8 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 			&s.old.CustomTimeouts, s.old.ImportID, s.old.RetainOnDelete, s.old.DeletedWith, s.old.Created, s.old.Modified,
 			s.old.SourcePosition,
 		)
+		s.new.RetryPolicy = s.old.RetryPolicy
+		s.new.DeleteHooks = s.old.DeleteHooks
 		complete = func() {
 			var inputsChange, outputsChange bool
 			if s.old != nil {
@@ -1054,6 +1056,8 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
 		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID, s.new.RetainOnDelete,
 		s.new.DeletedWith, nil, nil, s.new.SourcePosition)
+	s.old.RetryPolicy = s.new.RetryPolicy
+	s.old.DeleteHooks = s.new.DeleteHooks
 
 	// If this step came from an import deployment, we need to fetch any required inputs from the state.
 	if s.planned {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -95,6 +96,9 @@ type stepExecutor struct {
 	ctx      context.Context    // cancellation context for the current deployment.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
+
+	limits map[string]chan struct{} // semaphores enforcing the concurrency limits for packages and types.
+	slots  chan struct{}            // if non-nil, a semaphore enforcing the degree of parallelism.
 }
 
 //
@@ -247,7 +251,15 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 		default:
 		}
 
-		if err := se.executeStep(workerID, step); err != nil {
+		release, ok := se.acquire(step)
+		if !ok {
+			se.log(workerID, "step %v on %v canceled", step.Op(), step.URN())
+			return
+		}
+		err := se.executeStep(workerID, step)
+		release()
+
+		if err != nil {
 			se.log(workerID, "step %v on %v failed, signalling cancellation", step.Op(), step.URN())
 			se.cancelDueToError()
 			if err != errStepApplyFailed {
@@ -264,6 +276,53 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 	}
 }
 
+// acquire blocks until the given step can execute without exceeding the concurrency limits or the degree of
+// parallelism. It returns a function that releases the step's claim once it has executed, or false if the deployment
+// was canceled while waiting.
+func (se *stepExecutor) acquire(step Step) (func(), bool) {
+	sems := se.semaphores(step)
+	held := make([]chan struct{}, 0, len(sems))
+	release := func() {
+		for _, sem := range held {
+			<-sem
+		}
+	}
+
+	for _, sem := range sems {
+		select {
+		case sem <- struct{}{}:
+			held = append(held, sem)
+		case <-se.ctx.Done():
+			release()
+			return nil, false
+		}
+	}
+	return release, true
+}
+
+// semaphores returns the semaphores that must be held while executing the given step: the limit for its type, the
+// limit for its package and a parallelism slot, in that order. The narrowest limit is taken first so that steps
+// waiting for it don't hold the wider ones, which would block other types in the same package. Always acquiring them
+// in the same order prevents steps from deadlocking each other.
+func (se *stepExecutor) semaphores(step Step) []chan struct{} {
+	var sems []chan struct{}
+	if len(se.limits) > 0 {
+		typ := string(step.Type())
+		if sem, has := se.limits[typ]; has {
+			sems = append(sems, sem)
+		}
+		if pkg, _, ok := strings.Cut(typ, ":"); ok {
+			if sem, has := se.limits[pkg]; has {
+				sems = append(sems, sem)
+			}
+		}
+	}
+	if se.slots != nil {
+		sems = append(sems, se.slots)
+	}
+	return sems
+}
+
 func (se *stepExecutor) cancelDueToError() {
 	se.sawError.Store(true)
 	if !se.continueOnError {
@@ -297,7 +356,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
-	status, stepComplete, err := step.Apply(se.preview)
+	status, stepComplete, err := se.applyStepWithHooks(workerID, step)
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
@@ -474,6 +533,15 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, deployment
 
 	exec.sawError.Store(false)
 
+	for key, limit := range opts.ConcurrencyLimits {
+		if limit > 0 {
+			if exec.limits == nil {
+				exec.limits = make(map[string]chan struct{})
+			}
+			exec.limits[key] = make(chan struct{}, limit)
+		}
+	}
+
 	// If we're being asked to run as parallel as possible, spawn a single worker that launches chain executions
 	// asynchronously.
 	if opts.InfiniteParallelism() {
@@ -482,6 +550,16 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, deployment
 		return exec
 	}
 
+	// If there are concurrency limits, a chain may have to wait before it can execute. So that waiting chains don't
+	// hold up unrelated ones, launch every chain asynchronously and enforce the degree of parallelism with a semaphore
+	// rather than with a fixed number of workers.
+	if len(exec.limits) > 0 {
+		exec.slots = make(chan struct{}, opts.DegreeOfParallelism())
+		exec.workers.Add(1)
+		go exec.worker(infiniteWorkerID, true /*launchAsync*/)
+		return exec
+	}
+
 	// Otherwise, launch a worker goroutine for each degree of parallelism.
 	fanout := opts.DegreeOfParallelism()
 	for i := 0; i < fanout; i++ {
@@ -557,6 +557,8 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, err
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
 		goal.AdditionalSecretOutputs, aliasUrns, &goal.CustomTimeouts, "", goal.RetainOnDelete, goal.DeletedWith,
 		createdAt, modifiedAt, goal.SourcePosition)
+	new.RetryPolicy = goal.RetryPolicy
+	new.DeleteHooks = goal.Hooks.DeleteHookNames()
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -1005,6 +1007,18 @@ func (sg *stepGenerator) generateStepsFromDiff(
 			if goal.DeleteBeforeReplace != nil {
 				deleteBeforeReplace = *goal.DeleteBeforeReplace
 			}
+
+			// The old resource of a create-before-delete replacement is deleted once the program has exited, when
+			// the delete hooks that the program serves can no longer run.
+			if !deleteBeforeReplace && goal.Hooks.DeleteHookNames() != nil {
+				message := fmt.Sprintf("unable to replace resource %q\n"+
+					"as it has delete hooks that can't run once the program has exited. To replace the resource, "+
+					"set the `deleteBeforeReplace` option of the resource in your Pulumi program", urn)
+				sg.deployment.ctx.Diag.Errorf(diag.StreamMessage(urn, message, 0))
+				sg.sawError = true
+				return nil, result.BailErrorf(message)
+			}
+
 			if deleteBeforeReplace {
 				logging.V(7).Infof("Planner decided to delete-before-replacement for resource '%v'", urn)
 				contract.Assertf(sg.deployment.depGraph != nil,
@@ -1252,9 +1266,41 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt UrnTargets) ([]Step, error)
 		return nil, result.BailErrorf("delete untargeted resource")
 	}
 
+	// These deletes run once the program has exited, or without running it at all for destroys, so the delete hooks
+	// that the program served can't run. Refuse to delete the resources that have any rather than skip their hooks.
+	deletingResourceWithHooks := false
+	for _, step := range dels {
+		if step.Op() == OpRemovePendingReplace {
+			continue
+		}
+		if hooks := sg.deleteHooks(step.Old()); hooks != nil {
+			message := fmt.Sprintf("unable to delete resource %q\n"+
+				"as it has delete hooks (%s) that can't run once the program has exited. To delete the resource, "+
+				"remove its delete hooks in your Pulumi program and run `pulumi up` first",
+				step.URN(), strings.Join(hooks.Names(), ", "))
+			sg.deployment.Diag().Errorf(diag.StreamMessage(step.URN(), message, 0))
+			sg.sawError = true
+
+			deletingResourceWithHooks = true
+		}
+	}
+
+	if deletingResourceWithHooks && !sg.deployment.preview {
+		return nil, result.BailErrorf("delete resources with delete hooks")
+	}
+
 	return dels, nil
 }
 
+// deleteHooks returns the names of the delete hooks of a resource that is about to be deleted: those that the program
+// bound to the resource in this deployment if it registered the resource, or else those recorded in its state.
+func (sg *stepGenerator) deleteHooks(res *resource.State) *resource.DeleteHooks {
+	if goal, has := sg.deployment.goals.get(res.URN); has {
+		return goal.Hooks.DeleteHookNames()
+	}
+	return res.DeleteHooks
+}
+
 // getTargetDependents returns the (transitive) set of dependents on the target resources.
 // This includes both implicit and explicit dependents in the DAG itself, as well as children.
 func (sg *stepGenerator) getTargetDependents(targetsOpt UrnTargets) map[resource.URN]bool {
@@ -0,0 +1,90 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// hooksFor returns the resource hooks to run before and after the given step, along with their arguments. Hooks are
+// only run around the steps that create, update or delete a resource that the program registered in this deployment.
+// The step generator refuses to delete resources whose delete hooks can't run, such as those that were removed from
+// the program, so no delete skips its hooks.
+func (se *stepExecutor) hooksFor(step Step) (before, after []resource.ResourceHook, args resource.ResourceHookArgs) {
+	goal, has := se.deployment.goals.get(step.URN())
+	if !has || goal.Hooks == nil {
+		return nil, nil, args
+	}
+
+	args = resource.ResourceHookArgs{URN: step.URN(), Type: step.Type()}
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement:
+		args.NewInputs = step.New().Inputs
+		return goal.Hooks.BeforeCreate, goal.Hooks.AfterCreate, args
+	case OpUpdate:
+		args.ID = step.Old().ID
+		args.NewInputs, args.OldInputs, args.OldOutputs = step.New().Inputs, step.Old().Inputs, step.Old().Outputs
+		return goal.Hooks.BeforeUpdate, goal.Hooks.AfterUpdate, args
+	case OpDelete, OpDeleteReplaced:
+		args.ID = step.Old().ID
+		args.OldInputs, args.OldOutputs = step.Old().Inputs, step.Old().Outputs
+		return goal.Hooks.BeforeDelete, goal.Hooks.AfterDelete, args
+	default:
+		return nil, nil, args
+	}
+}
+
+// applyStepWithHooks applies the given step, running its resource hooks around it. Hooks don't run during previews.
+// A failing before hook fails the step without applying it, whereas a failing after hook is reported as a warning,
+// as the step has already taken effect. Hooks run once per step, however many times the step itself is retried.
+func (se *stepExecutor) applyStepWithHooks(workerID int, step Step) (resource.Status, StepCompleteFunc, error) {
+	if se.preview {
+		return se.applyStep(workerID, step)
+	}
+
+	before, after, args := se.hooksFor(step)
+	for _, hook := range before {
+		if err := se.runResourceHook(workerID, step, hook, args); err != nil {
+			return resource.StatusOK, nil, fmt.Errorf("resource hook '%s' failed: %w", hook.Name, err)
+		}
+	}
+
+	status, complete, err := se.applyStep(workerID, step)
+	if err != nil || len(after) == 0 {
+		return status, complete, err
+	}
+
+	if step.New() != nil {
+		args.ID, args.NewOutputs = step.New().ID, step.New().Outputs
+	}
+	for _, hook := range after {
+		if err := se.runResourceHook(workerID, step, hook, args); err != nil {
+			se.deployment.Diag().Warningf(diag.RawMessage(step.URN(),
+				fmt.Sprintf("resource hook '%s' failed after %s: %v", hook.Name, step.Op(), err)))
+		}
+	}
+	return status, complete, nil
+}
+
+// runResourceHook runs a single resource hook for the given step.
+func (se *stepExecutor) runResourceHook(workerID int, step Step, hook resource.ResourceHook,
+	args resource.ResourceHookArgs,
+) error {
+	se.log(workerID, "running resource hook %s for step %v on %v", hook.Name, step.Op(), step.URN())
+	return hook.Callback(se.ctx, args)
+}
@@ -0,0 +1,182 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/retry"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+)
+
+const (
+	defaultRetryDelay    = time.Second      // the delay before the first retry, unless a policy says otherwise.
+	defaultRetryBackoff  = 2.0              // the multiplier applied to the delay, unless a policy says otherwise.
+	defaultRetryMaxDelay = 30 * time.Second // the maximum delay between retries, unless a policy says otherwise.
+)
+
+// defaultRetryableCodes are the gRPC status codes that are retried when a retry policy doesn't say which failures are
+// retryable: those that providers use to report throttling and temporary network problems.
+var defaultRetryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+}
+
+// stepRetrier decides whether and when a step whose provider operation failed is retried.
+type stepRetrier struct {
+	maxAttempts int
+	delay       time.Duration
+	backoff     float64
+	maxDelay    time.Duration
+	codes       map[codes.Code]bool
+	patterns    []*regexp.Regexp
+}
+
+// newStepRetrier combines the retry policy of a resource with the deployment's policy. Each setting of the resource's
+// policy takes precedence over the deployment's. The retryable codes and error patterns are taken as a whole from the
+// resource's policy if it lists any. Returns nil if failed steps shouldn't be retried.
+func newStepRetrier(policy, deploymentPolicy *resource.RetryPolicy) *stepRetrier {
+	var merged resource.RetryPolicy
+	if deploymentPolicy != nil {
+		merged = *deploymentPolicy
+	}
+	if policy != nil {
+		if policy.MaxAttempts != 0 {
+			merged.MaxAttempts = policy.MaxAttempts
+		}
+		if policy.Delay != 0 {
+			merged.Delay = policy.Delay
+		}
+		if policy.Backoff != 0 {
+			merged.Backoff = policy.Backoff
+		}
+		if policy.MaxDelay != 0 {
+			merged.MaxDelay = policy.MaxDelay
+		}
+		if len(policy.RetryableCodes) != 0 || len(policy.RetryableErrors) != 0 {
+			merged.RetryableCodes = policy.RetryableCodes
+			merged.RetryableErrors = policy.RetryableErrors
+		}
+	}
+	if merged.MaxAttempts <= 1 {
+		return nil
+	}
+
+	r := &stepRetrier{
+		maxAttempts: merged.MaxAttempts,
+		delay:       defaultRetryDelay,
+		backoff:     defaultRetryBackoff,
+		maxDelay:    defaultRetryMaxDelay,
+		codes:       make(map[codes.Code]bool),
+	}
+	if merged.Delay != 0 {
+		r.delay = time.Duration(merged.Delay * float64(time.Second))
+	}
+	if merged.Backoff != 0 {
+		r.backoff = merged.Backoff
+	}
+	if merged.MaxDelay != 0 {
+		r.maxDelay = time.Duration(merged.MaxDelay * float64(time.Second))
+	}
+	for _, name := range merged.RetryableCodes {
+		if code, ok := resource.ParseRetryableCode(name); ok {
+			r.codes[code] = true
+		}
+	}
+	for _, pattern := range merged.RetryableErrors {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			logging.V(5).Infof("ignoring invalid retryable error pattern %q: %v", pattern, err)
+			continue
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	if len(merged.RetryableCodes) == 0 && len(merged.RetryableErrors) == 0 {
+		r.codes = defaultRetryableCodes
+	}
+	return r
+}
+
+// retryable returns true if the given error from a provider operation is transient.
+func (r *stepRetrier) retryable(err error) bool {
+	if rpcErr, ok := rpcerror.FromError(err); ok && r.codes[rpcErr.Code()] {
+		return true
+	}
+	for _, re := range r.patterns {
+		if re.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// retrierFor returns the retrier for the given step, or nil if the step shouldn't be retried. Only steps that create,
+// update or delete a resource through its provider are retried.
+func (se *stepExecutor) retrierFor(step Step) *stepRetrier {
+	var state *resource.State
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement, OpUpdate:
+		state = step.New()
+	case OpDelete, OpDeleteReplaced:
+		state = step.Old()
+	default:
+		return nil
+	}
+	if state == nil || !state.Custom {
+		return nil
+	}
+	return newStepRetrier(state.RetryPolicy, se.opts.RetryPolicy)
+}
+
+// applyStep applies the given step, retrying it with backoff while it fails with a transient error. Each retry is
+// reported as a warning on the step's resource. A step that partially failed is never retried, because its provider
+// operation had an effect that must be recorded.
+func (se *stepExecutor) applyStep(workerID int, step Step) (resource.Status, StepCompleteFunc, error) {
+	r := se.retrierFor(step)
+	if r == nil {
+		return step.Apply(se.preview)
+	}
+
+	var status resource.Status
+	var complete StepCompleteFunc
+	var err error
+	retryer := retry.Retryer{}
+	_, _, _ = retryer.Until(se.ctx, retry.Acceptor{
+		Delay:    &r.delay,
+		Backoff:  &r.backoff,
+		MaxDelay: &r.maxDelay,
+		Accept: func(try int, nextRetryTime time.Duration) (bool, interface{}, error) {
+			status, complete, err = step.Apply(se.preview)
+			if err == nil || status == resource.StatusPartialFailure || try+1 >= r.maxAttempts || !r.retryable(err) {
+				return true, nil, nil
+			}
+
+			se.log(workerID, "step %v on %v failed with a transient error, retrying in %v: %v",
+				step.Op(), step.URN(), nextRetryTime, err)
+			se.deployment.Diag().Warningf(diag.RawMessage(step.URN(), fmt.Sprintf(
+				"%s failed with a transient error, retrying in %v (attempt %d of %d): %v",
+				step.Op(), nextRetryTime, try+2, r.maxAttempts, err)))
+			return false, nil, nil
+		},
+	})
+	return status, complete, err
+}
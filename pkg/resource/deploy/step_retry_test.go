@@ -0,0 +1,64 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+)
+
+func TestNewStepRetrier(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newStepRetrier(nil, nil))
+	assert.Nil(t, newStepRetrier(&resource.RetryPolicy{MaxAttempts: 1}, &resource.RetryPolicy{MaxAttempts: 5}),
+		"a resource should be able to opt out of retries")
+
+	// Settings missing from the resource's policy come from the deployment's policy, then from the defaults.
+	r := newStepRetrier(
+		&resource.RetryPolicy{Delay: 2},
+		&resource.RetryPolicy{MaxAttempts: 4, Delay: 1, Backoff: 3, RetryableErrors: []string{"rate exceeded"}})
+	require.NotNil(t, r)
+	assert.Equal(t, 4, r.maxAttempts)
+	assert.Equal(t, 2*time.Second, r.delay)
+	assert.Equal(t, 3.0, r.backoff)
+	assert.Equal(t, defaultRetryMaxDelay, r.maxDelay)
+	assert.True(t, r.retryable(errors.New("rate exceeded")))
+	assert.False(t, r.retryable(rpcerror.New(codes.Unavailable, "unavailable")))
+
+	// The retryable failures are replaced as a whole.
+	r = newStepRetrier(
+		&resource.RetryPolicy{RetryableCodes: []string{"Aborted"}},
+		&resource.RetryPolicy{MaxAttempts: 4, RetryableErrors: []string{"rate exceeded"}})
+	require.NotNil(t, r)
+	assert.True(t, r.retryable(rpcerror.New(codes.Aborted, "aborted")))
+	assert.False(t, r.retryable(errors.New("rate exceeded")))
+
+	// Without any retryable failures, throttling and unavailability are retried.
+	r = newStepRetrier(&resource.RetryPolicy{MaxAttempts: 2}, nil)
+	require.NotNil(t, r)
+	assert.True(t, r.retryable(rpcerror.New(codes.Unavailable, "unavailable")))
+	assert.True(t, r.retryable(rpcerror.New(codes.ResourceExhausted, "throttled")))
+	assert.False(t, r.retryable(rpcerror.New(codes.InvalidArgument, "bad input")))
+	assert.False(t, r.retryable(errors.New("unavailable")))
+}
@@ -39,3 +39,20 @@ type ResourceProtectedError struct {
 func (ResourceProtectedError) Error() string {
 	return "Can't delete protected resource"
 }
+
+// DanglingDependency records a dependency of one resource on another that would end up in a different stack.
+type DanglingDependency struct {
+	Dependent  resource.URN
+	Dependency resource.URN
+}
+
+// ResourceMoveDependenciesError is returned by MoveResources if moving the requested resources would leave
+// dependencies between resources in different stacks.
+type ResourceMoveDependenciesError struct {
+	Dependencies []DanglingDependency
+}
+
+func (r ResourceMoveDependenciesError) Error() string {
+	return fmt.Sprintf("Can't move resources due to %d dependencies between the source and destination stacks",
+		len(r.Dependencies))
+}
@@ -217,3 +217,244 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.Name, newProject tokens.P
 
 	return nil
 }
+
+// MoveResources moves the resources with the given URNs, along with their children and the providers they use, from
+// the source snapshot to the dest snapshot. The URNs of moved resources are rewritten to belong to destStack and
+// destProject, and parent, dependency and provider references are rewritten to match. Resources whose parent is not
+// moved are parented to the root stack resource of dest, if there is one.
+//
+// Providers that are still used by resources left in the source snapshot are copied rather than moved. If dest
+// already contains a provider with the same URN and inputs, the moved resources are pointed at that provider instead.
+//
+// If a resource left in source depends on a moved resource, or a moved resource depends on a resource left in
+// source, an instance of `ResourceMoveDependenciesError` is returned unless force is true, in which case those
+// dependencies are dropped.
+//
+// The resources that were added to dest are returned in the order they were added.
+func MoveResources(
+	source, dest *deploy.Snapshot, urns []resource.URN,
+	destStack tokens.Name, destProject tokens.PackageName, force bool,
+) ([]*resource.State, error) {
+	contract.Requiref(source != nil, "source", "must not be nil")
+	contract.Requiref(dest != nil, "dest", "must not be nil")
+
+	// Work out the set of resources to move: the requested resources and all of their descendants. Snapshots are
+	// stored in topological order, so a single pass is enough to pick up every descendant.
+	moveSet := map[resource.URN]bool{}
+	for _, urn := range urns {
+		candidates := LocateResource(source, urn)
+		switch {
+		case len(candidates) == 0:
+			return nil, fmt.Errorf("no such resource %q exists in the source stack", urn)
+		case candidates[0].Type == resource.RootStackType:
+			return nil, fmt.Errorf("the root stack resource %q can't be moved", urn)
+		case providers.IsProviderType(candidates[0].Type):
+			return nil, fmt.Errorf("provider %q can't be moved directly; "+
+				"providers are moved along with the resources that use them", urn)
+		}
+		moveSet[urn] = true
+	}
+	for _, res := range source.Resources {
+		if res.Parent != "" && moveSet[res.Parent] {
+			moveSet[res.URN] = true
+		}
+	}
+
+	for _, op := range source.PendingOperations {
+		if moveSet[op.Resource.URN] {
+			return nil, fmt.Errorf("resource %q has a pending %s operation", op.Resource.URN, op.Type)
+		}
+	}
+
+	// Work out which providers the moved resources need, and whether they are still needed in the source stack.
+	providerSet := map[resource.URN]bool{}
+	for _, res := range source.Resources {
+		if !moveSet[res.URN] || res.Provider == "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return nil, err
+		}
+		providerSet[ref.URN()] = true
+	}
+	copySet := map[resource.URN]bool{}
+	for _, res := range source.Resources {
+		if moveSet[res.URN] || res.Provider == "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err != nil {
+			return nil, err
+		}
+		if providerSet[ref.URN()] {
+			copySet[ref.URN()] = true
+		}
+	}
+
+	leaving := func(urn resource.URN) bool {
+		return moveSet[urn] || providerSet[urn] && !copySet[urn]
+	}
+	arriving := func(urn resource.URN) bool {
+		return moveSet[urn] || providerSet[urn]
+	}
+
+	// Look for dependencies that would cross the boundary between the two stacks once the move is complete.
+	var dangling []DanglingDependency
+	checkDependencies := func(res *resource.State, crosses func(resource.URN) bool) {
+		seen := map[resource.URN]bool{}
+		add := func(dep resource.URN) {
+			if dep != "" && crosses(dep) && !seen[dep] {
+				seen[dep] = true
+				dangling = append(dangling, DanglingDependency{Dependent: res.URN, Dependency: dep})
+			}
+		}
+		for _, dep := range res.Dependencies {
+			add(dep)
+		}
+		for _, deps := range res.PropertyDependencies {
+			for _, dep := range deps {
+				add(dep)
+			}
+		}
+		add(res.DeletedWith)
+	}
+	for _, res := range source.Resources {
+		switch {
+		case arriving(res.URN):
+			checkDependencies(res, func(dep resource.URN) bool { return !arriving(dep) })
+		case !leaving(res.URN):
+			checkDependencies(res, leaving)
+		}
+	}
+	if len(dangling) != 0 && !force {
+		return nil, ResourceMoveDependenciesError{Dependencies: dangling}
+	}
+	danglingSet := map[resource.URN]map[resource.URN]bool{}
+	for _, d := range dangling {
+		if danglingSet[d.Dependent] == nil {
+			danglingSet[d.Dependent] = map[resource.URN]bool{}
+		}
+		danglingSet[d.Dependent][d.Dependency] = true
+	}
+
+	var destRoot resource.URN
+	for _, res := range dest.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			destRoot = res.URN
+			break
+		}
+	}
+
+	// Compute the new URN of every arriving resource. Parents always precede their children, so a parent's new URN
+	// is known by the time its children are visited.
+	newURNs := map[resource.URN]resource.URN{}
+	newParents := map[resource.URN]resource.URN{}
+	for _, res := range source.Resources {
+		if !arriving(res.URN) {
+			continue
+		}
+		parent, parentType := destRoot, tokens.Type("")
+		if newParent, ok := newURNs[res.Parent]; ok {
+			parent, parentType = newParent, newParent.QualifiedType()
+		}
+		newURNs[res.URN] = resource.NewURN(destStack.Q(), destProject, parentType, res.Type, res.URN.Name())
+		newParents[res.URN] = parent
+	}
+
+	// Check for collisions with resources already in the destination. Providers with identical inputs are reused.
+	reusedProviders := map[resource.URN]string{}
+	for _, res := range source.Resources {
+		if !arriving(res.URN) {
+			continue
+		}
+		existing := LocateResource(dest, newURNs[res.URN])
+		if len(existing) == 0 {
+			continue
+		}
+		if providerSet[res.URN] && len(existing) == 1 && existing[0].Inputs.DeepEquals(res.Inputs) {
+			ref, err := providers.NewReference(existing[0].URN, existing[0].ID)
+			if err != nil {
+				return nil, err
+			}
+			reusedProviders[res.URN] = ref.String()
+			continue
+		}
+		return nil, fmt.Errorf("resource %q already exists in the destination stack", newURNs[res.URN])
+	}
+
+	rewriteURNs := func(
+		res *resource.State, urns []resource.URN, rewrite func(resource.URN) resource.URN,
+	) []resource.URN {
+		var rewritten []resource.URN
+		for _, urn := range urns {
+			if danglingSet[res.URN][urn] {
+				continue
+			}
+			rewritten = append(rewritten, rewrite(urn))
+		}
+		return rewritten
+	}
+	rewriteDependencies := func(res *resource.State, rewrite func(resource.URN) resource.URN) {
+		res.Dependencies = rewriteURNs(res, res.Dependencies, rewrite)
+		if res.PropertyDependencies != nil {
+			propertyDependencies := make(map[resource.PropertyKey][]resource.URN, len(res.PropertyDependencies))
+			for key, deps := range res.PropertyDependencies {
+				propertyDependencies[key] = rewriteURNs(res, deps, rewrite)
+			}
+			res.PropertyDependencies = propertyDependencies
+		}
+		if res.DeletedWith != "" {
+			if danglingSet[res.URN][res.DeletedWith] {
+				res.DeletedWith = ""
+			} else {
+				res.DeletedWith = rewrite(res.DeletedWith)
+			}
+		}
+	}
+
+	var remaining, moved []*resource.State
+	for _, res := range source.Resources {
+		if !leaving(res.URN) {
+			remaining = append(remaining, res)
+		}
+		if !arriving(res.URN) {
+			// Only dangling dependencies need to be rewritten for resources that stay behind.
+			rewriteDependencies(res, func(urn resource.URN) resource.URN { return urn })
+			continue
+		}
+		if _, ok := reusedProviders[res.URN]; ok {
+			continue
+		}
+
+		target := res
+		if copySet[res.URN] {
+			copied := *res
+			target = &copied
+		}
+
+		rewriteDependencies(target, func(urn resource.URN) resource.URN { return newURNs[urn] })
+		target.Parent = newParents[res.URN]
+		if target.Provider != "" {
+			ref, err := providers.ParseReference(target.Provider)
+			if err != nil {
+				return nil, err
+			}
+			if reused, ok := reusedProviders[ref.URN()]; ok {
+				target.Provider = reused
+			} else {
+				ref, err = providers.NewReference(newURNs[ref.URN()], ref.ID())
+				if err != nil {
+					return nil, err
+				}
+				target.Provider = ref.String()
+			}
+		}
+		target.URN = newURNs[res.URN]
+		moved = append(moved, target)
+	}
+
+	source.Resources = remaining
+	dest.Resources = append(dest.Resources, moved...)
+	return moved, nil
+}
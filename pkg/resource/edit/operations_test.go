@@ -526,3 +526,135 @@ func TestRenameStack(t *testing.T) {
 		assert.Len(t, LocateResource(snap, updatedResourceURN), 1)
 	})
 }
+
+func TestMoveResources(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	aChild := NewResource("a-child", pA)
+	aChild.Parent = a.URN
+	aChild.URN = resource.NewURN("test", "test", a.Type, aChild.Type, "a-child")
+	b := NewResource("b", pA)
+	source := NewSnapshot([]*resource.State{pA, a, aChild, b})
+	dest := NewSnapshot(nil)
+
+	moved, err := MoveResources(source, dest, []resource.URN{a.URN}, "dest", "proj", false)
+	require.NoError(t, err)
+
+	// The provider is still used by b, so it is copied rather than moved.
+	assert.Equal(t, []*resource.State{pA, b}, source.Resources)
+	require.Len(t, dest.Resources, 3)
+	assert.Equal(t, moved, dest.Resources)
+
+	newProvider := dest.Resources[0]
+	assert.NotSame(t, pA, newProvider)
+	assert.Equal(t, resource.NewURN("dest", "proj", "", pA.Type, "p1"), newProvider.URN)
+	assert.Equal(t, resource.NewURN("test", "test", "", pA.Type, "p1"), pA.URN)
+
+	newA := dest.Resources[1]
+	assert.Equal(t, resource.NewURN("dest", "proj", "", a.Type, "a"), newA.URN)
+	ref, err := providers.ParseReference(newA.Provider)
+	require.NoError(t, err)
+	assert.Equal(t, newProvider.URN, ref.URN())
+	assert.Equal(t, newProvider.ID, ref.ID())
+
+	newChild := dest.Resources[2]
+	assert.Equal(t, newA.URN, newChild.Parent)
+	assert.Equal(t, resource.NewURN("dest", "proj", a.Type, aChild.Type, "a-child"), newChild.URN)
+
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesProvider(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", nil)
+	source := NewSnapshot([]*resource.State{pA, a, b})
+	dest := NewSnapshot(nil)
+
+	_, err := MoveResources(source, dest, []resource.URN{pA.URN}, "dest", "test", false)
+	assert.ErrorContains(t, err, "can't be moved directly")
+
+	// The provider is only used by a, so it is moved along with it.
+	_, err = MoveResources(source, dest, []resource.URN{a.URN}, "dest", "test", false)
+	require.NoError(t, err)
+	assert.Equal(t, []*resource.State{b}, source.Resources)
+	assert.Equal(t, []*resource.State{pA, a}, dest.Resources)
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesReusesProvider(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	source := NewSnapshot([]*resource.State{pA, a})
+
+	destProvider := NewProviderResource("a", "p1", "1")
+	destProvider.URN = resource.NewURN("dest", "test", "", destProvider.Type, "p1")
+	dest := NewSnapshot([]*resource.State{destProvider})
+
+	moved, err := MoveResources(source, dest, []resource.URN{a.URN}, "dest", "test", false)
+	require.NoError(t, err)
+	assert.Equal(t, []*resource.State{a}, moved)
+	assert.Empty(t, source.Resources)
+
+	ref, err := providers.ParseReference(a.Provider)
+	require.NoError(t, err)
+	assert.Equal(t, destProvider.URN, ref.URN())
+	assert.Equal(t, destProvider.ID, ref.ID())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesDependencies(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	c := NewResource("c", pA)
+	d := NewResource("d", pA, c.URN)
+	d.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"foo": {c.URN}}
+	source := NewSnapshot([]*resource.State{pA, a, b, c, d})
+	dest := NewSnapshot(nil)
+
+	// b depends on a, and d depends on c, so neither move is allowed.
+	_, err := MoveResources(source, dest, []resource.URN{a.URN, d.URN}, "dest", "test", false)
+	var depErr ResourceMoveDependenciesError
+	require.ErrorAs(t, err, &depErr)
+	assert.ElementsMatch(t, []DanglingDependency{
+		{Dependent: b.URN, Dependency: a.URN},
+		{Dependent: d.URN, Dependency: c.URN},
+	}, depErr.Dependencies)
+	assert.Len(t, source.Resources, 5)
+	assert.Empty(t, dest.Resources)
+
+	// With force the dependencies are dropped.
+	_, err = MoveResources(source, dest, []resource.URN{a.URN, d.URN}, "dest", "test", true)
+	require.NoError(t, err)
+	assert.Empty(t, b.Dependencies)
+	assert.Empty(t, d.Dependencies)
+	assert.Empty(t, d.PropertyDependencies["foo"])
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesConflict(t *testing.T) {
+	t.Parallel()
+
+	a := NewResource("a", nil)
+	source := NewSnapshot([]*resource.State{a})
+	dest := NewSnapshot([]*resource.State{NewResource("a", nil)})
+
+	_, err := MoveResources(source, dest, []resource.URN{a.URN}, "test", "test", false)
+	assert.ErrorContains(t, err, "already exists in the destination stack")
+	assert.Equal(t, []*resource.State{a}, source.Resources)
+
+	_, err = MoveResources(source, dest, []resource.URN{"urn:pulumi:test::test::a:b:c::missing"},
+		"test", "test", false)
+	assert.ErrorContains(t, err, "no such resource")
+}
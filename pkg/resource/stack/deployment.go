@@ -327,6 +327,8 @@ func SerializeResource(res *resource.State, enc config.Encrypter, showSecrets bo
 		Created:                 res.Created,
 		Modified:                res.Modified,
 		SourcePosition:          res.SourcePosition,
+		RetryPolicy:             res.RetryPolicy,
+		DeleteHooks:             res.DeleteHooks,
 	}
 
 	if res.CustomTimeouts.IsNotEmpty() {
@@ -509,11 +511,14 @@ func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter, enc confi
 		return nil, fmt.Errorf("resource '%s' has 'custom' false but non-empty ID", res.URN)
 	}
 
-	return resource.NewState(
+	state := resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID,
 		inputs, outputs, res.Parent, res.Protect, res.External, res.Dependencies, res.InitErrors, res.Provider,
 		res.PropertyDependencies, res.PendingReplacement, res.AdditionalSecretOutputs, res.Aliases, res.CustomTimeouts,
-		res.ImportID, res.RetainOnDelete, res.DeletedWith, res.Created, res.Modified, res.SourcePosition), nil
+		res.ImportID, res.RetainOnDelete, res.DeletedWith, res.Created, res.Modified, res.SourcePosition)
+	state.RetryPolicy = res.RetryPolicy
+	state.DeleteHooks = res.DeleteHooks
+	return state, nil
 }
 
 // DeserializeOperation hydrates a pending resource/operation pair.
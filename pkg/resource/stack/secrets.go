@@ -22,6 +22,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/external"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/service"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -50,6 +51,8 @@ func (defaultSecretsProvider) OfType(ty string, state json.RawMessage) (secrets.
 		sm, err = service.NewServiceSecretsManagerFromState(state)
 	case cloud.Type:
 		sm, err = cloud.NewCloudSecretsManagerFromState(state)
+	case external.Type:
+		sm, err = external.NewExternalSecretsManagerFromState(state)
 	default:
 		return nil, fmt.Errorf("no known secrets provider for type %q", ty)
 	}
@@ -20,6 +20,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	netUrl "net/url"
 	"os"
@@ -29,6 +30,7 @@ import (
 	_ "gocloud.dev/secrets/azurekeyvault" // support for azurekeyvault://
 	"gocloud.dev/secrets/gcpkms"          // support for gcpkms://
 	_ "gocloud.dev/secrets/hashivault"    // support for hashivault://
+	"gocloud.dev/secrets/localsecrets"    // support for base64key://
 	"google.golang.org/api/cloudkms/v1"
 
 	"github.com/pulumi/pulumi/pkg/v3/authhelpers"
@@ -41,10 +43,32 @@ import (
 const Type = "cloud"
 
 type cloudSecretsManagerState struct {
+	URL          string                       `json:"url"`
+	EncryptedKey []byte                       `json:"encryptedkey"`
+	Recipients   []cloudSecretsRecipientState `json:"recipients,omitempty"`
+}
+
+// cloudSecretsRecipientState is a further keeper that the data key is encrypted with.
+type cloudSecretsRecipientState struct {
 	URL          string `json:"url"`
 	EncryptedKey []byte `json:"encryptedkey"`
 }
 
+// checkSecretsRecipient returns an error if the given secrets provider URL can't be a secrets recipient. Recipient
+// URLs are stored in the stack's config file and checkpoint next to the data key they encrypt, so the URL must not
+// carry the key itself, as base64key:// URLs do. The error doesn't include the URL, so that the key isn't printed.
+func checkSecretsRecipient(url string) error {
+	u, err := netUrl.Parse(url)
+	if err != nil {
+		return errors.New("unable to parse the secrets recipient URL")
+	}
+	if u.Scheme == localsecrets.Scheme {
+		return fmt.Errorf("%s:// URLs contain the key itself, so they can't be used as secrets recipients",
+			localsecrets.Scheme)
+	}
+	return nil
+}
+
 // openKeeper opens the keeper, handling pulumi-specifc cases in the URL.
 func openKeeper(ctx context.Context, url string) (*gosecrets.Keeper, error) {
 	u, err := netUrl.Parse(url)
@@ -74,13 +98,24 @@ func openKeeper(ctx context.Context, url string) (*gosecrets.Keeper, error) {
 }
 
 // generateNewDataKey generates a new DataKey seeded by a fresh random 32-byte key and encrypted
-// using the target cloud key management service.
-func generateNewDataKey(url string) ([]byte, error) {
+// using the target cloud key management service. The new key is also encrypted for each of the given
+// recipients.
+func generateNewDataKey(url string, recipients []cloudSecretsRecipientState) ([]byte, error) {
 	plaintextDataKey := make([]byte, 32)
 	_, err := rand.Read(plaintextDataKey)
 	if err != nil {
 		return nil, err
 	}
+	for i := range recipients {
+		if recipients[i].EncryptedKey, err = encryptDataKey(recipients[i].URL, plaintextDataKey); err != nil {
+			return nil, fmt.Errorf("encrypting data key for recipient %s: %w", recipients[i].URL, err)
+		}
+	}
+	return encryptDataKey(url, plaintextDataKey)
+}
+
+// encryptDataKey encrypts a plaintext data key using the target cloud key management service.
+func encryptDataKey(url string, plaintextDataKey []byte) ([]byte, error) {
 	keeper, err := openKeeper(context.Background(), url)
 	if err != nil {
 		return nil, err
@@ -88,20 +123,47 @@ func generateNewDataKey(url string) ([]byte, error) {
 	return keeper.Encrypt(context.Background(), plaintextDataKey)
 }
 
-// newCloudSecretsManager returns a secrets manager that uses the target cloud key management
-// service to encrypt/decrypt a data key used for envelope encryption of secrets values.
-func newCloudSecretsManager(url string, encryptedDataKey []byte) (*Manager, error) {
-	keeper, err := openKeeper(context.Background(), url)
-	if err != nil {
-		return nil, err
+// decryptDataKey decrypts a data key using the target cloud key management service. If that fails, each of the
+// recipients is tried in turn, so that the data key can be decrypted as long as any one of them is available.
+func decryptDataKey(url string, encryptedDataKey []byte, recipients []cloudSecretsRecipientState) ([]byte, error) {
+	decrypt := func(url string, encryptedDataKey []byte) ([]byte, error) {
+		keeper, err := openKeeper(context.Background(), url)
+		if err != nil {
+			return nil, err
+		}
+		return keeper.Decrypt(context.Background(), encryptedDataKey)
+	}
+
+	plaintextDataKey, err := decrypt(url, encryptedDataKey)
+	if err == nil || len(recipients) == 0 {
+		return plaintextDataKey, err
 	}
-	plaintextDataKey, err := keeper.Decrypt(context.Background(), encryptedDataKey)
+
+	errs := []error{fmt.Errorf("decrypting data key with %s: %w", url, err)}
+	for _, recipient := range recipients {
+		plaintextDataKey, err := decrypt(recipient.URL, recipient.EncryptedKey)
+		if err == nil {
+			return plaintextDataKey, nil
+		}
+		errs = append(errs, fmt.Errorf("decrypting data key with %s: %w", recipient.URL, err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// newCloudSecretsManager returns a secrets manager that uses the target cloud key management
+// service to encrypt/decrypt a data key used for envelope encryption of secrets values. The data key
+// can also be decrypted by any of the given recipients.
+func newCloudSecretsManager(url string, encryptedDataKey []byte,
+	recipients []cloudSecretsRecipientState,
+) (*Manager, error) {
+	plaintextDataKey, err := decryptDataKey(url, encryptedDataKey, recipients)
 	if err != nil {
 		return nil, err
 	}
 	state, err := json.Marshal(cloudSecretsManagerState{
 		URL:          url,
 		EncryptedKey: encryptedDataKey,
+		Recipients:   recipients,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("marshalling state: %w", err)
@@ -133,7 +195,7 @@ func NewCloudSecretsManagerFromState(state json.RawMessage) (secrets.Manager, er
 		return nil, fmt.Errorf("unmarshalling state: %w", err)
 	}
 
-	return newCloudSecretsManager(s.URL, s.EncryptedKey)
+	return newCloudSecretsManager(s.URL, s.EncryptedKey, s.Recipients)
 }
 
 func NewCloudSecretsManager(info *workspace.ProjectStack,
@@ -158,25 +220,128 @@ func NewCloudSecretsManager(info *workspace.ProjectStack,
 		info.EncryptedKey = ""
 	}
 
+	// The recipients are dropped when the data key is encrypted by one of them instead.
+	recipients, err := recipientsFromProjectStack(info)
+	if err != nil {
+		return nil, err
+	}
+	for i, recipient := range recipients {
+		if recipient.URL == secretsProvider {
+			recipients = append(recipients[:i], recipients[i+1:]...)
+			break
+		}
+	}
+
 	// if there is no key OR the secrets provider is changing
 	// then we need to generate the new key based on the new secrets provider
 	if info.EncryptedKey == "" || info.SecretsProvider != secretsProvider {
-		dataKey, err := generateNewDataKey(secretsProvider)
+		dataKey, err := generateNewDataKey(secretsProvider, recipients)
 		if err != nil {
 			return nil, err
 		}
 		info.EncryptedKey = base64.StdEncoding.EncodeToString(dataKey)
 	}
 	info.SecretsProvider = secretsProvider
+	setProjectStackRecipients(info, recipients)
 
 	dataKey, err := base64.StdEncoding.DecodeString(info.EncryptedKey)
 	if err != nil {
 		return nil, err
 	}
-	secretsManager, err = newCloudSecretsManager(secretsProvider, dataKey)
+	secretsManager, err = newCloudSecretsManager(secretsProvider, dataKey, recipients)
 	if err != nil {
 		return nil, err
 	}
 
 	return secretsManager, nil
 }
+
+// AddSecretsRecipient encrypts the data key of a stack that uses a cloud secrets provider with a further cloud
+// secrets provider, so that the stack's secrets can also be decrypted with it. The secrets themselves are not
+// encrypted again.
+func AddSecretsRecipient(info *workspace.ProjectStack, url string) error {
+	if info.EncryptedKey == "" {
+		return errors.New("secrets recipients can only be added to stacks that use a cloud secrets provider")
+	}
+	if err := checkSecretsRecipient(url); err != nil {
+		return err
+	}
+	recipients, err := recipientsFromProjectStack(info)
+	if err != nil {
+		return err
+	}
+	if url == info.SecretsProvider {
+		return fmt.Errorf("%s is already the stack's secrets provider", url)
+	}
+	for _, recipient := range recipients {
+		if recipient.URL == url {
+			return fmt.Errorf("%s is already a secrets recipient of the stack", url)
+		}
+	}
+
+	encryptedDataKey, err := base64.StdEncoding.DecodeString(info.EncryptedKey)
+	if err != nil {
+		return err
+	}
+	plaintextDataKey, err := decryptDataKey(info.SecretsProvider, encryptedDataKey, recipients)
+	if err != nil {
+		return err
+	}
+	recipientKey, err := encryptDataKey(url, plaintextDataKey)
+	if err != nil {
+		return fmt.Errorf("encrypting data key for recipient %s: %w", url, err)
+	}
+
+	setProjectStackRecipients(info, append(recipients, cloudSecretsRecipientState{
+		URL:          url,
+		EncryptedKey: recipientKey,
+	}))
+	return nil
+}
+
+// RemoveSecretsRecipient removes a secrets recipient from a stack, so that its secrets can no longer be decrypted
+// with it.
+func RemoveSecretsRecipient(info *workspace.ProjectStack, url string) error {
+	if url == info.SecretsProvider {
+		return fmt.Errorf("%s is the stack's secrets provider, and can't be removed as a recipient", url)
+	}
+	for i, recipient := range info.SecretsRecipients {
+		if recipient.URL == url {
+			recipients := append([]workspace.SecretsRecipient{}, info.SecretsRecipients[:i]...)
+			recipients = append(recipients, info.SecretsRecipients[i+1:]...)
+			if len(recipients) == 0 {
+				recipients = nil
+			}
+			info.SecretsRecipients = recipients
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not a secrets recipient of the stack", url)
+}
+
+// recipientsFromProjectStack returns the secrets recipients recorded in a stack's config file.
+func recipientsFromProjectStack(info *workspace.ProjectStack) ([]cloudSecretsRecipientState, error) {
+	var recipients []cloudSecretsRecipientState
+	for _, recipient := range info.SecretsRecipients {
+		if err := checkSecretsRecipient(recipient.URL); err != nil {
+			return nil, err
+		}
+		encryptedKey, err := base64.StdEncoding.DecodeString(recipient.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("decoding data key of recipient %s: %w", recipient.URL, err)
+		}
+		recipients = append(recipients, cloudSecretsRecipientState{URL: recipient.URL, EncryptedKey: encryptedKey})
+	}
+	return recipients, nil
+}
+
+// setProjectStackRecipients records the given secrets recipients in a stack's config file.
+func setProjectStackRecipients(info *workspace.ProjectStack, recipients []cloudSecretsRecipientState) {
+	info.SecretsRecipients = nil
+	for _, recipient := range recipients {
+		info.SecretsRecipients = append(info.SecretsRecipients, workspace.SecretsRecipient{
+			URL:          recipient.URL,
+			EncryptedKey: base64.StdEncoding.EncodeToString(recipient.EncryptedKey),
+		})
+	}
+}
@@ -17,9 +17,12 @@ package cloud
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,15 +36,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"gocloud.dev/secrets"
 	"gocloud.dev/secrets/driver"
+	"gocloud.dev/secrets/localsecrets"
 )
 
 // the main testing function, takes a kms url and tries to make a new secret manager out of it and encrypt and
 // decrypt data
 func testURL(ctx context.Context, t *testing.T, url string) {
-	dataKey, err := generateNewDataKey(url)
+	dataKey, err := generateNewDataKey(url, nil)
 	require.NoError(t, err)
 
-	manager, err := newCloudSecretsManager(url, dataKey)
+	manager, err := newCloudSecretsManager(url, dataKey, nil)
 	require.NoError(t, err)
 
 	enc, err := manager.Encrypter()
@@ -294,3 +298,105 @@ func (k dummySecretsKeeper) Decrypt(ctx context.Context, ciphertext []byte) ([]b
 func (k dummySecretsKeeper) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
 	return plaintext, nil
 }
+
+func TestSecretsRecipients(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newKeyURL := func() string {
+		key, err := localsecrets.NewRandomKey()
+		require.NoError(t, err)
+		return "base64key://" + base64.URLEncoding.EncodeToString(key[:])
+	}
+	// Recipients can't be base64key:// URLs, so use a keeper whose key isn't in its URL.
+	secrets.DefaultURLMux().RegisterKeeper("testrecipient", &keyringOpener{keys: map[string][32]byte{}})
+	primaryURL, recipientURL := newKeyURL(), "testrecipient://recipient"
+
+	info := &workspace.ProjectStack{}
+	manager, err := NewCloudSecretsManager(info, primaryURL, false)
+	require.NoError(t, err)
+	enc, err := manager.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(ctx, "plaintext")
+	require.NoError(t, err)
+
+	keyURL := newKeyURL()
+	err = AddSecretsRecipient(info, keyURL)
+	assert.ErrorContains(t, err, "base64key:// URLs contain the key itself")
+	assert.NotContains(t, err.Error(), keyURL)
+	assert.Nil(t, info.SecretsRecipients)
+
+	require.NoError(t, AddSecretsRecipient(info, recipientURL))
+	require.Len(t, info.SecretsRecipients, 1)
+	assert.Equal(t, recipientURL, info.SecretsRecipients[0].URL)
+	assert.ErrorContains(t, AddSecretsRecipient(info, recipientURL), "is already a secrets recipient")
+
+	// A stack's secrets provider can't be one of its recipients too.
+	otherInfo := &workspace.ProjectStack{}
+	_, err = NewCloudSecretsManager(otherInfo, "testrecipient://other", false)
+	require.NoError(t, err)
+	assert.ErrorContains(t, AddSecretsRecipient(otherInfo, "testrecipient://other"),
+		"is already the stack's secrets provider")
+
+	// The state of the secrets manager records the recipients, and the data key can be decrypted with a recipient
+	// alone. Replace the primary keeper with one that can't decrypt the data key to check this.
+	manager, err = NewCloudSecretsManager(info, primaryURL, false)
+	require.NoError(t, err)
+	var state cloudSecretsManagerState
+	require.NoError(t, json.Unmarshal(manager.State(), &state))
+	require.Len(t, state.Recipients, 1)
+	state.URL = newKeyURL()
+	lostPrimaryState, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	recovered, err := NewCloudSecretsManagerFromState(lostPrimaryState)
+	require.NoError(t, err)
+	dec, err := recovered.Decrypter()
+	require.NoError(t, err)
+	plaintext, err := dec.DecryptValue(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+
+	assert.ErrorContains(t, RemoveSecretsRecipient(info, primaryURL), "can't be removed")
+	require.NoError(t, RemoveSecretsRecipient(info, recipientURL))
+	assert.Nil(t, info.SecretsRecipients)
+	assert.ErrorContains(t, RemoveSecretsRecipient(info, recipientURL), "is not a secrets recipient")
+
+	manager, err = NewCloudSecretsManager(info, primaryURL, false)
+	require.NoError(t, err)
+	var removedState cloudSecretsManagerState
+	require.NoError(t, json.Unmarshal(manager.State(), &removedState))
+	assert.Empty(t, removedState.Recipients)
+	removedState.URL = newKeyURL()
+	lostPrimaryState, err = json.Marshal(removedState)
+	require.NoError(t, err)
+	_, err = NewCloudSecretsManagerFromState(lostPrimaryState)
+	assert.Error(t, err)
+
+	// A base64key:// recipient written to the config file by hand is refused too.
+	info.SecretsRecipients = []workspace.SecretsRecipient{{URL: keyURL}}
+	_, err = NewCloudSecretsManager(info, primaryURL, false)
+	assert.ErrorContains(t, err, "base64key:// URLs contain the key itself")
+}
+
+// keyringOpener opens keepers for testrecipient://<name> URLs, each backed by a random local key that is made the
+// first time the name is opened.
+type keyringOpener struct {
+	mu   sync.Mutex
+	keys map[string][32]byte
+}
+
+func (o *keyringOpener) OpenKeeperURL(ctx context.Context, u *url.URL) (*secrets.Keeper, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key, has := o.keys[u.Host]
+	if !has {
+		var err error
+		if key, err = localsecrets.NewRandomKey(); err != nil {
+			return nil, err
+		}
+		o.keys[u.Host] = key
+	}
+	return localsecrets.NewKeeper(key), nil
+}
@@ -0,0 +1,332 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external implements a secrets manager that delegates encryption and decryption to an external command,
+// selected with a secrets provider URL of the form `exec://<path>`.
+//
+// The command is started without arguments the first time that a value needs to be encrypted or decrypted, and is
+// kept running until Pulumi exits or closes its standard input. Pulumi sends it one JSON request per line on standard
+// input, and it must write one JSON response per line to standard output. Each request holds the operation and the
+// plaintexts to encrypt or the ciphertexts to decrypt:
+//
+//	{"version": 1, "op": "encrypt", "values": ["...", "..."]}
+//	{"version": 1, "op": "decrypt", "values": ["...", "..."]}
+//
+// The response holds the results, in the same order as the request's values:
+//
+//	{"values": ["...", "..."]}
+//
+// To report a failure, the command should respond with a message instead, and keep running:
+//
+//	{"error": "..."}
+//
+// Ciphertexts are opaque to Pulumi, but must be valid UTF-8.
+//
+// Because the command runs with the user's privileges, a command is only run if it is the secrets provider configured
+// for a stack, and never just because it is named in a stack's state.
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Type is the type of secrets managed by this secrets provider
+const Type = "exec"
+
+// Scheme is the scheme of the secrets provider URLs that select this secrets provider.
+const Scheme = "exec://"
+
+// ProtocolVersion is the version of the protocol spoken with the external command.
+const ProtocolVersion = 1
+
+type externalSecretsManagerState struct {
+	URL string `json:"url"`
+}
+
+// request is a JSON request sent to the external command.
+type request struct {
+	Version int      `json:"version"`
+	Op      string   `json:"op"`
+	Values  []string `json:"values"`
+}
+
+// response is a JSON response read from the external command.
+type response struct {
+	Values []string `json:"values,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+var (
+	// trustedURLs holds the secrets provider URLs that have been configured for a stack in this process.
+	trustedURLs = map[string]bool{}
+	// processes holds the running external commands, keyed by path.
+	processes = map[string]*process{}
+	// lock guards trustedURLs and processes.
+	lock sync.Mutex
+)
+
+// Trust records that the given secrets provider URL is configured for a stack, so that secrets managers read from
+// state may run its command. NewExternalSecretsManager trusts the URL that it is given.
+func Trust(url string) {
+	lock.Lock()
+	defer lock.Unlock()
+	trustedURLs[url] = true
+}
+
+func isTrusted(url string) bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return trustedURLs[url]
+}
+
+// IsExternalSecretsProvider returns true if the given secrets provider URL selects this secrets provider.
+func IsExternalSecretsProvider(url string) bool {
+	return strings.HasPrefix(url, Scheme)
+}
+
+// newExternalSecretsManager returns a secrets manager that runs the command named by the given URL.
+func newExternalSecretsManager(url string) (*Manager, error) {
+	path := strings.TrimPrefix(url, Scheme)
+	if !IsExternalSecretsProvider(url) || path == "" {
+		return nil, fmt.Errorf("invalid secrets provider URL %q: expected %s<path>", url, Scheme)
+	}
+
+	state, err := json.Marshal(externalSecretsManagerState{URL: url})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling state: %w", err)
+	}
+	return &Manager{
+		crypter: &crypter{url: url, path: path},
+		state:   state,
+	}, nil
+}
+
+// Manager is the secrets.Manager implementation for external commands.
+type Manager struct {
+	state   json.RawMessage
+	crypter config.Crypter
+}
+
+func (m *Manager) Type() string                         { return Type }
+func (m *Manager) State() json.RawMessage               { return m.state }
+func (m *Manager) Encrypter() (config.Encrypter, error) { return m.crypter, nil }
+func (m *Manager) Decrypter() (config.Decrypter, error) { return m.crypter, nil }
+
+// NewExternalSecretsManagerFromState deserialize configuration from state and returns a secrets manager that
+// delegates to an external command. The state comes from a checkpoint, which may have been imported or written by
+// someone else, so the command is only run once its URL has been trusted as the secrets provider of a stack.
+func NewExternalSecretsManagerFromState(state json.RawMessage) (secrets.Manager, error) {
+	var s externalSecretsManagerState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, fmt.Errorf("unmarshalling state: %w", err)
+	}
+
+	return newExternalSecretsManager(s.URL)
+}
+
+// NewExternalSecretsManager returns a secrets manager that delegates to the external command named by the given
+// secrets provider URL, and records the secrets provider in the stack's config.
+func NewExternalSecretsManager(info *workspace.ProjectStack, secretsProvider string) (secrets.Manager, error) {
+	sm, err := newExternalSecretsManager(secretsProvider)
+	if err != nil {
+		return nil, err
+	}
+	Trust(secretsProvider)
+
+	// The external command manages its own keys, so remove the state of any other secrets provider.
+	info.EncryptionSalt = ""
+	info.EncryptedKey = ""
+	info.SecretsRecipients = nil
+	info.SecretsProvider = secretsProvider
+	return sm, nil
+}
+
+// crypter is a config.Crypter that sends requests to an external command.
+type crypter struct {
+	url  string
+	path string
+}
+
+func (c *crypter) EncryptValue(ctx context.Context, plaintext string) (string, error) {
+	values, err := c.run(ctx, "encrypt", []string{plaintext})
+	if err != nil {
+		return "", err
+	}
+	return values[0], nil
+}
+
+func (c *crypter) DecryptValue(ctx context.Context, ciphertext string) (string, error) {
+	values, err := c.run(ctx, "decrypt", []string{ciphertext})
+	if err != nil {
+		return "", err
+	}
+	return values[0], nil
+}
+
+func (c *crypter) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	if len(ciphertexts) == 0 {
+		return map[string]string{}, nil
+	}
+
+	values, err := c.run(ctx, "decrypt", ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		result[ciphertext] = values[i]
+	}
+	return result, nil
+}
+
+// run sends a request to perform the given operation on the given values to the external command, starting the
+// command if it isn't running yet, and returns the results.
+func (c *crypter) run(ctx context.Context, op string, values []string) ([]string, error) {
+	lock.Lock()
+	if !trustedURLs[c.url] {
+		lock.Unlock()
+		return nil, fmt.Errorf("refusing to run secrets provider %s: it is not the secrets provider configured for "+
+			"the stack; set `secretsprovider: %s` in the stack's config file if you trust it", c.path, c.url)
+	}
+	p, ok := processes[c.path]
+	if !ok {
+		p = &process{path: c.path}
+		processes[c.path] = p
+	}
+	lock.Unlock()
+
+	resp, err := p.send(ctx, request{Version: ProtocolVersion, Op: op, Values: values})
+	if err != nil {
+		return nil, fmt.Errorf("secrets provider %s failed to %s: %w", c.path, op, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("secrets provider %s failed to %s: %s", c.path, op, resp.Error)
+	}
+	if len(resp.Values) != len(values) {
+		return nil, fmt.Errorf("secrets provider %s returned %d values, expected %d",
+			c.path, len(resp.Values), len(values))
+	}
+	return resp.Values, nil
+}
+
+// process is a running external command. Requests are sent to it one at a time.
+type process struct {
+	path string
+
+	m      sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr bytes.Buffer
+}
+
+// start starts the command if it isn't running.
+func (p *process) start() error {
+	if p.cmd != nil {
+		return nil
+	}
+
+	cmd := exec.Command(p.path)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	p.stderr.Reset()
+	cmd.Stderr = &p.stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	p.cmd, p.stdin, p.stdout = cmd, stdin, bufio.NewReader(stdout)
+	return nil
+}
+
+// stop kills the command, so that it is started again by the next request, and returns what it wrote to standard
+// error.
+func (p *process) stop() string {
+	cmd := p.cmd
+	p.cmd = nil
+	_ = p.stdin.Close()
+	_ = cmd.Process.Kill()
+	_ = cmd.Wait()
+	return strings.TrimSpace(p.stderr.String())
+}
+
+// send sends a request to the command and reads its response. If the command fails or the context is canceled, the
+// command is stopped.
+func (p *process) send(ctx context.Context, req request) (response, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if err := p.start(); err != nil {
+		return response{}, err
+	}
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return response{}, err
+	}
+
+	type result struct {
+		line []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		if _, err := p.stdin.Write(append(input, '\n')); err != nil {
+			done <- result{err: err}
+			return
+		}
+		line, err := p.stdout.ReadBytes('\n')
+		done <- result{line: line, err: err}
+	}()
+
+	var res result
+	select {
+	case <-ctx.Done():
+		p.stop()
+		<-done
+		return response{}, ctx.Err()
+	case res = <-done:
+	}
+	if res.err != nil {
+		if msg := p.stop(); msg != "" {
+			return response{}, errors.New(msg)
+		}
+		return response{}, res.err
+	}
+
+	var resp response
+	if err := json.Unmarshal(res.line, &resp); err != nil {
+		p.stop()
+		return response{}, fmt.Errorf("invalid response: %w", err)
+	}
+	return resp, nil
+}
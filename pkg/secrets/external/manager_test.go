@@ -0,0 +1,199 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// sampleProviderEnvVar is set for the test binary when it is run as the sample external secrets provider.
+const sampleProviderEnvVar = "PULUMI_TEST_SAMPLE_SECRETS_PROVIDER"
+
+// TestMain runs the test binary as a sample external secrets provider when sampleProviderEnvVar is set, so that the
+// tests can use it as the external command.
+func TestMain(m *testing.M) {
+	if os.Getenv(sampleProviderEnvVar) != "" {
+		if err := runSampleProvider(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if err := os.Setenv(sampleProviderEnvVar, "true"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// runSampleProvider is a sample implementation of the external secrets provider protocol. It "encrypts" values by
+// base64 encoding them with a prefix, so that only values it encrypted can be decrypted.
+func runSampleProvider(stdin io.Reader, stdout io.Writer) error {
+	scanner := bufio.NewScanner(stdin)
+	enc := json.NewEncoder(stdout)
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			return err
+		}
+		if req.Version != ProtocolVersion {
+			return fmt.Errorf("unsupported protocol version %d", req.Version)
+		}
+
+		values, err := sampleProviderValues(req)
+		if err != nil {
+			if err := enc.Encode(response{Error: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := enc.Encode(response{Values: values}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func sampleProviderValues(req request) ([]string, error) {
+	var values []string
+	for _, value := range req.Values {
+		switch req.Op {
+		case "encrypt":
+			values = append(values, "sample:"+base64.StdEncoding.EncodeToString([]byte(value)))
+		case "decrypt":
+			encoded, ok := strings.CutPrefix(value, "sample:")
+			if !ok {
+				return nil, fmt.Errorf("%q was not encrypted by this provider", value)
+			}
+			plaintext, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, string(plaintext))
+		default:
+			return nil, fmt.Errorf("unknown operation %q", req.Op)
+		}
+	}
+	return values, nil
+}
+
+func sampleProviderURL(t *testing.T) string {
+	path, err := os.Executable()
+	require.NoError(t, err)
+	return Scheme + path
+}
+
+func TestExternalSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	url := sampleProviderURL(t)
+
+	info := &workspace.ProjectStack{EncryptionSalt: "salt", EncryptedKey: "key"}
+	manager, err := NewExternalSecretsManager(info, url)
+	require.NoError(t, err)
+	assert.Equal(t, &workspace.ProjectStack{SecretsProvider: url}, info)
+	assert.Equal(t, Type, manager.Type())
+
+	enc, err := manager.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(ctx, "plaintext")
+	require.NoError(t, err)
+	assert.Equal(t, "sample:cGxhaW50ZXh0", ciphertext)
+
+	// A manager reconstructed from the state uses the same command.
+	manager, err = NewExternalSecretsManagerFromState(manager.State())
+	require.NoError(t, err)
+	dec, err := manager.Decrypter()
+	require.NoError(t, err)
+	plaintext, err := dec.DecryptValue(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+
+	other, err := enc.EncryptValue(ctx, "other")
+	require.NoError(t, err)
+	plaintexts, err := dec.BulkDecrypt(ctx, []string{ciphertext, other})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{ciphertext: "plaintext", other: "other"}, plaintexts)
+
+	// Failures are reported with the command's message, and the command keeps serving requests.
+	_, err = dec.DecryptValue(ctx, "garbage")
+	assert.ErrorContains(t, err, `failed to decrypt: "garbage" was not encrypted by this provider`)
+	plaintext, err = dec.DecryptValue(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+
+	// A canceled request stops the command, and the next request starts it again.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = enc.EncryptValue(canceled, "plaintext")
+	assert.ErrorIs(t, err, context.Canceled)
+	plaintext, err = dec.DecryptValue(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+}
+
+func TestExternalSecretsManagerFromUntrustedState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// Link to the sample provider under a path that no other test trusts.
+	path := filepath.Join(t.TempDir(), "provider")
+	if err := os.Symlink(strings.TrimPrefix(sampleProviderURL(t), Scheme), path); err != nil {
+		t.Skipf("creating symlink: %v", err)
+	}
+	url := Scheme + path
+
+	state, err := json.Marshal(externalSecretsManagerState{URL: url})
+	require.NoError(t, err)
+	manager, err := NewExternalSecretsManagerFromState(state)
+	require.NoError(t, err)
+	enc, err := manager.Encrypter()
+	require.NoError(t, err)
+
+	// A command named by the state is not run unless it is the secrets provider configured for a stack.
+	_, err = enc.EncryptValue(ctx, "plaintext")
+	assert.ErrorContains(t, err, "it is not the secrets provider configured for the stack")
+
+	_, err = NewExternalSecretsManager(&workspace.ProjectStack{}, url)
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(ctx, "plaintext")
+	require.NoError(t, err)
+	assert.Equal(t, "sample:cGxhaW50ZXh0", ciphertext)
+}
+
+func TestExternalSecretsManagerInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewExternalSecretsManager(&workspace.ProjectStack{}, "exec://")
+	assert.ErrorContains(t, err, "expected exec://<path>")
+
+	_, err = NewExternalSecretsManagerFromState(json.RawMessage(`{"url": "awskms://alias/foo"}`))
+	assert.ErrorContains(t, err, "expected exec://<path>")
+}
@@ -253,6 +253,17 @@ func NewPromptingPassphraseSecretsManagerFromState(state json.RawMessage) (secre
 	}
 }
 
+// NewPassphraseSecretsManagerFromState returns a passphrase-based secrets manager from the given state, using the
+// given passphrase rather than one from the environment. Returns ErrIncorrectPassphrase if the passphrase doesn't
+// match the state.
+func NewPassphraseSecretsManagerFromState(phrase string, state json.RawMessage) (secrets.Manager, error) {
+	var s localSecretsManagerState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, fmt.Errorf("unmarshalling state: %w", err)
+	}
+	return GetPassphraseSecretsManager(phrase, s.Salt)
+}
+
 func NewPromptingPassphraseSecretsManager(info *workspace.ProjectStack,
 	rotateSecretsProvider bool,
 ) (secrets.Manager, error) {
@@ -263,6 +274,7 @@ func NewPromptingPassphraseSecretsManager(info *workspace.ProjectStack,
 	// If there are any other secrets providers set in the config, remove them, as the passphrase
 	// provider deals only with EncryptionSalt, not EncryptedKey or SecretsProvider.
 	info.EncryptedKey = ""
+	info.SecretsRecipients = nil
 	info.SecretsProvider = ""
 
 	// If we have a salt, we can just use it.
@@ -330,21 +342,40 @@ func promptForNewPassphrase(rotate bool) (string, secrets.Manager, error) {
 	return state, sm, err
 }
 
+// LookupPassphraseEnv returns the passphrase given by the phraseVar environment variable, or else read from the file
+// named by the fileVar environment variable. Returns false if neither variable is set.
+func LookupPassphraseEnv(phraseVar, fileVar string) (string, bool, error) {
+	if phrase, ok := os.LookupEnv(phraseVar); ok {
+		return phrase, true, nil
+	}
+	if phraseFile, ok := os.LookupEnv(fileVar); ok && phraseFile != "" {
+		phraseFilePath, err := filepath.Abs(phraseFile)
+		if err != nil {
+			return "", false, fmt.Errorf("unable to construct a path the %s: %w", fileVar, err)
+		}
+		phrase, err := ReadPassphraseFile(phraseFilePath)
+		if err != nil {
+			return "", false, fmt.Errorf("unable to read %s: %w", fileVar, err)
+		}
+		return phrase, true, nil
+	}
+	return "", false, nil
+}
+
+// ReadPassphraseFile reads a passphrase from the given file, ignoring any surrounding whitespace.
+func ReadPassphraseFile(path string) (string, error) {
+	phraseDetails, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(phraseDetails)), nil
+}
+
 func readPassphrase(prompt string, useEnv bool) (phrase string, interactive bool, err error) {
 	if useEnv {
-		if phrase, ok := os.LookupEnv("PULUMI_CONFIG_PASSPHRASE"); ok {
-			return phrase, false, nil
-		}
-		if phraseFile, ok := os.LookupEnv("PULUMI_CONFIG_PASSPHRASE_FILE"); ok && phraseFile != "" {
-			phraseFilePath, err := filepath.Abs(phraseFile)
-			if err != nil {
-				return "", false, fmt.Errorf("unable to construct a path the PULUMI_CONFIG_PASSPHRASE_FILE: %w", err)
-			}
-			phraseDetails, err := os.ReadFile(phraseFilePath)
-			if err != nil {
-				return "", false, fmt.Errorf("unable to read PULUMI_CONFIG_PASSPHRASE_FILE: %w", err)
-			}
-			return strings.TrimSpace(string(phraseDetails)), false, nil
+		envPhrase, ok, err := LookupPassphraseEnv("PULUMI_CONFIG_PASSPHRASE", "PULUMI_CONFIG_PASSPHRASE_FILE")
+		if err != nil || ok {
+			return envPhrase, false, err
 		}
 		if !isInteractive() {
 			return "", false, errors.New("passphrase must be set with PULUMI_CONFIG_PASSPHRASE or " +
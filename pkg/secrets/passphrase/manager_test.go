@@ -3,6 +3,7 @@ package passphrase
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -130,3 +131,42 @@ func TestPassphraseManagerEmptyPassfileReturnsError(t *testing.T) {
 	assert.NotNil(t, err, strings.Contains(err.Error(), "unable to find either `PULUMI_CONFIG_PASSPHRASE` nor "+
 		"`PULUMI_CONFIG_PASSPHRASE_FILE`"))
 }
+
+//nolint:paralleltest // clears the secrets manager cache
+func TestPassphraseManagerFromStateWithPassphrase(t *testing.T) {
+	resetEnv := resetPassphraseTestEnvVars()
+	defer resetEnv()
+
+	_, err := NewPassphraseSecretsManagerFromState("password123", []byte(state))
+	assert.Equal(t, ErrIncorrectPassphrase, err)
+
+	sm, err := NewPassphraseSecretsManagerFromState("password", []byte(state))
+	require.NoError(t, err)
+	assert.Equal(t, Type, sm.Type())
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestLookupPassphraseEnv(t *testing.T) {
+	t.Setenv("TEST_PASSPHRASE", "")
+	os.Unsetenv("TEST_PASSPHRASE")
+	t.Setenv("TEST_PASSPHRASE_FILE", "")
+
+	_, ok, err := LookupPassphraseEnv("TEST_PASSPHRASE", "TEST_PASSPHRASE_FILE")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	path := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+	t.Setenv("TEST_PASSPHRASE_FILE", path)
+	phrase, ok, err := LookupPassphraseEnv("TEST_PASSPHRASE", "TEST_PASSPHRASE_FILE")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "from-file", phrase)
+
+	// The passphrase itself takes precedence over the file.
+	t.Setenv("TEST_PASSPHRASE", "from-env")
+	phrase, ok, err = LookupPassphraseEnv("TEST_PASSPHRASE", "TEST_PASSPHRASE_FILE")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "from-env", phrase)
+}
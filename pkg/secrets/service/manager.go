@@ -139,6 +139,7 @@ func NewServiceSecretsManager(
 	info.EncryptionSalt = ""
 	info.SecretsProvider = ""
 	info.EncryptedKey = ""
+	info.SecretsRecipients = nil
 
 	state, err := json.Marshal(serviceSecretsManagerState{
 		URL:      client.URL(),
@@ -29,6 +29,7 @@ const unknownErrorCode = -2
 func runPulumiCommandSync(
 	ctx context.Context,
 	workdir string,
+	stdin io.Reader,
 	additionalOutput []io.Writer,
 	additionalErrorOutput []io.Writer,
 	additionalEnv []string,
@@ -40,6 +41,7 @@ func runPulumiCommandSync(
 	cmd := exec.CommandContext(ctx, "pulumi", args...)
 	cmd.Dir = workdir
 	cmd.Env = append(os.Environ(), additionalEnv...)
+	cmd.Stdin = stdin
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -15,11 +15,19 @@
 package auto
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 )
 
+// ErrStackNotFound is returned by a Workspace that does not use the Pulumi CLI when a stack does not exist.
+var ErrStackNotFound = errors.New("stack not found")
+
+// ErrStackAlreadyExists is returned by a Workspace that does not use the Pulumi CLI when creating a stack that
+// already exists.
+var ErrStackAlreadyExists = errors.New("stack already exists")
+
 type autoError struct {
 	stdout string
 	stderr string
@@ -54,6 +62,10 @@ func IsConcurrentUpdateError(e error) bool {
 
 // IsSelectStack404Error returns true if the error was a result of selecting a stack that does not exist.
 func IsSelectStack404Error(e error) bool {
+	if errors.Is(e, ErrStackNotFound) {
+		return true
+	}
+
 	ae, ok := e.(autoError)
 	if !ok {
 		return false
@@ -65,6 +77,10 @@ func IsSelectStack404Error(e error) bool {
 
 // IsCreateStack409Error returns true if the error was a result of creating a stack that already exists.
 func IsCreateStack409Error(e error) bool {
+	if errors.Is(e, ErrStackAlreadyExists) {
+		return true
+	}
+
 	ae, ok := e.(autoError)
 	if !ok {
 		return false
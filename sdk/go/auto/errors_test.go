@@ -578,3 +578,12 @@ func TestRuntimeErrorDotnet(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestStackErrorSentinels(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsSelectStack404Error(fmt.Errorf("selecting stack dev: %w", ErrStackNotFound)))
+	assert.False(t, IsSelectStack404Error(ErrStackAlreadyExists))
+	assert.True(t, IsCreateStack409Error(fmt.Errorf("creating stack dev: %w", ErrStackAlreadyExists)))
+	assert.False(t, IsCreateStack409Error(ErrStackNotFound))
+}
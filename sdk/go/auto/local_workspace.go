@@ -722,6 +722,7 @@ func (l *LocalWorkspace) runPulumiCmdSync(
 	}
 	return runPulumiCommandSync(ctx,
 		l.WorkDir(),
+		nil, /* stdin */
 		nil, /* additionalOutputs */
 		nil, /* additionalErrorOutputs */
 		env,
@@ -738,7 +739,7 @@ func (l *LocalWorkspace) supportsPulumiCmdFlag(ctx context.Context, flag string,
 	}
 
 	// Run the command with `--help`, and then we'll look for the flag in the output.
-	stdout, _, _, err := runPulumiCommandSync(ctx, l.WorkDir(), nil, nil, env, append(args, "--help")...)
+	stdout, _, _, err := runPulumiCommandSync(ctx, l.WorkDir(), nil, nil, nil, env, append(args, "--help")...)
 	if err != nil {
 		return false, err
 	}
@@ -19,6 +19,7 @@ import (
 	"context"
 	cryptorand "crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -35,10 +36,13 @@ import (
 
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/debug"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optchangesecretsprovider"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optdestroy"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optimport"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optpreview"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optrefresh"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optremove"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optstatedelete"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optup"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	resourceConfig "github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
@@ -1312,6 +1316,162 @@ func TestImportExportStack(t *testing.T) {
 	assert.Equal(t, "succeeded", dRes.Summary.Result)
 }
 
+func TestStateOperations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sName := randomStackName()
+	stackName := FullyQualifiedStackName(pulumiOrg, pName, sName)
+
+	// initialize
+	s, err := NewStackInlineSource(ctx, stackName, pName, func(ctx *pulumi.Context) error {
+		var parent, child pulumi.ResourceState
+		err := ctx.RegisterComponentResource("test:index:Component", "parent", &parent, pulumi.Protect(true))
+		if err != nil {
+			return err
+		}
+		return ctx.RegisterComponentResource("test:index:Component", "child", &child, pulumi.Parent(&parent))
+	})
+	require.NoError(t, err, "failed to initialize stack")
+
+	defer func() {
+		// -- pulumi stack rm --
+		err = s.Workspace().RemoveStack(ctx, s.Name(), optremove.Force())
+		assert.Nil(t, err, "failed to remove stack. Resources have leaked.")
+	}()
+
+	// -- pulumi up --
+	_, err = s.Up(ctx)
+	require.NoError(t, err, "up failed")
+
+	stackURN := func(name string) string {
+		return fmt.Sprintf("urn:pulumi:%s::%s::test:index:Component::%s", sName, pName, name)
+	}
+	resourceNames := func() []string {
+		state, err := s.Export(ctx)
+		require.NoError(t, err, "export failed")
+		var deployment apitype.DeploymentV3
+		require.NoError(t, json.Unmarshal(state.Deployment, &deployment))
+		var names []string
+		for _, res := range deployment.Resources {
+			if res.Type == "test:index:Component" {
+				names = append(names, string(res.URN.Name()))
+			}
+		}
+		return names
+	}
+
+	// -- pulumi state rename --
+	err = s.StateRename(ctx, stackURN("child"), "renamed")
+	require.NoError(t, err, "state rename failed")
+	assert.ElementsMatch(t, []string{"parent", "renamed"}, resourceNames())
+
+	// -- pulumi state delete --
+	// The parent is protected and has a child, so it can't be deleted by default.
+	err = s.StateDelete(ctx, stackURN("parent"))
+	assert.Error(t, err)
+	err = s.StateUnprotect(ctx, stackURN("parent"))
+	require.NoError(t, err, "state unprotect failed")
+	err = s.StateDelete(ctx, stackURN("parent"), optstatedelete.TargetDependents())
+	require.NoError(t, err, "state delete failed")
+	assert.Empty(t, resourceNames())
+
+	// -- pulumi stack rename --
+	newStackName := FullyQualifiedStackName(pulumiOrg, pName, sName+"-renamed")
+	err = s.Rename(ctx, newStackName)
+	require.NoError(t, err, "stack rename failed")
+	assert.Equal(t, newStackName, s.Name())
+	_, err = s.Info(ctx)
+	assert.NoError(t, err)
+}
+
+func TestChangeSecretsProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sName := randomStackName()
+	stackName := FullyQualifiedStackName(pulumiOrg, pName, sName)
+
+	// initialize
+	s, err := NewStackInlineSource(ctx, stackName, pName, func(ctx *pulumi.Context) error {
+		return nil
+	}, SecretsProvider("passphrase"), EnvVars(map[string]string{
+		"PULUMI_CONFIG_PASSPHRASE": "password",
+	}))
+	require.NoError(t, err, "failed to initialize stack")
+
+	defer func() {
+		// -- pulumi stack rm --
+		err = s.Workspace().RemoveStack(ctx, s.Name())
+		assert.Nil(t, err, "failed to remove stack. Resources have leaked.")
+	}()
+
+	err = s.SetConfig(ctx, "secret", ConfigValue{Value: "Password1234!", Secret: true})
+	require.NoError(t, err, "setConfig failed")
+
+	// -- pulumi stack change-secrets-provider --
+	// The current passphrase is still needed to decrypt the stack's secrets, so it stays in the environment.
+	err = s.ChangeSecretsProvider(ctx, "passphrase", optchangesecretsprovider.NewPassphrase("new-password"))
+	require.NoError(t, err, "change secrets provider failed")
+
+	// The secrets can no longer be decrypted with the old passphrase, only with the new one.
+	_, err = s.GetConfig(ctx, "secret")
+	assert.Error(t, err)
+	s.Workspace().SetEnvVar("PULUMI_CONFIG_PASSPHRASE", "new-password")
+	conf, err := s.GetConfig(ctx, "secret")
+	require.NoError(t, err, "GetConfig failed")
+	assert.Equal(t, "Password1234!", conf.Value)
+	assert.True(t, conf.Secret)
+}
+
+func TestImportResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sName := randomStackName()
+	stackName := FullyQualifiedStackName(pulumiOrg, pName, sName)
+
+	// initialize
+	s, err := NewStackInlineSource(ctx, stackName, pName, func(ctx *pulumi.Context) error {
+		return nil
+	})
+	require.NoError(t, err, "failed to initialize stack")
+
+	defer func() {
+		// -- pulumi stack rm --
+		err = s.Workspace().RemoveStack(ctx, s.Name(), optremove.Force())
+		assert.Nil(t, err, "failed to remove stack. Resources have leaked.")
+	}()
+
+	err = s.Workspace().InstallPlugin(ctx, "random", "v4.13.0")
+	require.NoError(t, err, "failed to install the random plugin")
+
+	// -- pulumi import --
+	res, err := s.ImportResources(ctx, []ImportSpec{{
+		Type: "random:index/randomPassword:RandomPassword",
+		Name: "imported",
+		ID:   "supersecret",
+	}}, optimport.Protect(false), optimport.GenerateCode())
+	require.NoError(t, err, "import failed")
+	assert.Equal(t, "resource-import", res.Summary.Kind)
+	assert.Equal(t, "succeeded", res.Summary.Result)
+	assert.Contains(t, res.GeneratedCode, "imported")
+
+	state, err := s.Export(ctx)
+	require.NoError(t, err, "export failed")
+	var deployment apitype.DeploymentV3
+	require.NoError(t, json.Unmarshal(state.Deployment, &deployment))
+	var imported *apitype.ResourceV3
+	for i, r := range deployment.Resources {
+		if r.Type == "random:index/randomPassword:RandomPassword" {
+			imported = &deployment.Resources[i]
+		}
+	}
+	require.NotNil(t, imported, "the imported resource is not in the stack")
+	assert.Equal(t, "imported", string(imported.URN.Name()))
+	assert.False(t, imported.Protect)
+}
+
 func TestConfigFlagLike(t *testing.T) {
 	t.Parallel()
 
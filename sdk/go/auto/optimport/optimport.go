@@ -0,0 +1,114 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package optimport contains functional options to be used with stack import operations
+// github.com/sdk/v2/go/x/auto Stack.ImportResources(...optimport.Option)
+package optimport
+
+import (
+	"io"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/debug"
+)
+
+// Message (optional) to associate with the import operation
+func Message(message string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Message = message
+	})
+}
+
+// Protect sets whether the imported resources are protected from deletion. Defaults to true.
+func Protect(protect bool) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Protect = &protect
+	})
+}
+
+// GenerateCode generates resource declaration code for the imported resources, which is returned in
+// ImportResult.GeneratedCode
+func GenerateCode() Option {
+	return optionFunc(func(opts *Options) {
+		opts.GenerateCode = true
+	})
+}
+
+// NameTable maps the names used for parents and providers in the import specs to the URNs of existing resources
+func NameTable(names map[string]string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.NameTable = names
+	})
+}
+
+// ProgressStreams allows specifying one or more io.Writers to redirect incremental import stdout
+func ProgressStreams(writers ...io.Writer) Option {
+	return optionFunc(func(opts *Options) {
+		opts.ProgressStreams = writers
+	})
+}
+
+// ErrorProgressStreams allows specifying one or more io.Writers to redirect incremental import stderr
+func ErrorProgressStreams(writers ...io.Writer) Option {
+	return optionFunc(func(opts *Options) {
+		opts.ErrorProgressStreams = writers
+	})
+}
+
+// DebugLogging provides options for verbose logging to standard error, and enabling plugin logs.
+func DebugLogging(debugOpts debug.LoggingOptions) Option {
+	return optionFunc(func(opts *Options) {
+		opts.DebugLogOpts = debugOpts
+	})
+}
+
+// UserAgent specifies the agent responsible for the import, stored in backends as "environment.exec.agent"
+func UserAgent(agent string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.UserAgent = agent
+	})
+}
+
+// Option is a parameter to be applied to a Stack.ImportResources() operation
+type Option interface {
+	ApplyOption(*Options)
+}
+
+// ---------------------------------- implementation details ----------------------------------
+
+// Options is an implementation detail
+type Options struct {
+	// Message (optional) to associate with the import operation
+	Message string
+	// Protect the imported resources from deletion
+	Protect *bool
+	// Generate resource declaration code for the imported resources
+	GenerateCode bool
+	// Names of existing resources that can be used as parents and providers
+	NameTable map[string]string
+	// ProgressStreams allows specifying one or more io.Writers to redirect incremental import stdout
+	ProgressStreams []io.Writer
+	// ErrorProgressStreams allows specifying one or more io.Writers to redirect incremental import stderr
+	ErrorProgressStreams []io.Writer
+	// DebugLogOpts specifies additional settings for debug logging
+	DebugLogOpts debug.LoggingOptions
+	// UserAgent specifies the agent responsible for the import, stored in backends as "environment.exec.agent"
+	UserAgent string
+}
+
+type optionFunc func(*Options)
+
+// ApplyOption is an implementation detail
+func (o optionFunc) ApplyOption(opts *Options) {
+	o(opts)
+}
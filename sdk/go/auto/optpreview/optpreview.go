@@ -115,6 +115,13 @@ func Plan(path string) Option {
 	})
 }
 
+// SummaryFile specifies the path of a file to write a JSON summary of the preview to once it ends.
+func SummaryFile(path string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.SummaryFile = path
+	})
+}
+
 // Option is a parameter to be applied to a Stack.Preview() operation
 type Option interface {
 	ApplyOption(*Options)
@@ -157,6 +164,8 @@ type Options struct {
 	PolicyPacks []string
 	// Path to JSON file containing the config for the policy pack of the corresponding "--policy-pack" flag
 	PolicyPackConfigs []string
+	// Write a JSON summary of the preview to the given path.
+	SummaryFile string
 }
 
 type optionFunc func(*Options)
@@ -94,6 +94,13 @@ func ShowSecrets(show bool) Option {
 	})
 }
 
+// SummaryFile specifies the path of a file to write a JSON summary of the refresh to once it ends.
+func SummaryFile(path string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.SummaryFile = path
+	})
+}
+
 // Option is a parameter to be applied to a Stack.Refresh() operation
 type Option interface {
 	ApplyOption(*Options)
@@ -126,6 +133,8 @@ type Options struct {
 	Color string
 	// Show config secrets when they appear.
 	ShowSecrets *bool
+	// Write a JSON summary of the refresh to the given path.
+	SummaryFile string
 }
 
 type optionFunc func(*Options)
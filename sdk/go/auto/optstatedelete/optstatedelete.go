@@ -0,0 +1,53 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package optstatedelete contains functional options to be used with stack state delete operations
+// github.com/sdk/v2/go/x/auto Stack.StateDelete(...optstatedelete.Option)
+package optstatedelete
+
+// Force deletes the resource even if it is protected
+func Force() Option {
+	return optionFunc(func(opts *Options) {
+		opts.Force = true
+	})
+}
+
+// TargetDependents deletes the resource and all the resources that depend on it
+func TargetDependents() Option {
+	return optionFunc(func(opts *Options) {
+		opts.TargetDependents = true
+	})
+}
+
+// Option is a parameter to be applied to a Stack.StateDelete() operation
+type Option interface {
+	ApplyOption(*Options)
+}
+
+// ---------------------------------- implementation details ----------------------------------
+
+// Options is an implementation detail
+type Options struct {
+	// Delete protected resources
+	Force bool
+	// Delete the resource and all its dependents
+	TargetDependents bool
+}
+
+type optionFunc func(*Options)
+
+// ApplyOption is an implementation detail
+func (o optionFunc) ApplyOption(opts *Options) {
+	o(opts)
+}
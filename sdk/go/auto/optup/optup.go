@@ -122,6 +122,13 @@ func ShowSecrets(show bool) Option {
 	})
 }
 
+// SummaryFile specifies the path of a file to write a JSON summary of the update to once it ends.
+func SummaryFile(path string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.SummaryFile = path
+	})
+}
+
 // Option is a parameter to be applied to a Stack.Up() operation
 type Option interface {
 	ApplyOption(*Options)
@@ -166,6 +173,8 @@ type Options struct {
 	PolicyPackConfigs []string
 	// Show config secrets when they appear.
 	ShowSecrets *bool
+	// Write a JSON summary of the update to the given path.
+	SummaryFile string
 }
 
 type optionFunc func(*Options)
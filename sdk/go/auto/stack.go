@@ -114,10 +114,13 @@ import (
 
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/debug"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optchangesecretsprovider"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optdestroy"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/opthistory"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optimport"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optpreview"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optrefresh"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optstatedelete"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optup"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/constant"
@@ -214,6 +217,17 @@ func (s *Stack) Preview(ctx context.Context, opts ...optpreview.Option) (Preview
 		o.ApplyOption(preOpts)
 	}
 
+	if ew, ok := s.workspace.(EngineWorkspace); ok {
+		steps := newStepCollector()
+		preOpts.EventStreams = append([]chan<- events.EngineEvent{steps.events}, preOpts.EventStreams...)
+		res, err := ew.Preview(ctx, s.Name(), preOpts)
+		if err != nil {
+			return res, err
+		}
+		res.Steps = steps.Steps()
+		return res, nil
+	}
+
 	bufferSizeHint := len(preOpts.Replace) + len(preOpts.Target) +
 		len(preOpts.PolicyPacks) + len(preOpts.PolicyPackConfigs)
 	sharedArgs := slice.Prealloc[string](bufferSizeHint)
@@ -255,6 +269,9 @@ func (s *Stack) Preview(ctx context.Context, opts ...optpreview.Option) (Preview
 	if preOpts.Plan != "" {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--save-plan=%s", preOpts.Plan))
 	}
+	if preOpts.SummaryFile != "" {
+		sharedArgs = append(sharedArgs, fmt.Sprintf("--summary-file=%s", preOpts.SummaryFile))
+	}
 
 	// Apply the remote args, if needed.
 	sharedArgs = append(sharedArgs, s.remoteArgs()...)
@@ -273,23 +290,8 @@ func (s *Stack) Preview(ctx context.Context, opts ...optpreview.Option) (Preview
 	args = append(args, fmt.Sprintf("--exec-kind=%s", kind))
 	args = append(args, sharedArgs...)
 
-	var summaryEvents []apitype.SummaryEvent
-	eventChannel := make(chan events.EngineEvent)
-	eventsDone := make(chan bool)
-	go func() {
-		for {
-			event, ok := <-eventChannel
-			if !ok {
-				close(eventsDone)
-				return
-			}
-			if event.SummaryEvent != nil {
-				summaryEvents = append(summaryEvents, *event.SummaryEvent)
-			}
-		}
-	}()
-
-	eventChannels := []chan<- events.EngineEvent{eventChannel}
+	steps := newStepCollector()
+	eventChannels := []chan<- events.EngineEvent{steps.events}
 	eventChannels = append(eventChannels, preOpts.EventStreams...)
 
 	t, err := tailLogs("preview", eventChannels)
@@ -311,8 +313,9 @@ func (s *Stack) Preview(ctx context.Context, opts ...optpreview.Option) (Preview
 
 	// Close the file watcher wait for all events to send
 	t.Close()
-	<-eventsDone
+	res.Steps = steps.Steps()
 
+	summaryEvents := steps.summaries
 	if len(summaryEvents) == 0 {
 		return res, newAutoError(errors.New("failed to get preview summary"), stdout, stderr, code)
 	}
@@ -337,6 +340,17 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 		o.ApplyOption(upOpts)
 	}
 
+	if ew, ok := s.workspace.(EngineWorkspace); ok {
+		steps := newStepCollector()
+		upOpts.EventStreams = append([]chan<- events.EngineEvent{steps.events}, upOpts.EventStreams...)
+		res, err := ew.Up(ctx, s.Name(), upOpts)
+		if err != nil {
+			return res, err
+		}
+		res.Steps = steps.Steps()
+		return res, nil
+	}
+
 	bufferSizeHint := len(upOpts.Replace) + len(upOpts.Target) + len(upOpts.PolicyPacks) + len(upOpts.PolicyPackConfigs)
 	sharedArgs := slice.Prealloc[string](bufferSizeHint)
 
@@ -377,6 +391,9 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 	if upOpts.Plan != "" {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--plan=%s", upOpts.Plan))
 	}
+	if upOpts.SummaryFile != "" {
+		sharedArgs = append(sharedArgs, fmt.Sprintf("--summary-file=%s", upOpts.SummaryFile))
+	}
 
 	// Apply the remote args, if needed.
 	sharedArgs = append(sharedArgs, s.remoteArgs()...)
@@ -393,15 +410,16 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 	}
 	args = append(args, fmt.Sprintf("--exec-kind=%s", kind))
 
-	if len(upOpts.EventStreams) > 0 {
-		eventChannels := upOpts.EventStreams
-		t, err := tailLogs("up", eventChannels)
-		if err != nil {
-			return res, fmt.Errorf("failed to tail logs: %w", err)
-		}
-		defer t.Close()
-		args = append(args, "--event-log", t.Filename)
+	steps := newStepCollector()
+	eventChannels := []chan<- events.EngineEvent{steps.events}
+	eventChannels = append(eventChannels, upOpts.EventStreams...)
+
+	t, err := tailLogs("up", eventChannels)
+	if err != nil {
+		return res, fmt.Errorf("failed to tail logs: %w", err)
 	}
+	defer t.Close()
+	args = append(args, "--event-log", t.Filename)
 
 	args = append(args, sharedArgs...)
 	stdout, stderr, code, err := s.runPulumiCmdSync(ctx, upOpts.ProgressStreams, upOpts.ErrorProgressStreams, args...)
@@ -409,6 +427,9 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 		return res, newAutoError(fmt.Errorf("failed to run update: %w", err), stdout, stderr, code)
 	}
 
+	// Close the file watcher wait for all events to send
+	t.Close()
+
 	outs, err := s.Outputs(ctx)
 	if err != nil {
 		return res, err
@@ -432,6 +453,7 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 		Outputs: outs,
 		StdOut:  stdout,
 		StdErr:  stderr,
+		Steps:   steps.Steps(),
 	}
 
 	if len(history) > 0 {
@@ -451,6 +473,10 @@ func (s *Stack) Refresh(ctx context.Context, opts ...optrefresh.Option) (Refresh
 		o.ApplyOption(refreshOpts)
 	}
 
+	if ew, ok := s.workspace.(EngineWorkspace); ok {
+		return ew.Refresh(ctx, s.Name(), refreshOpts)
+	}
+
 	args := slice.Prealloc[string](len(refreshOpts.Target))
 
 	args = debug.AddArgs(&refreshOpts.DebugLogOpts, args)
@@ -473,6 +499,9 @@ func (s *Stack) Refresh(ctx context.Context, opts ...optrefresh.Option) (Refresh
 	if refreshOpts.Color != "" {
 		args = append(args, fmt.Sprintf("--color=%s", refreshOpts.Color))
 	}
+	if refreshOpts.SummaryFile != "" {
+		args = append(args, fmt.Sprintf("--summary-file=%s", refreshOpts.SummaryFile))
+	}
 	execKind := constant.ExecKindAutoLocal
 	if s.Workspace().Program() != nil {
 		execKind = constant.ExecKindAutoInline
@@ -539,6 +568,10 @@ func (s *Stack) Destroy(ctx context.Context, opts ...optdestroy.Option) (Destroy
 		o.ApplyOption(destroyOpts)
 	}
 
+	if ew, ok := s.workspace.(EngineWorkspace); ok {
+		return ew.Destroy(ctx, s.Name(), destroyOpts)
+	}
+
 	args := slice.Prealloc[string](len(destroyOpts.Target))
 
 	args = debug.AddArgs(&destroyOpts.DebugLogOpts, args)
@@ -561,6 +594,9 @@ func (s *Stack) Destroy(ctx context.Context, opts ...optdestroy.Option) (Destroy
 	if destroyOpts.Color != "" {
 		args = append(args, fmt.Sprintf("--color=%s", destroyOpts.Color))
 	}
+	if destroyOpts.SummaryFile != "" {
+		args = append(args, fmt.Sprintf("--summary-file=%s", destroyOpts.SummaryFile))
+	}
 	execKind := constant.ExecKindAutoLocal
 	if s.Workspace().Program() != nil {
 		execKind = constant.ExecKindAutoInline
@@ -632,6 +668,9 @@ func (s *Stack) History(ctx context.Context,
 	for _, opt := range opts {
 		opt.ApplyOption(&options)
 	}
+	if ew, ok := s.workspace.(EngineWorkspace); ok {
+		return ew.History(ctx, s.Name(), pageSize, page, &options)
+	}
 	showSecrets := true
 	if options.ShowSecrets != nil {
 		showSecrets = *options.ShowSecrets
@@ -796,6 +835,246 @@ func (s *Stack) Import(ctx context.Context, state apitype.UntypedDeployment) err
 	return s.Workspace().ImportStack(ctx, s.Name(), state)
 }
 
+// Rename renames the stack, along with its stack config file. The stack's project can also be renamed by passing a
+// fully-qualified stack name, such as "org/new-project/stack".
+func (s *Stack) Rename(ctx context.Context, newName string) error {
+	stdout, stderr, errCode, err := s.runPulumiCmdSync(
+		ctx,
+		nil, /* additionalOutput */
+		nil, /* additionalErrorOutput */
+		"stack", "rename", newName)
+	if err != nil {
+		return newAutoError(fmt.Errorf("failed to rename stack: %w", err), stdout, stderr, errCode)
+	}
+
+	s.stackName = newName
+	return nil
+}
+
+// ChangeSecretsProvider changes the secrets provider of the stack, encrypting the secrets in its config and state
+// again with the new secrets provider. See `pulumi stack change-secrets-provider` for the supported providers.
+func (s *Stack) ChangeSecretsProvider(
+	ctx context.Context, newSecretsProvider string, opts ...optchangesecretsprovider.Option,
+) error {
+	changeOpts := &optchangesecretsprovider.Options{}
+	for _, o := range opts {
+		o.ApplyOption(changeOpts)
+	}
+
+	var stdin io.Reader
+	var env []string
+	if changeOpts.NewPassphrase != nil {
+		// The CLI reads the new passphrase from stdin when it replaces the stack's current passphrase, which it reads
+		// from the environment. When the stack doesn't use a passphrase yet, the CLI reads the new passphrase from the
+		// environment instead, where it doesn't replace anything.
+		stdin = strings.NewReader(*changeOpts.NewPassphrase + "\n")
+		var current string
+		if settings, err := s.Workspace().StackSettings(ctx, s.Name()); err == nil {
+			current = settings.SecretsProvider
+		}
+		rotate := newSecretsProvider == current || (newSecretsProvider == "passphrase" && current == "")
+		if !rotate {
+			env = append(env, "PULUMI_CONFIG_PASSPHRASE="+*changeOpts.NewPassphrase)
+		}
+	}
+
+	stdout, stderr, errCode, err := s.runPulumiCmdSyncWithInput(
+		ctx,
+		stdin,
+		env,
+		nil, /* additionalOutput */
+		nil, /* additionalErrorOutput */
+		"stack", "change-secrets-provider", newSecretsProvider)
+	if err != nil {
+		return newAutoError(fmt.Errorf("failed to change secrets provider: %w", err), stdout, stderr, errCode)
+	}
+
+	return nil
+}
+
+// StateDelete deletes a resource from the stack's state, without deleting the resource in its cloud provider.
+// It fails if the resource is protected or other resources depend on it, unless the options say otherwise.
+func (s *Stack) StateDelete(ctx context.Context, urn string, opts ...optstatedelete.Option) error {
+	deleteOpts := &optstatedelete.Options{}
+	for _, o := range opts {
+		o.ApplyOption(deleteOpts)
+	}
+
+	args := []string{"state", "delete", urn, "--yes"}
+	if deleteOpts.Force {
+		args = append(args, "--force")
+	}
+	if deleteOpts.TargetDependents {
+		args = append(args, "--target-dependents")
+	}
+
+	stdout, stderr, errCode, err := s.runPulumiCmdSync(
+		ctx,
+		nil, /* additionalOutput */
+		nil, /* additionalErrorOutput */
+		args...)
+	if err != nil {
+		return newAutoError(fmt.Errorf("failed to delete resource from state: %w", err), stdout, stderr, errCode)
+	}
+
+	return nil
+}
+
+// StateUnprotect removes the protection from a resource in the stack's state, so that it can be deleted.
+func (s *Stack) StateUnprotect(ctx context.Context, urn string) error {
+	stdout, stderr, errCode, err := s.runPulumiCmdSync(
+		ctx,
+		nil, /* additionalOutput */
+		nil, /* additionalErrorOutput */
+		"state", "unprotect", urn, "--yes")
+	if err != nil {
+		return newAutoError(fmt.Errorf("failed to unprotect resource: %w", err), stdout, stderr, errCode)
+	}
+
+	return nil
+}
+
+// StateUnprotectAll removes the protection from all the resources in the stack's state.
+func (s *Stack) StateUnprotectAll(ctx context.Context) error {
+	stdout, stderr, errCode, err := s.runPulumiCmdSync(
+		ctx,
+		nil, /* additionalOutput */
+		nil, /* additionalErrorOutput */
+		"state", "unprotect", "--all", "--yes")
+	if err != nil {
+		return newAutoError(fmt.Errorf("failed to unprotect resources: %w", err), stdout, stderr, errCode)
+	}
+
+	return nil
+}
+
+// StateRename renames a resource in the stack's state, and updates the references to it from other resources.
+func (s *Stack) StateRename(ctx context.Context, urn string, newName string) error {
+	stdout, stderr, errCode, err := s.runPulumiCmdSync(
+		ctx,
+		nil, /* additionalOutput */
+		nil, /* additionalErrorOutput */
+		"state", "rename", urn, newName, "--yes")
+	if err != nil {
+		return newAutoError(fmt.Errorf("failed to rename resource: %w", err), stdout, stderr, errCode)
+	}
+
+	return nil
+}
+
+// ImportSpec describes an existing cloud resource to import into a stack with Stack.ImportResources.
+type ImportSpec struct {
+	// Type is the type token of the resource, e.g. "aws:s3/bucket:Bucket".
+	Type string `json:"type"`
+	// Name is the name to give the resource in the stack.
+	Name string `json:"name"`
+	// ID is the ID of the resource in its cloud provider.
+	ID string `json:"id"`
+	// Parent is the name of the resource's parent in the name table, if any.
+	Parent string `json:"parent,omitempty"`
+	// Provider is the name of the resource's provider in the name table, if any.
+	Provider string `json:"provider,omitempty"`
+	// Version is the version of the provider plugin to use, if any.
+	Version string `json:"version,omitempty"`
+	// PluginDownloadURL is the URL to download the provider plugin from, if any.
+	PluginDownloadURL string `json:"pluginDownloadUrl,omitempty"`
+	// Properties are the names of the resource's properties to import. Defaults to its required inputs.
+	Properties []string `json:"properties,omitempty"`
+}
+
+// importFile is the format of the file read by `pulumi import --file`.
+type importFile struct {
+	NameTable map[string]string `json:"nameTable,omitempty"`
+	Resources []ImportSpec      `json:"resources"`
+}
+
+// ImportResources imports existing cloud resources into the stack, so that they are managed by Pulumi from then on.
+func (s *Stack) ImportResources(
+	ctx context.Context, resources []ImportSpec, opts ...optimport.Option,
+) (ImportResult, error) {
+	var res ImportResult
+
+	importOpts := &optimport.Options{}
+	for _, o := range opts {
+		o.ApplyOption(importOpts)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "automation-import-")
+	if err != nil {
+		return res, fmt.Errorf("failed to import resources: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	importFilePath := filepath.Join(tmpDir, "import.json")
+	b, err := json.Marshal(importFile{NameTable: importOpts.NameTable, Resources: resources})
+	if err != nil {
+		return res, fmt.Errorf("failed to import resources: %w", err)
+	}
+	if err = os.WriteFile(importFilePath, b, 0o600); err != nil {
+		return res, fmt.Errorf("failed to import resources: %w", err)
+	}
+
+	args := debug.AddArgs(&importOpts.DebugLogOpts, nil)
+	args = append(args, "import", "--yes", "--skip-preview", "--file", importFilePath)
+	if importOpts.Message != "" {
+		args = append(args, fmt.Sprintf("--message=%q", importOpts.Message))
+	}
+	if importOpts.Protect != nil {
+		args = append(args, fmt.Sprintf("--protect=%t", *importOpts.Protect))
+	}
+	codeFilePath := filepath.Join(tmpDir, "code")
+	if importOpts.GenerateCode {
+		args = append(args, "--out", codeFilePath)
+	} else {
+		args = append(args, "--generate-code=false")
+	}
+	if importOpts.UserAgent != "" {
+		args = append(args, fmt.Sprintf("--exec-agent=%s", importOpts.UserAgent))
+	}
+	execKind := constant.ExecKindAutoLocal
+	if s.Workspace().Program() != nil {
+		execKind = constant.ExecKindAutoInline
+	}
+	args = append(args, fmt.Sprintf("--exec-kind=%s", execKind))
+
+	stdout, stderr, code, err := s.runPulumiCmdSync(
+		ctx,
+		importOpts.ProgressStreams,      /* additionalOutputs */
+		importOpts.ErrorProgressStreams, /* additionalErrorOutputs */
+		args...,
+	)
+	if err != nil {
+		return res, newAutoError(fmt.Errorf("failed to import resources: %w", err), stdout, stderr, code)
+	}
+
+	var generatedCode []byte
+	if importOpts.GenerateCode {
+		generatedCode, err = os.ReadFile(codeFilePath)
+		if err != nil {
+			return res, fmt.Errorf("failed to read generated code: %w", err)
+		}
+	}
+
+	history, err := s.History(ctx, 1 /*pageSize*/, 1 /*page*/)
+	if err != nil {
+		return res, fmt.Errorf("failed to import resources: %w", err)
+	}
+
+	var summary UpdateSummary
+	if len(history) > 0 {
+		summary = history[0]
+	}
+
+	res = ImportResult{
+		StdOut:        stdout,
+		StdErr:        stderr,
+		GeneratedCode: string(generatedCode),
+		Summary:       summary,
+	}
+
+	return res, nil
+}
+
 // UpdateSummary provides a summary of a Stack lifecycle operation (up/preview/refresh/destroy).
 type UpdateSummary struct {
 	Version     int               `json:"version"`
@@ -824,6 +1103,8 @@ type UpResult struct {
 	StdErr  string
 	Outputs OutputMap
 	Summary UpdateSummary
+	// Steps are the changes made to each resource, in the order that they were performed.
+	Steps []ResourceStep
 }
 
 // GetPermalink returns the permalink URL in the Pulumi Console for the update operation.
@@ -888,11 +1169,38 @@ type PropertyDiff struct {
 	InputDiff bool `json:"inputDiff"`
 }
 
+// ResourceStep describes the change that an operation makes, or plans to make, to a single resource. It is assembled
+// from the engine events of the operation.
+type ResourceStep struct {
+	// URN is the resource being affected by this step.
+	URN resource.URN
+	// Type is the type of the resource.
+	Type string
+	// Op is the kind of operation being performed.
+	Op apitype.OpType
+	// Provider is the provider that performs this step.
+	Provider string
+	// Old is the state of the resource before this step, if appropriate given the operation type.
+	Old *apitype.StepEventStateMetadata
+	// New is the state of the resource after this step, if appropriate given the operation type.
+	New *apitype.StepEventStateMetadata
+	// Diffs is a list of the top-level properties that change with this step.
+	Diffs []string
+	// ReplaceReasons is a list of the properties that cause the resource to be replaced (for replacement steps only).
+	ReplaceReasons []string
+	// DetailedDiff is a structured diff that indicates precise per-property differences.
+	DetailedDiff map[string]apitype.PropertyDiff
+	// PolicyViolations are the policy violations reported for the resource.
+	PolicyViolations []apitype.PolicyEvent
+}
+
 // PreviewResult is the output of Stack.Preview() describing the expected set of changes from the next Stack.Up()
 type PreviewResult struct {
 	StdOut        string
 	StdErr        string
 	ChangeSummary map[apitype.OpType]int
+	// Steps are the changes planned for each resource, in the order that they were planned.
+	Steps []ResourceStep
 }
 
 // GetPermalink returns the permalink URL in the Pulumi Console for the preview operation.
@@ -924,6 +1232,21 @@ func (dr *DestroyResult) GetPermalink() (string, error) {
 	return GetPermalink(dr.StdOut)
 }
 
+// ImportResult is the output of a successful Stack.ImportResources operation
+type ImportResult struct {
+	StdOut string
+	StdErr string
+	// GeneratedCode is the resource declaration code for the imported resources, if it was requested with
+	// optimport.GenerateCode.
+	GeneratedCode string
+	Summary       UpdateSummary
+}
+
+// GetPermalink returns the permalink URL in the Pulumi Console for the import operation.
+func (ir *ImportResult) GetPermalink() (string, error) {
+	return GetPermalink(ir.StdOut)
+}
+
 // secretSentinel represents the CLI response for an output marked as "secret"
 const secretSentinel = "[secret]"
 
@@ -932,6 +1255,20 @@ func (s *Stack) runPulumiCmdSync(
 	additionalOutput []io.Writer,
 	additionalErrorOutput []io.Writer,
 	args ...string,
+) (string, string, int, error) {
+	return s.runPulumiCmdSyncWithInput(ctx, nil /* stdin */, nil /* additionalEnv */, additionalOutput,
+		additionalErrorOutput, args...)
+}
+
+// runPulumiCmdSyncWithInput is like runPulumiCmdSync, but gives the command the given stdin and sets the given
+// environment variables for the command in addition to the workspace's.
+func (s *Stack) runPulumiCmdSyncWithInput(
+	ctx context.Context,
+	stdin io.Reader,
+	additionalEnv []string,
+	additionalOutput []io.Writer,
+	additionalErrorOutput []io.Writer,
+	args ...string,
 ) (string, string, int, error) {
 	var env []string
 	debugEnv := fmt.Sprintf("%s=%s", "PULUMI_DEBUG_COMMANDS", "true")
@@ -956,6 +1293,7 @@ func (s *Stack) runPulumiCmdSync(
 			env = append(env, strings.Join(e, "="))
 		}
 	}
+	env = append(env, additionalEnv...)
 	additionalArgs, err := s.Workspace().SerializeArgsForOp(ctx, s.Name())
 	if err != nil {
 		return "", "", -1, fmt.Errorf("failed to exec command, error getting additional args: %w", err)
@@ -966,6 +1304,7 @@ func (s *Stack) runPulumiCmdSync(
 	stdout, stderr, errCode, err := runPulumiCommandSync(
 		ctx,
 		s.Workspace().WorkDir(),
+		stdin,
 		additionalOutput,
 		additionalErrorOutput,
 		env,
@@ -1223,6 +1562,77 @@ func (s *languageRuntimeServer) InstallDependencies(
 	return nil
 }
 
+// stepCollector assembles the resource steps of an operation from its engine events, which are sent to its events
+// channel. The channel must be closed once the operation ends.
+type stepCollector struct {
+	events chan events.EngineEvent
+	done   chan bool
+
+	steps     []ResourceStep
+	index     map[string]int
+	policies  map[resource.URN][]apitype.PolicyEvent
+	summaries []apitype.SummaryEvent
+}
+
+func newStepCollector() *stepCollector {
+	c := &stepCollector{
+		events:   make(chan events.EngineEvent),
+		done:     make(chan bool),
+		index:    map[string]int{},
+		policies: map[resource.URN][]apitype.PolicyEvent{},
+	}
+	go func() {
+		defer close(c.done)
+		for e := range c.events {
+			switch {
+			case e.ResourcePreEvent != nil:
+				c.addStep(e.ResourcePreEvent.Metadata)
+			case e.ResOutputsEvent != nil:
+				c.addStep(e.ResOutputsEvent.Metadata)
+			case e.PolicyEvent != nil:
+				urn := resource.URN(e.PolicyEvent.ResourceURN)
+				c.policies[urn] = append(c.policies[urn], *e.PolicyEvent)
+			case e.SummaryEvent != nil:
+				c.summaries = append(c.summaries, *e.SummaryEvent)
+			}
+		}
+	}()
+	return c
+}
+
+// addStep records the metadata of a step. A step is reported both before and after it is performed, and only the
+// states reported afterwards include the resource's outputs, so they replace the states of a step already recorded.
+func (c *stepCollector) addStep(metadata apitype.StepEventMetadata) {
+	step := ResourceStep{
+		URN:            resource.URN(metadata.URN),
+		Type:           metadata.Type,
+		Op:             metadata.Op,
+		Provider:       metadata.Provider,
+		Old:            metadata.Old,
+		New:            metadata.New,
+		Diffs:          metadata.Diffs,
+		ReplaceReasons: metadata.Keys,
+		DetailedDiff:   metadata.DetailedDiff,
+	}
+
+	key := string(metadata.Op) + "\x00" + metadata.URN
+	if i, ok := c.index[key]; ok {
+		c.steps[i].Old, c.steps[i].New = step.Old, step.New
+		return
+	}
+	c.index[key] = len(c.steps)
+	c.steps = append(c.steps, step)
+}
+
+// Steps waits for the events channel to be closed and returns the steps of the operation.
+func (c *stepCollector) Steps() []ResourceStep {
+	<-c.done
+	for i := range c.steps {
+		c.steps[i].PolicyViolations = c.policies[c.steps[i].URN]
+	}
+	return c.steps
+}
+
 type fileWatcher struct {
 	Filename  string
 	tail      *tail.Tail
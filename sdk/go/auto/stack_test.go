@@ -20,8 +20,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optpreview"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optup"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -61,6 +63,53 @@ func TestGetPermalink(t *testing.T) {
 	}
 }
 
+func TestStepCollector(t *testing.T) {
+	t.Parallel()
+
+	const urn = "urn:pulumi:dev::proj::random:index/randomString:RandomString::str"
+	diff := map[string]apitype.PropertyDiff{"length": {Kind: apitype.DiffUpdateReplace, InputDiff: true}}
+
+	c := newStepCollector()
+	c.events <- events.EngineEvent{EngineEvent: apitype.EngineEvent{PolicyEvent: &apitype.PolicyEvent{
+		ResourceURN: urn,
+		PolicyName:  "no-short-strings",
+	}}}
+	for _, op := range []apitype.OpType{apitype.OpReplace, apitype.OpCreateReplacement} {
+		c.events <- events.EngineEvent{EngineEvent: apitype.EngineEvent{ResourcePreEvent: &apitype.ResourcePreEvent{
+			Metadata: apitype.StepEventMetadata{
+				Op:           op,
+				URN:          urn,
+				Keys:         []string{"length"},
+				Diffs:        []string{"length"},
+				DetailedDiff: diff,
+				New:          &apitype.StepEventStateMetadata{URN: urn},
+			},
+		}}}
+	}
+	c.events <- events.EngineEvent{EngineEvent: apitype.EngineEvent{ResOutputsEvent: &apitype.ResOutputsEvent{
+		Metadata: apitype.StepEventMetadata{
+			Op:  apitype.OpCreateReplacement,
+			URN: urn,
+			New: &apitype.StepEventStateMetadata{URN: urn, ID: "new-id"},
+		},
+	}}}
+	c.events <- events.EngineEvent{EngineEvent: apitype.EngineEvent{SummaryEvent: &apitype.SummaryEvent{}}}
+	close(c.events)
+
+	steps := c.Steps()
+	require.Len(t, steps, 2)
+	assert.Len(t, c.summaries, 1)
+	for i, op := range []apitype.OpType{apitype.OpReplace, apitype.OpCreateReplacement} {
+		assert.Equal(t, op, steps[i].Op)
+		assert.Equal(t, []string{"length"}, steps[i].ReplaceReasons)
+		assert.Equal(t, diff, steps[i].DetailedDiff)
+		require.Len(t, steps[i].PolicyViolations, 1)
+		assert.Equal(t, "no-short-strings", steps[i].PolicyViolations[0].PolicyName)
+	}
+	assert.Equal(t, "", steps[0].New.ID)
+	assert.Equal(t, "new-id", steps[1].New.ID)
+}
+
 func TestUpdatePlans(t *testing.T) {
 	t.Parallel()
 
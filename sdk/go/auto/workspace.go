@@ -17,7 +17,12 @@ package auto
 import (
 	"context"
 
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optdestroy"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/opthistory"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optpreview"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optrefresh"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optremove"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/optup"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 
@@ -140,6 +145,28 @@ type Workspace interface {
 	StackOutputs(context.Context, string) (OutputMap, error)
 }
 
+// EngineWorkspace is a Workspace that runs stack lifecycle operations itself rather than with the Pulumi CLI,
+// for example by hosting the Pulumi engine in the current process.
+// When a Stack's Workspace implements EngineWorkspace, Stack.Preview, Stack.Up, Stack.Refresh, Stack.Destroy and
+// Stack.History call the matching method of the Workspace with the stack name and the options they were given.
+// Operations must send their engine events to the EventStreams of their options and close the streams once the
+// operation ends, whether or not it succeeds; Stack.Preview and Stack.Up use the events to fill in the result's Steps.
+type EngineWorkspace interface {
+	Workspace
+
+	// Preview performs a dry-run update to the stack matching the specified stack name.
+	Preview(context.Context, string, *optpreview.Options) (PreviewResult, error)
+	// Up creates or updates the resources in the stack matching the specified stack name.
+	Up(context.Context, string, *optup.Options) (UpResult, error)
+	// Refresh refreshes the state of the stack matching the specified stack name.
+	Refresh(context.Context, string, *optrefresh.Options) (RefreshResult, error)
+	// Destroy deletes all resources in the stack matching the specified stack name.
+	Destroy(context.Context, string, *optdestroy.Options) (DestroyResult, error)
+	// History returns a page of the update history of the stack matching the specified stack name, newest first.
+	// A page size of 0 returns the whole history.
+	History(context.Context, string, int, int, *opthistory.Options) ([]UpdateSummary, error)
+}
+
 // ConfigValue is a configuration value used by a Pulumi program.
 // Allows differentiating between secret and plaintext values by setting the `Secret` property.
 type ConfigValue struct {
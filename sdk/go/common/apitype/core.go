@@ -335,6 +335,10 @@ type ResourceV3 struct {
 	Modified *time.Time `json:"modified,omitempty" yaml:"modified,omitempty"`
 	// SourcePosition tracks the source location of this resource's registration
 	SourcePosition string `json:"sourcePosition,omitempty" yaml:"sourcePosition,omitempty"`
+	// RetryPolicy controls how transient failures of this resource's provider operations are retried.
+	RetryPolicy *resource.RetryPolicy `json:"retryPolicy,omitempty" yaml:"retryPolicy,omitempty"`
+	// DeleteHooks names the resource hooks to run around the deletion of this resource.
+	DeleteHooks *resource.DeleteHooks `json:"deleteHooks,omitempty" yaml:"deleteHooks,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
@@ -138,6 +138,71 @@ func (m Map) Get(k Key, path bool) (Value, bool, error) {
 	return NewObjectValue(string(json)), true, nil
 }
 
+// Merge returns a new map with the values of other layered over the values of m. Where both maps have an object
+// value for the same key, the objects are merged recursively; otherwise the value from other replaces the value from m.
+func (m Map) Merge(other Map) (Map, error) {
+	result := make(Map, len(m)+len(other))
+	for k, v := range m {
+		result[k] = v
+	}
+	for k, v := range other {
+		base, has := result[k]
+		if !has || !base.Object() || !v.Object() {
+			result[k] = v
+			continue
+		}
+
+		baseObj, err := base.ToObject()
+		if err != nil {
+			return nil, err
+		}
+		obj, err := v.ToObject()
+		if err != nil {
+			return nil, err
+		}
+		merged := mergeObjects(baseObj, obj)
+		json, err := json.Marshal(merged)
+		if err != nil {
+			return nil, err
+		}
+		if hasSecureValue(merged) {
+			result[k] = NewSecureObjectValue(string(json))
+		} else {
+			result[k] = NewObjectValue(string(json))
+		}
+	}
+	return result, nil
+}
+
+// mergeObjects merges override into base, recursing into the maps they both have under the same key. Secure values
+// are never merged.
+func mergeObjects(base, override interface{}) interface{} {
+	baseMap, baseIsMap := base.(map[string]interface{})
+	overrideMap, overrideIsMap := override.(map[string]interface{})
+	if !baseIsMap || !overrideIsMap {
+		return override
+	}
+	if is, _ := isSecureValue(baseMap); is {
+		return override
+	}
+	if is, _ := isSecureValue(overrideMap); is {
+		return override
+	}
+
+	merged := make(map[string]interface{}, len(baseMap)+len(overrideMap))
+	for k, v := range baseMap {
+		merged[k] = v
+	}
+	for k, v := range overrideMap {
+		if b, has := merged[k]; has {
+			merged[k] = mergeObjects(b, v)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
 // Remove removes the value for a given key. If path is true, the key's name portion is treated as a path.
 func (m Map) Remove(k Key, path bool) error {
 	// If the key isn't a path, go ahead and delete it and return.
@@ -1392,3 +1392,36 @@ func roundtripMap(m Map, marshal func(v interface{}) ([]byte, error),
 	err = unmarshal(b, &newM)
 	return newM, err
 }
+
+func TestMergeMap(t *testing.T) {
+	t.Parallel()
+
+	base := Map{
+		MustMakeKey("my", "name"):   NewValue("base"),
+		MustMakeKey("my", "region"): NewValue("us-west-2"),
+		MustMakeKey("my", "db"): NewObjectValue(
+			`{"host":"db.internal","port":5432,"tags":{"tier":"shared"},"password":{"secure":"c2VjcmV0"}}`),
+		MustMakeKey("my", "list"): NewObjectValue(`["a","b"]`),
+	}
+	override := Map{
+		MustMakeKey("my", "name"): NewValue("dev"),
+		MustMakeKey("my", "db"):   NewObjectValue(`{"port":6543,"tags":{"env":"dev"}}`),
+		MustMakeKey("my", "list"): NewObjectValue(`["c"]`),
+		MustMakeKey("my", "new"):  NewSecureValue("c2VjcmV0"),
+	}
+
+	merged, err := base.Merge(override)
+	assert.NoError(t, err)
+	assert.Equal(t, Map{
+		MustMakeKey("my", "name"):   NewValue("dev"),
+		MustMakeKey("my", "region"): NewValue("us-west-2"),
+		MustMakeKey("my", "db"): NewSecureObjectValue(
+			`{"host":"db.internal","password":{"secure":"c2VjcmV0"},"port":6543,"tags":{"env":"dev","tier":"shared"}}`),
+		MustMakeKey("my", "list"): NewObjectValue(`["c"]`),
+		MustMakeKey("my", "new"):  NewSecureValue("c2VjcmV0"),
+	}, merged)
+
+	// Neither of the original maps is modified.
+	assert.Equal(t, NewValue("base"), base[MustMakeKey("my", "name")])
+	assert.Len(t, override, 4)
+}
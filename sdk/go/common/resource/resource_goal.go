@@ -43,7 +43,11 @@ type Goal struct {
 	// if set, the providers Delete method will not be called for this resource
 	// if specified resource is being deleted as well.
 	DeletedWith    URN
-	SourcePosition string // If set, the source location of the resource registration
+	SourcePosition string       // If set, the source location of the resource registration
+	RetryPolicy    *RetryPolicy // an optional policy for retrying transient failures of provider operations.
+	// the hooks to run around the resource's lifecycle operations. Only the names of the delete hooks are persisted in
+	// the resource's state.
+	Hooks *ResourceHooks
 }
 
 // NewGoal allocates a new resource goal state.
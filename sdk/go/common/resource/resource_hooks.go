@@ -0,0 +1,83 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// ResourceHookArgs are the arguments passed to a resource hook. Which of the inputs and outputs are set depends on the
+// operation: create hooks only see new state, delete hooks only see old state, and before hooks never see new outputs.
+type ResourceHookArgs struct {
+	URN        URN         // the URN of the resource.
+	ID         ID          // the ID of the resource, if it has one.
+	Type       tokens.Type // the type of the resource.
+	NewInputs  PropertyMap // the new inputs of the resource, if any.
+	OldInputs  PropertyMap // the old inputs of the resource, if any.
+	NewOutputs PropertyMap // the new outputs of the resource, if any.
+	OldOutputs PropertyMap // the old outputs of the resource, if any.
+}
+
+// ResourceHookFunc is the callback of a resource hook.
+type ResourceHookFunc func(ctx context.Context, args ResourceHookArgs) error
+
+// ResourceHook is a named callback that the engine runs around a lifecycle operation of a resource.
+type ResourceHook struct {
+	Name     string           // the unique name of the hook.
+	Callback ResourceHookFunc // the callback to run.
+}
+
+// ResourceHooks are the hooks to run around each of the lifecycle operations of a resource.
+type ResourceHooks struct {
+	BeforeCreate []ResourceHook // the hooks to run before the resource is created.
+	AfterCreate  []ResourceHook // the hooks to run after the resource is created.
+	BeforeUpdate []ResourceHook // the hooks to run before the resource is updated.
+	AfterUpdate  []ResourceHook // the hooks to run after the resource is updated.
+	BeforeDelete []ResourceHook // the hooks to run before the resource is deleted.
+	AfterDelete  []ResourceHook // the hooks to run after the resource is deleted.
+}
+
+// DeleteHookNames returns the names of the hooks to run around the deletion of the resource, or nil if there are none.
+func (h *ResourceHooks) DeleteHookNames() *DeleteHooks {
+	if h == nil || len(h.BeforeDelete) == 0 && len(h.AfterDelete) == 0 {
+		return nil
+	}
+	names := func(hooks []ResourceHook) []string {
+		var names []string
+		for _, hook := range hooks {
+			names = append(names, hook.Name)
+		}
+		return names
+	}
+	return &DeleteHooks{Before: names(h.BeforeDelete), After: names(h.AfterDelete)}
+}
+
+// DeleteHooks names the resource hooks to run around the deletion of a resource. Unlike the resource's other hooks,
+// their names are persisted in the resource's state, so that a deployment that deletes the resource after the program
+// has stopped registering it knows that it has hooks that can't run.
+type DeleteHooks struct {
+	Before []string `json:"before,omitempty" yaml:"before,omitempty"` // the hooks to run before the resource is deleted.
+	After  []string `json:"after,omitempty" yaml:"after,omitempty"`   // the hooks to run after the resource is deleted.
+}
+
+// Names returns the names of all of the delete hooks.
+func (h *DeleteHooks) Names() []string {
+	if h == nil {
+		return nil
+	}
+	return append(append([]string{}, h.Before...), h.After...)
+}
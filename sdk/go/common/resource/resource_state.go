@@ -51,6 +51,8 @@ type State struct {
 	Created                 *time.Time            // If set, the time when the state was initially added to the state file. (i.e. Create, Import)
 	Modified                *time.Time            // If set, the time when the state was last modified in the state file.
 	SourcePosition          string                // If set, the source location of the resource registration
+	RetryPolicy             *RetryPolicy          // an optional policy for retrying transient failures of provider operations.
+	DeleteHooks             *DeleteHooks          // the names of the hooks to run around the resource's deletion, if any.
 }
 
 func (s *State) GetAliasURNs() []URN {
@@ -0,0 +1,100 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// RetryPolicy controls how the engine retries the provider operations of a resource that fail with a transient error,
+// such as throttling or a temporary network problem. Fields left unset fall back to the engine's defaults.
+//
+//nolint:lll
+type RetryPolicy struct {
+	MaxAttempts     int      `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`         // the maximum number of attempts, including the first one.
+	Delay           float64  `json:"delay,omitempty" yaml:"delay,omitempty"`                     // the delay before the first retry, in seconds.
+	Backoff         float64  `json:"backoff,omitempty" yaml:"backoff,omitempty"`                 // the multiplier applied to the delay after each retry.
+	MaxDelay        float64  `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`               // the maximum delay between retries, in seconds.
+	RetryableCodes  []string `json:"retryableCodes,omitempty" yaml:"retryableCodes,omitempty"`   // the gRPC status codes that are retryable.
+	RetryableErrors []string `json:"retryableErrors,omitempty" yaml:"retryableErrors,omitempty"` // patterns matching retryable error messages.
+}
+
+// NewRetryPolicy creates a retry policy from its configured form, where delays are duration strings such as "1s" and
+// retryable errors are regular expressions.
+func NewRetryPolicy(maxAttempts int, delay string, backoff float64, maxDelay string,
+	retryableCodes []string, retryableErrors []string,
+) (*RetryPolicy, error) {
+	if maxAttempts < 0 {
+		return nil, fmt.Errorf("maxAttempts must not be negative, got %d", maxAttempts)
+	}
+	if backoff != 0 && backoff < 1 {
+		return nil, fmt.Errorf("backoff must be at least 1, got %v", backoff)
+	}
+
+	parseDelay := func(name, value string) (float64, error) {
+		if value == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("%s must be a positive duration such as \"5s\", got %q", name, value)
+		}
+		return d.Seconds(), nil
+	}
+	delaySeconds, err := parseDelay("delay", delay)
+	if err != nil {
+		return nil, err
+	}
+	maxDelaySeconds, err := parseDelay("maxDelay", maxDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range retryableCodes {
+		if _, ok := ParseRetryableCode(name); !ok {
+			return nil, fmt.Errorf("unknown gRPC status code %q", name)
+		}
+	}
+	for _, pattern := range retryableErrors {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid retryable error pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &RetryPolicy{
+		MaxAttempts:     maxAttempts,
+		Delay:           delaySeconds,
+		Backoff:         backoff,
+		MaxDelay:        maxDelaySeconds,
+		RetryableCodes:  retryableCodes,
+		RetryableErrors: retryableErrors,
+	}, nil
+}
+
+// ParseRetryableCode parses the name of a gRPC status code, such as "Unavailable" or "RESOURCE_EXHAUSTED".
+func ParseRetryableCode(name string) (codes.Code, bool) {
+	normalized := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.ToLower(c.String()) == normalized {
+			return c, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,66 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := NewRetryPolicy(5, "500ms", 1.5, "1m", []string{"Unavailable"}, []string{"(?i)throttl"})
+	require.NoError(t, err)
+	assert.Equal(t, &RetryPolicy{
+		MaxAttempts:     5,
+		Delay:           0.5,
+		Backoff:         1.5,
+		MaxDelay:        60,
+		RetryableCodes:  []string{"Unavailable"},
+		RetryableErrors: []string{"(?i)throttl"},
+	}, policy)
+
+	_, err = NewRetryPolicy(-1, "", 0, "", nil, nil)
+	assert.ErrorContains(t, err, "maxAttempts must not be negative")
+	_, err = NewRetryPolicy(3, "soon", 0, "", nil, nil)
+	assert.ErrorContains(t, err, "delay must be a positive duration")
+	_, err = NewRetryPolicy(3, "", 0.5, "", nil, nil)
+	assert.ErrorContains(t, err, "backoff must be at least 1")
+	_, err = NewRetryPolicy(3, "", 0, "", []string{"Throttled"}, nil)
+	assert.ErrorContains(t, err, `unknown gRPC status code "Throttled"`)
+	_, err = NewRetryPolicy(3, "", 0, "", nil, []string{"("})
+	assert.ErrorContains(t, err, "invalid retryable error pattern")
+}
+
+func TestParseRetryableCode(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]codes.Code{
+		"Unavailable":        codes.Unavailable,
+		"RESOURCE_EXHAUSTED": codes.ResourceExhausted,
+		"deadlineExceeded":   codes.DeadlineExceeded,
+	} {
+		code, ok := ParseRetryableCode(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, code, name)
+	}
+
+	_, ok := ParseRetryableCode("Throttled")
+	assert.False(t, ok)
+}
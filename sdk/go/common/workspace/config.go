@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 )
 
@@ -45,6 +46,23 @@ func missingStackConfigurationKeysError(missingKeys []string, stackName string)
 		formatMissingKeys(missingKeys))
 }
 
+// InvalidStackConfigValueError is returned when a stack's value for a key doesn't match the type or constraints that
+// the project's config declares for the key.
+type InvalidStackConfigValueError struct {
+	// Key is the stack config key whose value is invalid.
+	Key config.Key
+
+	err error
+}
+
+func (err *InvalidStackConfigValueError) Error() string {
+	return err.err.Error()
+}
+
+func (err *InvalidStackConfigValueError) Unwrap() error {
+	return err.err
+}
+
 type (
 	StackName        = string
 	ProjectConfigKey = string
@@ -84,13 +102,14 @@ func validateStackConfigValue(
 		}
 	}
 
-	if !ValidateConfigValue(*projectConfigType.Type, projectConfigType.Items, content) {
-		typeName := InferFullTypeName(*projectConfigType.Type, projectConfigType.Items)
+	invalid := checkConfigValue("", *projectConfigType.Type, projectConfigType.Items,
+		&projectConfigType.ProjectConfigConstraints, content)
+	if invalid != nil {
 		validationError := fmt.Errorf(
-			"Stack '%v' with configuration key '%v' must be of type '%v'",
+			"Stack '%v' with configuration key '%v' %v",
 			stackName,
-			projectConfigKey,
-			typeName)
+			projectConfigKey+invalid.path,
+			invalid.message)
 
 		return validationError
 	}
@@ -114,6 +133,18 @@ func createConfigValue(rawValue interface{}) (config.Value, error) {
 	return config.NewObjectValue(string(configValueJSON)), nil
 }
 
+// projectConfigKeyToKey returns the stack config key for the given key of the project's config.
+func projectConfigKeyToKey(project *Project, projectConfigKey string) (config.Key, error) {
+	if strings.Contains(projectConfigKey, ":") {
+		// key is already namespaced
+		return config.ParseKey(projectConfigKey)
+	}
+
+	// key is not namespaced
+	// use the project as default namespace
+	return config.MustMakeKey(project.Name.String(), projectConfigKey), nil
+}
+
 func mergeConfig(
 	stackName string,
 	project *Project,
@@ -133,19 +164,9 @@ func mergeConfig(
 	for _, projectConfigKey := range keys {
 		projectConfigType := project.Config[projectConfigKey]
 
-		var key config.Key
-		if strings.Contains(projectConfigKey, ":") {
-			// key is already namespaced
-			parsedKey, parseError := config.ParseKey(projectConfigKey)
-			if parseError != nil {
-				return parseError
-			}
-
-			key = parsedKey
-		} else {
-			// key is not namespaced
-			// use the project as default namespace
-			key = config.MustMakeKey(projectName, projectConfigKey)
+		key, err := projectConfigKeyToKey(project, projectConfigKey)
+		if err != nil {
+			return err
 		}
 
 		stackValue, foundOnStack, err := stackConfig.Get(key, true)
@@ -191,7 +212,7 @@ func mergeConfig(
 		if validate && projectConfigType.IsExplicitlyTyped() {
 			err := validateStackConfigValue(stackName, projectConfigKey, projectConfigType, stackValue, decrypter)
 			if err != nil {
-				return err
+				return &InvalidStackConfigValueError{Key: key, err: err}
 			}
 		}
 	}
@@ -221,3 +242,43 @@ func ValidateStackConfigAndApplyProjectConfig(
 func ApplyProjectConfig(stackName string, project *Project, stackConfig config.Map) error {
 	return mergeConfig(stackName, project, stackConfig, nil, false)
 }
+
+// ValidateStackConfigValue validates the stack's value for the given key against the project's config schema, if the
+// project declares a type for the key. If path is true, the key may be a path to a value inside a map or list, in which
+// case the whole value is validated.
+func ValidateStackConfigValue(
+	stackName string,
+	project *Project,
+	stackConfig config.Map,
+	key config.Key,
+	path bool,
+	decrypter config.Decrypter,
+) error {
+	if path {
+		p, err := resource.ParsePropertyPath(key.Name())
+		if err != nil {
+			return fmt.Errorf("invalid config key path: %w", err)
+		}
+		if name, ok := p[0].(string); ok {
+			key = config.MustMakeKey(key.Namespace(), name)
+		}
+	}
+
+	for projectConfigKey, projectConfigType := range project.Config {
+		projectKey, err := projectConfigKeyToKey(project, projectConfigKey)
+		if err != nil {
+			return err
+		}
+		if projectKey != key || !projectConfigType.IsExplicitlyTyped() {
+			continue
+		}
+
+		stackValue, found, err := stackConfig.Get(key, false)
+		if err != nil || !found {
+			return err
+		}
+		return validateStackConfigValue(stackName, projectConfigKey, projectConfigType, stackValue, decrypter)
+	}
+
+	return nil
+}
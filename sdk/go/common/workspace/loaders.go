@@ -17,6 +17,7 @@ package workspace
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
@@ -202,6 +203,71 @@ func LoadProjectStack(project *Project, path string) (*ProjectStack, error) {
 	return &projectStack, nil
 }
 
+// ProjectStackLayer is a stack config file that is merged beneath a stack's own config.
+type ProjectStackLayer struct {
+	// Path is the absolute path of the file.
+	Path string
+	// Stack holds the contents of the file.
+	Stack *ProjectStack
+}
+
+// LoadProjectStackBases loads the base config files of the stack definition read from the given path, including the
+// bases of those files. The layers are returned in the order in which they are merged: each file comes after its own
+// bases, and a file's bases come in the order in which it lists them. It is an error for a base file to be missing or
+// for the bases to form a cycle.
+func LoadProjectStackBases(project *Project, path string, ps *ProjectStack) ([]ProjectStackLayer, error) {
+	contract.Requiref(path != "", "path", "must not be empty")
+	contract.Requiref(ps != nil, "ps", "must not be nil")
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []ProjectStackLayer
+	loaded := make(map[string]bool)
+	var load func(path string, ps *ProjectStack, chain []string) error
+	load = func(path string, ps *ProjectStack, chain []string) error {
+		chain = append(chain, path)
+		for _, base := range ps.Base {
+			if !filepath.IsAbs(base) {
+				base = filepath.Join(filepath.Dir(path), base)
+			}
+			base = filepath.Clean(base)
+
+			for _, p := range chain {
+				if p == base {
+					return fmt.Errorf("base config file %s is included by itself: %s",
+						base, strings.Join(append(chain, base), " -> "))
+				}
+			}
+			if loaded[base] {
+				continue
+			}
+
+			if _, err := os.Stat(base); err != nil {
+				return fmt.Errorf("could not read base config file %s of %s: %w", base, path, err)
+			}
+			baseStack, err := LoadProjectStack(project, base)
+			if err != nil {
+				return fmt.Errorf("could not load base config file %s: %w", base, err)
+			}
+			if err := load(base, baseStack, chain); err != nil {
+				return err
+			}
+
+			loaded[base] = true
+			layers = append(layers, ProjectStackLayer{Path: base, Stack: baseStack})
+		}
+		return nil
+	}
+
+	if err := load(path, ps, nil); err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
 // LoadPluginProject reads a plugin project definition from a file.
 func LoadPluginProject(path string) (*PluginProject, error) {
 	contract.Requiref(path != "", "path", "must not be empty")
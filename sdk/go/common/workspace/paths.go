@@ -44,10 +44,14 @@ const (
 	PluginDir = "plugins"
 	// PolicyDir is the name of the directory that holds policy packs.
 	PolicyDir = "policies"
+	// SchemaDir is the name of the directory that caches the schemas of resource plugins.
+	SchemaDir = "schemas"
 	// StackDir is the name of the directory that holds stack information for projects.
 	StackDir = "stacks"
 	// LockDir is the name of the directory that holds locking information for projects.
 	LockDir = "locks"
+	// TagsDir is the name of the directory that holds stack tags for projects.
+	TagsDir = "tags"
 	// TemplateDir is the name of the directory containing templates.
 	TemplateDir = "templates"
 	// TemplatePolicyDir is the name of the directory containing templates for Policy Packs.
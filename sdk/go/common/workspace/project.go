@@ -23,8 +23,12 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
@@ -39,6 +43,7 @@ const (
 	integerTypeName = "integer"
 	stringTypeName  = "string"
 	booleanTypeName = "boolean"
+	objectTypeName  = "object"
 )
 
 //go:embed project.json
@@ -86,11 +91,47 @@ type ProjectTemplateConfigValue struct {
 type ProjectBackend struct {
 	// URL is optional field to explicitly set backend url
 	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Retention is an optional policy for how much history a self-managed backend keeps for the project's stacks.
+	Retention *ProjectBackendRetention `json:"retention,omitempty" yaml:"retention,omitempty"`
+}
+
+// ProjectBackendRetention limits the update history and checkpoint backups kept for each stack in a self-managed
+// backend. An entry is kept if any of the configured limits keeps it.
+type ProjectBackendRetention struct {
+	// Keep is the number of most recent entries to keep.
+	Keep int `json:"keep,omitempty" yaml:"keep,omitempty"`
+	// MaxAge keeps entries newer than the given duration, for example "720h".
+	MaxAge string `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
 }
 
 type ProjectOptions struct {
 	// Refresh is the ability to always run a refresh as part of a pulumi update / preview / destroy
 	Refresh string `json:"refresh,omitempty" yaml:"refresh,omitempty"`
+	// ConcurrencyLimits caps the number of concurrent operations on resources of a package or type, keyed by the
+	// package name (e.g. "aws") or type token (e.g. "aws:iam/role:Role").
+	ConcurrencyLimits map[string]int `json:"concurrencyLimits,omitempty" yaml:"concurrencyLimits,omitempty"`
+	// Retry controls how provider operations that fail with a transient error are retried. Resources can override it
+	// with their own retry policy.
+	Retry *ProjectRetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
+
+// ProjectRetryPolicy controls how the create, update and delete operations of providers are retried when they fail
+// with a transient error, such as throttling or a temporary network problem.
+type ProjectRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Operations are retried only if this is
+	// greater than one.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	// Delay is the delay before the first retry, for example "1s".
+	Delay string `json:"delay,omitempty" yaml:"delay,omitempty"`
+	// Backoff is the multiplier applied to the delay after each retry.
+	Backoff float64 `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// MaxDelay is the maximum delay between retries, for example "30s".
+	MaxDelay string `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`
+	// RetryableCodes lists the gRPC status codes that are retryable, for example "Unavailable". If neither codes nor
+	// error patterns are given, "Unavailable" and "ResourceExhausted" errors are retried.
+	RetryableCodes []string `json:"retryableCodes,omitempty" yaml:"retryableCodes,omitempty"`
+	// RetryableErrors lists regular expressions matching the error messages that are retryable.
+	RetryableErrors []string `json:"retryableErrors,omitempty" yaml:"retryableErrors,omitempty"`
 }
 
 type PluginOptions struct {
@@ -105,9 +146,31 @@ type Plugins struct {
 	Analyzers []PluginOptions `json:"analyzers,omitempty" yaml:"analyzers,omitempty"`
 }
 
+// ProjectConfigConstraints are JSON Schema style constraints on a config value, checked in addition to its type.
+//
+//nolint:lll
+type ProjectConfigConstraints struct {
+	Enum       []interface{}                     `json:"enum,omitempty" yaml:"enum,omitempty"`             // the allowed values.
+	Pattern    string                            `json:"pattern,omitempty" yaml:"pattern,omitempty"`       // a regular expression strings must match.
+	Minimum    *float64                          `json:"minimum,omitempty" yaml:"minimum,omitempty"`       // the smallest allowed integer.
+	Maximum    *float64                          `json:"maximum,omitempty" yaml:"maximum,omitempty"`       // the largest allowed integer.
+	MinLength  *int                              `json:"minLength,omitempty" yaml:"minLength,omitempty"`   // the minimum length of strings.
+	MaxLength  *int                              `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`   // the maximum length of strings.
+	Properties map[string]ProjectConfigItemsType `json:"properties,omitempty" yaml:"properties,omitempty"` // the types of the properties of objects.
+	Required   []string                          `json:"required,omitempty" yaml:"required,omitempty"`     // the properties objects must have.
+}
+
+// IsEmpty returns true if there are no constraints.
+func (c *ProjectConfigConstraints) IsEmpty() bool {
+	return len(c.Enum) == 0 && c.Pattern == "" && c.Minimum == nil && c.Maximum == nil &&
+		c.MinLength == nil && c.MaxLength == nil && len(c.Properties) == 0 && len(c.Required) == 0
+}
+
 type ProjectConfigItemsType struct {
 	Type  string                  `json:"type,omitempty" yaml:"type,omitempty"`
 	Items *ProjectConfigItemsType `json:"items,omitempty" yaml:"items,omitempty"`
+
+	ProjectConfigConstraints `yaml:",inline"`
 }
 
 type ProjectConfigType struct {
@@ -117,6 +180,8 @@ type ProjectConfigType struct {
 	Default     interface{}             `json:"default,omitempty" yaml:"default,omitempty"`
 	Value       interface{}             `json:"value,omitempty" yaml:"value,omitempty"`
 	Secret      bool                    `json:"secret,omitempty" yaml:"secret,omitempty"`
+
+	ProjectConfigConstraints `yaml:",inline"`
 }
 
 // IsExplicitlyTyped returns whether the project config type is explicitly typed.
@@ -422,6 +487,11 @@ func ValidateConfigValue(typeName string, itemsType *ProjectConfigItemsType, val
 		return ok
 	}
 
+	if typeName == objectTypeName {
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+
 	items, isArray := value.([]interface{})
 
 	if !isArray || itemsType == nil {
@@ -440,6 +510,152 @@ func ValidateConfigValue(typeName string, itemsType *ProjectConfigItemsType, val
 	return true
 }
 
+// configValueError describes a config value, or a value nested within it, that doesn't satisfy its schema.
+type configValueError struct {
+	path    string // the path to the invalid value within the config value, e.g. ".servers[0].port", if nested.
+	message string // what is wrong with the value, e.g. "must be at most 65535".
+}
+
+// checkConfigValue checks the given config value against its type and constraints, including the types and
+// constraints of any nested items and properties.
+func checkConfigValue(path string, typeName string, itemsType *ProjectConfigItemsType,
+	constraints *ProjectConfigConstraints, value interface{},
+) *configValueError {
+	if !ValidateConfigValue(typeName, itemsType, value) {
+		return &configValueError{path, fmt.Sprintf("must be of type '%v'", InferFullTypeName(typeName, itemsType))}
+	}
+
+	if len(constraints.Enum) > 0 && !configEnumContains(constraints.Enum, value) {
+		allowed := make([]string, len(constraints.Enum))
+		for i, v := range constraints.Enum {
+			allowed[i] = fmt.Sprintf("'%v'", v)
+		}
+		return &configValueError{path, "must be one of " + strings.Join(allowed, ", ")}
+	}
+
+	switch typeName {
+	case stringTypeName:
+		s := value.(string)
+		if constraints.Pattern != "" {
+			// The pattern has already been checked by Project.Validate.
+			if matched, err := regexp.MatchString(constraints.Pattern, s); err == nil && !matched {
+				return &configValueError{path, fmt.Sprintf("must match the pattern '%v'", constraints.Pattern)}
+			}
+		}
+		length := utf8.RuneCountInString(s)
+		if constraints.MinLength != nil && length < *constraints.MinLength {
+			return &configValueError{path, fmt.Sprintf("must be at least %d characters long", *constraints.MinLength)}
+		}
+		if constraints.MaxLength != nil && length > *constraints.MaxLength {
+			return &configValueError{path, fmt.Sprintf("must be at most %d characters long", *constraints.MaxLength)}
+		}
+	case integerTypeName:
+		n, ok := configNumberValue(value)
+		contract.Assertf(ok, "integer config value %v should be a number", value)
+		if constraints.Minimum != nil && n < *constraints.Minimum {
+			return &configValueError{path, fmt.Sprintf("must be at least %v", *constraints.Minimum)}
+		}
+		if constraints.Maximum != nil && n > *constraints.Maximum {
+			return &configValueError{path, fmt.Sprintf("must be at most %v", *constraints.Maximum)}
+		}
+	case arrayTypeName:
+		for i, item := range value.([]interface{}) {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			err := checkConfigValue(itemPath, itemsType.Type, itemsType.Items, &itemsType.ProjectConfigConstraints, item)
+			if err != nil {
+				return err
+			}
+		}
+	case objectTypeName:
+		obj := value.(map[string]interface{})
+		for _, name := range constraints.Required {
+			if _, ok := obj[name]; !ok {
+				return &configValueError{path, fmt.Sprintf("is missing the required property '%v'", name)}
+			}
+		}
+		names := make([]string, 0, len(constraints.Properties))
+		for name := range constraints.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			v, ok := obj[name]
+			if !ok {
+				continue
+			}
+			prop := constraints.Properties[name]
+			err := checkConfigValue(path+"."+name, prop.Type, prop.Items, &prop.ProjectConfigConstraints, v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// configEnumContains returns true if the given config value is one of the allowed values. Stack config values are
+// strings unless they are objects, so primitive values are compared by their textual form.
+func configEnumContains(allowed []interface{}, value interface{}) bool {
+	for _, v := range allowed {
+		if isPrimitiveValue(v) || isPrimitiveValue(value) {
+			if fmt.Sprintf("%v", v) == fmt.Sprintf("%v", value) {
+				return true
+			}
+		} else if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// configNumberValue returns the given config value as a number, parsing it if it's a string.
+func configNumberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// validateConfigConstraints checks that the constraints of the given config type are well formed.
+func validateConfigConstraints(configKey string, typeName string, itemsType *ProjectConfigItemsType,
+	constraints *ProjectConfigConstraints,
+) error {
+	if constraints.Pattern != "" {
+		if _, err := regexp.Compile(constraints.Pattern); err != nil {
+			return fmt.Errorf("The configuration key '%v' has an invalid pattern: %w", configKey, err)
+		}
+	}
+	if (len(constraints.Properties) > 0 || len(constraints.Required) > 0) && typeName != objectTypeName {
+		return fmt.Errorf("The configuration key '%v' declares properties but is not of type 'object'", configKey)
+	}
+	if itemsType != nil {
+		err := validateConfigConstraints(configKey+"[]", itemsType.Type, itemsType.Items,
+			&itemsType.ProjectConfigConstraints)
+		if err != nil {
+			return err
+		}
+	}
+	for name, prop := range constraints.Properties {
+		if prop.Type == arrayTypeName && prop.Items == nil {
+			return fmt.Errorf("The configuration key '%v.%v' declares an array "+
+				"but does not specify the underlying type via the 'items' attribute", configKey, name)
+		}
+		err := validateConfigConstraints(configKey+"."+name, prop.Type, prop.Items, &prop.ProjectConfigConstraints)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func configKeyIsNamespacedByProject(projectName string, configKey string) bool {
 	return !strings.Contains(configKey, ":") || strings.HasPrefix(configKey, projectName+":")
 }
@@ -467,6 +683,19 @@ func (proj *Project) Validate() error {
 					"but does not specify the underlying type via the 'items' attribute", configKey)
 			}
 
+			if !configType.IsExplicitlyTyped() && !configType.ProjectConfigConstraints.IsEmpty() {
+				return fmt.Errorf("The configuration key '%v' declares constraints "+
+					"but does not specify its type via the 'type' attribute", configKey)
+			}
+
+			if configType.IsExplicitlyTyped() {
+				err := validateConfigConstraints(configKey, configTypeName, configType.Items,
+					&configType.ProjectConfigConstraints)
+				if err != nil {
+					return err
+				}
+			}
+
 			// when we have a config _type_ with a schema
 			if configType.IsExplicitlyTyped() && configType.Default != nil {
 				if !ValidateConfigValue(configTypeName, configType.Items, configType.Default) {
@@ -475,6 +704,13 @@ func (proj *Project) Validate() error {
 						configKey,
 						inferredTypeName)
 				}
+
+				invalid := checkConfigValue("", configTypeName, configType.Items, &configType.ProjectConfigConstraints,
+					configType.Default)
+				if invalid != nil {
+					return fmt.Errorf("The default value specified for configuration key '%v' %v",
+						configKey+invalid.path, invalid.message)
+				}
 			}
 
 		} else {
@@ -580,16 +816,32 @@ type ProjectStack struct {
 	// EncryptedKey is the KMS-encrypted ciphertext for the data key used for secrets encryption.
 	// Only used for cloud-based secrets providers.
 	EncryptedKey string `json:"encryptedkey,omitempty" yaml:"encryptedkey,omitempty"`
+	// SecretsRecipients are further cloud secrets providers that the data key is encrypted with, so that the stack's
+	// secrets can be decrypted with any one of them. Only used for cloud-based secrets providers.
+	SecretsRecipients []SecretsRecipient `json:"secretsrecipients,omitempty" yaml:"secretsrecipients,omitempty"`
 	// EncryptionSalt is this stack's base64 encoded encryption salt.  Only used for
 	// passphrase-based secrets providers.
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// Base is an optional list of config files whose config is merged, in order, beneath this stack's config. Relative
+	// paths are resolved against the directory of the file that declares them.
+	Base []string `json:"base,omitempty" yaml:"base,omitempty"`
 
 	// The original byte representation of the file, used to attempt trivia-preserving edits
 	raw []byte
 }
 
+// SecretsRecipient is a cloud secrets provider that a stack's data key is encrypted with, in addition to the stack's
+// own secrets provider.
+type SecretsRecipient struct {
+	// URL is the URL of the cloud secrets provider, e.g. `awskms://alias/ExampleAlias`. It is stored in plain text, so
+	// it must identify a key rather than contain one: `base64key://` URLs are not allowed.
+	URL string `json:"url" yaml:"url"`
+	// EncryptedKey is the ciphertext of the data key, encrypted by this secrets provider.
+	EncryptedKey string `json:"encryptedkey" yaml:"encryptedkey"`
+}
+
 func (ps ProjectStack) RawValue() []byte {
 	return ps.raw
 }
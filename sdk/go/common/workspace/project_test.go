@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
@@ -735,6 +736,9 @@ config:
 	assert.Contains(t,
 		configError.Error(),
 		"Stack 'dev' with configuration key 'importantNumber' must be of type 'integer'")
+	var valueError *InvalidStackConfigValueError
+	require.ErrorAs(t, configError, &valueError)
+	assert.Equal(t, config.MustMakeKey("test", "importantNumber"), valueError.Key)
 }
 
 func TestStackConfigErrorsWhenMissingStackValueForConfigTypeWithNoDefault(t *testing.T) {
@@ -871,6 +875,292 @@ config:
 		"Stack 'dev' with configuration key 'importantNumber' must be encrypted as it's secret")
 }
 
+func TestStackConfigConstraintsAreValidated(t *testing.T) {
+	t.Parallel()
+	projectYaml := `
+name: test
+runtime: dotnet
+config:
+  size:
+    type: string
+    enum: [small, medium, large]
+    default: small
+  name:
+    type: string
+    pattern: ^[a-z]+$
+    minLength: 3
+    maxLength: 8
+    default: web
+  port:
+    type: integer
+    minimum: 1
+    maximum: 65535
+    default: 80
+  database:
+    type: object
+    required: [host]
+    default:
+      host: db.internal
+    properties:
+      host:
+        type: string
+      replicas:
+        type: array
+        items:
+          type: integer
+          maximum: 5
+`
+
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name: "valid",
+			config: `
+  test:size: medium
+  test:name: web
+  test:port: 8080
+  test:database:
+    host: db.internal
+    replicas: [1, 2]`,
+		},
+		{
+			name:     "enum",
+			config:   "\n  test:size: huge",
+			expected: "Stack 'dev' with configuration key 'size' must be one of 'small', 'medium', 'large'",
+		},
+		{
+			name:     "pattern",
+			config:   "\n  test:name: Web",
+			expected: "Stack 'dev' with configuration key 'name' must match the pattern '^[a-z]+$'",
+		},
+		{
+			name:     "minLength",
+			config:   "\n  test:name: ab",
+			expected: "Stack 'dev' with configuration key 'name' must be at least 3 characters long",
+		},
+		{
+			name:     "maxLength",
+			config:   "\n  test:name: abcdefghi",
+			expected: "Stack 'dev' with configuration key 'name' must be at most 8 characters long",
+		},
+		{
+			name:     "minimum",
+			config:   "\n  test:port: 0",
+			expected: "Stack 'dev' with configuration key 'port' must be at least 1",
+		},
+		{
+			name:     "maximum",
+			config:   "\n  test:port: 70000",
+			expected: "Stack 'dev' with configuration key 'port' must be at most 65535",
+		},
+		{
+			name:     "object type",
+			config:   "\n  test:database: db.internal",
+			expected: "Stack 'dev' with configuration key 'database' must be of type 'object'",
+		},
+		{
+			name: "required",
+			config: `
+  test:database:
+    replicas: [1]`,
+			expected: "Stack 'dev' with configuration key 'database' is missing the required property 'host'",
+		},
+		{
+			name: "nested property type",
+			config: `
+  test:database:
+    host: 42`,
+			expected: "Stack 'dev' with configuration key 'database.host' must be of type 'string'",
+		},
+		{
+			name: "nested items",
+			config: `
+  test:database:
+    host: db.internal
+    replicas: [1, 7]`,
+			expected: "Stack 'dev' with configuration key 'database.replicas[1]' must be at most 5",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, projectError := loadProjectFromText(t, projectYaml)
+			require.NoError(t, projectError)
+			stack, stackError := loadProjectStackFromText(t, project, "config:"+tt.config)
+			require.NoError(t, stackError)
+
+			configError := ValidateStackConfigAndApplyProjectConfig("dev", project, stack.Config, config.NewPanicCrypter())
+			if tt.expected == "" {
+				assert.NoError(t, configError)
+			} else {
+				assert.ErrorContains(t, configError, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateStackConfigValue(t *testing.T) {
+	t.Parallel()
+	projectYaml := `
+name: test
+runtime: dotnet
+config:
+  database:
+    type: object
+    properties:
+      port:
+        type: integer
+        maximum: 65535
+    default:
+      port: 5432
+  aws:region:
+    value: us-west-2
+`
+
+	project, projectError := loadProjectFromText(t, projectYaml)
+	require.NoError(t, projectError)
+
+	cfg := config.Map{}
+	key := config.MustMakeKey("test", "database.port")
+	require.NoError(t, cfg.Set(key, config.NewValue("70000"), true))
+	err := ValidateStackConfigValue("dev", project, cfg, key, true, config.NopDecrypter)
+	assert.ErrorContains(t, err, "Stack 'dev' with configuration key 'database.port' must be at most 65535")
+
+	require.NoError(t, cfg.Set(key, config.NewValue("8080"), true))
+	err = ValidateStackConfigValue("dev", project, cfg, key, true, config.NopDecrypter)
+	assert.NoError(t, err)
+
+	// Keys without a declared type aren't validated.
+	regionKey := config.MustMakeKey("aws", "region")
+	require.NoError(t, cfg.Set(regionKey, config.NewValue("anything"), false))
+	err = ValidateStackConfigValue("dev", project, cfg, regionKey, false, config.NopDecrypter)
+	assert.NoError(t, err)
+}
+
+func TestLoadProjectStackBases(t *testing.T) {
+	t.Parallel()
+
+	project := &Project{Name: "test"}
+	dir := t.TempDir()
+	writeStack := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	writeStack("Pulumi.base.yaml", `
+config:
+  instanceSize: t3.micro`)
+	writeStack("Pulumi.tier.yaml", `
+base:
+- Pulumi.base.yaml
+config:
+  instanceCount: 2`)
+	writeStack("Pulumi.region.yaml", `
+base:
+- ./Pulumi.base.yaml
+config:
+  aws:region: us-west-2`)
+	path := writeStack("Pulumi.dev.yaml", `
+base:
+- Pulumi.tier.yaml
+- Pulumi.region.yaml
+config:
+  instanceCount: 3`)
+
+	stack, err := LoadProjectStack(project, path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pulumi.tier.yaml", "Pulumi.region.yaml"}, stack.Base)
+
+	layers, err := LoadProjectStackBases(project, path, stack)
+	require.NoError(t, err)
+	var paths []string
+	for _, layer := range layers {
+		paths = append(paths, filepath.Base(layer.Path))
+	}
+	// Each file comes after its own bases, and a file shared by several bases is only merged once.
+	assert.Equal(t, []string{"Pulumi.base.yaml", "Pulumi.tier.yaml", "Pulumi.region.yaml"}, paths)
+	assert.Equal(t, "t3.micro", getConfigValue(t, layers[0].Stack.Config, "test:instanceSize"))
+
+	// Missing base files are an error.
+	stack.Base = []string{"Pulumi.missing.yaml"}
+	_, err = LoadProjectStackBases(project, path, stack)
+	assert.ErrorContains(t, err, "could not read base config file")
+
+	// So are cycles.
+	cyclePath := writeStack("Pulumi.cycle.yaml", `
+base:
+- Pulumi.loop.yaml`)
+	writeStack("Pulumi.loop.yaml", `
+base:
+- Pulumi.cycle.yaml`)
+	stack, err = LoadProjectStack(project, cyclePath)
+	require.NoError(t, err)
+	_, err = LoadProjectStackBases(project, cyclePath, stack)
+	assert.ErrorContains(t, err, "is included by itself")
+}
+
+func TestProjectValidationChecksConfigConstraints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name: "default violates constraint",
+			config: `
+  port:
+    type: integer
+    maximum: 10
+    default: 20`,
+			expected: "The default value specified for configuration key 'port' must be at most 10",
+		},
+		{
+			name: "constraints without type",
+			config: `
+  port:
+    maximum: 10`,
+			expected: "The configuration key 'port' declares constraints but does not specify its type",
+		},
+		{
+			name: "invalid pattern",
+			config: `
+  name:
+    type: string
+    pattern: "[a-z"`,
+			expected: "The configuration key 'name' has an invalid pattern",
+		},
+		{
+			name: "properties on a string",
+			config: `
+  name:
+    type: string
+    properties:
+      first:
+        type: string`,
+			expected: "The configuration key 'name' declares properties but is not of type 'object'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := loadProjectFromText(t, "name: test\nruntime: dotnet\nconfig:"+tt.config)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
 func TestProjectLoadYAML(t *testing.T) {
 	t.Parallel()
 
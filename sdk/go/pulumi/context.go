@@ -69,6 +69,9 @@ type Context struct {
 	rpcsLock            sync.Mutex // a lock protecting the RPC count and event.
 	rpcError            error      // the first error (if any) encountered during an RPC.
 
+	hooks     *resourceHooksServer // the server of the program's resource hooks, if any.
+	hooksLock sync.Mutex           // a lock protecting the resource hooks server.
+
 	join workGroup // the waitgroup for non-RPC async work associated with this context
 
 	Log Log // the logging interface for the Pulumi log stream.
@@ -179,6 +182,9 @@ func (ctx *Context) Context() context.Context {
 
 // Close implements io.Closer and relinquishes any outstanding resources held by the context.
 func (ctx *Context) Close() error {
+	if err := ctx.closeResourceHooks(); err != nil {
+		return err
+	}
 	if ctx.engineConn != nil {
 		if err := ctx.engineConn.Close(); err != nil {
 			return err
@@ -956,6 +962,8 @@ func (ctx *Context) registerResource(
 				RetainOnDelete:          inputs.retainOnDelete,
 				DeletedWith:             inputs.deletedWith,
 				SourcePosition:          sourcePosition,
+				RetryPolicy:             inputs.retryPolicy,
+				Hooks:                   inputs.hooks,
 			})
 			if err != nil {
 				logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -1355,6 +1363,8 @@ type resourceInputs struct {
 	replaceOnChanges        []string
 	retainOnDelete          bool
 	deletedWith             string
+	retryPolicy             *pulumirpc.RegisterResourceRequest_RetryPolicy
+	hooks                   *pulumirpc.RegisterResourceRequest_ResourceHooksBinding
 }
 
 func (ctx *Context) resolveAliasParent(alias Alias, spec *pulumirpc.Alias_Spec) error {
@@ -1600,6 +1610,11 @@ func (ctx *Context) prepareResourceInputs(res Resource, props Input, t string, o
 		deletedWithURN = urn
 	}
 
+	hooks, err := ctx.registerResourceHooks(opts.Hooks)
+	if err != nil {
+		return nil, fmt.Errorf("registering resource hooks: %w", err)
+	}
+
 	return &resourceInputs{
 		parent:                  string(resOpts.parentURN),
 		deps:                    deps,
@@ -1620,6 +1635,8 @@ func (ctx *Context) prepareResourceInputs(res Resource, props Input, t string, o
 		replaceOnChanges:        resOpts.replaceOnChanges,
 		retainOnDelete:          opts.RetainOnDelete,
 		deletedWith:             string(deletedWithURN),
+		retryPolicy:             getRetryPolicy(opts.RetryPolicy),
+		hooks:                   hooks,
 	}, nil
 }
 
@@ -1633,6 +1650,20 @@ func getTimeouts(custom *CustomTimeouts) *pulumirpc.RegisterResourceRequest_Cust
 	return &timeouts
 }
 
+func getRetryPolicy(policy *RetryPolicy) *pulumirpc.RegisterResourceRequest_RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &pulumirpc.RegisterResourceRequest_RetryPolicy{
+		MaxAttempts:     int32(policy.MaxAttempts),
+		Delay:           policy.Delay,
+		Backoff:         policy.Backoff,
+		MaxDelay:        policy.MaxDelay,
+		RetryableCodes:  policy.RetryableCodes,
+		RetryableErrors: policy.RetryableErrors,
+	}
+}
+
 // Helper struct for the return type of `getOpts`.
 type resourceOpts struct {
 	parentURN               URN
@@ -8,10 +8,13 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 )
 
@@ -68,6 +71,10 @@ type mockMonitor struct {
 	stack     string
 	mocks     MockResourceMonitor
 	resources sync.Map // map[string]resource.PropertyMap
+
+	hooks     map[string]pulumirpc.ResourceHooksClient // the registered resource hooks.
+	hookConns map[string]*grpc.ClientConn              // connections to the servers of resource hooks.
+	hooksLock sync.Mutex                               // a lock protecting the resource hooks.
 }
 
 func (m *mockMonitor) newURN(parent, typ, name string) string {
@@ -220,6 +227,20 @@ func (m *mockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.Regist
 		return nil, err
 	}
 
+	var hooks *pulumirpc.RegisterResourceRequest_ResourceHooksBinding
+	if in.GetCustom() {
+		hooks = in.GetHooks()
+	}
+	urn := m.newURN(in.GetParent(), in.GetType(), in.GetName())
+	hookReq := &pulumirpc.InvokeResourceHookRequest{
+		Urn:       urn,
+		Type:      in.GetType(),
+		NewInputs: in.GetObject(),
+	}
+	if err := m.invokeResourceHooks(ctx, hooks.GetBeforeCreate(), hookReq); err != nil {
+		return nil, err
+	}
+
 	id, state, err := m.mocks.NewResource(MockResourceArgs{
 		TypeToken:   in.GetType(),
 		Name:        in.GetName(),
@@ -233,8 +254,6 @@ func (m *mockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.Regist
 		return nil, err
 	}
 
-	urn := m.newURN(in.GetParent(), in.GetType(), in.GetName())
-
 	m.resources.Store(urn, resource.PropertyMap{
 		resource.PropertyKey("urn"):   resource.NewStringProperty(urn),
 		resource.PropertyKey("id"):    resource.NewStringProperty(id),
@@ -249,6 +268,11 @@ func (m *mockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.Regist
 		return nil, err
 	}
 
+	hookReq.Id, hookReq.NewOutputs = id, stateOut
+	if err := m.invokeResourceHooks(ctx, hooks.GetAfterCreate(), hookReq); err != nil {
+		return nil, err
+	}
+
 	return &pulumirpc.RegisterResourceResponse{
 		Urn:    urn,
 		Id:     id,
@@ -262,6 +286,70 @@ func (m *mockMonitor) RegisterResourceOutputs(ctx context.Context, in *pulumirpc
 	return &empty.Empty{}, nil
 }
 
+// RegisterResourceHook connects to the server of the given resource hook. As the mock monitor only creates resources,
+// it runs the create hooks of custom resources around calls to NewResource, and fails the registration of the
+// resource if a hook returns an error.
+func (m *mockMonitor) RegisterResourceHook(ctx context.Context, in *pulumirpc.RegisterResourceHookRequest,
+	opts ...grpc.CallOption,
+) (*empty.Empty, error) {
+	m.hooksLock.Lock()
+	defer m.hooksLock.Unlock()
+
+	if m.hooks == nil {
+		m.hooks = map[string]pulumirpc.ResourceHooksClient{}
+		m.hookConns = map[string]*grpc.ClientConn{}
+	}
+	conn, ok := m.hookConns[in.GetTarget()]
+	if !ok {
+		var err error
+		conn, err = grpc.Dial(
+			in.GetTarget(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			rpcutil.GrpcChannelOptions(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to resource hooks server: %w", err)
+		}
+		m.hookConns[in.GetTarget()] = conn
+	}
+	m.hooks[in.GetName()] = pulumirpc.NewResourceHooksClient(conn)
+	return &empty.Empty{}, nil
+}
+
+func (m *mockMonitor) invokeResourceHooks(ctx context.Context, names []string,
+	req *pulumirpc.InvokeResourceHookRequest,
+) error {
+	for _, name := range names {
+		m.hooksLock.Lock()
+		client, ok := m.hooks[name]
+		m.hooksLock.Unlock()
+		if !ok {
+			return fmt.Errorf("unknown resource hook %q", name)
+		}
+
+		req.Name = name
+		resp, err := client.InvokeResourceHook(ctx, req)
+		if err != nil {
+			return err
+		}
+		if resp.GetError() != "" {
+			return fmt.Errorf("resource hook failed: %s", resp.GetError())
+		}
+	}
+	return nil
+}
+
+func (m *mockMonitor) closeResourceHooks() {
+	m.hooksLock.Lock()
+	defer m.hooksLock.Unlock()
+
+	for target, conn := range m.hookConns {
+		contract.IgnoreClose(conn)
+		delete(m.hookConns, target)
+	}
+	m.hooks = nil
+}
+
 type mockEngine struct {
 	logger       *log.Logger
 	rootResource string
@@ -297,6 +297,78 @@ type CustomTimeouts struct {
 	Delete string
 }
 
+// RetryPolicy controls how the engine retries the create, update and delete operations of a resource
+// when they fail with a transient error, such as throttling or a temporary network problem.
+// Use it with the [Retries] option when creating new resources.
+// Settings left unset fall back to the retry policy of the project, if any, and then to the engine's defaults.
+//
+// Delays are specified as duration strings such as "500ms" or "1m30s".
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Set it to 1 to disable retries for the resource.
+	MaxAttempts int
+	// Delay is the delay before the first retry.
+	Delay string
+	// Backoff is the multiplier applied to the delay after each retry.
+	Backoff float64
+	// MaxDelay is the maximum delay between retries.
+	MaxDelay string
+	// RetryableCodes lists the gRPC status codes of the errors that are retryable,
+	// such as "Unavailable" or "ResourceExhausted".
+	RetryableCodes []string
+	// RetryableErrors lists regular expressions matching the messages of the errors that are retryable.
+	RetryableErrors []string
+}
+
+// ResourceHookArgs are the arguments passed to a [ResourceHookFunc].
+// Which of the inputs and outputs are set depends on the operation:
+// create hooks only see the new state, delete hooks only see the old state,
+// and hooks that run before an operation never see the new outputs.
+type ResourceHookArgs struct {
+	// URN is the URN of the resource.
+	URN URN
+	// ID is the ID of the resource, if it has one.
+	ID ID
+	// Type is the type token of the resource.
+	Type string
+	// NewInputs are the new inputs of the resource, if any.
+	NewInputs resource.PropertyMap
+	// OldInputs are the old inputs of the resource, if any.
+	OldInputs resource.PropertyMap
+	// NewOutputs are the new outputs of the resource, if any.
+	NewOutputs resource.PropertyMap
+	// OldOutputs are the old outputs of the resource, if any.
+	OldOutputs resource.PropertyMap
+}
+
+// ResourceHookFunc is a function that the engine runs around a lifecycle operation of a resource.
+// An error returned by a hook that runs before an operation fails the operation.
+// An error returned by a hook that runs after an operation is reported as a warning.
+type ResourceHookFunc func(ctx context.Context, args *ResourceHookArgs) error
+
+// ResourceHookBinding lists the hooks to run around the create, update and delete operations of a resource.
+// Use it with the [ResourceHooks] option when creating new resources.
+//
+// Hooks don't run during previews.
+// Delete hooks only run while the program is running, which is the case for resources that are deleted before
+// they are replaced. The engine refuses to delete a resource whose delete hooks can't run:
+// a resource that is removed from the program or destroyed, or that is replaced by creating its replacement first.
+// To delete such a resource, remove its delete hooks and run `pulumi up` first.
+type ResourceHookBinding struct {
+	// BeforeCreate are the hooks to run before the resource is created.
+	BeforeCreate []ResourceHookFunc
+	// AfterCreate are the hooks to run after the resource is created.
+	AfterCreate []ResourceHookFunc
+	// BeforeUpdate are the hooks to run before the resource is updated.
+	BeforeUpdate []ResourceHookFunc
+	// AfterUpdate are the hooks to run after the resource is updated.
+	AfterUpdate []ResourceHookFunc
+	// BeforeDelete are the hooks to run before the resource is deleted.
+	BeforeDelete []ResourceHookFunc
+	// AfterDelete are the hooks to run after the resource is deleted.
+	AfterDelete []ResourceHookFunc
+}
+
 // ResourceOptions is a snapshot of one or more [ResourceOption]s.
 //
 // You cannot pass a ResourceOptions struct to a resource constructor.
@@ -387,6 +459,14 @@ type ResourceOptions struct {
 	// DeletedWith holds a container resource that, if deleted,
 	// also deletes this resource.
 	DeletedWith Resource
+
+	// RetryPolicy, if set, controls how provider operations on the resource
+	// that fail with a transient error are retried.
+	RetryPolicy *RetryPolicy
+
+	// Hooks, if set, lists the hooks to run around
+	// the create, update and delete operations of the resource.
+	Hooks *ResourceHookBinding
 }
 
 // NewResourceOptions builds a preview of the effect of the provided options.
@@ -421,6 +501,8 @@ type resourceOptions struct {
 	PluginDownloadURL       string
 	RetainOnDelete          bool
 	DeletedWith             Resource
+	RetryPolicy             *RetryPolicy
+	Hooks                   *ResourceHookBinding
 }
 
 func resourceOptionsSnapshot(ro *resourceOptions) *ResourceOptions {
@@ -476,6 +558,8 @@ func resourceOptionsSnapshot(ro *resourceOptions) *ResourceOptions {
 		PluginDownloadURL:       ro.PluginDownloadURL,
 		RetainOnDelete:          ro.RetainOnDelete,
 		DeletedWith:             ro.DeletedWith,
+		RetryPolicy:             ro.RetryPolicy,
+		Hooks:                   ro.Hooks,
 	}
 }
 
@@ -828,6 +912,24 @@ func Timeouts(o *CustomTimeouts) ResourceOption {
 	})
 }
 
+// Retries sets the policy for retrying the resource's create, update and delete operations
+// when they fail with a transient error.
+// Older versions of the Pulumi CLI ignore this option.
+func Retries(o *RetryPolicy) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RetryPolicy = o
+	})
+}
+
+// ResourceHooks sets the hooks to run around the resource's create, update and delete operations.
+// Component resources ignore this option.
+// Older versions of the Pulumi CLI don't support this option and fail to register the resource.
+func ResourceHooks(o *ResourceHookBinding) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.Hooks = o
+	})
+}
+
 // Transformations is an optional list of transformations to be applied to the resource.
 func Transformations(o []ResourceTransformation) ResourceOption {
 	return resourceOption(func(ro *resourceOptions) {
@@ -0,0 +1,217 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// resourceHooksServer serves the resource hooks of a program,
+// so that the engine can run them around the lifecycle operations of the program's resources.
+type resourceHooksServer struct {
+	pulumirpc.UnimplementedResourceHooksServer
+
+	address string
+	cancel  chan bool
+	done    <-chan error
+
+	hooks     map[string]ResourceHookFunc
+	hooksLock sync.Mutex
+}
+
+func startResourceHooksServer() (*resourceHooksServer, error) {
+	s := &resourceHooksServer{
+		cancel: make(chan bool),
+		hooks:  map[string]ResourceHookFunc{},
+	}
+	handle, err := rpcutil.ServeWithOptions(rpcutil.ServeOptions{
+		Cancel: s.cancel,
+		Init: func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceHooksServer(srv, s)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.address, s.done = fmt.Sprintf("127.0.0.1:%d", handle.Port), handle.Done
+	return s, nil
+}
+
+// add adds a hook to the server and returns its name, which is unique within the program.
+func (s *resourceHooksServer) add(fn ResourceHookFunc) string {
+	s.hooksLock.Lock()
+	defer s.hooksLock.Unlock()
+	name := fmt.Sprintf("go-resource-hook-%d", len(s.hooks))
+	s.hooks[name] = fn
+	return name
+}
+
+func (s *resourceHooksServer) InvokeResourceHook(ctx context.Context,
+	req *pulumirpc.InvokeResourceHookRequest,
+) (*pulumirpc.InvokeResourceHookResponse, error) {
+	s.hooksLock.Lock()
+	fn, ok := s.hooks[req.GetName()]
+	s.hooksLock.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown resource hook %q", req.GetName())
+	}
+
+	args := &ResourceHookArgs{
+		URN:  URN(req.GetUrn()),
+		ID:   ID(req.GetId()),
+		Type: req.GetType(),
+	}
+	var err error
+	if args.NewInputs, err = unmarshalResourceHookProperties(req.GetNewInputs()); err != nil {
+		return nil, fmt.Errorf("unmarshaling new inputs: %w", err)
+	}
+	if args.OldInputs, err = unmarshalResourceHookProperties(req.GetOldInputs()); err != nil {
+		return nil, fmt.Errorf("unmarshaling old inputs: %w", err)
+	}
+	if args.NewOutputs, err = unmarshalResourceHookProperties(req.GetNewOutputs()); err != nil {
+		return nil, fmt.Errorf("unmarshaling new outputs: %w", err)
+	}
+	if args.OldOutputs, err = unmarshalResourceHookProperties(req.GetOldOutputs()); err != nil {
+		return nil, fmt.Errorf("unmarshaling old outputs: %w", err)
+	}
+
+	if err := runResourceHook(ctx, fn, args); err != nil {
+		return &pulumirpc.InvokeResourceHookResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.InvokeResourceHookResponse{}, nil
+}
+
+// runResourceHook runs a hook, turning a panic into an error.
+func runResourceHook(ctx context.Context, fn ResourceHookFunc, args *ResourceHookArgs) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("resource hook panicked: %v", r)
+		}
+	}()
+	return fn(ctx, args)
+}
+
+func unmarshalResourceHookProperties(props *structpb.Struct) (resource.PropertyMap, error) {
+	if props == nil {
+		return nil, nil
+	}
+	return plugin.UnmarshalProperties(props, plugin.MarshalOptions{
+		KeepUnknowns:  true,
+		KeepSecrets:   true,
+		KeepResources: true,
+	})
+}
+
+// registerResourceHooks registers the hooks of a resource with the engine
+// and returns the binding that refers to them by name.
+func (ctx *Context) registerResourceHooks(
+	binding *ResourceHookBinding,
+) (*pulumirpc.RegisterResourceRequest_ResourceHooksBinding, error) {
+	if binding == nil {
+		return nil, nil
+	}
+
+	register := func(fns []ResourceHookFunc) ([]string, error) {
+		names := make([]string, 0, len(fns))
+		for _, fn := range fns {
+			if fn == nil {
+				continue
+			}
+			name, err := ctx.registerResourceHook(fn)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+		}
+		return names, nil
+	}
+
+	var result pulumirpc.RegisterResourceRequest_ResourceHooksBinding
+	var err error
+	if result.BeforeCreate, err = register(binding.BeforeCreate); err != nil {
+		return nil, err
+	}
+	if result.AfterCreate, err = register(binding.AfterCreate); err != nil {
+		return nil, err
+	}
+	if result.BeforeUpdate, err = register(binding.BeforeUpdate); err != nil {
+		return nil, err
+	}
+	if result.AfterUpdate, err = register(binding.AfterUpdate); err != nil {
+		return nil, err
+	}
+	if result.BeforeDelete, err = register(binding.BeforeDelete); err != nil {
+		return nil, err
+	}
+	if result.AfterDelete, err = register(binding.AfterDelete); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// registerResourceHook serves a single hook, starting the server on first use, and registers it with the engine.
+func (ctx *Context) registerResourceHook(fn ResourceHookFunc) (string, error) {
+	ctx.hooksLock.Lock()
+	defer ctx.hooksLock.Unlock()
+
+	if ctx.hooks == nil {
+		hooks, err := startResourceHooksServer()
+		if err != nil {
+			return "", fmt.Errorf("starting resource hooks server: %w", err)
+		}
+		ctx.hooks = hooks
+	}
+
+	name := ctx.hooks.add(fn)
+	_, err := ctx.monitor.RegisterResourceHook(ctx.ctx, &pulumirpc.RegisterResourceHookRequest{
+		Name:   name,
+		Target: ctx.hooks.address,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return "", errors.New("the Pulumi CLI does not support resource hooks; please upgrade to a newer version")
+	}
+	return name, err
+}
+
+// closeResourceHooks stops serving the program's resource hooks.
+func (ctx *Context) closeResourceHooks() error {
+	ctx.hooksLock.Lock()
+	defer ctx.hooksLock.Unlock()
+
+	if mock, ok := ctx.monitor.(*mockMonitor); ok {
+		mock.closeResourceHooks()
+	}
+	if ctx.hooks == nil {
+		return nil
+	}
+	close(ctx.hooks.cancel)
+	err := <-ctx.hooks.done
+	ctx.hooks = nil
+	return err
+}
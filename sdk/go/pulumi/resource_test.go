@@ -1130,6 +1130,11 @@ func TestNewResourceOptions(t *testing.T) {
 			give: DeletedWith(&testRes{foo: "a"}),
 			want: ResourceOptions{DeletedWith: &testRes{foo: "a"}},
 		},
+		{
+			desc: "Retries",
+			give: Retries(&RetryPolicy{MaxAttempts: 3, Delay: "1s"}),
+			want: ResourceOptions{RetryPolicy: &RetryPolicy{MaxAttempts: 3, Delay: "1s"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,6 +2,7 @@ package pulumi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/internal"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // WithDryRun is an internal, test-only option
@@ -220,6 +222,64 @@ func TestRegisterResource(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRegisterResourceWithHooks(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	var afterCreate *ResourceHookArgs
+	mocks := &testMonitor{
+		NewResourceF: func(args MockResourceArgs) (string, resource.PropertyMap, error) {
+			calls = append(calls, "create")
+			return "someID", resource.PropertyMap{"foo": resource.NewStringProperty("qux")}, nil
+		},
+	}
+	hooks := &ResourceHookBinding{
+		BeforeCreate: []ResourceHookFunc{func(_ context.Context, args *ResourceHookArgs) error {
+			calls = append(calls, "beforeCreate")
+			assert.Equal(t, "oof", args.NewInputs["foo"].StringValue())
+			assert.Nil(t, args.NewOutputs)
+			return nil
+		}},
+		AfterCreate: []ResourceHookFunc{func(_ context.Context, args *ResourceHookArgs) error {
+			calls = append(calls, "afterCreate")
+			afterCreate = args
+			return nil
+		}},
+	}
+
+	err := RunErr(func(ctx *Context) error {
+		var res testResource2
+		err := ctx.RegisterResource("test:resource:type", "resA", &testResource2Inputs{
+			Foo: String("oof"),
+		}, &res, ResourceHooks(hooks))
+		assert.NoError(t, err)
+
+		_, _, _, _, err = await(res.ID())
+		assert.NoError(t, err)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"beforeCreate", "create", "afterCreate"}, calls)
+	require.NotNil(t, afterCreate)
+	assert.Equal(t, ID("someID"), afterCreate.ID)
+	assert.Equal(t, "test:resource:type", afterCreate.Type)
+	assert.Equal(t, "qux", afterCreate.NewOutputs["foo"].StringValue())
+
+	// A failing hook fails the registration of the resource.
+	calls = nil
+	err = RunErr(func(ctx *Context) error {
+		var res testResource2
+		return ctx.RegisterResource("test:resource:type", "resA", &testResource2Inputs{}, &res,
+			ResourceHooks(&ResourceHookBinding{
+				BeforeCreate: []ResourceHookFunc{func(context.Context, *ResourceHookArgs) error {
+					return errors.New("not ready")
+				}},
+			}))
+	}, WithMocks("project", "stack", mocks))
+	assert.ErrorContains(t, err, "not ready")
+	assert.Empty(t, calls)
+}
+
 func TestReadResource(t *testing.T) {
 	t.Parallel()
 
@@ -57,6 +57,28 @@ function deserialize_pulumirpc_CallResponse(buffer_arg) {
   return pulumi_provider_pb.CallResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_InvokeResourceHookRequest(arg) {
+  if (!(arg instanceof pulumi_resource_pb.InvokeResourceHookRequest)) {
+    throw new Error('Expected argument of type pulumirpc.InvokeResourceHookRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_InvokeResourceHookRequest(buffer_arg) {
+  return pulumi_resource_pb.InvokeResourceHookRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_InvokeResourceHookResponse(arg) {
+  if (!(arg instanceof pulumi_resource_pb.InvokeResourceHookResponse)) {
+    throw new Error('Expected argument of type pulumirpc.InvokeResourceHookResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_InvokeResourceHookResponse(buffer_arg) {
+  return pulumi_resource_pb.InvokeResourceHookResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_InvokeResponse(arg) {
   if (!(arg instanceof pulumi_provider_pb.InvokeResponse)) {
     throw new Error('Expected argument of type pulumirpc.InvokeResponse');
@@ -90,6 +112,17 @@ function deserialize_pulumirpc_ReadResourceResponse(buffer_arg) {
   return pulumi_resource_pb.ReadResourceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_RegisterResourceHookRequest(arg) {
+  if (!(arg instanceof pulumi_resource_pb.RegisterResourceHookRequest)) {
+    throw new Error('Expected argument of type pulumirpc.RegisterResourceHookRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RegisterResourceHookRequest(buffer_arg) {
+  return pulumi_resource_pb.RegisterResourceHookRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_RegisterResourceOutputsRequest(arg) {
   if (!(arg instanceof pulumi_resource_pb.RegisterResourceOutputsRequest)) {
     throw new Error('Expected argument of type pulumirpc.RegisterResourceOutputsRequest');
@@ -236,6 +269,35 @@ var ResourceMonitorService = exports.ResourceMonitorService = {
     responseSerialize: serialize_google_protobuf_Empty,
     responseDeserialize: deserialize_google_protobuf_Empty,
   },
+  registerResourceHook: {
+    path: '/pulumirpc.ResourceMonitor/RegisterResourceHook',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_resource_pb.RegisterResourceHookRequest,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_pulumirpc_RegisterResourceHookRequest,
+    requestDeserialize: deserialize_pulumirpc_RegisterResourceHookRequest,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
 };
 
 exports.ResourceMonitorClient = grpc.makeGenericClientConstructor(ResourceMonitorService);
+
+// ResourceHooks is the interface a source serves so that the engine can run the resource hooks that it registered with
+// the resource monitor.
+var ResourceHooksService = exports.ResourceHooksService = {
+  invokeResourceHook: {
+    path: '/pulumirpc.ResourceHooks/InvokeResourceHook',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_resource_pb.InvokeResourceHookRequest,
+    responseType: pulumi_resource_pb.InvokeResourceHookResponse,
+    requestSerialize: serialize_pulumirpc_InvokeResourceHookRequest,
+    requestDeserialize: deserialize_pulumirpc_InvokeResourceHookRequest,
+    responseSerialize: serialize_pulumirpc_InvokeResourceHookResponse,
+    responseDeserialize: deserialize_pulumirpc_InvokeResourceHookResponse,
+  },
+};
+
+exports.ResourceHooksClient = grpc.makeGenericClientConstructor(ResourceHooksService);
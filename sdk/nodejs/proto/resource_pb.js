@@ -25,12 +25,17 @@ var pulumi_alias_pb = require('./alias_pb.js');
 goog.object.extend(proto, pulumi_alias_pb);
 var pulumi_source_pb = require('./source_pb.js');
 goog.object.extend(proto, pulumi_source_pb);
+goog.exportSymbol('proto.pulumirpc.InvokeResourceHookRequest', null, global);
+goog.exportSymbol('proto.pulumirpc.InvokeResourceHookResponse', null, global);
 goog.exportSymbol('proto.pulumirpc.ReadResourceRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.ReadResourceResponse', null, global);
+goog.exportSymbol('proto.pulumirpc.RegisterResourceHookRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceOutputsRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.CustomTimeouts', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.PropertyDependencies', null, global);
+goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding', null, global);
+goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.RetryPolicy', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceResponse', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceResponse.PropertyDependencies', null, global);
 goog.exportSymbol('proto.pulumirpc.ResourceInvokeRequest', null, global);
@@ -183,6 +188,48 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.displayName = 'proto.pulumirpc.RegisterResourceRequest.CustomTimeouts';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RegisterResourceRequest.RetryPolicy.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceRequest.RetryPolicy, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.RegisterResourceRequest.RetryPolicy.displayName = 'proto.pulumirpc.RegisterResourceRequest.RetryPolicy';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.displayName = 'proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -267,6 +314,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.pulumirpc.ResourceInvokeRequest.displayName = 'proto.pulumirpc.ResourceInvokeRequest';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceHookRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceHookRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.RegisterResourceHookRequest.displayName = 'proto.pulumirpc.RegisterResourceHookRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.InvokeResourceHookRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.InvokeResourceHookRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.InvokeResourceHookRequest.displayName = 'proto.pulumirpc.InvokeResourceHookRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.InvokeResourceHookResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.InvokeResourceHookResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.InvokeResourceHookResponse.displayName = 'proto.pulumirpc.InvokeResourceHookResponse';
+}
 
 
 
@@ -1387,7 +1497,9 @@ proto.pulumirpc.RegisterResourceRequest.toObject = function(includeInstance, msg
     pulumi_alias_pb.Alias.toObject, includeInstance),
     deletedwith: jspb.Message.getFieldWithDefault(msg, 27, ""),
     aliasspecs: jspb.Message.getBooleanFieldWithDefault(msg, 28, false),
-    sourceposition: (f = msg.getSourceposition()) && pulumi_source_pb.SourcePosition.toObject(includeInstance, f)
+    sourceposition: (f = msg.getSourceposition()) && pulumi_source_pb.SourcePosition.toObject(includeInstance, f),
+    retrypolicy: (f = msg.getRetrypolicy()) && proto.pulumirpc.RegisterResourceRequest.RetryPolicy.toObject(includeInstance, f),
+    hooks: (f = msg.getHooks()) && proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1554,6 +1666,16 @@ proto.pulumirpc.RegisterResourceRequest.deserializeBinaryFromReader = function(m
       reader.readMessage(value,pulumi_source_pb.SourcePosition.deserializeBinaryFromReader);
       msg.setSourceposition(value);
       break;
+    case 31:
+      var value = new proto.pulumirpc.RegisterResourceRequest.RetryPolicy;
+      reader.readMessage(value,proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinaryFromReader);
+      msg.setRetrypolicy(value);
+      break;
+    case 32:
+      var value = new proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding;
+      reader.readMessage(value,proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader);
+      msg.setHooks(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1788,6 +1910,22 @@ proto.pulumirpc.RegisterResourceRequest.serializeBinaryToWriter = function(messa
       pulumi_source_pb.SourcePosition.serializeBinaryToWriter
     );
   }
+  f = message.getRetrypolicy();
+  if (f != null) {
+    writer.writeMessage(
+      31,
+      f,
+      proto.pulumirpc.RegisterResourceRequest.RetryPolicy.serializeBinaryToWriter
+    );
+  }
+  f = message.getHooks();
+  if (f != null) {
+    writer.writeMessage(
+      32,
+      f,
+      proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -2137,607 +2275,847 @@ proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.prototype.setDelete = fun
 };
 
 
+
 /**
- * optional string type = 1;
- * @return {string}
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getType = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
-};
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.repeatedFields_ = [5,6];
 
 
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setType = function(value) {
-  return jspb.Message.setProto3StringField(this, 1, value);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceRequest.RetryPolicy.toObject(opt_includeInstance, this);
 };
 
 
 /**
- * optional string name = 2;
- * @return {string}
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getName = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    maxattempts: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    delay: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    backoff: jspb.Message.getFloatingPointFieldWithDefault(msg, 3, 0.0),
+    maxdelay: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    retryablecodesList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
+    retryableerrorsList: (f = jspb.Message.getRepeatedField(msg, 6)) == null ? undefined : f
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
 };
+}
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setName = function(value) {
-  return jspb.Message.setProto3StringField(this, 2, value);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceRequest.RetryPolicy;
+  return proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
- * optional string parent = 3;
- * @return {string}
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getParent = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setMaxattempts(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDelay(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setBackoff(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMaxdelay(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addRetryablecodes(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addRetryableerrors(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setParent = function(value) {
-  return jspb.Message.setProto3StringField(this, 3, value);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceRequest.RetryPolicy.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
 };
 
 
 /**
- * optional bool custom = 4;
- * @return {boolean}
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getCustom = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getMaxattempts();
+  if (f !== 0) {
+    writer.writeInt32(
+      1,
+      f
+    );
+  }
+  f = message.getDelay();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getBackoff();
+  if (f !== 0.0) {
+    writer.writeDouble(
+      3,
+      f
+    );
+  }
+  f = message.getMaxdelay();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getRetryablecodesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getRetryableerrorsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      6,
+      f
+    );
+  }
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * optional int32 maxAttempts = 1;
+ * @return {number}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setCustom = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 4, value);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getMaxattempts = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 
 /**
- * optional google.protobuf.Struct object = 5;
- * @return {?proto.google.protobuf.Struct}
+ * @param {number} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getObject = function() {
-  return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setMaxattempts = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
 };
 
 
 /**
- * @param {?proto.google.protobuf.Struct|undefined} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
-*/
-proto.pulumirpc.RegisterResourceRequest.prototype.setObject = function(value) {
-  return jspb.Message.setWrapperField(this, 5, value);
+ * optional string delay = 2;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getDelay = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearObject = function() {
-  return this.setObject(undefined);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setDelay = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * optional double backoff = 3;
+ * @return {number}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.hasObject = function() {
-  return jspb.Message.getField(this, 5) != null;
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getBackoff = function() {
+  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 3, 0.0));
 };
 
 
 /**
- * optional bool protect = 6;
- * @return {boolean}
+ * @param {number} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getProtect = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 6, false));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setBackoff = function(value) {
+  return jspb.Message.setProto3FloatField(this, 3, value);
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * optional string maxDelay = 4;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setProtect = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 6, value);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getMaxdelay = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 
 /**
- * repeated string dependencies = 7;
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setMaxdelay = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * repeated string retryableCodes = 5;
  * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getDependenciesList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 7));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getRetryablecodesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 5));
 };
 
 
 /**
  * @param {!Array<string>} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setDependenciesList = function(value) {
-  return jspb.Message.setField(this, 7, value || []);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setRetryablecodesList = function(value) {
+  return jspb.Message.setField(this, 5, value || []);
 };
 
 
 /**
  * @param {string} value
  * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addDependencies = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 7, value, opt_index);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.addRetryablecodes = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 5, value, opt_index);
 };
 
 
 /**
  * Clears the list making it empty but non-null.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearDependenciesList = function() {
-  return this.setDependenciesList([]);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.clearRetryablecodesList = function() {
+  return this.setRetryablecodesList([]);
 };
 
 
 /**
- * optional string provider = 8;
- * @return {string}
+ * repeated string retryableErrors = 6;
+ * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getProvider = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getRetryableerrorsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 6));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setRetryableerrorsList = function(value) {
+  return jspb.Message.setField(this, 6, value || []);
 };
 
 
 /**
  * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setProvider = function(value) {
-  return jspb.Message.setProto3StringField(this, 8, value);
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.addRetryableerrors = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 6, value, opt_index);
 };
 
 
 /**
- * map<string, PropertyDependencies> propertyDependencies = 9;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>}
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
-  return /** @type {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>} */ (
-      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
-      proto.pulumirpc.RegisterResourceRequest.PropertyDependencies));
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.clearRetryableerrorsList = function() {
+  return this.setRetryableerrorsList([]);
 };
 
 
+
 /**
- * Clears values from the map. The map will be non-null.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearPropertydependenciesMap = function() {
-  this.getPropertydependenciesMap().clear();
-  return this;};
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.repeatedFields_ = [1,2,3,4,5,6];
 
 
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * optional bool deleteBeforeReplace = 10;
- * @return {boolean}
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getDeletebeforereplace = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 10, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject(opt_includeInstance, this);
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setDeletebeforereplace = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 10, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    beforecreateList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f,
+    aftercreateList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f,
+    beforeupdateList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
+    afterupdateList: (f = jspb.Message.getRepeatedField(msg, 4)) == null ? undefined : f,
+    beforedeleteList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
+    afterdeleteList: (f = jspb.Message.getRepeatedField(msg, 6)) == null ? undefined : f
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
 };
+}
 
 
 /**
- * optional string version = 11;
- * @return {string}
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getVersion = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 11, ""));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding;
+  return proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setVersion = function(value) {
-  return jspb.Message.setProto3StringField(this, 11, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addBeforecreate(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAftercreate(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addBeforeupdate(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAfterupdate(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addBeforedelete(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAfterdelete(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
 };
 
 
 /**
- * repeated string ignoreChanges = 12;
- * @return {!Array<string>}
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getIgnorechangesList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 12));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
 };
 
 
 /**
- * @param {!Array<string>} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setIgnorechangesList = function(value) {
-  return jspb.Message.setField(this, 12, value || []);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getBeforecreateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+  f = message.getAftercreateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      2,
+      f
+    );
+  }
+  f = message.getBeforeupdateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      3,
+      f
+    );
+  }
+  f = message.getAfterupdateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      4,
+      f
+    );
+  }
+  f = message.getBeforedeleteList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getAfterdeleteList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      6,
+      f
+    );
+  }
 };
 
 
 /**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * repeated string beforeCreate = 1;
+ * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addIgnorechanges = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 12, value, opt_index);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getBeforecreateList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
 };
 
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearIgnorechangesList = function() {
-  return this.setIgnorechangesList([]);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setBeforecreateList = function(value) {
+  return jspb.Message.setField(this, 1, value || []);
 };
 
 
 /**
- * optional bool acceptSecrets = 13;
- * @return {boolean}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getAcceptsecrets = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 13, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addBeforecreate = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setAcceptsecrets = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 13, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearBeforecreateList = function() {
+  return this.setBeforecreateList([]);
 };
 
 
 /**
- * repeated string additionalSecretOutputs = 14;
+ * repeated string afterCreate = 2;
  * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getAdditionalsecretoutputsList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 14));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getAftercreateList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 2));
 };
 
 
 /**
  * @param {!Array<string>} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setAdditionalsecretoutputsList = function(value) {
-  return jspb.Message.setField(this, 14, value || []);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setAftercreateList = function(value) {
+  return jspb.Message.setField(this, 2, value || []);
 };
 
 
 /**
  * @param {string} value
  * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addAdditionalsecretoutputs = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 14, value, opt_index);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addAftercreate = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 2, value, opt_index);
 };
 
 
 /**
  * Clears the list making it empty but non-null.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearAdditionalsecretoutputsList = function() {
-  return this.setAdditionalsecretoutputsList([]);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearAftercreateList = function() {
+  return this.setAftercreateList([]);
 };
 
 
 /**
- * repeated string aliasURNs = 15;
+ * repeated string beforeUpdate = 3;
  * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getAliasurnsList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 15));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getBeforeupdateList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 3));
 };
 
 
 /**
  * @param {!Array<string>} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setAliasurnsList = function(value) {
-  return jspb.Message.setField(this, 15, value || []);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setBeforeupdateList = function(value) {
+  return jspb.Message.setField(this, 3, value || []);
 };
 
 
 /**
  * @param {string} value
  * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addAliasurns = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 15, value, opt_index);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addBeforeupdate = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
 };
 
 
 /**
  * Clears the list making it empty but non-null.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearAliasurnsList = function() {
-  return this.setAliasurnsList([]);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearBeforeupdateList = function() {
+  return this.setBeforeupdateList([]);
 };
 
 
 /**
- * optional string importId = 16;
- * @return {string}
+ * repeated string afterUpdate = 4;
+ * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getImportid = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 16, ""));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getAfterupdateList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 4));
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setImportid = function(value) {
-  return jspb.Message.setProto3StringField(this, 16, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setAfterupdateList = function(value) {
+  return jspb.Message.setField(this, 4, value || []);
 };
 
 
 /**
- * optional CustomTimeouts customTimeouts = 17;
- * @return {?proto.pulumirpc.RegisterResourceRequest.CustomTimeouts}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getCustomtimeouts = function() {
-  return /** @type{?proto.pulumirpc.RegisterResourceRequest.CustomTimeouts} */ (
-    jspb.Message.getWrapperField(this, proto.pulumirpc.RegisterResourceRequest.CustomTimeouts, 17));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addAfterupdate = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 4, value, opt_index);
 };
 
 
 /**
- * @param {?proto.pulumirpc.RegisterResourceRequest.CustomTimeouts|undefined} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
-*/
-proto.pulumirpc.RegisterResourceRequest.prototype.setCustomtimeouts = function(value) {
-  return jspb.Message.setWrapperField(this, 17, value);
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearAfterupdateList = function() {
+  return this.setAfterupdateList([]);
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * repeated string beforeDelete = 5;
+ * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearCustomtimeouts = function() {
-  return this.setCustomtimeouts(undefined);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getBeforedeleteList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 5));
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.hasCustomtimeouts = function() {
-  return jspb.Message.getField(this, 17) != null;
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setBeforedeleteList = function(value) {
+  return jspb.Message.setField(this, 5, value || []);
 };
 
 
 /**
- * optional bool deleteBeforeReplaceDefined = 18;
- * @return {boolean}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getDeletebeforereplacedefined = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 18, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addBeforedelete = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 5, value, opt_index);
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setDeletebeforereplacedefined = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 18, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearBeforedeleteList = function() {
+  return this.setBeforedeleteList([]);
 };
 
 
 /**
- * optional bool supportsPartialValues = 19;
- * @return {boolean}
+ * repeated string afterDelete = 6;
+ * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getSupportspartialvalues = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 19, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getAfterdeleteList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 6));
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setSupportspartialvalues = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 19, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setAfterdeleteList = function(value) {
+  return jspb.Message.setField(this, 6, value || []);
 };
 
 
 /**
- * optional bool remote = 20;
- * @return {boolean}
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getRemote = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 20, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addAfterdelete = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 6, value, opt_index);
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setRemote = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 20, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearAfterdeleteList = function() {
+  return this.setAfterdeleteList([]);
 };
 
 
 /**
- * optional bool acceptResources = 21;
- * @return {boolean}
+ * optional string type = 1;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getAcceptresources = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 21, false));
+proto.pulumirpc.RegisterResourceRequest.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /**
- * @param {boolean} value
+ * @param {string} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setAcceptresources = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 21, value);
+proto.pulumirpc.RegisterResourceRequest.prototype.setType = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * map<string, string> providers = 22;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,string>}
+ * optional string name = 2;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getProvidersMap = function(opt_noLazyCreate) {
-  return /** @type {!jspb.Map<string,string>} */ (
-      jspb.Message.getMapField(this, 22, opt_noLazyCreate,
-      null));
+proto.pulumirpc.RegisterResourceRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /**
- * Clears values from the map. The map will be non-null.
+ * @param {string} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearProvidersMap = function() {
-  this.getProvidersMap().clear();
-  return this;};
+proto.pulumirpc.RegisterResourceRequest.prototype.setName = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
 
 
 /**
- * repeated string replaceOnChanges = 23;
- * @return {!Array<string>}
+ * optional string parent = 3;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getReplaceonchangesList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 23));
+proto.pulumirpc.RegisterResourceRequest.prototype.getParent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
 };
 
 
 /**
- * @param {!Array<string>} value
+ * @param {string} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setReplaceonchangesList = function(value) {
-  return jspb.Message.setField(this, 23, value || []);
+proto.pulumirpc.RegisterResourceRequest.prototype.setParent = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
 };
 
 
 /**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * optional bool custom = 4;
+ * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addReplaceonchanges = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 23, value, opt_index);
+proto.pulumirpc.RegisterResourceRequest.prototype.getCustom = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
 };
 
 
 /**
- * Clears the list making it empty but non-null.
+ * @param {boolean} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearReplaceonchangesList = function() {
-  return this.setReplaceonchangesList([]);
+proto.pulumirpc.RegisterResourceRequest.prototype.setCustom = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 4, value);
 };
 
 
 /**
- * optional string pluginDownloadURL = 24;
- * @return {string}
+ * optional google.protobuf.Struct object = 5;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getPlugindownloadurl = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 24, ""));
+proto.pulumirpc.RegisterResourceRequest.prototype.getObject = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
 };
 
 
 /**
- * @param {string} value
+ * @param {?proto.google.protobuf.Struct|undefined} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
- */
-proto.pulumirpc.RegisterResourceRequest.prototype.setPlugindownloadurl = function(value) {
-  return jspb.Message.setProto3StringField(this, 24, value);
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setObject = function(value) {
+  return jspb.Message.setWrapperField(this, 5, value);
 };
 
 
 /**
- * map<string, bytes> pluginChecksums = 30;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,!(string|Uint8Array)>}
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getPluginchecksumsMap = function(opt_noLazyCreate) {
-  return /** @type {!jspb.Map<string,!(string|Uint8Array)>} */ (
-      jspb.Message.getMapField(this, 30, opt_noLazyCreate,
-      null));
+proto.pulumirpc.RegisterResourceRequest.prototype.clearObject = function() {
+  return this.setObject(undefined);
 };
 
 
 /**
- * Clears values from the map. The map will be non-null.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearPluginchecksumsMap = function() {
-  this.getPluginchecksumsMap().clear();
-  return this;};
+proto.pulumirpc.RegisterResourceRequest.prototype.hasObject = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
 
 
 /**
- * optional bool retainOnDelete = 25;
+ * optional bool protect = 6;
  * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getRetainondelete = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 25, false));
+proto.pulumirpc.RegisterResourceRequest.prototype.getProtect = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 6, false));
 };
 
 
@@ -2745,37 +3123,36 @@ proto.pulumirpc.RegisterResourceRequest.prototype.getRetainondelete = function()
  * @param {boolean} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setRetainondelete = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 25, value);
+proto.pulumirpc.RegisterResourceRequest.prototype.setProtect = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 6, value);
 };
 
 
 /**
- * repeated Alias aliases = 26;
- * @return {!Array<!proto.pulumirpc.Alias>}
+ * repeated string dependencies = 7;
+ * @return {!Array<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getAliasesList = function() {
-  return /** @type{!Array<!proto.pulumirpc.Alias>} */ (
-    jspb.Message.getRepeatedWrapperField(this, pulumi_alias_pb.Alias, 26));
+proto.pulumirpc.RegisterResourceRequest.prototype.getDependenciesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 7));
 };
 
 
 /**
- * @param {!Array<!proto.pulumirpc.Alias>} value
+ * @param {!Array<string>} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
-*/
-proto.pulumirpc.RegisterResourceRequest.prototype.setAliasesList = function(value) {
-  return jspb.Message.setRepeatedWrapperField(this, 26, value);
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDependenciesList = function(value) {
+  return jspb.Message.setField(this, 7, value || []);
 };
 
 
 /**
- * @param {!proto.pulumirpc.Alias=} opt_value
+ * @param {string} value
  * @param {number=} opt_index
- * @return {!proto.pulumirpc.Alias}
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addAliases = function(opt_value, opt_index) {
-  return jspb.Message.addToRepeatedWrapperField(this, 26, opt_value, proto.pulumirpc.Alias, opt_index);
+proto.pulumirpc.RegisterResourceRequest.prototype.addDependencies = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 7, value, opt_index);
 };
 
 
@@ -2783,17 +3160,17 @@ proto.pulumirpc.RegisterResourceRequest.prototype.addAliases = function(opt_valu
  * Clears the list making it empty but non-null.
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearAliasesList = function() {
-  return this.setAliasesList([]);
+proto.pulumirpc.RegisterResourceRequest.prototype.clearDependenciesList = function() {
+  return this.setDependenciesList([]);
 };
 
 
 /**
- * optional string deletedWith = 27;
+ * optional string provider = 8;
  * @return {string}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getDeletedwith = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 27, ""));
+proto.pulumirpc.RegisterResourceRequest.prototype.getProvider = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
 };
 
 
@@ -2801,54 +3178,1024 @@ proto.pulumirpc.RegisterResourceRequest.prototype.getDeletedwith = function() {
  * @param {string} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setDeletedwith = function(value) {
-  return jspb.Message.setProto3StringField(this, 27, value);
+proto.pulumirpc.RegisterResourceRequest.prototype.setProvider = function(value) {
+  return jspb.Message.setProto3StringField(this, 8, value);
 };
 
 
 /**
- * optional bool aliasSpecs = 28;
- * @return {boolean}
+ * map<string, PropertyDependencies> propertyDependencies = 9;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getAliasspecs = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 28, false));
+proto.pulumirpc.RegisterResourceRequest.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>} */ (
+      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
+      proto.pulumirpc.RegisterResourceRequest.PropertyDependencies));
 };
 
 
 /**
- * @param {boolean} value
+ * Clears values from the map. The map will be non-null.
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.setAliasspecs = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 28, value);
-};
+proto.pulumirpc.RegisterResourceRequest.prototype.clearPropertydependenciesMap = function() {
+  this.getPropertydependenciesMap().clear();
+  return this;};
 
 
 /**
- * optional SourcePosition sourcePosition = 29;
- * @return {?proto.pulumirpc.SourcePosition}
+ * optional bool deleteBeforeReplace = 10;
+ * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getSourceposition = function() {
-  return /** @type{?proto.pulumirpc.SourcePosition} */ (
-    jspb.Message.getWrapperField(this, pulumi_source_pb.SourcePosition, 29));
+proto.pulumirpc.RegisterResourceRequest.prototype.getDeletebeforereplace = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 10, false));
 };
 
 
 /**
- * @param {?proto.pulumirpc.SourcePosition|undefined} value
+ * @param {boolean} value
  * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
-*/
-proto.pulumirpc.RegisterResourceRequest.prototype.setSourceposition = function(value) {
-  return jspb.Message.setWrapperField(this, 29, value);
-};
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDeletebeforereplace = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 10, value);
+};
+
+
+/**
+ * optional string version = 11;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getVersion = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 11, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setVersion = function(value) {
+  return jspb.Message.setProto3StringField(this, 11, value);
+};
+
+
+/**
+ * repeated string ignoreChanges = 12;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getIgnorechangesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 12));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setIgnorechangesList = function(value) {
+  return jspb.Message.setField(this, 12, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.addIgnorechanges = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 12, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearIgnorechangesList = function() {
+  return this.setIgnorechangesList([]);
+};
+
+
+/**
+ * optional bool acceptSecrets = 13;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAcceptsecrets = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 13, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setAcceptsecrets = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 13, value);
+};
+
+
+/**
+ * repeated string additionalSecretOutputs = 14;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAdditionalsecretoutputsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 14));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setAdditionalsecretoutputsList = function(value) {
+  return jspb.Message.setField(this, 14, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.addAdditionalsecretoutputs = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 14, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearAdditionalsecretoutputsList = function() {
+  return this.setAdditionalsecretoutputsList([]);
+};
+
+
+/**
+ * repeated string aliasURNs = 15;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAliasurnsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 15));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setAliasurnsList = function(value) {
+  return jspb.Message.setField(this, 15, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.addAliasurns = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 15, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearAliasurnsList = function() {
+  return this.setAliasurnsList([]);
+};
+
+
+/**
+ * optional string importId = 16;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getImportid = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 16, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setImportid = function(value) {
+  return jspb.Message.setProto3StringField(this, 16, value);
+};
+
+
+/**
+ * optional CustomTimeouts customTimeouts = 17;
+ * @return {?proto.pulumirpc.RegisterResourceRequest.CustomTimeouts}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getCustomtimeouts = function() {
+  return /** @type{?proto.pulumirpc.RegisterResourceRequest.CustomTimeouts} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.RegisterResourceRequest.CustomTimeouts, 17));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.RegisterResourceRequest.CustomTimeouts|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setCustomtimeouts = function(value) {
+  return jspb.Message.setWrapperField(this, 17, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearCustomtimeouts = function() {
+  return this.setCustomtimeouts(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasCustomtimeouts = function() {
+  return jspb.Message.getField(this, 17) != null;
+};
+
+
+/**
+ * optional bool deleteBeforeReplaceDefined = 18;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getDeletebeforereplacedefined = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 18, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDeletebeforereplacedefined = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 18, value);
+};
+
+
+/**
+ * optional bool supportsPartialValues = 19;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getSupportspartialvalues = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 19, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setSupportspartialvalues = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 19, value);
+};
+
+
+/**
+ * optional bool remote = 20;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getRemote = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 20, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setRemote = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 20, value);
+};
+
+
+/**
+ * optional bool acceptResources = 21;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAcceptresources = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 21, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setAcceptresources = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 21, value);
+};
+
+
+/**
+ * map<string, string> providers = 22;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getProvidersMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,string>} */ (
+      jspb.Message.getMapField(this, 22, opt_noLazyCreate,
+      null));
+};
+
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearProvidersMap = function() {
+  this.getProvidersMap().clear();
+  return this;};
+
+
+/**
+ * repeated string replaceOnChanges = 23;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getReplaceonchangesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 23));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setReplaceonchangesList = function(value) {
+  return jspb.Message.setField(this, 23, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.addReplaceonchanges = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 23, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearReplaceonchangesList = function() {
+  return this.setReplaceonchangesList([]);
+};
+
+
+/**
+ * optional string pluginDownloadURL = 24;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getPlugindownloadurl = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 24, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setPlugindownloadurl = function(value) {
+  return jspb.Message.setProto3StringField(this, 24, value);
+};
+
+
+/**
+ * map<string, bytes> pluginChecksums = 30;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!(string|Uint8Array)>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getPluginchecksumsMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!(string|Uint8Array)>} */ (
+      jspb.Message.getMapField(this, 30, opt_noLazyCreate,
+      null));
+};
+
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearPluginchecksumsMap = function() {
+  this.getPluginchecksumsMap().clear();
+  return this;};
+
+
+/**
+ * optional bool retainOnDelete = 25;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getRetainondelete = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 25, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setRetainondelete = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 25, value);
+};
+
+
+/**
+ * repeated Alias aliases = 26;
+ * @return {!Array<!proto.pulumirpc.Alias>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAliasesList = function() {
+  return /** @type{!Array<!proto.pulumirpc.Alias>} */ (
+    jspb.Message.getRepeatedWrapperField(this, pulumi_alias_pb.Alias, 26));
+};
+
+
+/**
+ * @param {!Array<!proto.pulumirpc.Alias>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setAliasesList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 26, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.Alias=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.Alias}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.addAliases = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 26, opt_value, proto.pulumirpc.Alias, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearAliasesList = function() {
+  return this.setAliasesList([]);
+};
+
+
+/**
+ * optional string deletedWith = 27;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getDeletedwith = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 27, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDeletedwith = function(value) {
+  return jspb.Message.setProto3StringField(this, 27, value);
+};
+
+
+/**
+ * optional bool aliasSpecs = 28;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAliasspecs = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 28, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setAliasspecs = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 28, value);
+};
+
+
+/**
+ * optional SourcePosition sourcePosition = 29;
+ * @return {?proto.pulumirpc.SourcePosition}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getSourceposition = function() {
+  return /** @type{?proto.pulumirpc.SourcePosition} */ (
+    jspb.Message.getWrapperField(this, pulumi_source_pb.SourcePosition, 29));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.SourcePosition|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setSourceposition = function(value) {
+  return jspb.Message.setWrapperField(this, 29, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearSourceposition = function() {
+  return this.setSourceposition(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasSourceposition = function() {
+  return jspb.Message.getField(this, 29) != null;
+};
+
+
+/**
+ * optional RetryPolicy retryPolicy = 31;
+ * @return {?proto.pulumirpc.RegisterResourceRequest.RetryPolicy}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getRetrypolicy = function() {
+  return /** @type{?proto.pulumirpc.RegisterResourceRequest.RetryPolicy} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.RegisterResourceRequest.RetryPolicy, 31));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.RegisterResourceRequest.RetryPolicy|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setRetrypolicy = function(value) {
+  return jspb.Message.setWrapperField(this, 31, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearRetrypolicy = function() {
+  return this.setRetrypolicy(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasRetrypolicy = function() {
+  return jspb.Message.getField(this, 31) != null;
+};
+
+
+/**
+ * optional ResourceHooksBinding hooks = 32;
+ * @return {?proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getHooks = function() {
+  return /** @type{?proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding, 32));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setHooks = function(value) {
+  return jspb.Message.setWrapperField(this, 32, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearHooks = function() {
+  return this.setHooks(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasHooks = function() {
+  return jspb.Message.getField(this, 32) != null;
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.RegisterResourceResponse.repeatedFields_ = [5];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    id: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    object: (f = msg.getObject()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    stable: jspb.Message.getBooleanFieldWithDefault(msg, 4, false),
+    stablesList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
+    propertydependenciesMap: (f = msg.getPropertydependenciesMap()) ? f.toObject(includeInstance, proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject) : []
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceResponse}
+ */
+proto.pulumirpc.RegisterResourceResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceResponse;
+  return proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceResponse}
+ */
+proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setId(value);
+      break;
+    case 3:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setObject(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setStable(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addStables(value);
+      break;
+    case 6:
+      var value = msg.getPropertydependenciesMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readMessage, proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryFromReader, "", new proto.pulumirpc.RegisterResourceResponse.PropertyDependencies());
+         });
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getId();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getObject();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getStable();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+  f = message.getStablesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getPropertydependenciesMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(6, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeMessage, proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.serializeBinaryToWriter);
+  }
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    urnsList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies}
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceResponse.PropertyDependencies;
+  return proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies}
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addUrns(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUrnsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * repeated string urns = 1;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.getUrnsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.setUrnsList = function(value) {
+  return jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.addUrns = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.clearUrnsList = function() {
+  return this.setUrnsList([]);
+};
+
+
+/**
+ * optional string urn = 1;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.setUrn = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string id = 2;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.setId = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct object = 3;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getObject = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 3));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+*/
+proto.pulumirpc.RegisterResourceResponse.prototype.setObject = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
 
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.clearSourceposition = function() {
-  return this.setSourceposition(undefined);
+proto.pulumirpc.RegisterResourceResponse.prototype.clearObject = function() {
+  return this.setObject(undefined);
 };
 
 
@@ -2856,18 +4203,88 @@ proto.pulumirpc.RegisterResourceRequest.prototype.clearSourceposition = function
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.hasSourceposition = function() {
-  return jspb.Message.getField(this, 29) != null;
+proto.pulumirpc.RegisterResourceResponse.prototype.hasObject = function() {
+  return jspb.Message.getField(this, 3) != null;
 };
 
 
+/**
+ * optional bool stable = 4;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getStable = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
+};
+
 
 /**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.repeatedFields_ = [5];
+proto.pulumirpc.RegisterResourceResponse.prototype.setStable = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
+/**
+ * repeated string stables = 5;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getStablesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 5));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.setStablesList = function(value) {
+  return jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.addStables = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.clearStablesList = function() {
+  return this.setStablesList([]);
+};
+
+
+/**
+ * map<string, PropertyDependencies> propertyDependencies = 6;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies>}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies>} */ (
+      jspb.Message.getMapField(this, 6, opt_noLazyCreate,
+      proto.pulumirpc.RegisterResourceResponse.PropertyDependencies));
+};
+
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.clearPropertydependenciesMap = function() {
+  this.getPropertydependenciesMap().clear();
+  return this;};
+
+
 
 
 
@@ -2884,8 +4301,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.RegisterResourceResponse.toObject(opt_includeInstance, this);
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceOutputsRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -2894,18 +4311,14 @@ proto.pulumirpc.RegisterResourceResponse.prototype.toObject = function(opt_inclu
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.RegisterResourceResponse} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceResponse.toObject = function(includeInstance, msg) {
+proto.pulumirpc.RegisterResourceOutputsRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    id: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    object: (f = msg.getObject()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
-    stable: jspb.Message.getBooleanFieldWithDefault(msg, 4, false),
-    stablesList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
-    propertydependenciesMap: (f = msg.getPropertydependenciesMap()) ? f.toObject(includeInstance, proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject) : []
+    outputs: (f = msg.getOutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -2919,23 +4332,23 @@ proto.pulumirpc.RegisterResourceResponse.toObject = function(includeInstance, ms
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.RegisterResourceResponse}
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
  */
-proto.pulumirpc.RegisterResourceResponse.deserializeBinary = function(bytes) {
+proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.RegisterResourceResponse;
-  return proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.RegisterResourceOutputsRequest;
+  return proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.RegisterResourceResponse} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.RegisterResourceResponse}
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
  */
-proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2947,27 +4360,9 @@ proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(
       msg.setUrn(value);
       break;
     case 2:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setId(value);
-      break;
-    case 3:
       var value = new google_protobuf_struct_pb.Struct;
       reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
-      msg.setObject(value);
-      break;
-    case 4:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setStable(value);
-      break;
-    case 5:
-      var value = /** @type {string} */ (reader.readString());
-      msg.addStables(value);
-      break;
-    case 6:
-      var value = msg.getPropertydependenciesMap();
-      reader.readMessage(value, function(message, reader) {
-        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readMessage, proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryFromReader, "", new proto.pulumirpc.RegisterResourceResponse.PropertyDependencies());
-         });
+      msg.setOutputs(value);
       break;
     default:
       reader.skipField();
@@ -2982,9 +4377,9 @@ proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.serializeBinary = function() {
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2992,11 +4387,11 @@ proto.pulumirpc.RegisterResourceResponse.prototype.serializeBinary = function()
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.RegisterResourceResponse} message
+ * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
   f = message.getUrn();
   if (f.length > 0) {
@@ -3005,49 +4400,72 @@ proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter = function(mess
       f
     );
   }
-  f = message.getId();
-  if (f.length > 0) {
-    writer.writeString(
-      2,
-      f
-    );
-  }
-  f = message.getObject();
+  f = message.getOutputs();
   if (f != null) {
     writer.writeMessage(
-      3,
+      2,
       f,
       google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
-  f = message.getStable();
-  if (f) {
-    writer.writeBool(
-      4,
-      f
-    );
-  }
-  f = message.getStablesList();
-  if (f.length > 0) {
-    writer.writeRepeatedString(
-      5,
-      f
-    );
-  }
-  f = message.getPropertydependenciesMap(true);
-  if (f && f.getLength() > 0) {
-    f.serializeBinary(6, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeMessage, proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.serializeBinaryToWriter);
-  }
 };
 
 
+/**
+ * optional string urn = 1;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setUrn = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
 
 /**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
+ * optional google.protobuf.Struct outputs = 2;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.repeatedFields_ = [1];
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getOutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setOutputs = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.clearOutputs = function() {
+  return this.setOutputs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.hasOutputs = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
 
 
 
@@ -3064,8 +4482,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject(opt_includeInstance, this);
+proto.pulumirpc.ResourceInvokeRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.ResourceInvokeRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -3074,13 +4492,20 @@ proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.toObject
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.ResourceInvokeRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject = function(includeInstance, msg) {
+proto.pulumirpc.ResourceInvokeRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    urnsList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f
+    tok: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    args: (f = msg.getArgs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    provider: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    version: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    acceptresources: jspb.Message.getBooleanFieldWithDefault(msg, 5, false),
+    plugindownloadurl: jspb.Message.getFieldWithDefault(msg, 6, ""),
+    pluginchecksumsMap: (f = msg.getPluginchecksumsMap()) ? f.toObject(includeInstance, undefined) : [],
+    sourceposition: (f = msg.getSourceposition()) && pulumi_source_pb.SourcePosition.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -3094,23 +4519,23 @@ proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.toObject = functio
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies}
+ * @return {!proto.pulumirpc.ResourceInvokeRequest}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinary = function(bytes) {
+proto.pulumirpc.ResourceInvokeRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.RegisterResourceResponse.PropertyDependencies;
-  return proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.ResourceInvokeRequest;
+  return proto.pulumirpc.ResourceInvokeRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.ResourceInvokeRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies}
+ * @return {!proto.pulumirpc.ResourceInvokeRequest}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.ResourceInvokeRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -3119,7 +4544,39 @@ proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryF
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.addUrns(value);
+      msg.setTok(value);
+      break;
+    case 2:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setArgs(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setProvider(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setVersion(value);
+      break;
+    case 5:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setAcceptresources(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPlugindownloadurl(value);
+      break;
+    case 8:
+      var value = msg.getPluginchecksumsMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readBytes, null, "", "");
+         });
+      break;
+    case 7:
+      var value = new pulumi_source_pb.SourcePosition;
+      reader.readMessage(value,pulumi_source_pb.SourcePosition.deserializeBinaryFromReader);
+      msg.setSourceposition(value);
       break;
     default:
       reader.skipField();
@@ -3134,9 +4591,9 @@ proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.deserializeBinaryF
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.serializeBinary = function() {
+proto.pulumirpc.ResourceInvokeRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.ResourceInvokeRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -3144,207 +4601,254 @@ proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.serializ
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} message
+ * @param {!proto.pulumirpc.ResourceInvokeRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.ResourceInvokeRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getUrnsList();
+  f = message.getTok();
   if (f.length > 0) {
-    writer.writeRepeatedString(
+    writer.writeString(
       1,
       f
     );
   }
+  f = message.getArgs();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getProvider();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getVersion();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getAcceptresources();
+  if (f) {
+    writer.writeBool(
+      5,
+      f
+    );
+  }
+  f = message.getPlugindownloadurl();
+  if (f.length > 0) {
+    writer.writeString(
+      6,
+      f
+    );
+  }
+  f = message.getPluginchecksumsMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(8, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeBytes);
+  }
+  f = message.getSourceposition();
+  if (f != null) {
+    writer.writeMessage(
+      7,
+      f,
+      pulumi_source_pb.SourcePosition.serializeBinaryToWriter
+    );
+  }
 };
 
 
 /**
- * repeated string urns = 1;
- * @return {!Array<string>}
+ * optional string tok = 1;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.getUrnsList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
+proto.pulumirpc.ResourceInvokeRequest.prototype.getTok = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /**
- * @param {!Array<string>} value
- * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} returns this
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.setUrnsList = function(value) {
-  return jspb.Message.setField(this, 1, value || []);
+proto.pulumirpc.ResourceInvokeRequest.prototype.setTok = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} returns this
+ * optional google.protobuf.Struct args = 2;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.addUrns = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+proto.pulumirpc.ResourceInvokeRequest.prototype.getArgs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
 };
 
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies} returns this
- */
-proto.pulumirpc.RegisterResourceResponse.PropertyDependencies.prototype.clearUrnsList = function() {
-  return this.setUrnsList([]);
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+*/
+proto.pulumirpc.ResourceInvokeRequest.prototype.setArgs = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
 };
 
 
 /**
- * optional string urn = 1;
- * @return {string}
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getUrn = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.pulumirpc.ResourceInvokeRequest.prototype.clearArgs = function() {
+  return this.setArgs(undefined);
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.setUrn = function(value) {
-  return jspb.Message.setProto3StringField(this, 1, value);
+proto.pulumirpc.ResourceInvokeRequest.prototype.hasArgs = function() {
+  return jspb.Message.getField(this, 2) != null;
 };
 
 
 /**
- * optional string id = 2;
+ * optional string provider = 3;
  * @return {string}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getId = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.pulumirpc.ResourceInvokeRequest.prototype.getProvider = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
 };
 
 
 /**
  * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.setId = function(value) {
-  return jspb.Message.setProto3StringField(this, 2, value);
+proto.pulumirpc.ResourceInvokeRequest.prototype.setProvider = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
 };
 
 
 /**
- * optional google.protobuf.Struct object = 3;
- * @return {?proto.google.protobuf.Struct}
+ * optional string version = 4;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getObject = function() {
-  return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 3));
-};
-
-
-/**
- * @param {?proto.google.protobuf.Struct|undefined} value
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
-*/
-proto.pulumirpc.RegisterResourceResponse.prototype.setObject = function(value) {
-  return jspb.Message.setWrapperField(this, 3, value);
+proto.pulumirpc.ResourceInvokeRequest.prototype.getVersion = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.clearObject = function() {
-  return this.setObject(undefined);
+proto.pulumirpc.ResourceInvokeRequest.prototype.setVersion = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
 };
 
 
 /**
- * Returns whether this field is set.
+ * optional bool acceptResources = 5;
  * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.hasObject = function() {
-  return jspb.Message.getField(this, 3) != null;
+proto.pulumirpc.ResourceInvokeRequest.prototype.getAcceptresources = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 5, false));
 };
 
 
 /**
- * optional bool stable = 4;
- * @return {boolean}
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getStable = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
+proto.pulumirpc.ResourceInvokeRequest.prototype.setAcceptresources = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 5, value);
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * optional string pluginDownloadURL = 6;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.setStable = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 4, value);
+proto.pulumirpc.ResourceInvokeRequest.prototype.getPlugindownloadurl = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
 };
 
 
 /**
- * repeated string stables = 5;
- * @return {!Array<string>}
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getStablesList = function() {
-  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 5));
+proto.pulumirpc.ResourceInvokeRequest.prototype.setPlugindownloadurl = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
 };
 
 
 /**
- * @param {!Array<string>} value
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * map<string, bytes> pluginChecksums = 8;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!(string|Uint8Array)>}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.setStablesList = function(value) {
-  return jspb.Message.setField(this, 5, value || []);
+proto.pulumirpc.ResourceInvokeRequest.prototype.getPluginchecksumsMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!(string|Uint8Array)>} */ (
+      jspb.Message.getMapField(this, 8, opt_noLazyCreate,
+      null));
 };
 
 
 /**
- * @param {string} value
- * @param {number=} opt_index
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.addStables = function(value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 5, value, opt_index);
-};
+proto.pulumirpc.ResourceInvokeRequest.prototype.clearPluginchecksumsMap = function() {
+  this.getPluginchecksumsMap().clear();
+  return this;};
 
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * optional SourcePosition sourcePosition = 7;
+ * @return {?proto.pulumirpc.SourcePosition}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.clearStablesList = function() {
-  return this.setStablesList([]);
+proto.pulumirpc.ResourceInvokeRequest.prototype.getSourceposition = function() {
+  return /** @type{?proto.pulumirpc.SourcePosition} */ (
+    jspb.Message.getWrapperField(this, pulumi_source_pb.SourcePosition, 7));
 };
 
 
 /**
- * map<string, PropertyDependencies> propertyDependencies = 6;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies>}
+ * @param {?proto.pulumirpc.SourcePosition|undefined} value
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+*/
+proto.pulumirpc.ResourceInvokeRequest.prototype.setSourceposition = function(value) {
+  return jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
-  return /** @type {!jspb.Map<string,!proto.pulumirpc.RegisterResourceResponse.PropertyDependencies>} */ (
-      jspb.Message.getMapField(this, 6, opt_noLazyCreate,
-      proto.pulumirpc.RegisterResourceResponse.PropertyDependencies));
+proto.pulumirpc.ResourceInvokeRequest.prototype.clearSourceposition = function() {
+  return this.setSourceposition(undefined);
 };
 
 
 /**
- * Clears values from the map. The map will be non-null.
- * @return {!proto.pulumirpc.RegisterResourceResponse} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.clearPropertydependenciesMap = function() {
-  this.getPropertydependenciesMap().clear();
-  return this;};
+proto.pulumirpc.ResourceInvokeRequest.prototype.hasSourceposition = function() {
+  return jspb.Message.getField(this, 7) != null;
+};
 
 
 
@@ -3363,8 +4867,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.RegisterResourceOutputsRequest.toObject(opt_includeInstance, this);
+proto.pulumirpc.RegisterResourceHookRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceHookRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -3373,14 +4877,14 @@ proto.pulumirpc.RegisterResourceOutputsRequest.prototype.toObject = function(opt
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.RegisterResourceHookRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.toObject = function(includeInstance, msg) {
+proto.pulumirpc.RegisterResourceHookRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    outputs: (f = msg.getOutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    target: jspb.Message.getFieldWithDefault(msg, 2, "")
   };
 
   if (includeInstance) {
@@ -3394,23 +4898,23 @@ proto.pulumirpc.RegisterResourceOutputsRequest.toObject = function(includeInstan
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
+ * @return {!proto.pulumirpc.RegisterResourceHookRequest}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinary = function(bytes) {
+proto.pulumirpc.RegisterResourceHookRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.RegisterResourceOutputsRequest;
-  return proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.RegisterResourceHookRequest;
+  return proto.pulumirpc.RegisterResourceHookRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.RegisterResourceHookRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
+ * @return {!proto.pulumirpc.RegisterResourceHookRequest}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.RegisterResourceHookRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -3419,12 +4923,11 @@ proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = fun
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setUrn(value);
+      msg.setName(value);
       break;
     case 2:
-      var value = new google_protobuf_struct_pb.Struct;
-      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
-      msg.setOutputs(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTarget(value);
       break;
     default:
       reader.skipField();
@@ -3439,9 +4942,9 @@ proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = fun
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.serializeBinary = function() {
+proto.pulumirpc.RegisterResourceHookRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.RegisterResourceHookRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -3449,82 +4952,62 @@ proto.pulumirpc.RegisterResourceOutputsRequest.prototype.serializeBinary = funct
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} message
+ * @param {!proto.pulumirpc.RegisterResourceHookRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.RegisterResourceHookRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getUrn();
+  f = message.getName();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getOutputs();
-  if (f != null) {
-    writer.writeMessage(
+  f = message.getTarget();
+  if (f.length > 0) {
+    writer.writeString(
       2,
-      f,
-      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+      f
     );
   }
 };
 
 
 /**
- * optional string urn = 1;
+ * optional string name = 1;
  * @return {string}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getUrn = function() {
+proto.pulumirpc.RegisterResourceHookRequest.prototype.getName = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /**
  * @param {string} value
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest} returns this
+ * @return {!proto.pulumirpc.RegisterResourceHookRequest} returns this
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setUrn = function(value) {
+proto.pulumirpc.RegisterResourceHookRequest.prototype.setName = function(value) {
   return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * optional google.protobuf.Struct outputs = 2;
- * @return {?proto.google.protobuf.Struct}
- */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getOutputs = function() {
-  return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
-};
-
-
-/**
- * @param {?proto.google.protobuf.Struct|undefined} value
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest} returns this
-*/
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setOutputs = function(value) {
-  return jspb.Message.setWrapperField(this, 2, value);
-};
-
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest} returns this
+ * optional string target = 2;
+ * @return {string}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.clearOutputs = function() {
-  return this.setOutputs(undefined);
+proto.pulumirpc.RegisterResourceHookRequest.prototype.getTarget = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceHookRequest} returns this
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.hasOutputs = function() {
-  return jspb.Message.getField(this, 2) != null;
+proto.pulumirpc.RegisterResourceHookRequest.prototype.setTarget = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
@@ -3544,8 +5027,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.ResourceInvokeRequest.toObject(opt_includeInstance, this);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.InvokeResourceHookRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -3554,20 +5037,20 @@ proto.pulumirpc.ResourceInvokeRequest.prototype.toObject = function(opt_includeI
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.ResourceInvokeRequest} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.InvokeResourceHookRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.ResourceInvokeRequest.toObject = function(includeInstance, msg) {
+proto.pulumirpc.InvokeResourceHookRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    tok: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    args: (f = msg.getArgs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
-    provider: jspb.Message.getFieldWithDefault(msg, 3, ""),
-    version: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    acceptresources: jspb.Message.getBooleanFieldWithDefault(msg, 5, false),
-    plugindownloadurl: jspb.Message.getFieldWithDefault(msg, 6, ""),
-    pluginchecksumsMap: (f = msg.getPluginchecksumsMap()) ? f.toObject(includeInstance, undefined) : [],
-    sourceposition: (f = msg.getSourceposition()) && pulumi_source_pb.SourcePosition.toObject(includeInstance, f)
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    urn: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    id: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    type: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    newinputs: (f = msg.getNewinputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    oldinputs: (f = msg.getOldinputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    newoutputs: (f = msg.getNewoutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    oldoutputs: (f = msg.getOldoutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -3581,23 +5064,23 @@ proto.pulumirpc.ResourceInvokeRequest.toObject = function(includeInstance, msg)
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.ResourceInvokeRequest}
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest}
  */
-proto.pulumirpc.ResourceInvokeRequest.deserializeBinary = function(bytes) {
+proto.pulumirpc.InvokeResourceHookRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.ResourceInvokeRequest;
-  return proto.pulumirpc.ResourceInvokeRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.InvokeResourceHookRequest;
+  return proto.pulumirpc.InvokeResourceHookRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.ResourceInvokeRequest} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.InvokeResourceHookRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.ResourceInvokeRequest}
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest}
  */
-proto.pulumirpc.ResourceInvokeRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.InvokeResourceHookRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -3606,39 +5089,39 @@ proto.pulumirpc.ResourceInvokeRequest.deserializeBinaryFromReader = function(msg
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setTok(value);
+      msg.setName(value);
       break;
     case 2:
-      var value = new google_protobuf_struct_pb.Struct;
-      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
-      msg.setArgs(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
       break;
     case 3:
       var value = /** @type {string} */ (reader.readString());
-      msg.setProvider(value);
+      msg.setId(value);
       break;
     case 4:
       var value = /** @type {string} */ (reader.readString());
-      msg.setVersion(value);
+      msg.setType(value);
       break;
     case 5:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setAcceptresources(value);
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setNewinputs(value);
       break;
     case 6:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setPlugindownloadurl(value);
-      break;
-    case 8:
-      var value = msg.getPluginchecksumsMap();
-      reader.readMessage(value, function(message, reader) {
-        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readBytes, null, "", "");
-         });
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setOldinputs(value);
       break;
     case 7:
-      var value = new pulumi_source_pb.SourcePosition;
-      reader.readMessage(value,pulumi_source_pb.SourcePosition.deserializeBinaryFromReader);
-      msg.setSourceposition(value);
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setNewoutputs(value);
+      break;
+    case 8:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setOldoutputs(value);
       break;
     default:
       reader.skipField();
@@ -3653,9 +5136,9 @@ proto.pulumirpc.ResourceInvokeRequest.deserializeBinaryFromReader = function(msg
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.serializeBinary = function() {
+proto.pulumirpc.InvokeResourceHookRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.ResourceInvokeRequest.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.InvokeResourceHookRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -3663,113 +5146,172 @@ proto.pulumirpc.ResourceInvokeRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.ResourceInvokeRequest} message
+ * @param {!proto.pulumirpc.InvokeResourceHookRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.ResourceInvokeRequest.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.InvokeResourceHookRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getTok();
+  f = message.getName();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getArgs();
-  if (f != null) {
-    writer.writeMessage(
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
       2,
-      f,
-      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+      f
     );
   }
-  f = message.getProvider();
+  f = message.getId();
   if (f.length > 0) {
     writer.writeString(
       3,
       f
     );
   }
-  f = message.getVersion();
+  f = message.getType();
   if (f.length > 0) {
     writer.writeString(
       4,
       f
     );
   }
-  f = message.getAcceptresources();
-  if (f) {
-    writer.writeBool(
+  f = message.getNewinputs();
+  if (f != null) {
+    writer.writeMessage(
       5,
-      f
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
-  f = message.getPlugindownloadurl();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getOldinputs();
+  if (f != null) {
+    writer.writeMessage(
       6,
-      f
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
-  f = message.getPluginchecksumsMap(true);
-  if (f && f.getLength() > 0) {
-    f.serializeBinary(8, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeBytes);
-  }
-  f = message.getSourceposition();
+  f = message.getNewoutputs();
   if (f != null) {
     writer.writeMessage(
       7,
       f,
-      pulumi_source_pb.SourcePosition.serializeBinaryToWriter
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getOldoutputs();
+  if (f != null) {
+    writer.writeMessage(
+      8,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional string tok = 1;
+ * optional string name = 1;
  * @return {string}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getTok = function() {
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getName = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /**
  * @param {string} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.setTok = function(value) {
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setName = function(value) {
   return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * optional google.protobuf.Struct args = 2;
+ * optional string urn = 2;
+ * @return {string}
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setUrn = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string id = 3;
+ * @return {string}
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string type = 4;
+ * @return {string}
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setType = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct newInputs = 5;
  * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getArgs = function() {
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getNewinputs = function() {
   return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
 };
 
 
 /**
  * @param {?proto.google.protobuf.Struct|undefined} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
 */
-proto.pulumirpc.ResourceInvokeRequest.prototype.setArgs = function(value) {
-  return jspb.Message.setWrapperField(this, 2, value);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setNewinputs = function(value) {
+  return jspb.Message.setWrapperField(this, 5, value);
 };
 
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.clearArgs = function() {
-  return this.setArgs(undefined);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.clearNewinputs = function() {
+  return this.setNewinputs(undefined);
 };
 
 
@@ -3777,139 +5319,249 @@ proto.pulumirpc.ResourceInvokeRequest.prototype.clearArgs = function() {
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.hasArgs = function() {
-  return jspb.Message.getField(this, 2) != null;
+proto.pulumirpc.InvokeResourceHookRequest.prototype.hasNewinputs = function() {
+  return jspb.Message.getField(this, 5) != null;
 };
 
 
 /**
- * optional string provider = 3;
- * @return {string}
+ * optional google.protobuf.Struct oldInputs = 6;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getProvider = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getOldinputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 6));
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+*/
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setOldinputs = function(value) {
+  return jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.setProvider = function(value) {
-  return jspb.Message.setProto3StringField(this, 3, value);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.clearOldinputs = function() {
+  return this.setOldinputs(undefined);
 };
 
 
 /**
- * optional string version = 4;
- * @return {string}
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getVersion = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+proto.pulumirpc.InvokeResourceHookRequest.prototype.hasOldinputs = function() {
+  return jspb.Message.getField(this, 6) != null;
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * optional google.protobuf.Struct newOutputs = 7;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.setVersion = function(value) {
-  return jspb.Message.setProto3StringField(this, 4, value);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getNewoutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 7));
 };
 
 
 /**
- * optional bool acceptResources = 5;
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+*/
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setNewoutputs = function(value) {
+  return jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+ */
+proto.pulumirpc.InvokeResourceHookRequest.prototype.clearNewoutputs = function() {
+  return this.setNewoutputs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
  * @return {boolean}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getAcceptresources = function() {
-  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 5, false));
+proto.pulumirpc.InvokeResourceHookRequest.prototype.hasNewoutputs = function() {
+  return jspb.Message.getField(this, 7) != null;
 };
 
 
 /**
- * @param {boolean} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * optional google.protobuf.Struct oldOutputs = 8;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.setAcceptresources = function(value) {
-  return jspb.Message.setProto3BooleanField(this, 5, value);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.getOldoutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 8));
 };
 
 
 /**
- * optional string pluginDownloadURL = 6;
- * @return {string}
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
+*/
+proto.pulumirpc.InvokeResourceHookRequest.prototype.setOldoutputs = function(value) {
+  return jspb.Message.setWrapperField(this, 8, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.InvokeResourceHookRequest} returns this
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getPlugindownloadurl = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+proto.pulumirpc.InvokeResourceHookRequest.prototype.clearOldoutputs = function() {
+  return this.setOldoutputs(undefined);
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.setPlugindownloadurl = function(value) {
-  return jspb.Message.setProto3StringField(this, 6, value);
+proto.pulumirpc.InvokeResourceHookRequest.prototype.hasOldoutputs = function() {
+  return jspb.Message.getField(this, 8) != null;
 };
 
 
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * map<string, bytes> pluginChecksums = 8;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,!(string|Uint8Array)>}
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getPluginchecksumsMap = function(opt_noLazyCreate) {
-  return /** @type {!jspb.Map<string,!(string|Uint8Array)>} */ (
-      jspb.Message.getMapField(this, 8, opt_noLazyCreate,
-      null));
+proto.pulumirpc.InvokeResourceHookResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.InvokeResourceHookResponse.toObject(opt_includeInstance, this);
 };
 
 
 /**
- * Clears values from the map. The map will be non-null.
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.InvokeResourceHookResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.clearPluginchecksumsMap = function() {
-  this.getPluginchecksumsMap().clear();
-  return this;};
+proto.pulumirpc.InvokeResourceHookResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    error: jspb.Message.getFieldWithDefault(msg, 1, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
 
 
 /**
- * optional SourcePosition sourcePosition = 7;
- * @return {?proto.pulumirpc.SourcePosition}
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.InvokeResourceHookResponse}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.getSourceposition = function() {
-  return /** @type{?proto.pulumirpc.SourcePosition} */ (
-    jspb.Message.getWrapperField(this, pulumi_source_pb.SourcePosition, 7));
+proto.pulumirpc.InvokeResourceHookResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.InvokeResourceHookResponse;
+  return proto.pulumirpc.InvokeResourceHookResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
- * @param {?proto.pulumirpc.SourcePosition|undefined} value
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
-*/
-proto.pulumirpc.ResourceInvokeRequest.prototype.setSourceposition = function(value) {
-  return jspb.Message.setWrapperField(this, 7, value);
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.InvokeResourceHookResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.InvokeResourceHookResponse}
+ */
+proto.pulumirpc.InvokeResourceHookResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setError(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.pulumirpc.ResourceInvokeRequest} returns this
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.clearSourceposition = function() {
-  return this.setSourceposition(undefined);
+proto.pulumirpc.InvokeResourceHookResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.InvokeResourceHookResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.InvokeResourceHookResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.ResourceInvokeRequest.prototype.hasSourceposition = function() {
-  return jspb.Message.getField(this, 7) != null;
+proto.pulumirpc.InvokeResourceHookResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getError();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string error = 1;
+ * @return {string}
+ */
+proto.pulumirpc.InvokeResourceHookResponse.prototype.getError = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.InvokeResourceHookResponse} returns this
+ */
+proto.pulumirpc.InvokeResourceHookResponse.prototype.setError = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
@@ -30,6 +30,7 @@ type ResourceMonitorClient interface {
 	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error)
 	RegisterResource(ctx context.Context, in *RegisterResourceRequest, opts ...grpc.CallOption) (*RegisterResourceResponse, error)
 	RegisterResourceOutputs(ctx context.Context, in *RegisterResourceOutputsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RegisterResourceHook(ctx context.Context, in *RegisterResourceHookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type resourceMonitorClient struct {
@@ -126,6 +127,15 @@ func (c *resourceMonitorClient) RegisterResourceOutputs(ctx context.Context, in
 	return out, nil
 }
 
+func (c *resourceMonitorClient) RegisterResourceHook(ctx context.Context, in *RegisterResourceHookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceMonitor/RegisterResourceHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResourceMonitorServer is the server API for ResourceMonitor service.
 // All implementations must embed UnimplementedResourceMonitorServer
 // for forward compatibility
@@ -137,6 +147,7 @@ type ResourceMonitorServer interface {
 	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error)
 	RegisterResource(context.Context, *RegisterResourceRequest) (*RegisterResourceResponse, error)
 	RegisterResourceOutputs(context.Context, *RegisterResourceOutputsRequest) (*emptypb.Empty, error)
+	RegisterResourceHook(context.Context, *RegisterResourceHookRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedResourceMonitorServer()
 }
 
@@ -165,6 +176,9 @@ func (UnimplementedResourceMonitorServer) RegisterResource(context.Context, *Reg
 func (UnimplementedResourceMonitorServer) RegisterResourceOutputs(context.Context, *RegisterResourceOutputsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterResourceOutputs not implemented")
 }
+func (UnimplementedResourceMonitorServer) RegisterResourceHook(context.Context, *RegisterResourceHookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterResourceHook not implemented")
+}
 func (UnimplementedResourceMonitorServer) mustEmbedUnimplementedResourceMonitorServer() {}
 
 // UnsafeResourceMonitorServer may be embedded to opt out of forward compatibility for this service.
@@ -307,6 +321,24 @@ func _ResourceMonitor_RegisterResourceOutputs_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceMonitor_RegisterResourceHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceMonitorServer).RegisterResourceHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceMonitor/RegisterResourceHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceMonitorServer).RegisterResourceHook(ctx, req.(*RegisterResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ResourceMonitor_ServiceDesc is the grpc.ServiceDesc for ResourceMonitor service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -338,6 +370,10 @@ var ResourceMonitor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterResourceOutputs",
 			Handler:    _ResourceMonitor_RegisterResourceOutputs_Handler,
 		},
+		{
+			MethodName: "RegisterResourceHook",
+			Handler:    _ResourceMonitor_RegisterResourceHook_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -348,3 +384,89 @@ var ResourceMonitor_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "pulumi/resource.proto",
 }
+
+// ResourceHooksClient is the client API for ResourceHooks service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ResourceHooksClient interface {
+	InvokeResourceHook(ctx context.Context, in *InvokeResourceHookRequest, opts ...grpc.CallOption) (*InvokeResourceHookResponse, error)
+}
+
+type resourceHooksClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceHooksClient(cc grpc.ClientConnInterface) ResourceHooksClient {
+	return &resourceHooksClient{cc}
+}
+
+func (c *resourceHooksClient) InvokeResourceHook(ctx context.Context, in *InvokeResourceHookRequest, opts ...grpc.CallOption) (*InvokeResourceHookResponse, error) {
+	out := new(InvokeResourceHookResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceHooks/InvokeResourceHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResourceHooksServer is the server API for ResourceHooks service.
+// All implementations must embed UnimplementedResourceHooksServer
+// for forward compatibility
+type ResourceHooksServer interface {
+	InvokeResourceHook(context.Context, *InvokeResourceHookRequest) (*InvokeResourceHookResponse, error)
+	mustEmbedUnimplementedResourceHooksServer()
+}
+
+// UnimplementedResourceHooksServer must be embedded to have forward compatible implementations.
+type UnimplementedResourceHooksServer struct {
+}
+
+func (UnimplementedResourceHooksServer) InvokeResourceHook(context.Context, *InvokeResourceHookRequest) (*InvokeResourceHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeResourceHook not implemented")
+}
+func (UnimplementedResourceHooksServer) mustEmbedUnimplementedResourceHooksServer() {}
+
+// UnsafeResourceHooksServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResourceHooksServer will
+// result in compilation errors.
+type UnsafeResourceHooksServer interface {
+	mustEmbedUnimplementedResourceHooksServer()
+}
+
+func RegisterResourceHooksServer(s grpc.ServiceRegistrar, srv ResourceHooksServer) {
+	s.RegisterService(&ResourceHooks_ServiceDesc, srv)
+}
+
+func _ResourceHooks_InvokeResourceHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceHooksServer).InvokeResourceHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceHooks/InvokeResourceHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceHooksServer).InvokeResourceHook(ctx, req.(*InvokeResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResourceHooks_ServiceDesc is the grpc.ServiceDesc for ResourceHooks service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResourceHooks_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pulumirpc.ResourceHooks",
+	HandlerType: (*ResourceHooksServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InvokeResourceHook",
+			Handler:    _ResourceHooks_InvokeResourceHook_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pulumi/resource.proto",
+}
@@ -238,6 +238,86 @@ class RegisterResourceRequest(google.protobuf.message.Message):
         ) -> None: ...
         def ClearField(self, field_name: typing_extensions.Literal["create", b"create", "delete", b"delete", "update", b"update"]) -> None: ...
 
+    @typing_extensions.final
+    class RetryPolicy(google.protobuf.message.Message):
+        """RetryPolicy controls how the engine retries provider operations that fail with a transient error."""
+
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        MAXATTEMPTS_FIELD_NUMBER: builtins.int
+        DELAY_FIELD_NUMBER: builtins.int
+        BACKOFF_FIELD_NUMBER: builtins.int
+        MAXDELAY_FIELD_NUMBER: builtins.int
+        RETRYABLECODES_FIELD_NUMBER: builtins.int
+        RETRYABLEERRORS_FIELD_NUMBER: builtins.int
+        maxAttempts: builtins.int
+        """the maximum number of attempts, including the first one."""
+        delay: builtins.str
+        """the delay before the first retry represented as a string e.g. 1s."""
+        backoff: builtins.float
+        """the multiplier applied to the delay after each retry."""
+        maxDelay: builtins.str
+        """the maximum delay between retries represented as a string e.g. 30s."""
+        @property
+        def retryableCodes(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the gRPC status codes that are retryable, e.g. Unavailable."""
+        @property
+        def retryableErrors(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """regular expressions matching the error messages that are retryable."""
+        def __init__(
+            self,
+            *,
+            maxAttempts: builtins.int = ...,
+            delay: builtins.str = ...,
+            backoff: builtins.float = ...,
+            maxDelay: builtins.str = ...,
+            retryableCodes: collections.abc.Iterable[builtins.str] | None = ...,
+            retryableErrors: collections.abc.Iterable[builtins.str] | None = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["backoff", b"backoff", "delay", b"delay", "maxAttempts", b"maxAttempts", "maxDelay", b"maxDelay", "retryableCodes", b"retryableCodes", "retryableErrors", b"retryableErrors"]) -> None: ...
+
+    @typing_extensions.final
+    class ResourceHooksBinding(google.protobuf.message.Message):
+        """ResourceHooksBinding names the registered resource hooks to run around the resource's lifecycle operations."""
+
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        BEFORECREATE_FIELD_NUMBER: builtins.int
+        AFTERCREATE_FIELD_NUMBER: builtins.int
+        BEFOREUPDATE_FIELD_NUMBER: builtins.int
+        AFTERUPDATE_FIELD_NUMBER: builtins.int
+        BEFOREDELETE_FIELD_NUMBER: builtins.int
+        AFTERDELETE_FIELD_NUMBER: builtins.int
+        @property
+        def beforeCreate(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the hooks to run before the resource is created."""
+        @property
+        def afterCreate(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the hooks to run after the resource is created."""
+        @property
+        def beforeUpdate(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the hooks to run before the resource is updated."""
+        @property
+        def afterUpdate(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the hooks to run after the resource is updated."""
+        @property
+        def beforeDelete(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the hooks to run before the resource is deleted."""
+        @property
+        def afterDelete(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+            """the hooks to run after the resource is deleted."""
+        def __init__(
+            self,
+            *,
+            beforeCreate: collections.abc.Iterable[builtins.str] | None = ...,
+            afterCreate: collections.abc.Iterable[builtins.str] | None = ...,
+            beforeUpdate: collections.abc.Iterable[builtins.str] | None = ...,
+            afterUpdate: collections.abc.Iterable[builtins.str] | None = ...,
+            beforeDelete: collections.abc.Iterable[builtins.str] | None = ...,
+            afterDelete: collections.abc.Iterable[builtins.str] | None = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["afterCreate", b"afterCreate", "afterDelete", b"afterDelete", "afterUpdate", b"afterUpdate", "beforeCreate", b"beforeCreate", "beforeDelete", b"beforeDelete", "beforeUpdate", b"beforeUpdate"]) -> None: ...
+
     @typing_extensions.final
     class PropertyDependenciesEntry(google.protobuf.message.Message):
         DESCRIPTOR: google.protobuf.descriptor.Descriptor
@@ -318,6 +398,8 @@ class RegisterResourceRequest(google.protobuf.message.Message):
     DELETEDWITH_FIELD_NUMBER: builtins.int
     ALIASSPECS_FIELD_NUMBER: builtins.int
     SOURCEPOSITION_FIELD_NUMBER: builtins.int
+    RETRYPOLICY_FIELD_NUMBER: builtins.int
+    HOOKS_FIELD_NUMBER: builtins.int
     type: builtins.str
     """the type of the object allocated."""
     name: builtins.str
@@ -397,6 +479,12 @@ class RegisterResourceRequest(google.protobuf.message.Message):
     @property
     def sourcePosition(self) -> pulumi.source_pb2.SourcePosition:
         """the optional source position of the user code that initiated the register."""
+    @property
+    def retryPolicy(self) -> global___RegisterResourceRequest.RetryPolicy:
+        """an optional policy for retrying transient failures of provider operations."""
+    @property
+    def hooks(self) -> global___RegisterResourceRequest.ResourceHooksBinding:
+        """the optional resource hooks to run around the resource's lifecycle operations."""
     def __init__(
         self,
         *,
@@ -430,9 +518,11 @@ class RegisterResourceRequest(google.protobuf.message.Message):
         deletedWith: builtins.str = ...,
         aliasSpecs: builtins.bool = ...,
         sourcePosition: pulumi.source_pb2.SourcePosition | None = ...,
+        retryPolicy: global___RegisterResourceRequest.RetryPolicy | None = ...,
+        hooks: global___RegisterResourceRequest.ResourceHooksBinding | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["customTimeouts", b"customTimeouts", "object", b"object", "sourcePosition", b"sourcePosition"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["acceptResources", b"acceptResources", "acceptSecrets", b"acceptSecrets", "additionalSecretOutputs", b"additionalSecretOutputs", "aliasSpecs", b"aliasSpecs", "aliasURNs", b"aliasURNs", "aliases", b"aliases", "custom", b"custom", "customTimeouts", b"customTimeouts", "deleteBeforeReplace", b"deleteBeforeReplace", "deleteBeforeReplaceDefined", b"deleteBeforeReplaceDefined", "deletedWith", b"deletedWith", "dependencies", b"dependencies", "ignoreChanges", b"ignoreChanges", "importId", b"importId", "name", b"name", "object", b"object", "parent", b"parent", "pluginChecksums", b"pluginChecksums", "pluginDownloadURL", b"pluginDownloadURL", "propertyDependencies", b"propertyDependencies", "protect", b"protect", "provider", b"provider", "providers", b"providers", "remote", b"remote", "replaceOnChanges", b"replaceOnChanges", "retainOnDelete", b"retainOnDelete", "sourcePosition", b"sourcePosition", "supportsPartialValues", b"supportsPartialValues", "type", b"type", "version", b"version"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["customTimeouts", b"customTimeouts", "hooks", b"hooks", "object", b"object", "retryPolicy", b"retryPolicy", "sourcePosition", b"sourcePosition"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["acceptResources", b"acceptResources", "acceptSecrets", b"acceptSecrets", "additionalSecretOutputs", b"additionalSecretOutputs", "aliasSpecs", b"aliasSpecs", "aliasURNs", b"aliasURNs", "aliases", b"aliases", "custom", b"custom", "customTimeouts", b"customTimeouts", "deleteBeforeReplace", b"deleteBeforeReplace", "deleteBeforeReplaceDefined", b"deleteBeforeReplaceDefined", "deletedWith", b"deletedWith", "dependencies", b"dependencies", "hooks", b"hooks", "ignoreChanges", b"ignoreChanges", "importId", b"importId", "name", b"name", "object", b"object", "parent", b"parent", "pluginChecksums", b"pluginChecksums", "pluginDownloadURL", b"pluginDownloadURL", "propertyDependencies", b"propertyDependencies", "protect", b"protect", "provider", b"provider", "providers", b"providers", "remote", b"remote", "replaceOnChanges", b"replaceOnChanges", "retainOnDelete", b"retainOnDelete", "retryPolicy", b"retryPolicy", "sourcePosition", b"sourcePosition", "supportsPartialValues", b"supportsPartialValues", "type", b"type", "version", b"version"]) -> None: ...
 
 global___RegisterResourceRequest = RegisterResourceRequest
 
@@ -602,3 +692,96 @@ class ResourceInvokeRequest(google.protobuf.message.Message):
     def ClearField(self, field_name: typing_extensions.Literal["acceptResources", b"acceptResources", "args", b"args", "pluginChecksums", b"pluginChecksums", "pluginDownloadURL", b"pluginDownloadURL", "provider", b"provider", "sourcePosition", b"sourcePosition", "tok", b"tok", "version", b"version"]) -> None: ...
 
 global___ResourceInvokeRequest = ResourceInvokeRequest
+
+@typing_extensions.final
+class RegisterResourceHookRequest(google.protobuf.message.Message):
+    """RegisterResourceHookRequest registers a resource hook that the engine can run around the lifecycle operations of
+    resources that are bound to it.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    NAME_FIELD_NUMBER: builtins.int
+    TARGET_FIELD_NUMBER: builtins.int
+    name: builtins.str
+    """the unique name of the hook."""
+    target: builtins.str
+    """the address of the ResourceHooks server that runs the hook."""
+    def __init__(
+        self,
+        *,
+        name: builtins.str = ...,
+        target: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["name", b"name", "target", b"target"]) -> None: ...
+
+global___RegisterResourceHookRequest = RegisterResourceHookRequest
+
+@typing_extensions.final
+class InvokeResourceHookRequest(google.protobuf.message.Message):
+    """InvokeResourceHookRequest asks a source to run one of its resource hooks."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    NAME_FIELD_NUMBER: builtins.int
+    URN_FIELD_NUMBER: builtins.int
+    ID_FIELD_NUMBER: builtins.int
+    TYPE_FIELD_NUMBER: builtins.int
+    NEWINPUTS_FIELD_NUMBER: builtins.int
+    OLDINPUTS_FIELD_NUMBER: builtins.int
+    NEWOUTPUTS_FIELD_NUMBER: builtins.int
+    OLDOUTPUTS_FIELD_NUMBER: builtins.int
+    name: builtins.str
+    """the name of the hook to run."""
+    urn: builtins.str
+    """the URN of the resource."""
+    id: builtins.str
+    """the ID of the resource, if it has one."""
+    type: builtins.str
+    """the type of the resource."""
+    @property
+    def newInputs(self) -> google.protobuf.struct_pb2.Struct:
+        """the new inputs of the resource, if any."""
+    @property
+    def oldInputs(self) -> google.protobuf.struct_pb2.Struct:
+        """the old inputs of the resource, if any."""
+    @property
+    def newOutputs(self) -> google.protobuf.struct_pb2.Struct:
+        """the new outputs of the resource, if any."""
+    @property
+    def oldOutputs(self) -> google.protobuf.struct_pb2.Struct:
+        """the old outputs of the resource, if any."""
+    def __init__(
+        self,
+        *,
+        name: builtins.str = ...,
+        urn: builtins.str = ...,
+        id: builtins.str = ...,
+        type: builtins.str = ...,
+        newInputs: google.protobuf.struct_pb2.Struct | None = ...,
+        oldInputs: google.protobuf.struct_pb2.Struct | None = ...,
+        newOutputs: google.protobuf.struct_pb2.Struct | None = ...,
+        oldOutputs: google.protobuf.struct_pb2.Struct | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["newInputs", b"newInputs", "newOutputs", b"newOutputs", "oldInputs", b"oldInputs", "oldOutputs", b"oldOutputs"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["id", b"id", "name", b"name", "newInputs", b"newInputs", "newOutputs", b"newOutputs", "oldInputs", b"oldInputs", "oldOutputs", b"oldOutputs", "type", b"type", "urn", b"urn"]) -> None: ...
+
+global___InvokeResourceHookRequest = InvokeResourceHookRequest
+
+@typing_extensions.final
+class InvokeResourceHookResponse(google.protobuf.message.Message):
+    """InvokeResourceHookResponse is returned by a source after running a resource hook."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ERROR_FIELD_NUMBER: builtins.int
+    error: builtins.str
+    """the error message of the hook, if it failed."""
+    def __init__(
+        self,
+        *,
+        error: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["error", b"error"]) -> None: ...
+
+global___InvokeResourceHookResponse = InvokeResourceHookResponse
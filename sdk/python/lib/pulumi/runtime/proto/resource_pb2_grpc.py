@@ -52,6 +52,11 @@ class ResourceMonitorStub(object):
                 request_serializer=pulumi_dot_resource__pb2.RegisterResourceOutputsRequest.SerializeToString,
                 response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
                 )
+        self.RegisterResourceHook = channel.unary_unary(
+                '/pulumirpc.ResourceMonitor/RegisterResourceHook',
+                request_serializer=pulumi_dot_resource__pb2.RegisterResourceHookRequest.SerializeToString,
+                response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+                )
 
 
 class ResourceMonitorServicer(object):
@@ -100,6 +105,12 @@ class ResourceMonitorServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def RegisterResourceHook(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_ResourceMonitorServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -138,6 +149,11 @@ def add_ResourceMonitorServicer_to_server(servicer, server):
                     request_deserializer=pulumi_dot_resource__pb2.RegisterResourceOutputsRequest.FromString,
                     response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
             ),
+            'RegisterResourceHook': grpc.unary_unary_rpc_method_handler(
+                    servicer.RegisterResourceHook,
+                    request_deserializer=pulumi_dot_resource__pb2.RegisterResourceHookRequest.FromString,
+                    response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'pulumirpc.ResourceMonitor', rpc_method_handlers)
@@ -267,3 +283,87 @@ class ResourceMonitor(object):
             google_dot_protobuf_dot_empty__pb2.Empty.FromString,
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def RegisterResourceHook(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.ResourceMonitor/RegisterResourceHook',
+            pulumi_dot_resource__pb2.RegisterResourceHookRequest.SerializeToString,
+            google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+
+class ResourceHooksStub(object):
+    """ResourceHooks is the interface a source serves so that the engine can run the resource hooks that it registered with
+    the resource monitor.
+    """
+
+    def __init__(self, channel):
+        """Constructor.
+
+        Args:
+            channel: A grpc.Channel.
+        """
+        self.InvokeResourceHook = channel.unary_unary(
+                '/pulumirpc.ResourceHooks/InvokeResourceHook',
+                request_serializer=pulumi_dot_resource__pb2.InvokeResourceHookRequest.SerializeToString,
+                response_deserializer=pulumi_dot_resource__pb2.InvokeResourceHookResponse.FromString,
+                )
+
+
+class ResourceHooksServicer(object):
+    """ResourceHooks is the interface a source serves so that the engine can run the resource hooks that it registered with
+    the resource monitor.
+    """
+
+    def InvokeResourceHook(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+
+def add_ResourceHooksServicer_to_server(servicer, server):
+    rpc_method_handlers = {
+            'InvokeResourceHook': grpc.unary_unary_rpc_method_handler(
+                    servicer.InvokeResourceHook,
+                    request_deserializer=pulumi_dot_resource__pb2.InvokeResourceHookRequest.FromString,
+                    response_serializer=pulumi_dot_resource__pb2.InvokeResourceHookResponse.SerializeToString,
+            ),
+    }
+    generic_handler = grpc.method_handlers_generic_handler(
+            'pulumirpc.ResourceHooks', rpc_method_handlers)
+    server.add_generic_rpc_handlers((generic_handler,))
+
+
+ # This class is part of an EXPERIMENTAL API.
+class ResourceHooks(object):
+    """ResourceHooks is the interface a source serves so that the engine can run the resource hooks that it registered with
+    the resource monitor.
+    """
+
+    @staticmethod
+    def InvokeResourceHook(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.ResourceHooks/InvokeResourceHook',
+            pulumi_dot_resource__pb2.InvokeResourceHookRequest.SerializeToString,
+            pulumi_dot_resource__pb2.InvokeResourceHookResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
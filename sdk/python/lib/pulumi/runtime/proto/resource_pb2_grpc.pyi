@@ -56,6 +56,10 @@ class ResourceMonitorStub:
         pulumi.resource_pb2.RegisterResourceOutputsRequest,
         google.protobuf.empty_pb2.Empty,
     ]
+    RegisterResourceHook: grpc.UnaryUnaryMultiCallable[
+        pulumi.resource_pb2.RegisterResourceHookRequest,
+        google.protobuf.empty_pb2.Empty,
+    ]
 
 class ResourceMonitorServicer(metaclass=abc.ABCMeta):
     """ResourceMonitor is the interface a source uses to talk back to the planning monitor orchestrating the execution."""
@@ -102,5 +106,36 @@ class ResourceMonitorServicer(metaclass=abc.ABCMeta):
         request: pulumi.resource_pb2.RegisterResourceOutputsRequest,
         context: grpc.ServicerContext,
     ) -> google.protobuf.empty_pb2.Empty: ...
+    
+    def RegisterResourceHook(
+        self,
+        request: pulumi.resource_pb2.RegisterResourceHookRequest,
+        context: grpc.ServicerContext,
+    ) -> google.protobuf.empty_pb2.Empty: ...
 
 def add_ResourceMonitorServicer_to_server(servicer: ResourceMonitorServicer, server: typing.Union[grpc.Server, grpc.aio.Server]) -> None: ...
+
+class ResourceHooksStub:
+    """ResourceHooks is the interface a source serves so that the engine can run the resource hooks that it registered with
+    the resource monitor.
+    """
+
+    def __init__(self, channel: grpc.Channel) -> None: ...
+    InvokeResourceHook: grpc.UnaryUnaryMultiCallable[
+        pulumi.resource_pb2.InvokeResourceHookRequest,
+        pulumi.resource_pb2.InvokeResourceHookResponse,
+    ]
+
+class ResourceHooksServicer(metaclass=abc.ABCMeta):
+    """ResourceHooks is the interface a source serves so that the engine can run the resource hooks that it registered with
+    the resource monitor.
+    """
+
+    
+    def InvokeResourceHook(
+        self,
+        request: pulumi.resource_pb2.InvokeResourceHookRequest,
+        context: grpc.ServicerContext,
+    ) -> pulumi.resource_pb2.InvokeResourceHookResponse: ...
+
+def add_ResourceHooksServicer_to_server(servicer: ResourceHooksServicer, server: typing.Union[grpc.Server, grpc.aio.Server]) -> None: ...